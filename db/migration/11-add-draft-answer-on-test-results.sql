@@ -0,0 +1,7 @@
+-- +migrate Up notransaction
+
+ALTER TABLE "test_results" ADD COLUMN IF NOT EXISTS draft_answer JSONB DEFAULT NULL;
+
+-- +migrate Down
+
+ALTER TABLE "test_results" DROP COLUMN IF EXISTS draft_answer;
@@ -0,0 +1,13 @@
+-- +migrate Up notransaction
+
+ALTER TABLE "test_templates" ADD COLUMN IF NOT EXISTS "type" TEXT NOT NULL DEFAULT 'speech_delay';
+ALTER TABLE "test_packages" ADD COLUMN IF NOT EXISTS "type" TEXT NOT NULL DEFAULT 'speech_delay';
+CREATE INDEX IF NOT EXISTS idx_test_templates_type ON "test_templates" USING BTREE("type");
+CREATE INDEX IF NOT EXISTS idx_test_packages_type ON "test_packages" USING BTREE("type");
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_test_templates_type;
+DROP INDEX IF EXISTS idx_test_packages_type;
+ALTER TABLE "test_templates" DROP COLUMN IF EXISTS "type";
+ALTER TABLE "test_packages" DROP COLUMN IF EXISTS "type";
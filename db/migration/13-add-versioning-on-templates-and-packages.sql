@@ -0,0 +1,51 @@
+-- +migrate Up notransaction
+
+ALTER TABLE "test_templates" ADD COLUMN IF NOT EXISTS current_version INT NOT NULL DEFAULT 1;
+ALTER TABLE "test_packages" ADD COLUMN IF NOT EXISTS current_version INT NOT NULL DEFAULT 1;
+ALTER TABLE "test_packages" ADD COLUMN IF NOT EXISTS template_version INT NOT NULL DEFAULT 1;
+ALTER TABLE "test_results" ADD COLUMN IF NOT EXISTS package_version INT NOT NULL DEFAULT 1;
+ALTER TABLE "test_results" ADD COLUMN IF NOT EXISTS template_version INT NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS "test_template_versions" (
+    template_id UUID NOT NULL,
+    version INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    template JSONB NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (template_id, version)
+);
+
+ALTER TABLE "test_template_versions" ADD FOREIGN KEY (template_id) REFERENCES "test_templates" (id);
+ALTER TABLE "test_template_versions" ADD FOREIGN KEY (created_by) REFERENCES "users" (id);
+
+CREATE TABLE IF NOT EXISTS "test_package_versions" (
+    package_id UUID NOT NULL,
+    version INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    package JSONB NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (package_id, version)
+);
+
+ALTER TABLE "test_package_versions" ADD FOREIGN KEY (package_id) REFERENCES "test_packages" (id);
+ALTER TABLE "test_package_versions" ADD FOREIGN KEY (created_by) REFERENCES "users" (id);
+
+INSERT INTO "test_template_versions" (template_id, version, name, template, created_by, created_at)
+    SELECT id, current_version, name, template, created_by, updated_at FROM "test_templates"
+    ON CONFLICT DO NOTHING;
+
+INSERT INTO "test_package_versions" (package_id, version, name, package, created_by, created_at)
+    SELECT id, current_version, name, package, created_by, updated_at FROM "test_packages"
+    ON CONFLICT DO NOTHING;
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS "test_package_versions";
+DROP TABLE IF EXISTS "test_template_versions";
+ALTER TABLE "test_results" DROP COLUMN IF EXISTS template_version;
+ALTER TABLE "test_results" DROP COLUMN IF EXISTS package_version;
+ALTER TABLE "test_packages" DROP COLUMN IF EXISTS template_version;
+ALTER TABLE "test_packages" DROP COLUMN IF EXISTS current_version;
+ALTER TABLE "test_templates" DROP COLUMN IF EXISTS current_version;
@@ -0,0 +1,7 @@
+-- +migrate Up notransaction
+
+ALTER TABLE "test_results" ADD COLUMN IF NOT EXISTS question_order JSONB DEFAULT NULL;
+
+-- +migrate Down
+
+ALTER TABLE "test_results" DROP COLUMN IF EXISTS question_order;
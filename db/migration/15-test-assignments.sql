@@ -0,0 +1,34 @@
+-- +migrate Up notransaction
+
+CREATE TABLE IF NOT EXISTS "test_assignments" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    assignee_id UUID NOT NULL,
+    package_id UUID DEFAULT NULL,
+    template_id UUID DEFAULT NULL,
+    due_at TIMESTAMPTZ NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    status VARCHAR(32) NOT NULL DEFAULT 'assigned',
+    test_id UUID DEFAULT NULL,
+    created_by UUID NOT NULL,
+    completed_at TIMESTAMPTZ DEFAULT NULL,
+    cancelled_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ DEFAULT NULL
+);
+
+ALTER TABLE "test_assignments" ADD FOREIGN KEY (assignee_id) REFERENCES "users" (id);
+ALTER TABLE "test_assignments" ADD FOREIGN KEY (package_id) REFERENCES "test_packages" (id);
+ALTER TABLE "test_assignments" ADD FOREIGN KEY (template_id) REFERENCES "test_templates" (id);
+ALTER TABLE "test_assignments" ADD FOREIGN KEY (test_id) REFERENCES "test_results" (id);
+ALTER TABLE "test_assignments" ADD FOREIGN KEY (created_by) REFERENCES "users" (id);
+CREATE INDEX IF NOT EXISTS idx_test_assignments_assignee_id ON "test_assignments" USING BTREE(assignee_id);
+
+ALTER TABLE "test_results" ADD COLUMN IF NOT EXISTS assignment_id UUID DEFAULT NULL;
+ALTER TABLE "test_results" ADD FOREIGN KEY (assignment_id) REFERENCES "test_assignments" (id);
+
+-- +migrate Down
+
+ALTER TABLE "test_results" DROP COLUMN IF EXISTS assignment_id;
+DROP INDEX IF EXISTS idx_test_assignments_assignee_id;
+DROP TABLE IF EXISTS "test_assignments";
@@ -0,0 +1,27 @@
+-- +migrate Up notransaction
+
+CREATE TABLE IF NOT EXISTS "child_profiles" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    "name" VARCHAR(255) NOT NULL,
+    birth_date DATE NOT NULL,
+    sex VARCHAR(16) NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ DEFAULT NULL
+);
+
+ALTER TABLE "child_profiles" ADD FOREIGN KEY (user_id) REFERENCES "users" (id);
+CREATE INDEX IF NOT EXISTS idx_child_profiles_user_id ON "child_profiles" USING BTREE(user_id);
+
+ALTER TABLE "test_results" ADD COLUMN IF NOT EXISTS child_id UUID DEFAULT NULL;
+ALTER TABLE "test_results" ADD FOREIGN KEY (child_id) REFERENCES "child_profiles" (id);
+CREATE INDEX IF NOT EXISTS idx_test_results_child_id ON "test_results" USING BTREE(child_id);
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_test_results_child_id;
+ALTER TABLE "test_results" DROP COLUMN IF EXISTS child_id;
+DROP INDEX IF EXISTS idx_child_profiles_user_id;
+DROP TABLE IF EXISTS "child_profiles";
@@ -0,0 +1,11 @@
+-- +migrate Up notransaction
+
+ALTER TABLE "test_results" ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'in_progress';
+UPDATE "test_results" SET status = 'finished' WHERE finished_at IS NOT NULL;
+UPDATE "test_results" SET status = 'expired' WHERE finished_at IS NULL AND open_until < NOW();
+CREATE INDEX IF NOT EXISTS idx_test_results_status ON "test_results" USING BTREE(status);
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_test_results_status;
+ALTER TABLE "test_results" DROP COLUMN IF EXISTS status;
@@ -0,0 +1,18 @@
+-- +migrate Up notransaction
+
+CREATE TABLE IF NOT EXISTS "email_attachments" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    email_id UUID NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    content BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE "email_attachments" ADD FOREIGN KEY (email_id) REFERENCES "emails" (id);
+CREATE INDEX IF NOT EXISTS idx_email_attachments_email_id ON "email_attachments" USING BTREE(email_id);
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_email_attachments_email_id;
+DROP TABLE IF EXISTS "email_attachments";
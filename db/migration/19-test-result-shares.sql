@@ -0,0 +1,40 @@
+-- +migrate Up notransaction
+
+CREATE TABLE IF NOT EXISTS "test_result_shares" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    test_id UUID NOT NULL,
+    token TEXT NOT NULL,
+    scope VARCHAR(32) NOT NULL,
+    expired_at TIMESTAMPTZ NOT NULL,
+    max_views INT DEFAULT NULL,
+    view_count INT NOT NULL DEFAULT 0,
+    revoked_at TIMESTAMPTZ DEFAULT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE "test_result_shares" ADD FOREIGN KEY (test_id) REFERENCES "test_results" (id);
+ALTER TABLE "test_result_shares" ADD FOREIGN KEY (created_by) REFERENCES "users" (id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_test_result_shares_token ON "test_result_shares" USING BTREE(token);
+CREATE INDEX IF NOT EXISTS idx_test_result_shares_test_id ON "test_result_shares" USING BTREE(test_id);
+
+CREATE TABLE IF NOT EXISTS "test_result_share_views" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    share_id UUID NOT NULL,
+    scope VARCHAR(32) NOT NULL,
+    ip_address VARCHAR(255) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    viewed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE "test_result_share_views" ADD FOREIGN KEY (share_id) REFERENCES "test_result_shares" (id);
+CREATE INDEX IF NOT EXISTS idx_test_result_share_views_share_id ON "test_result_share_views" USING BTREE(share_id);
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_test_result_share_views_share_id;
+DROP TABLE IF EXISTS "test_result_share_views";
+DROP INDEX IF EXISTS idx_test_result_shares_test_id;
+DROP INDEX IF EXISTS idx_test_result_shares_token;
+DROP TABLE IF EXISTS "test_result_shares";
@@ -0,0 +1,12 @@
+-- +migrate Up notransaction
+
+ALTER TABLE "test_results" ADD COLUMN IF NOT EXISTS template_id UUID DEFAULT NULL;
+UPDATE "test_results" tr SET template_id = tp.template_id FROM "test_packages" tp WHERE tr.package_id = tp.id AND tr.template_id IS NULL;
+ALTER TABLE "test_results" ALTER COLUMN template_id SET NOT NULL;
+ALTER TABLE "test_results" ADD FOREIGN KEY (template_id) REFERENCES "test_templates" (id);
+CREATE INDEX IF NOT EXISTS idx_test_results_template_id ON "test_results" USING BTREE(template_id);
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_test_results_template_id;
+ALTER TABLE "test_results" DROP COLUMN IF EXISTS template_id;
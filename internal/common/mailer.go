@@ -0,0 +1,91 @@
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/sendinblue/APIv3-go-library/lib"
+	"github.com/sweet-go/stdlib/mail"
+)
+
+// SendinblueSignature is the client signature of the email sent by AttachmentMailer
+const SendinblueSignature mail.ClientSignature = "sendinblue"
+
+// ErrAttachmentMailerNotActivated returned when sending email with attachments while sendinblue is not activated
+var ErrAttachmentMailerNotActivated = errors.New("attachment mailer is not activated")
+
+// MailAttachment a file attached on the email
+type MailAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// AttachmentMailer send the email along with its attachments. The mail utility from stdlib can't send attachments,
+// thus the email having attachments is sent directly using sendinblue transactional email API
+type AttachmentMailer interface {
+	SendEmailWithAttachments(ctx context.Context, m *mail.Mail, attachments []MailAttachment) (string, mail.ClientSignature, error)
+}
+
+type sendinblueMailer struct {
+	client      *lib.APIClient
+	sender      *lib.SendSmtpEmailSender
+	apiKey      string
+	isActivated bool
+}
+
+// NewAttachmentMailer returns a new instance of AttachmentMailer using sendinblue
+func NewAttachmentMailer(client *lib.APIClient, sender *lib.SendSmtpEmailSender, apiKey string, isActivated bool) AttachmentMailer {
+	return &sendinblueMailer{
+		client:      client,
+		sender:      sender,
+		apiKey:      apiKey,
+		isActivated: isActivated,
+	}
+}
+
+func (s *sendinblueMailer) SendEmailWithAttachments(ctx context.Context, m *mail.Mail, attachments []MailAttachment) (string, mail.ClientSignature, error) {
+	if !s.isActivated {
+		return "", "", ErrAttachmentMailerNotActivated
+	}
+
+	body := lib.SendSmtpEmail{
+		Sender:      s.sender,
+		Subject:     m.Subject,
+		HtmlContent: m.HTMLContent,
+	}
+
+	for _, r := range m.To {
+		body.To = append(body.To, lib.SendSmtpEmailTo{Email: r.Email, Name: r.Name})
+	}
+
+	for _, r := range m.Cc {
+		body.Cc = append(body.Cc, lib.SendSmtpEmailCc{Email: r.Email, Name: r.Name})
+	}
+
+	for _, r := range m.Bcc {
+		body.Bcc = append(body.Bcc, lib.SendSmtpEmailBcc{Email: r.Email, Name: r.Name})
+	}
+
+	for _, a := range attachments {
+		body.Attachment = append(body.Attachment, lib.SendSmtpEmailAttachment{
+			Name:    a.Filename,
+			Content: base64.StdEncoding.EncodeToString(a.Content),
+		})
+	}
+
+	ctx = context.WithValue(ctx, lib.ContextAPIKey, lib.APIKey{Key: s.apiKey})
+	res, _, err := s.client.TransactionalEmailsApi.SendTransacEmail(ctx, body)
+	if err != nil {
+		return "", "", err
+	}
+
+	md, err := json.Marshal(res)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(md), SendinblueSignature, nil
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/luckyAkbar/atec-api/internal/common (interfaces: AttachmentMailer)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	common "github.com/luckyAkbar/atec-api/internal/common"
+	mail "github.com/sweet-go/stdlib/mail"
+)
+
+// MockAttachmentMailer is a mock of AttachmentMailer interface.
+type MockAttachmentMailer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMailerMockRecorder
+}
+
+// MockAttachmentMailerMockRecorder is the mock recorder for MockAttachmentMailer.
+type MockAttachmentMailerMockRecorder struct {
+	mock *MockAttachmentMailer
+}
+
+// NewMockAttachmentMailer creates a new mock instance.
+func NewMockAttachmentMailer(ctrl *gomock.Controller) *MockAttachmentMailer {
+	mock := &MockAttachmentMailer{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMailerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentMailer) EXPECT() *MockAttachmentMailerMockRecorder {
+	return m.recorder
+}
+
+// SendEmailWithAttachments mocks base method.
+func (m *MockAttachmentMailer) SendEmailWithAttachments(arg0 context.Context, arg1 *mail.Mail, arg2 []common.MailAttachment) (string, mail.ClientSignature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailWithAttachments", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(mail.ClientSignature)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SendEmailWithAttachments indicates an expected call of SendEmailWithAttachments.
+func (mr *MockAttachmentMailerMockRecorder) SendEmailWithAttachments(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailWithAttachments", reflect.TypeOf((*MockAttachmentMailer)(nil).SendEmailWithAttachments), arg0, arg1, arg2)
+}
@@ -0,0 +1,49 @@
+package common
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+)
+
+// SignatureAlgorithm is the algorithm used by Signer, written using the JWA name
+const SignatureAlgorithm = "RS256"
+
+// Signer sign the message using the server private key. The signature can be verified by anyone
+// having the public key, thus can be used to prove the message is issued by the server
+type Signer interface {
+	Sign(message []byte) ([]byte, error)
+	Verify(message, signature []byte) error
+	PublicKeyPEM() (string, error)
+}
+
+type signer struct {
+	privateKey *rsa.PrivateKey
+}
+
+// NewSigner returns a new instance of Signer using RSASSA-PKCS1-v1_5 with SHA-256
+func NewSigner(privateKey *rsa.PrivateKey) Signer {
+	return &signer{privateKey: privateKey}
+}
+
+func (s *signer) Sign(message []byte) ([]byte, error) {
+	hashed := sha256.Sum256(message)
+	return rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, hashed[:])
+}
+
+func (s *signer) Verify(message, signature []byte) error {
+	hashed := sha256.Sum256(message)
+	return rsa.VerifyPKCS1v15(&s.privateKey.PublicKey, crypto.SHA256, hashed[:], signature)
+}
+
+func (s *signer) PublicKeyPEM() (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(&s.privateKey.PublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
@@ -76,6 +76,16 @@ func WorkerLimiterBurst() int {
 	return viper.GetInt("worker.limiter.burst")
 }
 
+// WorkerSweepExpiredSDTestCronspec returns the cronspec of the scheduled task to mark expired sd test. Default to every 5 minutes
+func WorkerSweepExpiredSDTestCronspec() string {
+	spec := viper.GetString("worker.scheduler.sweep_expired_sd_test_cronspec")
+	if spec == "" {
+		return "@every 5m"
+	}
+
+	return spec
+}
+
 // Env returns application environment
 func Env() string {
 	return viper.GetString("env")
@@ -174,6 +184,17 @@ func ChangePasswordBaseURL() string {
 	return viper.GetString("server.user.change_password_base_url")
 }
 
+// SDAssignmentBaseURL return the sd test assignment base url. Should point to FE page which start the test from the assignment
+func SDAssignmentBaseURL() string {
+	return viper.GetString("server.sdt.assignment_base_url")
+}
+
+// SDResultVerificationBaseURL return the sd test result verification base url, written on the result qr code.
+// Should point to FE page which verify the result using the id, total and signature query
+func SDResultVerificationBaseURL() string {
+	return viper.GetString("server.sdt.result_verification_base_url")
+}
+
 // RedisAddr redis address
 func RedisAddr() string {
 	return viper.GetString("redis.addr")
@@ -0,0 +1,101 @@
+package console
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/db"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/luckyAkbar/atec-api/internal/usecase"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var analyticsCMD = &cobra.Command{
+	Use:  "analytics",
+	Long: "generate the analytics report of the sd tests",
+}
+
+var analyticsItemAnalysisCMD = &cobra.Command{
+	Use:  "item-analysis",
+	Long: "generate the item analysis report of a sd package as csv",
+	Run:  analyticsItemAnalysisFn,
+}
+
+func init() {
+	analyticsItemAnalysisCMD.Flags().String("package-id", "", "id of the sd package to analyse")
+	_ = analyticsItemAnalysisCMD.MarkFlagRequired("package-id")
+	analyticsItemAnalysisCMD.Flags().String("from", "", "only analyse the tests finished since this time, in RFC3339 format")
+	analyticsItemAnalysisCMD.Flags().String("to", "", "only analyse the tests finished until this time, in RFC3339 format")
+	analyticsItemAnalysisCMD.Flags().Float64("dominance-threshold", model.DefaultSDItemDominanceThreshold, "ratio of the answers taken by a single option to flag the question")
+	analyticsItemAnalysisCMD.Flags().String("output", "", "path of the csv file to write. will write to stdout if not set")
+
+	analyticsCMD.AddCommand(analyticsItemAnalysisCMD)
+	RootCMD.AddCommand(analyticsCMD)
+}
+
+func analyticsItemAnalysisFn(cmd *cobra.Command, _ []string) {
+	packageID, err := uuid.Parse(cmd.Flag("package-id").Value.String())
+	if err != nil {
+		logrus.WithError(err).Error("flag package-id must be a valid uuid")
+		os.Exit(1)
+	}
+
+	dominanceThreshold, err := cmd.Flags().GetFloat64("dominance-threshold")
+	if err != nil {
+		logrus.WithError(err).Error("invalid flag dominance-threshold")
+		os.Exit(1)
+	}
+
+	input := &model.SDItemAnalysisInput{
+		ID:                 packageID,
+		DominanceThreshold: dominanceThreshold,
+	}
+
+	if v := cmd.Flag("from").Value.String(); v != "" {
+		if input.From, err = time.Parse(time.RFC3339, v); err != nil {
+			logrus.WithError(err).Error("flag from must be in RFC3339 format")
+			os.Exit(1)
+		}
+	}
+
+	if v := cmd.Flag("to").Value.String(); v != "" {
+		if input.To, err = time.Parse(time.RFC3339, v); err != nil {
+			logrus.WithError(err).Error("flag to must be in RFC3339 format")
+			os.Exit(1)
+		}
+	}
+
+	db.InitializePostgresConn()
+
+	analyticsUsecase := usecase.NewSDAnalyticsUsecase(repository.NewSDAnalyticsRepository(db.PostgresDB), repository.NewSDTemplateRepository(db.PostgresDB), repository.NewSDPackageRepository(db.PostgresDB))
+
+	report, cerr := analyticsUsecase.PackageItemAnalysis(context.Background(), input)
+	if cerr.Type != nil {
+		logrus.WithError(cerr.Cause).Error("failed to generate sd package item analysis: ", cerr.Message)
+		os.Exit(1)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := report.WriteCSV(buf); err != nil {
+		logrus.WithError(err).Error("failed to write sd package item analysis")
+		os.Exit(1)
+	}
+
+	output := cmd.Flag("output").Value.String()
+	if output == "" {
+		_, _ = os.Stdout.Write(buf.Bytes())
+		return
+	}
+
+	if err := os.WriteFile(output, buf.Bytes(), 0o644); err != nil {
+		logrus.WithError(err).Error("failed to write sd package item analysis file")
+		os.Exit(1)
+	}
+
+	logrus.Info("sd package item analysis written to ", output)
+}
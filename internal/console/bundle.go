@@ -0,0 +1,163 @@
+package console
+
+import (
+	"context"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/db"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/luckyAkbar/atec-api/internal/usecase"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var bundleCMD = &cobra.Command{
+	Use:  "bundle",
+	Long: "export or import sd templates and packages as a bundle file",
+}
+
+var bundleExportCMD = &cobra.Command{
+	Use:  "export",
+	Long: "export the sd templates and all of their packages to a bundle file",
+	Run:  bundleExportFn,
+}
+
+var bundleImportCMD = &cobra.Command{
+	Use:  "import",
+	Long: "import the sd templates and packages from a bundle file as new records",
+	Run:  bundleImportFn,
+}
+
+func init() {
+	bundleExportCMD.Flags().StringSlice("template-id", []string{}, "id of the sd template to export, can be repeated")
+	_ = bundleExportCMD.MarkFlagRequired("template-id")
+	bundleExportCMD.Flags().String("format", "json", "bundle format, json or yaml")
+	bundleExportCMD.Flags().String("output", "", "path of the bundle file to write. will write to stdout if not set")
+
+	bundleImportCMD.Flags().String("file", "", "path of the bundle file to import")
+	_ = bundleImportCMD.MarkFlagRequired("file")
+	bundleImportCMD.Flags().String("format", "json", "bundle format, json or yaml")
+	bundleImportCMD.Flags().String("created-by", "", "id of the admin user set as the creator of the imported records")
+	_ = bundleImportCMD.MarkFlagRequired("created-by")
+	bundleImportCMD.Flags().Bool("dry-run", false, "only validate the bundle without saving anything")
+
+	bundleCMD.AddCommand(bundleExportCMD, bundleImportCMD)
+	RootCMD.AddCommand(bundleCMD)
+}
+
+func bundleExportFn(cmd *cobra.Command, _ []string) {
+	format, err := model.ParseSDBundleFormat(cmd.Flag("format").Value.String())
+	if err != nil {
+		logrus.WithError(err).Error("invalid flag format")
+		os.Exit(1)
+	}
+
+	rawIDs, err := cmd.Flags().GetStringSlice("template-id")
+	if err != nil {
+		logrus.WithError(err).Error("invalid flag template-id")
+		os.Exit(1)
+	}
+
+	input := &model.ExportSDBundleInput{}
+	for _, v := range rawIDs {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			logrus.WithError(err).Error("flag template-id must be a valid uuid")
+			os.Exit(1)
+		}
+
+		input.TemplateIDs = append(input.TemplateIDs, id)
+	}
+
+	db.InitializePostgresConn()
+
+	bundleUsecase := usecase.NewSDBundleUsecase(repository.NewSDTemplateRepository(db.PostgresDB), repository.NewSDPackageRepository(db.PostgresDB), db.PostgresDB)
+
+	bundle, cerr := bundleUsecase.Export(context.Background(), input)
+	if cerr.Type != nil {
+		logrus.WithError(cerr.Cause).Error("failed to export sd bundle: ", cerr.Message)
+		os.Exit(1)
+	}
+
+	raw, err := bundle.Encode(format)
+	if err != nil {
+		logrus.WithError(err).Error("failed to encode sd bundle")
+		os.Exit(1)
+	}
+
+	output := cmd.Flag("output").Value.String()
+	if output == "" {
+		_, _ = os.Stdout.Write(raw)
+		return
+	}
+
+	if err := os.WriteFile(output, raw, 0o644); err != nil {
+		logrus.WithError(err).Error("failed to write sd bundle file")
+		os.Exit(1)
+	}
+
+	logrus.Info("sd bundle exported to ", output)
+}
+
+func bundleImportFn(cmd *cobra.Command, _ []string) {
+	format, err := model.ParseSDBundleFormat(cmd.Flag("format").Value.String())
+	if err != nil {
+		logrus.WithError(err).Error("invalid flag format")
+		os.Exit(1)
+	}
+
+	createdBy, err := uuid.Parse(cmd.Flag("created-by").Value.String())
+	if err != nil {
+		logrus.WithError(err).Error("flag created-by must be a valid uuid")
+		os.Exit(1)
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		logrus.WithError(err).Error("invalid flag dry-run")
+		os.Exit(1)
+	}
+
+	raw, err := os.ReadFile(cmd.Flag("file").Value.String())
+	if err != nil {
+		logrus.WithError(err).Error("failed to read sd bundle file")
+		os.Exit(1)
+	}
+
+	bundle, err := model.DecodeSDBundle(raw, format)
+	if err != nil {
+		logrus.WithError(err).Error("failed to decode sd bundle file")
+		os.Exit(1)
+	}
+
+	db.InitializePostgresConn()
+
+	bundleUsecase := usecase.NewSDBundleUsecase(repository.NewSDTemplateRepository(db.PostgresDB), repository.NewSDPackageRepository(db.PostgresDB), db.PostgresDB)
+
+	ctx := model.SetUserToCtx(context.Background(), model.AuthUser{
+		UserID: createdBy,
+		Role:   model.RoleAdmin,
+	})
+
+	res, cerr := bundleUsecase.Import(ctx, &model.ImportSDBundleInput{
+		Bundle: bundle,
+		DryRun: dryRun,
+	})
+	if cerr.Type != nil {
+		logrus.WithError(cerr.Cause).Error("failed to import sd bundle: ", cerr.Message)
+		os.Exit(1)
+	}
+
+	if res.DryRun {
+		logrus.Infof("sd bundle is valid: %d templates and %d packages will be imported", len(res.Templates), len(res.Packages))
+		return
+	}
+
+	for oldID, newID := range res.IDMapping {
+		logrus.Infof("%s imported as %s", oldID, newID)
+	}
+
+	logrus.Infof("sd bundle imported: %d templates and %d packages", len(res.Templates), len(res.Packages))
+}
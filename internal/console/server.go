@@ -16,6 +16,7 @@ import (
 	"github.com/luckyAkbar/atec-api/internal/config"
 	"github.com/luckyAkbar/atec-api/internal/db"
 	"github.com/luckyAkbar/atec-api/internal/delivery/rest"
+	"github.com/luckyAkbar/atec-api/internal/model"
 	"github.com/luckyAkbar/atec-api/internal/repository"
 	"github.com/luckyAkbar/atec-api/internal/usecase"
 	"github.com/luckyAkbar/atec-api/internal/worker"
@@ -53,6 +54,10 @@ func serverFn(_ *cobra.Command, _ []string) {
 		panic(err)
 	}
 
+	if err := model.ValidateSDResultVerificationBaseURL(config.SDResultVerificationBaseURL()); err != nil {
+		panic(err)
+	}
+
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:         config.RedisAddr(),
 		Password:     config.RedisPassword(),
@@ -85,6 +90,10 @@ func serverFn(_ *cobra.Command, _ []string) {
 	sdtemplateRepo := repository.NewSDTemplateRepository(db.PostgresDB)
 	sdpackageRepo := repository.NewSDPackageRepository(db.PostgresDB)
 	sdtRepo := repository.NewSDTestResultRepository(db.PostgresDB)
+	sdassignmentRepo := repository.NewSDAssignmentRepository(db.PostgresDB)
+	childprofileRepo := repository.NewChildProfileRepository(db.PostgresDB)
+	sdanalyticsRepo := repository.NewSDAnalyticsRepository(db.PostgresDB)
+	sdresultshareRepo := repository.NewSDResultShareRepository(db.PostgresDB)
 
 	workerPkgClient, err := workerPkg.NewClient(config.WorkerBrokerHost())
 	if err != nil {
@@ -98,7 +107,11 @@ func serverFn(_ *cobra.Command, _ []string) {
 	authUsecase := usecase.NewAuthUsecase(accessTokenRepo, userRepo, sharedCryptor, workerClient)
 	sdtemplateUsecase := usecase.NewSDTemplateUsecase(sdtemplateRepo)
 	sdpackageUsecase := usecase.NewSDPackageUsecase(sdpackageRepo, sdtemplateRepo)
-	sdtUsecase := usecase.NewSDTestResultUsecase(sdtRepo, sdpackageRepo, sharedCryptor, db.PostgresDB, f)
+	sdtUsecase := usecase.NewSDTestResultUsecase(sdtRepo, sdpackageRepo, sdtemplateRepo, sdassignmentRepo, childprofileRepo, sdresultshareRepo, userRepo, sharedCryptor, common.NewSigner(key.PrivateKey), emailUsecase, workerClient, db.PostgresDB, f)
+	sdbundleUsecase := usecase.NewSDBundleUsecase(sdtemplateRepo, sdpackageRepo, db.PostgresDB)
+	sdassignmentUsecase := usecase.NewSDAssignmentUsecase(sdassignmentRepo, userRepo, sdpackageRepo, sdtemplateRepo, sharedCryptor, emailUsecase, db.PostgresDB)
+	childprofileUsecase := usecase.NewChildProfileUsecase(childprofileRepo)
+	sdanalyticsUsecase := usecase.NewSDAnalyticsUsecase(sdanalyticsRepo, sdtemplateRepo, sdpackageRepo)
 
 	httpServer := echo.New()
 
@@ -109,7 +122,7 @@ func serverFn(_ *cobra.Command, _ []string) {
 
 	rootGroup := httpServer.Group("")
 
-	rest.NewService(rootGroup, apirespGen, userUsecase, authUsecase, sdtemplateUsecase, sdpackageUsecase, sdtUsecase)
+	rest.NewService(rootGroup, apirespGen, userUsecase, authUsecase, sdtemplateUsecase, sdpackageUsecase, sdtUsecase, sdbundleUsecase, sdassignmentUsecase, childprofileUsecase, sdanalyticsUsecase)
 
 	sigCh := make(chan os.Signal, 1)
 	errCh := make(chan error, 1)
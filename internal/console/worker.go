@@ -6,16 +6,23 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/golang/freetype/truetype"
 	"github.com/hibiken/asynq"
+	"github.com/luckyAkbar/atec-api/internal/common"
 	"github.com/luckyAkbar/atec-api/internal/config"
 	"github.com/luckyAkbar/atec-api/internal/db"
+	"github.com/luckyAkbar/atec-api/internal/model"
 	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/luckyAkbar/atec-api/internal/usecase"
 	"github.com/luckyAkbar/atec-api/internal/worker"
 	"github.com/redis/go-redis/v9"
+	"github.com/sendinblue/APIv3-go-library/lib"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/time/rate"
 
+	"github.com/sweet-go/stdlib/encryption"
 	"github.com/sweet-go/stdlib/mail"
 	workerPkg "github.com/sweet-go/stdlib/worker"
 )
@@ -31,6 +38,25 @@ func init() {
 }
 
 func workerFn(_ *cobra.Command, _ []string) {
+	key, err := encryption.ReadKeyFromFile("./private.pem")
+	if err != nil {
+		panic(err)
+	}
+
+	// the font is required to generate the sd test result delivered by email
+	fontBytes, err := os.ReadFile("./assets/font.ttf")
+	if err != nil {
+		panic(err)
+	}
+	f, err := truetype.Parse(fontBytes)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := model.ValidateSDResultVerificationBaseURL(config.SDResultVerificationBaseURL()); err != nil {
+		panic(err)
+	}
+
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:         config.RedisAddr(),
 		Password:     config.RedisPassword(),
@@ -53,8 +79,37 @@ func workerFn(_ *cobra.Command, _ []string) {
 
 	emailRepo := repository.NewEmailRepository(db.PostgresDB)
 	mailUtil := mail.NewUtility(sibClient, mailgunClient)
+	attachmentMailer := common.NewAttachmentMailer(lib.NewAPIClient(lib.NewConfiguration()), config.SendInBlueSender(), config.SendinblueAPIKey(), config.SendInBlueIsActivated())
 	userRepo := repository.NewUserRepository(db.PostgresDB, cacher)
 	accessTokenRepo := repository.NewAccessTokenRepository(db.PostgresDB, cacher)
+	sdtestRepo := repository.NewSDTestResultRepository(db.PostgresDB)
+	sdpackageRepo := repository.NewSDPackageRepository(db.PostgresDB)
+	sdtemplateRepo := repository.NewSDTemplateRepository(db.PostgresDB)
+	sdassignmentRepo := repository.NewSDAssignmentRepository(db.PostgresDB)
+	childprofileRepo := repository.NewChildProfileRepository(db.PostgresDB)
+	sdresultshareRepo := repository.NewSDResultShareRepository(db.PostgresDB)
+
+	sharedCryptor := common.NewSharedCryptor(&common.CreateCryptorOpts{
+		HashCost:      bcrypt.DefaultCost,
+		EncryptionKey: key.Bytes,
+		IV:            config.IVKey(),
+		BlockSize:     common.DefaultBlockSize,
+	})
+
+	workerPkgClient, err := workerPkg.NewClient(config.WorkerBrokerHost())
+	if err != nil {
+		panic(err)
+	}
+
+	workerClient := worker.NewClient(workerPkgClient)
+	emailUsecase := usecase.NewEmailUsecase(emailRepo, workerClient)
+	sdtUsecase := usecase.NewSDTestResultUsecase(sdtestRepo, sdpackageRepo, sdtemplateRepo, sdassignmentRepo, childprofileRepo, sdresultshareRepo, userRepo, sharedCryptor, common.NewSigner(key.PrivateKey), emailUsecase, workerClient, db.PostgresDB, f)
+
+	schedulerOpts := &asynq.SchedulerOpts{
+		LogLevel: config.WorkerLogLevel(),
+		Logger:   logrus.New(),
+		Location: time.UTC,
+	}
 
 	server, err := worker.NewServer(config.WorkerBrokerHost(), worker.ServerConfig{
 		AsynqConfig: asynq.Config{
@@ -67,16 +122,15 @@ func workerFn(_ *cobra.Command, _ []string) {
 			StrictPriority:      true,
 			RetryDelayFunc:      workerPkg.DefaultRetryDelayFn,
 		},
-		SchedulerOpts: &asynq.SchedulerOpts{
-			LogLevel: config.WorkerLogLevel(),
-			Logger:   logrus.New(),
-			Location: time.UTC,
-		},
-		MailUtil:        mailUtil,
-		MailRepo:        emailRepo,
-		UserRepo:        userRepo,
-		AccessTokenRepo: accessTokenRepo,
-		Limiter:         rate.NewLimiter(rate.Limit(config.WorkerLimiterLimit()), config.WorkerLimiterBurst()),
+		SchedulerOpts:    schedulerOpts,
+		MailUtil:         mailUtil,
+		AttachmentMailer: attachmentMailer,
+		MailRepo:         emailRepo,
+		UserRepo:         userRepo,
+		AccessTokenRepo:  accessTokenRepo,
+		SDTestRepo:       sdtestRepo,
+		SDTestUsecase:    sdtUsecase,
+		Limiter:          rate.NewLimiter(rate.Limit(config.WorkerLimiterLimit()), config.WorkerLimiterBurst()),
 	})
 
 	if err != nil {
@@ -84,6 +138,17 @@ func workerFn(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	scheduler, err := worker.NewScheduler(config.WorkerBrokerHost(), schedulerOpts)
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to create worker scheduler")
+		os.Exit(1)
+	}
+
+	if err := scheduler.Start(); err != nil {
+		logrus.WithError(err).Fatal("failed to start worker scheduler")
+		os.Exit(1)
+	}
+
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
 	errch := make(chan error)
@@ -92,10 +157,12 @@ func workerFn(_ *cobra.Command, _ []string) {
 	select {
 	case sig := <-sigCh:
 		logrus.Infof("receiving signal to stop worker server from console: %s. Gracefully shutting down worker", sig.String())
+		scheduler.Shutdown()
 		server.Stop()
 		os.Exit(0)
 	case err := <-errch:
 		logrus.WithError(err).Error("receiving quit signal from worker server. Gracefully shutting down worker")
+		scheduler.Shutdown()
 		server.Stop()
 		os.Exit(1)
 	}
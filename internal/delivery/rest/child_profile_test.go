@@ -0,0 +1,494 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/model/mock"
+	"github.com/luckyAkbar/atec-api/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	stdhttp "github.com/sweet-go/stdlib/http"
+	httpMock "github.com/sweet-go/stdlib/http/mock"
+)
+
+func TestRest_handleCreateChildProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockChildProfileUc := mock.NewMockChildProfileUsecase(ctrl)
+
+	body := `
+		{
+			"request": {
+				"name": "child",
+				"birthDate": "2021-01-01T00:00:00Z",
+				"sex": "female",
+				"notes": "notes"
+			},
+			"signature": "sig"
+		}
+	`
+	input := &model.ChildProfileInput{
+		Name:      "child",
+		BirthDate: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Sex:       model.ChildSexFemale,
+		Notes:     "notes",
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "binding json failed",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					childprofileUsecase:  mockChildProfileUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/users/children/", strings.NewReader(`{"request": invalid}`))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleCreateChildProfile()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					childprofileUsecase:  mockChildProfileUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/users/children/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockChildProfileUc.EXPECT().Create(ectx.Request().Context(), input).Times(1).Return(nil, &common.Error{
+					Type: usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleCreateChildProfile()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					childprofileUsecase:  mockChildProfileUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/users/children/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				res := &model.GeneratedChildProfile{
+					ID:        uuid.New(),
+					Name:      input.Name,
+					BirthDate: input.BirthDate,
+					Sex:       input.Sex,
+				}
+				mockChildProfileUc.EXPECT().Create(ectx.Request().Context(), input).Times(1).Return(res, &common.Error{
+					Type: nil,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleCreateChildProfile()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleSearchChildProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockChildProfileUc := mock.NewMockChildProfileUsecase(ctrl)
+
+	userID := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid query",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					childprofileUsecase:  mockChildProfileUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/users/children/?limit=invalid", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleSearchChildProfile()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					childprofileUsecase:  mockChildProfileUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/users/children/?limit=10&userID="+userID.String(), nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				res := []*model.GeneratedChildProfile{{ID: uuid.New(), UserID: userID}}
+				mockChildProfileUc.EXPECT().Search(ectx.Request().Context(), &model.SearchChildProfileInput{
+					UserID: uuid.NullUUID{UUID: userID, Valid: true},
+					Limit:  10,
+				}).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleSearchChildProfile()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleFindChildProfileByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockChildProfileUc := mock.NewMockChildProfileUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid id",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					childprofileUsecase:  mockChildProfileUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues("invalid")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleFindChildProfileByID()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return specific err",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					childprofileUsecase:  mockChildProfileUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				cerr := &common.Error{
+					Message: "forbidden to access other people child profile",
+					Code:    http.StatusForbidden,
+					Type:    usecase.ErrForbiddenToAccessChildProfile,
+				}
+				mockChildProfileUc.EXPECT().FindByID(ectx.Request().Context(), id).Times(1).Return(nil, cerr)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleFindChildProfileByID()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					childprofileUsecase:  mockChildProfileUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.GeneratedChildProfile{ID: id}
+				mockChildProfileUc.EXPECT().FindByID(ectx.Request().Context(), id).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleFindChildProfileByID()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleUpdateChildProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockChildProfileUc := mock.NewMockChildProfileUsecase(ctrl)
+
+	body := `
+		{
+			"request": {
+				"name": "child",
+				"birthDate": "2021-01-01T00:00:00Z",
+				"sex": "male"
+			}
+		}
+	`
+	input := &model.ChildProfileInput{
+		Name:      "child",
+		BirthDate: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Sex:       model.ChildSexMale,
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid id",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					childprofileUsecase:  mockChildProfileUc,
+				}
+				req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues("invalid")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleUpdateChildProfile()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					childprofileUsecase:  mockChildProfileUc,
+				}
+				req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.GeneratedChildProfile{ID: id, Name: input.Name}
+				mockChildProfileUc.EXPECT().Update(ectx.Request().Context(), id, input).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleUpdateChildProfile()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleDeleteChildProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockChildProfileUc := mock.NewMockChildProfileUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid id",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					childprofileUsecase:  mockChildProfileUc,
+				}
+				req := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues("invalid")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleDeleteChildProfile()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					childprofileUsecase:  mockChildProfileUc,
+				}
+				req := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				mockChildProfileUc.EXPECT().Delete(ectx.Request().Context(), id).Times(1).Return(nil, &common.Error{Type: usecase.ErrInternal})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleDeleteChildProfile()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					childprofileUsecase:  mockChildProfileUc,
+				}
+				req := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.GeneratedChildProfile{ID: id}
+				mockChildProfileUc.EXPECT().Delete(ectx.Request().Context(), id).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleDeleteChildProfile()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
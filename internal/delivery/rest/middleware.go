@@ -65,6 +65,26 @@ func (s *service) allowUnauthorizedAccess() echo.MiddlewareFunc {
 	}
 }
 
+// localeMiddleware will set the requested locale to the request context. The lang query param
+// take precedence over the Accept-Language header. If neither is set, the default locale will be used
+func (s *service) localeMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			locale := model.ParseLocale(c.QueryParam("lang"))
+			if locale == "" {
+				locale = model.LocaleFromAcceptLanguage(c.Request().Header.Get("Accept-Language"))
+			}
+
+			if locale != "" {
+				newCtx := model.SetLocaleToCtx(c.Request().Context(), locale)
+				c.SetRequest(c.Request().WithContext(newCtx))
+			}
+
+			return next(c)
+		}
+	}
+}
+
 func getAccessToken(req *http.Request) (accessToken string) {
 	authHeaders := strings.Split(req.Header.Get("Authorization"), " ")
 
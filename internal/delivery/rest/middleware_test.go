@@ -320,3 +320,53 @@ func TestRest_allowUnauthorizedAccess(t *testing.T) {
 		})
 	}
 }
+
+func TestRest_localeMiddleware(t *testing.T) {
+	s := &service{}
+
+	tests := []struct {
+		Name           string
+		Target         string
+		AcceptLanguage string
+		Expected       model.Locale
+	}{
+		{
+			Name:     "nothing requested, use default locale",
+			Target:   "/",
+			Expected: model.DefaultLocale,
+		},
+		{
+			Name:           "use the most preferred Accept-Language",
+			Target:         "/",
+			AcceptLanguage: "id;q=0.5, en-US;q=0.9, *;q=0.1",
+			Expected:       model.LocaleEnglish,
+		},
+		{
+			Name:           "lang query param take precedence",
+			Target:         "/?lang=id",
+			AcceptLanguage: "en-US",
+			Expected:       model.LocaleIndonesian,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, tt.Target, nil)
+			if tt.AcceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.AcceptLanguage)
+			}
+
+			rec := httptest.NewRecorder()
+			ectx := e.NewContext(req, rec)
+
+			fn := func(c echo.Context) error {
+				assert.Equal(t, model.GetLocaleFromCtx(c.Request().Context()), tt.Expected)
+				return c.JSON(http.StatusOK, `{"message": "ok"}`)
+			}
+
+			err := s.localeMiddleware()(fn)(ectx)
+			assert.NoError(t, err)
+		})
+	}
+}
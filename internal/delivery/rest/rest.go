@@ -14,10 +14,14 @@ type service struct {
 	sdtemplateUsecase    model.SDTemplateUsecase
 	sdpackageUsecase     model.SDPackageUsecase
 	sdtestUsecase        model.SDTestUsecase
+	sdbundleUsecase      model.SDBundleUsecase
+	sdassignmentUsecase  model.SDAssignmentUsecase
+	childprofileUsecase  model.ChildProfileUsecase
+	sdanalyticsUsecase   model.SDAnalyticsUsecase
 }
 
 // NewService will create http service and register all of it's routes
-func NewService(rootGroup *echo.Group, apiResponseGenerator stdhttp.APIResponseGenerator, userUsecase model.UserUsecase, authUsecase model.AuthUsecase, sdtemplateUsecase model.SDTemplateUsecase, sdpackageUsecase model.SDPackageUsecase, sdtestUsecase model.SDTestUsecase) {
+func NewService(rootGroup *echo.Group, apiResponseGenerator stdhttp.APIResponseGenerator, userUsecase model.UserUsecase, authUsecase model.AuthUsecase, sdtemplateUsecase model.SDTemplateUsecase, sdpackageUsecase model.SDPackageUsecase, sdtestUsecase model.SDTestUsecase, sdbundleUsecase model.SDBundleUsecase, sdassignmentUsecase model.SDAssignmentUsecase, childprofileUsecase model.ChildProfileUsecase, sdanalyticsUsecase model.SDAnalyticsUsecase) {
 	s := &service{
 		rootGroup:            rootGroup,
 		apiResponseGenerator: apiResponseGenerator,
@@ -26,6 +30,10 @@ func NewService(rootGroup *echo.Group, apiResponseGenerator stdhttp.APIResponseG
 		sdtemplateUsecase:    sdtemplateUsecase,
 		sdpackageUsecase:     sdpackageUsecase,
 		sdtestUsecase:        sdtestUsecase,
+		sdbundleUsecase:      sdbundleUsecase,
+		sdassignmentUsecase:  sdassignmentUsecase,
+		childprofileUsecase:  childprofileUsecase,
+		sdanalyticsUsecase:   sdanalyticsUsecase,
 	}
 
 	s.initRoutes()
@@ -37,6 +45,11 @@ func (s *service) initRoutes() {
 	s.rootGroup.POST("/users/accounts/validation/", s.handleAccountVerification())
 	s.rootGroup.PATCH("/users/accounts/:id/reset-password/", s.handleInitiateResetUserPassword(), s.authMiddleware(true))
 	s.rootGroup.PATCH("/users/accounts/:id/activation-status/", s.handleChangeUserActivationStatus(), s.authMiddleware(true))
+	s.rootGroup.POST("/users/children/", s.handleCreateChildProfile(), s.authMiddleware(false))
+	s.rootGroup.GET("/users/children/", s.handleSearchChildProfile(), s.authMiddleware(false))
+	s.rootGroup.GET("/users/children/:id/", s.handleFindChildProfileByID(), s.authMiddleware(false))
+	s.rootGroup.PUT("/users/children/:id/", s.handleUpdateChildProfile(), s.authMiddleware(false))
+	s.rootGroup.DELETE("/users/children/:id/", s.handleDeleteChildProfile(), s.authMiddleware(false))
 
 	s.rootGroup.POST("/auth/sessions/", s.handleLogIn())
 	s.rootGroup.DELETE("/auth/sessions/", s.handleLogOut(), s.authMiddleware(false))
@@ -50,6 +63,9 @@ func (s *service) initRoutes() {
 	s.rootGroup.DELETE("/sdt/templates/:id/", s.handleDeleteSDTemplate(), s.authMiddleware(true))
 	s.rootGroup.PATCH("/sdt/templates/:id/", s.handleUndoDeleteSDTemplate(), s.authMiddleware(true))
 	s.rootGroup.PATCH("/sdt/templates/:id/activation-status/", s.handleChangeSDTemplateActivationStatus(), s.authMiddleware(true))
+	s.rootGroup.GET("/sdt/templates/:id/versions/", s.handleFindSDTemplateVersions(), s.authMiddleware(true))
+	s.rootGroup.GET("/sdt/templates/:id/versions/:version/", s.handleFindSDTemplateVersion(), s.authMiddleware(true))
+	s.rootGroup.POST("/sdt/templates/:id/clone/", s.handleCloneSDTemplate(), s.authMiddleware(true))
 
 	s.rootGroup.POST("/sdt/packages/", s.handleCreateSDPackage(), s.authMiddleware(true))
 	s.rootGroup.GET("/sdt/packages/lists/", s.handleFindReadyToUsePackages())
@@ -59,10 +75,36 @@ func (s *service) initRoutes() {
 	s.rootGroup.DELETE("/sdt/packages/:id/", s.handleDeleteSDPackage(), s.authMiddleware(true))
 	s.rootGroup.PATCH("/sdt/packages/:id/", s.handleUndoDeleteSDPackage(), s.authMiddleware(true))
 	s.rootGroup.PATCH("/sdt/packages/:id/activation-status/", s.handleChangeSDPackageActivationStatus(), s.authMiddleware(true))
+	s.rootGroup.GET("/sdt/packages/:id/versions/", s.handleFindSDPackageVersions(), s.authMiddleware(true))
+	s.rootGroup.GET("/sdt/packages/:id/versions/:version/", s.handleFindSDPackageVersion(), s.authMiddleware(true))
+	s.rootGroup.POST("/sdt/packages/:id/clone/", s.handleCloneSDPackage(), s.authMiddleware(true))
 
-	s.rootGroup.POST("/sdt/tests/", s.handleInitiateSDTest(), s.allowUnauthorizedAccess())
-	s.rootGroup.POST("/sdt/tests/submissions/", s.handleSubmitSDTestAnswer(), s.allowUnauthorizedAccess())
+	s.rootGroup.GET("/sdt/bundles/", s.handleExportSDBundle(), s.authMiddleware(true))
+	s.rootGroup.POST("/sdt/bundles/", s.handleImportSDBundle(), s.authMiddleware(true))
+
+	s.rootGroup.GET("/sdt/analytics/templates/:id/", s.handleGetSDTemplateAnalytics(), s.authMiddleware(true))
+	s.rootGroup.GET("/sdt/analytics/packages/:id/", s.handleGetSDPackageAnalytics(), s.authMiddleware(true))
+	s.rootGroup.GET("/sdt/analytics/packages/:id/items/", s.handleGetSDPackageItemAnalysis(), s.authMiddleware(true))
+
+	s.rootGroup.POST("/sdt/assignments/", s.handleCreateSDAssignment(), s.authMiddleware(true))
+	s.rootGroup.GET("/sdt/assignments/", s.handleSearchSDAssignment(), s.authMiddleware(false))
+	s.rootGroup.DELETE("/sdt/assignments/:id/", s.handleCancelSDAssignment(), s.authMiddleware(true))
+
+	s.rootGroup.POST("/sdt/tests/", s.handleInitiateSDTest(), s.allowUnauthorizedAccess(), s.localeMiddleware())
+	s.rootGroup.POST("/sdt/tests/submissions/", s.handleSubmitSDTestAnswer(), s.allowUnauthorizedAccess(), s.localeMiddleware())
 	s.rootGroup.GET("/sdt/tests/submissions/", s.handleViewSDTestHistories(), s.authMiddleware(false))
+	s.rootGroup.POST("/sdt/tests/claims/", s.handleClaimSDTest(), s.authMiddleware(false))
+	s.rootGroup.PUT("/sdt/tests/drafts/", s.handleSaveSDTestDraft(), s.allowUnauthorizedAccess(), s.localeMiddleware())
+	s.rootGroup.POST("/sdt/tests/drafts/views/", s.handleViewSDTestDraft(), s.allowUnauthorizedAccess(), s.localeMiddleware())
 	s.rootGroup.GET("/sdt/results/statistics/:user_id/", s.handleGetSDTestStatistic(), s.authMiddleware(false))
-	s.rootGroup.GET("/sdt/results/:id/image/", s.handleDownloadTestResult(), s.allowUnauthorizedAccess())
+	s.rootGroup.GET("/sdt/results/statistics/:user_id/charts/:template_id/", s.handleGetSDTestProgressChart(), s.authMiddleware(false), s.localeMiddleware())
+	s.rootGroup.GET("/sdt/results/:id/image/", s.handleDownloadTestResult(), s.allowUnauthorizedAccess(), s.localeMiddleware())
+	s.rootGroup.GET("/sdt/results/:id/report/", s.handleDownloadTestResultReport(), s.allowUnauthorizedAccess(), s.localeMiddleware())
+	s.rootGroup.GET("/sdt/results/:id/verification/", s.handleVerifySDTestResult())
+	s.rootGroup.GET("/sdt/results/public-key/", s.handleGetSDTestResultPublicKey())
+	s.rootGroup.POST("/sdt/results/:id/shares/", s.handleCreateSDResultShare(), s.authMiddleware(false))
+	s.rootGroup.GET("/sdt/results/:id/shares/", s.handleFindSDResultShares(), s.authMiddleware(false))
+	s.rootGroup.DELETE("/sdt/results/:id/shares/:share_id/", s.handleRevokeSDResultShare(), s.authMiddleware(false))
+	s.rootGroup.GET("/sdt/results/shared/", s.handleViewSharedSDTestResult(), s.localeMiddleware())
+	s.rootGroup.GET("/sdt/results/shared/image/", s.handleViewSharedSDTestResultImage(), s.localeMiddleware())
 }
@@ -0,0 +1,352 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/model/mock"
+	"github.com/luckyAkbar/atec-api/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	stdhttp "github.com/sweet-go/stdlib/http"
+	httpMock "github.com/sweet-go/stdlib/http/mock"
+)
+
+func TestRest_handleGetSDTemplateAnalytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockSDAnalyticsUc := mock.NewMockSDAnalyticsUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid id",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdanalyticsUsecase:   mockSDAnalyticsUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues("invalid")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleGetSDTemplateAnalytics()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdanalyticsUsecase:   mockSDAnalyticsUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				mockSDAnalyticsUc.EXPECT().TemplateAnalytics(ectx.Request().Context(), &model.SDAnalyticsInput{ID: id}).Times(1).Return(nil, &common.Error{
+					Type: usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleGetSDTemplateAnalytics()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return specific err",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdanalyticsUsecase:   mockSDAnalyticsUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				cerr := &common.Error{
+					Message: "sd template not found",
+					Code:    http.StatusNotFound,
+					Type:    usecase.ErrResourceNotFound,
+				}
+				mockSDAnalyticsUc.EXPECT().TemplateAnalytics(ectx.Request().Context(), &model.SDAnalyticsInput{ID: id}).Times(1).Return(nil, cerr)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleGetSDTemplateAnalytics()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok with date range",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdanalyticsUsecase:   mockSDAnalyticsUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?from=2023-01-01T00:00:00Z&to=2023-02-01T00:00:00Z", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				input := &model.SDAnalyticsInput{
+					ID:   id,
+					From: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC),
+				}
+				res := &model.SDAnalytics{TemplateID: id, From: input.From, To: input.To}
+				mockSDAnalyticsUc.EXPECT().TemplateAnalytics(ectx.Request().Context(), input).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleGetSDTemplateAnalytics()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleGetSDPackageAnalytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockSDAnalyticsUc := mock.NewMockSDAnalyticsUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid id",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdanalyticsUsecase:   mockSDAnalyticsUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues("invalid")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleGetSDPackageAnalytics()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdanalyticsUsecase:   mockSDAnalyticsUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				mockSDAnalyticsUc.EXPECT().PackageAnalytics(ectx.Request().Context(), &model.SDAnalyticsInput{ID: id}).Times(1).Return(nil, &common.Error{
+					Type: usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleGetSDPackageAnalytics()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdanalyticsUsecase:   mockSDAnalyticsUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.SDAnalytics{PackageID: uuid.NullUUID{UUID: id, Valid: true}}
+				mockSDAnalyticsUc.EXPECT().PackageAnalytics(ectx.Request().Context(), &model.SDAnalyticsInput{ID: id}).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleGetSDPackageAnalytics()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleGetSDPackageItemAnalysis(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockSDAnalyticsUc := mock.NewMockSDAnalyticsUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid dominance threshold",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdanalyticsUsecase:   mockSDAnalyticsUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?dominanceThreshold=invalid", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(uuid.NewString())
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleGetSDPackageItemAnalysis()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdanalyticsUsecase:   mockSDAnalyticsUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				mockSDAnalyticsUc.EXPECT().PackageItemAnalysis(ectx.Request().Context(), &model.SDItemAnalysisInput{ID: id}).Times(1).Return(nil, &common.Error{
+					Type: usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleGetSDPackageItemAnalysis()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdanalyticsUsecase:   mockSDAnalyticsUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?dominanceThreshold=0.8", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.SDItemAnalysisReport{PackageID: id, DominanceThreshold: 0.8}
+				mockSDAnalyticsUc.EXPECT().PackageItemAnalysis(ectx.Request().Context(), &model.SDItemAnalysisInput{ID: id, DominanceThreshold: 0.8}).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleGetSDPackageItemAnalysis()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/model/mock"
+	"github.com/luckyAkbar/atec-api/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	stdhttp "github.com/sweet-go/stdlib/http"
+	httpMock "github.com/sweet-go/stdlib/http/mock"
+)
+
+func TestRest_handleCreateSDAssignment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockSDAssignmentUc := mock.NewMockSDAssignmentUsecase(ctrl)
+
+	assigneeID := uuid.New()
+	packageID := uuid.New()
+	dueAt := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	body := `
+		{
+			"request": {
+				"assigneeID": "` + assigneeID.String() + `",
+				"packageID": "` + packageID.String() + `",
+				"dueAt": "2030-01-01T00:00:00Z",
+				"note": "note"
+			},
+			"signature": "sig"
+		}
+	`
+	input := &model.CreateSDAssignmentInput{
+		AssigneeID: assigneeID,
+		PackageID:  uuid.NullUUID{UUID: packageID, Valid: true},
+		DueAt:      dueAt,
+		Note:       "note",
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "binding json failed",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdassignmentUsecase:  mockSDAssignmentUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/assignments/", strings.NewReader(`{"request": invalid}`))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleCreateSDAssignment()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdassignmentUsecase:  mockSDAssignmentUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/assignments/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockSDAssignmentUc.EXPECT().Create(ectx.Request().Context(), input).Times(1).Return(nil, &common.Error{
+					Type: usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleCreateSDAssignment()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdassignmentUsecase:  mockSDAssignmentUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/assignments/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				res := &model.GeneratedSDAssignment{
+					ID:         uuid.New(),
+					AssigneeID: assigneeID,
+					PackageID:  input.PackageID,
+					DueAt:      dueAt,
+					Status:     model.SDAssignmentStatusAssigned,
+				}
+				mockSDAssignmentUc.EXPECT().Create(ectx.Request().Context(), input).Times(1).Return(res, &common.Error{
+					Type: nil,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleCreateSDAssignment()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleCancelSDAssignment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockSDAssignmentUc := mock.NewMockSDAssignmentUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid id",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdassignmentUsecase:  mockSDAssignmentUc,
+				}
+				req := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues("invalid")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleCancelSDAssignment()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return specific err",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdassignmentUsecase:  mockSDAssignmentUc,
+				}
+				req := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				cerr := &common.Error{
+					Message: "sd test assignment is already completed",
+					Code:    http.StatusForbidden,
+					Type:    usecase.ErrSDAssignmentNotOpen,
+				}
+				mockSDAssignmentUc.EXPECT().Cancel(ectx.Request().Context(), id).Times(1).Return(nil, cerr)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleCancelSDAssignment()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdassignmentUsecase:  mockSDAssignmentUc,
+				}
+				req := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.GeneratedSDAssignment{
+					ID:     id,
+					Status: model.SDAssignmentStatusCancelled,
+				}
+				mockSDAssignmentUc.EXPECT().Cancel(ectx.Request().Context(), id).Times(1).Return(res, &common.Error{
+					Type: nil,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleCancelSDAssignment()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
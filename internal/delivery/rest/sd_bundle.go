@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/usecase"
+	"github.com/sirupsen/logrus"
+	stdhttp "github.com/sweet-go/stdlib/http"
+)
+
+func (s *service) handleExportSDBundle() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		format, err := model.ParseSDBundleFormat(c.QueryParam("format"))
+		if err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		input := &model.ExportSDBundleInput{}
+		for _, v := range c.QueryParams()["templateID"] {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+			}
+
+			input.TemplateIDs = append(input.TemplateIDs, id)
+		}
+
+		bundle, custerr := s.sdbundleUsecase.Export(c.Request().Context(), input)
+		switch custerr.Type {
+		default:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, custerr.GenerateStdlibHTTPResponse(nil), nil)
+		case usecase.ErrInternal:
+			logrus.WithContext(c.Request().Context()).WithError(custerr.Cause).Error("failed to handle export sd bundle request")
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		case nil:
+			break
+		}
+
+		raw, err := bundle.Encode(format)
+		if err != nil {
+			logrus.WithContext(c.Request().Context()).WithError(err).Error("failed to encode sd bundle")
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		c.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"sd-bundle.%s\"", format))
+		return c.Blob(http.StatusOK, format.ContentType(), raw)
+	}
+}
+
+func (s *service) handleImportSDBundle() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		format, err := model.ParseSDBundleFormat(c.QueryParam("format"))
+		if err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		dryRun := false
+		if v := c.QueryParam("dryRun"); v != "" {
+			dryRun, err = strconv.ParseBool(v)
+			if err != nil {
+				return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+			}
+		}
+
+		raw, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		bundle, err := model.DecodeSDBundle(raw, format)
+		if err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		resp, custerr := s.sdbundleUsecase.Import(c.Request().Context(), &model.ImportSDBundleInput{
+			Bundle: bundle,
+			DryRun: dryRun,
+		})
+		switch custerr.Type {
+		default:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, custerr.GenerateStdlibHTTPResponse(nil), nil)
+		case usecase.ErrInternal:
+			logrus.WithContext(c.Request().Context()).WithError(custerr.Cause).Error("failed to handle import sd bundle request")
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		case nil:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, &stdhttp.StandardResponse{
+				Success: true,
+				Message: "success",
+				Status:  http.StatusOK,
+				Data:    resp,
+			}, nil)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/model/mock"
+	"github.com/luckyAkbar/atec-api/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	stdhttp "github.com/sweet-go/stdlib/http"
+	httpMock "github.com/sweet-go/stdlib/http/mock"
+)
+
+func TestRest_handleExportSDBundle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockSDBundleUc := mock.NewMockSDBundleUsecase(ctrl)
+
+	templateID := uuid.New()
+	bundle := &model.SDBundle{
+		Version: model.SDBundleVersion,
+		Templates: []model.SDBundleTemplate{
+			{
+				ID:       templateID,
+				Template: &model.SDTemplate{Name: "template"},
+				Packages: []model.SDBundlePackage{},
+			},
+		},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid format",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdbundleUsecase:      mockSDBundleUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/sdt/bundles/?format=xml&templateID="+templateID.String(), nil)
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleExportSDBundle()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "invalid template id",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdbundleUsecase:      mockSDBundleUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/sdt/bundles/?templateID=invalid", nil)
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleExportSDBundle()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdbundleUsecase:      mockSDBundleUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/sdt/bundles/?templateID="+templateID.String(), nil)
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockSDBundleUc.EXPECT().Export(ectx.Request().Context(), &model.ExportSDBundleInput{TemplateIDs: []uuid.UUID{templateID}}).Times(1).Return(nil, &common.Error{
+					Message: "err internal",
+					Cause:   errors.New("err internal"),
+					Code:    http.StatusInternalServerError,
+					Type:    usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleExportSDBundle()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return err not found",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdbundleUsecase:      mockSDBundleUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/sdt/bundles/?templateID="+templateID.String(), nil)
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				cerr := &common.Error{
+					Message: "not found",
+					Cause:   errors.New("not found"),
+					Code:    http.StatusNotFound,
+					Type:    usecase.ErrResourceNotFound,
+				}
+				mockSDBundleUc.EXPECT().Export(ectx.Request().Context(), &model.ExportSDBundleInput{TemplateIDs: []uuid.UUID{templateID}}).Times(1).Return(nil, cerr)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleExportSDBundle()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok as yaml",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdbundleUsecase:      mockSDBundleUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/sdt/bundles/?format=yaml&templateID="+templateID.String(), nil)
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockSDBundleUc.EXPECT().Export(ectx.Request().Context(), &model.ExportSDBundleInput{TemplateIDs: []uuid.UUID{templateID}}).Times(1).Return(bundle, &common.Error{
+					Type: nil,
+				})
+
+				err := restService.handleExportSDBundle()(ectx)
+				assert.NoError(t, err)
+				assert.Equal(t, rec.Code, http.StatusOK)
+				assert.Equal(t, rec.Header().Get("Content-Type"), "application/yaml")
+				assert.Contains(t, rec.Header().Get("Content-Disposition"), "sd-bundle.yaml")
+
+				decoded, err := model.DecodeSDBundle(rec.Body.Bytes(), model.SDBundleFormatYAML)
+				assert.NoError(t, err)
+				assert.Equal(t, decoded.Templates[0].ID, templateID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleImportSDBundle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockSDBundleUc := mock.NewMockSDBundleUsecase(ctrl)
+
+	output := &model.ImportSDBundleOutput{
+		DryRun:    true,
+		Templates: []*model.GeneratedSDTemplate{},
+		Packages:  []*model.GeneratedSDPackage{},
+		IDMapping: map[uuid.UUID]uuid.UUID{},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid dry run value",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdbundleUsecase:      mockSDBundleUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/bundles/?dryRun=maybe", strings.NewReader(`{"version": 1}`))
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleImportSDBundle()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "malformed bundle",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdbundleUsecase:      mockSDBundleUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/bundles/", strings.NewReader(`{"version": 1,`))
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleImportSDBundle()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdbundleUsecase:      mockSDBundleUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/bundles/", strings.NewReader(`{"version": 1}`))
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockSDBundleUc.EXPECT().Import(ectx.Request().Context(), &model.ImportSDBundleInput{Bundle: &model.SDBundle{Version: 1}}).Times(1).Return(nil, &common.Error{
+					Message: "err internal",
+					Cause:   errors.New("err internal"),
+					Code:    http.StatusInternalServerError,
+					Type:    usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleImportSDBundle()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok dry run from yaml",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdbundleUsecase:      mockSDBundleUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/bundles/?format=yaml&dryRun=true", strings.NewReader("version: 1\n"))
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockSDBundleUc.EXPECT().Import(ectx.Request().Context(), &model.ImportSDBundleInput{Bundle: &model.SDBundle{Version: 1}, DryRun: true}).Times(1).Return(output, &common.Error{
+					Type: nil,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    output,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleImportSDBundle()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
@@ -1610,3 +1610,576 @@ func TestRest_handleFindReadyToUsePackages(t *testing.T) {
 		})
 	}
 }
+
+func TestRest_handleFindSDPackageVersions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockSDPackageUc := mock.NewMockSDPackageUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "id is invalid",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues("invalid")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleFindSDPackageVersions()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				mockSDPackageUc.EXPECT().FindVersions(ectx.Request().Context(), id).Times(1).Return(nil, &common.Error{
+					Message: "err internal",
+					Cause:   errors.New("err internal"),
+					Code:    http.StatusInternalServerError,
+					Type:    usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleFindSDPackageVersions()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return spesific err",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				cerr := &common.Error{
+					Code: http.StatusNotFound,
+					Type: usecase.ErrResourceNotFound,
+				}
+
+				mockSDPackageUc.EXPECT().FindVersions(ectx.Request().Context(), id).Times(1).Return(nil, cerr)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleFindSDPackageVersions()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.SDPackageVersionsOutput{}
+
+				mockSDPackageUc.EXPECT().FindVersions(ectx.Request().Context(), id).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleFindSDPackageVersions()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleFindSDPackageVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockSDPackageUc := mock.NewMockSDPackageUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "id is invalid",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id", "version")
+				ectx.SetParamValues("invalid", "1")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleFindSDPackageVersion()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "version is not a number",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id", "version")
+				ectx.SetParamValues(uuid.NewString(), "latest")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleFindSDPackageVersion()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "version is less than 1",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id", "version")
+				ectx.SetParamValues(uuid.NewString(), "0")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleFindSDPackageVersion()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id", "version")
+				ectx.SetParamValues(id.String(), "2")
+
+				mockSDPackageUc.EXPECT().FindVersion(ectx.Request().Context(), id, 2).Times(1).Return(nil, &common.Error{
+					Message: "err internal",
+					Cause:   errors.New("err internal"),
+					Code:    http.StatusInternalServerError,
+					Type:    usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleFindSDPackageVersion()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return spesific err",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id", "version")
+				ectx.SetParamValues(id.String(), "2")
+
+				cerr := &common.Error{
+					Code: http.StatusNotFound,
+					Type: usecase.ErrResourceNotFound,
+				}
+
+				mockSDPackageUc.EXPECT().FindVersion(ectx.Request().Context(), id, 2).Times(1).Return(nil, cerr)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleFindSDPackageVersion()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id", "version")
+				ectx.SetParamValues(id.String(), "2")
+
+				res := &model.GeneratedSDPackageVersion{}
+
+				mockSDPackageUc.EXPECT().FindVersion(ectx.Request().Context(), id, 2).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleFindSDPackageVersion()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleCloneSDPackage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockSDPackageUc := mock.NewMockSDPackageUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "binding json failed",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"request": {}, <- invalid here}`))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(uuid.NewString())
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleCloneSDPackage()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "param id is invalid uuid",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`
+					{
+						"request": {
+							"templateID": "b3b3d3a2-6f4c-4f8e-9c2a-0d8f3c3f1a11",
+							"packageName": "cloned"
+						},
+						"signature": "ok"
+					}
+				`))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues("invalid here")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleCloneSDPackage()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return internal error",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`
+					{
+						"request": {
+							"templateID": "b3b3d3a2-6f4c-4f8e-9c2a-0d8f3c3f1a11",
+							"packageName": "cloned"
+						},
+						"signature": "ok"
+					}
+				`))
+				req.Header.Set("Content-Type", "application/json")
+
+				id := uuid.New()
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				mockSDPackageUc.EXPECT().Clone(ectx.Request().Context(), id, &model.CloneSDPackageInput{
+					TemplateID:  uuid.NullUUID{UUID: uuid.MustParse("b3b3d3a2-6f4c-4f8e-9c2a-0d8f3c3f1a11"), Valid: true},
+					PackageName: "cloned",
+				}).Times(1).Return(nil, &common.Error{
+					Message: "err internal",
+					Cause:   errors.New("err internal"),
+					Code:    http.StatusInternalServerError,
+					Type:    usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleCloneSDPackage()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return spesific error",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`
+					{
+						"request": {
+							"templateID": "b3b3d3a2-6f4c-4f8e-9c2a-0d8f3c3f1a11",
+							"packageName": "cloned"
+						},
+						"signature": "ok"
+					}
+				`))
+				req.Header.Set("Content-Type", "application/json")
+
+				id := uuid.New()
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				cerr := &common.Error{
+					Code: http.StatusNotFound,
+					Type: usecase.ErrResourceNotFound,
+				}
+
+				mockSDPackageUc.EXPECT().Clone(ectx.Request().Context(), id, &model.CloneSDPackageInput{
+					TemplateID:  uuid.NullUUID{UUID: uuid.MustParse("b3b3d3a2-6f4c-4f8e-9c2a-0d8f3c3f1a11"), Valid: true},
+					PackageName: "cloned",
+				}).Times(1).Return(nil, cerr)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleCloneSDPackage()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok without request body",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/", nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				id := uuid.New()
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.CloneSDPackageOutput{}
+
+				mockSDPackageUc.EXPECT().Clone(ectx.Request().Context(), id, &model.CloneSDPackageInput{}).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleCloneSDPackage()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdpackageUsecase:     mockSDPackageUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`
+					{
+						"request": {
+							"templateID": "b3b3d3a2-6f4c-4f8e-9c2a-0d8f3c3f1a11",
+							"packageName": "cloned"
+						},
+						"signature": "ok"
+					}
+				`))
+				req.Header.Set("Content-Type", "application/json")
+
+				id := uuid.New()
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.CloneSDPackageOutput{}
+
+				mockSDPackageUc.EXPECT().Clone(ectx.Request().Context(), id, &model.CloneSDPackageInput{
+					TemplateID:  uuid.NullUUID{UUID: uuid.MustParse("b3b3d3a2-6f4c-4f8e-9c2a-0d8f3c3f1a11"), Valid: true},
+					PackageName: "cloned",
+				}).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleCloneSDPackage()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
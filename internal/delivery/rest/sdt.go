@@ -23,7 +23,9 @@ func (s *service) handleInitiateSDTest() echo.HandlerFunc {
 			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
 		}
 
+		// the owner of the test is taken from the auth context, thus anonymous test is never owned by anyone
 		requester := model.GetUserFromCtx(c.Request().Context())
+		input.Request.UserID = uuid.NullUUID{}
 		if requester != nil {
 			input.Request.UserID = uuid.NullUUID{
 				UUID:  requester.UserID,
@@ -31,10 +33,10 @@ func (s *service) handleInitiateSDTest() echo.HandlerFunc {
 			}
 		}
 
-		resp, custerr := s.sdtestUsecase.Initiate(c.Request().Context(), input.Request)
+		resp, notAllowed, custerr := s.sdtestUsecase.Initiate(c.Request().Context(), input.Request)
 		switch custerr.Type {
 		default:
-			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, custerr.GenerateStdlibHTTPResponse(nil), nil)
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, custerr.GenerateStdlibHTTPResponse(notAllowed), nil)
 		case usecase.ErrInternal:
 			logrus.WithContext(c.Request().Context()).WithError(custerr.Cause).Error("failed to handle initiate sd test")
 			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
@@ -78,6 +80,90 @@ func (s *service) handleSubmitSDTestAnswer() echo.HandlerFunc {
 	}
 }
 
+func (s *service) handleSaveSDTestDraft() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var input = struct {
+			Request   *model.SaveSDTestDraftInput `json:"request"`
+			Signature string                      `json:"signature"`
+		}{}
+		if err := c.Bind(&input); err != nil || input.Request == nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		resp, custerr := s.sdtestUsecase.SaveDraft(c.Request().Context(), input.Request)
+		switch custerr.Type {
+		default:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, custerr.GenerateStdlibHTTPResponse(nil), nil)
+		case usecase.ErrInternal:
+			logrus.WithContext(c.Request().Context()).WithError(custerr.Cause).Error("failed to handle save sd test draft")
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		case nil:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, &stdhttp.StandardResponse{
+				Success: true,
+				Message: "success",
+				Status:  http.StatusOK,
+				Data:    resp,
+			}, nil)
+		}
+	}
+}
+
+func (s *service) handleViewSDTestDraft() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var input = struct {
+			Request   *model.ViewSDTestDraftInput `json:"request"`
+			Signature string                      `json:"signature"`
+		}{}
+		if err := c.Bind(&input); err != nil || input.Request == nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		resp, custerr := s.sdtestUsecase.ViewDraft(c.Request().Context(), input.Request)
+		switch custerr.Type {
+		default:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, custerr.GenerateStdlibHTTPResponse(nil), nil)
+		case usecase.ErrInternal:
+			logrus.WithContext(c.Request().Context()).WithError(custerr.Cause).Error("failed to handle view sd test draft")
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		case nil:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, &stdhttp.StandardResponse{
+				Success: true,
+				Message: "success",
+				Status:  http.StatusOK,
+				Data:    resp,
+			}, nil)
+		}
+	}
+}
+
+func (s *service) handleClaimSDTest() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var input = struct {
+			Request   *model.ClaimSDTestInput `json:"request"`
+			Signature string                  `json:"signature"`
+		}{}
+		if err := c.Bind(&input); err != nil || input.Request == nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		resp, custerr := s.sdtestUsecase.Claim(c.Request().Context(), input.Request)
+		switch custerr.Type {
+		default:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, custerr.GenerateStdlibHTTPResponse(nil), nil)
+		case usecase.ErrInternal:
+			logrus.WithContext(c.Request().Context()).WithError(custerr.Cause).Error("failed to handle claim sd test")
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		case nil:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, &stdhttp.StandardResponse{
+				Success: true,
+				Message: "success",
+				Status:  http.StatusOK,
+				Data:    resp,
+			}, nil)
+		}
+	}
+}
+
 func (s *service) handleViewSDTestHistories() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		input := &model.ViewHistoriesInput{}
@@ -101,13 +187,12 @@ func (s *service) handleViewSDTestHistories() echo.HandlerFunc {
 
 func (s *service) handleGetSDTestStatistic() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		input := c.Param("user_id")
-		id, err := uuid.Parse(input)
-		if err != nil {
+		input := &model.SDTestStatisticInput{}
+		if err := c.Bind(input); err != nil {
 			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
 		}
 
-		resp, cerr := s.sdtestUsecase.Statistic(c.Request().Context(), id)
+		resp, cerr := s.sdtestUsecase.Statistic(c.Request().Context(), input)
 		switch cerr.Type {
 		default:
 			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, cerr.GenerateStdlibHTTPResponse(nil), nil)
@@ -124,7 +209,95 @@ func (s *service) handleGetSDTestStatistic() echo.HandlerFunc {
 	}
 }
 
+// handleGetSDTestProgressChart will draw the progress chart in the format requested using the format query.
+// Only jpeg can be negotiated from the Accept header, the other formats fallback to png
+func (s *service) handleGetSDTestProgressChart() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &model.SDTestProgressChartInput{}
+		if err := c.Bind(input); err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		negotiated := model.NegotiateSDResultFormat(c.Request().Header.Get(echo.HeaderAccept), model.SDResultFormatPNG)
+		if negotiated != model.SDResultFormatJPEG {
+			negotiated = model.SDResultFormatPNG
+		}
+
+		format, err := model.ParseSDResultFormat(c.QueryParam("format"), negotiated)
+		if err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		input.Format = format
+		res, cerr := s.sdtestUsecase.ProgressChart(c.Request().Context(), input)
+		switch cerr.Type {
+		default:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, cerr.GenerateStdlibHTTPResponse(nil), nil)
+		case usecase.ErrInternal:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		case nil:
+			c.Response().Header().Set("Content-Type", res.ContentType)
+			c.Response().Header().Set("Content-Length", fmt.Sprintf("%d", res.Buffer.Len()))
+			return c.Blob(http.StatusOK, res.ContentType, res.Buffer.Bytes())
+		}
+	}
+}
+
+func (s *service) handleVerifySDTestResult() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &model.VerifySDTestResultInput{}
+		if err := c.Bind(input); err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		res, cerr := s.sdtestUsecase.VerifyResult(c.Request().Context(), input)
+		switch cerr.Type {
+		default:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, cerr.GenerateStdlibHTTPResponse(nil), nil)
+		case usecase.ErrInternal:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		case nil:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, &stdhttp.StandardResponse{
+				Success: true,
+				Message: "success",
+				Status:  http.StatusOK,
+				Data:    res,
+			}, nil)
+		}
+	}
+}
+
+func (s *service) handleGetSDTestResultPublicKey() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res, cerr := s.sdtestUsecase.ResultPublicKey(c.Request().Context())
+		switch cerr.Type {
+		default:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, cerr.GenerateStdlibHTTPResponse(nil), nil)
+		case usecase.ErrInternal:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		case nil:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, &stdhttp.StandardResponse{
+				Success: true,
+				Message: "success",
+				Status:  http.StatusOK,
+				Data:    res,
+			}, nil)
+		}
+	}
+}
+
 func (s *service) handleDownloadTestResult() echo.HandlerFunc {
+	return s.downloadTestResult(model.SDResultFormatJPEG)
+}
+
+func (s *service) handleDownloadTestResultReport() echo.HandlerFunc {
+	return s.downloadTestResult(model.SDResultFormatPDF)
+}
+
+// downloadTestResult will generate the test result in the format requested using the format query,
+// or negotiated from the Accept header. Fallback format is used when neither are specified.
+// The raster image size can be adjusted using the width and dpi query
+func (s *service) downloadTestResult(fallback model.SDResultFormat) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		input := c.Param("id")
 		id, err := uuid.Parse(input)
@@ -132,7 +305,22 @@ func (s *service) handleDownloadTestResult() echo.HandlerFunc {
 			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
 		}
 
-		res, cerr := s.sdtestUsecase.DownloadResult(c.Request().Context(), id)
+		negotiated := model.NegotiateSDResultFormat(c.Request().Header.Get(echo.HeaderAccept), fallback)
+		format, err := model.ParseSDResultFormat(c.QueryParam("format"), negotiated)
+		if err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		downloadInput := &model.DownloadSDTestResultInput{
+			ID:     id,
+			Format: format,
+		}
+		err = echo.QueryParamsBinder(c).Int("width", &downloadInput.Width).Int("dpi", &downloadInput.DPI).BindError()
+		if err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		res, cerr := s.sdtestUsecase.DownloadResult(c.Request().Context(), downloadInput)
 		switch cerr.Type {
 		default:
 			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, cerr.GenerateStdlibHTTPResponse(nil), nil)
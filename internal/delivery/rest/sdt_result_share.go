@@ -0,0 +1,158 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/usecase"
+	"github.com/sirupsen/logrus"
+	stdhttp "github.com/sweet-go/stdlib/http"
+)
+
+func (s *service) handleCreateSDResultShare() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		var input = struct {
+			Request   *model.CreateSDResultShareInput `json:"request"`
+			Signature string                          `json:"signature"`
+		}{}
+		if err := c.Bind(&input); err != nil || input.Request == nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		input.Request.TestID = id
+		resp, custerr := s.sdtestUsecase.CreateShare(c.Request().Context(), input.Request)
+		switch custerr.Type {
+		default:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, custerr.GenerateStdlibHTTPResponse(nil), nil)
+		case usecase.ErrInternal:
+			logrus.WithContext(c.Request().Context()).WithError(custerr.Cause).Error("failed to handle create sd test result share request")
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		case nil:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, &stdhttp.StandardResponse{
+				Success: true,
+				Message: "success",
+				Status:  http.StatusOK,
+				Data:    resp,
+			}, nil)
+		}
+	}
+}
+
+func (s *service) handleFindSDResultShares() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		resp, custerr := s.sdtestUsecase.FindShares(c.Request().Context(), id)
+		switch custerr.Type {
+		default:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, custerr.GenerateStdlibHTTPResponse(nil), nil)
+		case usecase.ErrInternal:
+			logrus.WithContext(c.Request().Context()).WithError(custerr.Cause).Error("failed to handle find sd test result shares request")
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		case nil:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, &stdhttp.StandardResponse{
+				Success: true,
+				Message: "success",
+				Status:  http.StatusOK,
+				Data:    resp,
+			}, nil)
+		}
+	}
+}
+
+func (s *service) handleRevokeSDResultShare() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &model.RevokeSDResultShareInput{}
+		if err := c.Bind(input); err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		resp, custerr := s.sdtestUsecase.RevokeShare(c.Request().Context(), input)
+		switch custerr.Type {
+		default:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, custerr.GenerateStdlibHTTPResponse(nil), nil)
+		case usecase.ErrInternal:
+			logrus.WithContext(c.Request().Context()).WithError(custerr.Cause).Error("failed to handle revoke sd test result share request")
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		case nil:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, &stdhttp.StandardResponse{
+				Success: true,
+				Message: "success",
+				Status:  http.StatusOK,
+				Data:    resp,
+			}, nil)
+		}
+	}
+}
+
+// handleViewSharedSDTestResult is public, the share token is passed using the token query
+// because the token may contain characters not allowed on the path
+func (s *service) handleViewSharedSDTestResult() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := &model.ViewSharedSDTestResultInput{
+			Token:     c.QueryParam("token"),
+			IPAddress: c.RealIP(),
+			UserAgent: c.Request().UserAgent(),
+		}
+
+		resp, custerr := s.sdtestUsecase.ViewSharedResult(c.Request().Context(), input)
+		switch custerr.Type {
+		default:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, custerr.GenerateStdlibHTTPResponse(nil), nil)
+		case usecase.ErrInternal:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		case nil:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, &stdhttp.StandardResponse{
+				Success: true,
+				Message: "success",
+				Status:  http.StatusOK,
+				Data:    resp,
+			}, nil)
+		}
+	}
+}
+
+// handleViewSharedSDTestResultImage generate the shared test result the same way as downloadTestResult
+func (s *service) handleViewSharedSDTestResultImage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		negotiated := model.NegotiateSDResultFormat(c.Request().Header.Get(echo.HeaderAccept), model.SDResultFormatJPEG)
+		format, err := model.ParseSDResultFormat(c.QueryParam("format"), negotiated)
+		if err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		input := &model.ViewSharedSDTestResultInput{
+			Token:     c.QueryParam("token"),
+			Format:    format,
+			IPAddress: c.RealIP(),
+			UserAgent: c.Request().UserAgent(),
+		}
+		err = echo.QueryParamsBinder(c).Int("width", &input.Width).Int("dpi", &input.DPI).BindError()
+		if err != nil {
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+		}
+
+		res, cerr := s.sdtestUsecase.ViewSharedResultImage(c.Request().Context(), input)
+		switch cerr.Type {
+		default:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, cerr.GenerateStdlibHTTPResponse(nil), nil)
+		case usecase.ErrInternal:
+			return s.apiResponseGenerator.GenerateEchoAPIResponse(c, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+		case nil:
+			c.Response().Header().Set("Content-Type", res.ContentType)
+			c.Response().Header().Set("Content-Length", fmt.Sprintf("%d", res.Buffer.Len()))
+			return c.Blob(http.StatusOK, res.ContentType, res.Buffer.Bytes())
+		}
+	}
+}
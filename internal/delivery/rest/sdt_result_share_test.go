@@ -0,0 +1,524 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/model/mock"
+	"github.com/luckyAkbar/atec-api/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	stdhttp "github.com/sweet-go/stdlib/http"
+	httpMock "github.com/sweet-go/stdlib/http/mock"
+)
+
+func TestRest_handleCreateSDResultShare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	sdt := mock.NewMockSDTestUsecase(ctrl)
+
+	id := uuid.New()
+	body := `{"request": {"scope": "image", "expiredAt": "2030-01-02T03:04:05Z", "maxViews": 3}}`
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid id",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues("invalid")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleCreateSDResultShare()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "missing request",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleCreateSDResultShare()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				sdt.EXPECT().CreateShare(ectx.Request().Context(), gomock.Any()).Times(1).Return(nil, &common.Error{
+					Message: "err internal",
+					Cause:   errors.New("err internal"),
+					Code:    http.StatusInternalServerError,
+					Type:    usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleCreateSDResultShare()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				resp := &model.GeneratedSDResultShare{ID: uuid.New(), TestID: id, Token: "token"}
+				sdt.EXPECT().CreateShare(ectx.Request().Context(), gomock.Any()).Times(1).DoAndReturn(func(_ interface{}, input *model.CreateSDResultShareInput) (*model.GeneratedSDResultShare, *common.Error) {
+					assert.Equal(t, input.TestID, id)
+					assert.Equal(t, input.Scope, model.SDResultShareScopeImage)
+					assert.Equal(t, input.ExpiredAt, time.Date(2030, time.January, 2, 3, 4, 5, 0, time.UTC))
+					assert.Equal(t, input.MaxViews.Int64, int64(3))
+					return resp, &common.Error{Type: nil}
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    resp,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleCreateSDResultShare()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleFindSDResultShares(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	sdt := mock.NewMockSDTestUsecase(ctrl)
+
+	id := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid id",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues("invalid")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleFindSDResultShares()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return specific err",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				cerr := &common.Error{
+					Message: "forbidden",
+					Cause:   errors.New("forbidden"),
+					Code:    http.StatusForbidden,
+					Type:    usecase.ErrForbiddenToShareSDTestResult,
+				}
+				sdt.EXPECT().FindShares(ectx.Request().Context(), id).Times(1).Return(nil, cerr)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleFindSDResultShares()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				resp := []*model.GeneratedSDResultShare{{ID: uuid.New(), TestID: id}}
+				sdt.EXPECT().FindShares(ectx.Request().Context(), id).Times(1).Return(resp, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    resp,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleFindSDResultShares()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleRevokeSDResultShare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	sdt := mock.NewMockSDTestUsecase(ctrl)
+
+	id := uuid.New()
+	shareID := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid share id",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id", "share_id")
+				ectx.SetParamValues(id.String(), "invalid")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleRevokeSDResultShare()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodDelete, "/", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id", "share_id")
+				ectx.SetParamValues(id.String(), shareID.String())
+
+				resp := &model.GeneratedSDResultShare{ID: shareID, TestID: id}
+				sdt.EXPECT().RevokeShare(ectx.Request().Context(), &model.RevokeSDResultShareInput{TestID: id, ShareID: shareID}).Times(1).Return(resp, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    resp,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleRevokeSDResultShare()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleViewSharedSDTestResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	sdt := mock.NewMockSDTestUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "share is unavailable",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?token=abc", nil)
+				req.Header.Set("User-Agent", "agent")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				cerr := &common.Error{
+					Message: "gone",
+					Cause:   errors.New("gone"),
+					Code:    http.StatusGone,
+					Type:    usecase.ErrSDResultShareUnavailable,
+				}
+				sdt.EXPECT().ViewSharedResult(ectx.Request().Context(), &model.ViewSharedSDTestResultInput{
+					Token:     "abc",
+					IPAddress: ectx.RealIP(),
+					UserAgent: "agent",
+				}).Times(1).Return(nil, cerr)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleViewSharedSDTestResult()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?token=abc", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				resp := &model.SharedSDTestResultOutput{TestID: uuid.New()}
+				sdt.EXPECT().ViewSharedResult(ectx.Request().Context(), gomock.Any()).Times(1).Return(resp, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    resp,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleViewSharedSDTestResult()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleViewSharedSDTestResultImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	sdt := mock.NewMockSDTestUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid format",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?token=abc&format=gif", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleViewSharedSDTestResultImage()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "invalid width",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?token=abc&width=wide", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleViewSharedSDTestResultImage()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "scope not allowed",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?token=abc", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				cerr := &common.Error{
+					Message: "not found",
+					Cause:   errors.New("not found"),
+					Code:    http.StatusNotFound,
+					Type:    usecase.ErrResourceNotFound,
+				}
+				sdt.EXPECT().ViewSharedResultImage(ectx.Request().Context(), gomock.Any()).Times(1).Return(nil, cerr)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleViewSharedSDTestResultImage()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok png negotiated from accept header",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?token=abc&width=640&dpi=100", nil)
+				req.Header.Set(echo.HeaderAccept, "image/png")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				res := &model.ImageResult{ContentType: "image/png", Buffer: *bytes.NewBufferString("png")}
+				sdt.EXPECT().ViewSharedResultImage(ectx.Request().Context(), gomock.Any()).Times(1).DoAndReturn(func(_ interface{}, input *model.ViewSharedSDTestResultInput) (*model.ImageResult, *common.Error) {
+					assert.Equal(t, input.Token, "abc")
+					assert.Equal(t, input.Format, model.SDResultFormatPNG)
+					assert.Equal(t, input.Width, 640)
+					assert.Equal(t, input.DPI, 100)
+					return res, &common.Error{Type: nil}
+				})
+
+				err := restService.handleViewSharedSDTestResultImage()(ectx)
+				assert.NoError(t, err)
+				assert.Equal(t, rec.Code, http.StatusOK)
+				assert.Equal(t, rec.Header().Get("Content-Type"), "image/png")
+				assert.Equal(t, rec.Body.String(), "png")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
@@ -3,10 +3,12 @@ package rest
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -56,7 +58,50 @@ func TestRest_handleInitiateSDTest(t *testing.T) {
 					ID: uuid.New(),
 				}
 
-				mockSDTestUc.EXPECT().Initiate(ectx.Request().Context(), input).Times(1).Return(res, &common.Error{
+				mockSDTestUc.EXPECT().Initiate(ectx.Request().Context(), input).Times(1).Return(res, nil, &common.Error{
+					Type: nil,
+				})
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleInitiateSDTest()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "user id from anonymous request is ignored",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/templates/", strings.NewReader(fmt.Sprintf(`
+					{
+						"request": {
+							"userID": "%s"
+						},
+						"signature": "sig"
+					}
+				`, uuid.New().String())))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				res := &model.InitiateSDTestOutput{
+					ID: uuid.New(),
+				}
+
+				mockSDTestUc.EXPECT().Initiate(ectx.Request().Context(), input).Times(1).Return(res, nil, &common.Error{
 					Type: nil,
 				})
 
@@ -108,7 +153,7 @@ func TestRest_handleInitiateSDTest(t *testing.T) {
 					UserID: uuid.NullUUID{UUID: user.UserID, Valid: true},
 				}
 
-				mockSDTestUc.EXPECT().Initiate(ectx.Request().Context(), inputWithCtx).Times(1).Return(res, &common.Error{
+				mockSDTestUc.EXPECT().Initiate(ectx.Request().Context(), inputWithCtx).Times(1).Return(res, nil, &common.Error{
 					Type: nil,
 				})
 
@@ -214,7 +259,7 @@ func TestRest_handleInitiateSDTest(t *testing.T) {
 				rec := httptest.NewRecorder()
 				ectx := e.NewContext(req, rec)
 
-				mockSDTestUc.EXPECT().Initiate(ectx.Request().Context(), input).Times(1).Return(nil, &common.Error{
+				mockSDTestUc.EXPECT().Initiate(ectx.Request().Context(), input).Times(1).Return(nil, nil, &common.Error{
 					Type: usecase.ErrInternal,
 				})
 
@@ -265,9 +310,51 @@ func TestRest_handleInitiateSDTest(t *testing.T) {
 					Type:    usecase.ErrSDTemplateInputInvalid,
 				}
 
-				mockSDTestUc.EXPECT().Initiate(ectx.Request().Context(), input).Times(1).Return(nil, cerr)
+				var notAllowed *model.SDRetakeNotAllowedOutput
+				mockSDTestUc.EXPECT().Initiate(ectx.Request().Context(), input).Times(1).Return(nil, notAllowed, cerr)
 
-				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(notAllowed), nil).Times(1).Return(nil)
+
+				err := restService.handleInitiateSDTest()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "retake is not allowed",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/tests/", strings.NewReader(`
+					{
+						"request": {},
+						"signature": "sig"
+					}
+				`))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				cerr := &common.Error{
+					Message: "retake is not allowed",
+					Cause:   errors.New("retake is not allowed"),
+					Code:    http.StatusTooManyRequests,
+					Type:    usecase.ErrSDTestRetakeNotAllowed,
+				}
+				notAllowed := &model.SDRetakeNotAllowedOutput{
+					Rule:          model.SDRetakeRuleMinInterval,
+					NextAllowedAt: time.Now().UTC().Add(time.Hour),
+				}
+
+				mockSDTestUc.EXPECT().Initiate(ectx.Request().Context(), input).Times(1).Return(nil, notAllowed, cerr)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(notAllowed), nil).Times(1).Return(nil)
 
 				err := restService.handleInitiateSDTest()(ectx)
 				assert.NoError(t, err)
@@ -726,7 +813,7 @@ func TestRest_handleGetSDTestStatistic(t *testing.T) {
 				ectx.SetParamNames("user_id")
 				ectx.SetParamValues(id.String())
 
-				sdtUc.EXPECT().Statistic(ectx.Request().Context(), id).Times(1).Return(nil, &common.Error{
+				sdtUc.EXPECT().Statistic(ectx.Request().Context(), &model.SDTestStatisticInput{UserID: id}).Times(1).Return(nil, &common.Error{
 					Message: "err internal",
 					Cause:   errors.New("err internal"),
 					Code:    http.StatusInternalServerError,
@@ -764,13 +851,95 @@ func TestRest_handleGetSDTestStatistic(t *testing.T) {
 					Type: usecase.ErrInputResetPasswordInvalid,
 				}
 
-				sdtUc.EXPECT().Statistic(ectx.Request().Context(), id).Times(1).Return(nil, cerr)
+				sdtUc.EXPECT().Statistic(ectx.Request().Context(), &model.SDTestStatisticInput{UserID: id}).Times(1).Return(nil, cerr)
 				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
 
 				err := restService.handleGetSDTestStatistic()(ectx)
 				assert.NoError(t, err)
 			},
 		},
+		{
+			Name:   "ok, filtered by date range and paginated",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdtUc,
+				}
+
+				id := uuid.New()
+
+				req := httptest.NewRequest(http.MethodGet, "/?from=2023-01-01T00:00:00Z&to=2023-12-31T00:00:00Z&limit=10&offset=20", nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("user_id")
+				ectx.SetParamValues(id.String())
+
+				res := []model.SDTestStatistic{}
+
+				sdtUc.EXPECT().Statistic(ectx.Request().Context(), &model.SDTestStatisticInput{
+					UserID: id,
+					From:   time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:     time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+					Limit:  10,
+					Offset: 20,
+				}).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleGetSDTestStatistic()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok, filtered by child",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdtUc,
+				}
+
+				id := uuid.New()
+				childID := uuid.New()
+
+				req := httptest.NewRequest(http.MethodGet, "/?childID="+childID.String(), nil)
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("user_id")
+				ectx.SetParamValues(id.String())
+
+				res := []model.SDTestStatistic{}
+
+				sdtUc.EXPECT().Statistic(ectx.Request().Context(), &model.SDTestStatisticInput{
+					UserID:  id,
+					ChildID: uuid.NullUUID{UUID: childID, Valid: true},
+				}).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleGetSDTestStatistic()(ectx)
+				assert.NoError(t, err)
+			},
+		},
 		{
 			Name:   "ok",
 			MockFn: func() {},
@@ -798,7 +967,7 @@ func TestRest_handleGetSDTestStatistic(t *testing.T) {
 
 				res := []model.SDTestStatistic{}
 
-				sdtUc.EXPECT().Statistic(ectx.Request().Context(), id).Times(1).Return(res, cerr)
+				sdtUc.EXPECT().Statistic(ectx.Request().Context(), &model.SDTestStatisticInput{UserID: id}).Times(1).Return(res, cerr)
 				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
 					Success: true,
 					Message: "success",
@@ -898,7 +1067,7 @@ func TestRest_handleDownloadTestResult(t *testing.T) {
 				ectx.SetParamNames("id")
 				ectx.SetParamValues(id.String())
 
-				sdt.EXPECT().DownloadResult(ectx.Request().Context(), id).Times(1).Return(nil, &common.Error{
+				sdt.EXPECT().DownloadResult(ectx.Request().Context(), &model.DownloadSDTestResultInput{ID: id, Format: model.SDResultFormatJPEG}).Times(1).Return(nil, &common.Error{
 					Message: "err internal",
 					Cause:   errors.New("err internal"),
 					Code:    http.StatusInternalServerError,
@@ -936,7 +1105,7 @@ func TestRest_handleDownloadTestResult(t *testing.T) {
 					Type: usecase.ErrInputResetPasswordInvalid,
 				}
 
-				sdt.EXPECT().DownloadResult(ectx.Request().Context(), id).Times(1).Return(nil, cerr)
+				sdt.EXPECT().DownloadResult(ectx.Request().Context(), &model.DownloadSDTestResultInput{ID: id, Format: model.SDResultFormatJPEG}).Times(1).Return(nil, cerr)
 				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
 
 				err := restService.handleDownloadTestResult()(ectx)
@@ -970,11 +1139,1089 @@ func TestRest_handleDownloadTestResult(t *testing.T) {
 
 				res := &model.ImageResult{}
 
-				sdt.EXPECT().DownloadResult(ectx.Request().Context(), id).Times(1).Return(res, cerr)
+				sdt.EXPECT().DownloadResult(ectx.Request().Context(), &model.DownloadSDTestResultInput{ID: id, Format: model.SDResultFormatJPEG}).Times(1).Return(res, cerr)
+
+				err := restService.handleDownloadTestResult()(ectx)
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			Name:   "unsupported format",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?format=gif", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(uuid.NewString())
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleDownloadTestResult()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "invalid image width",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?width=wide", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(uuid.NewString())
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleDownloadTestResult()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok png negotiated from accept header with custom size",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?width=640&dpi=96", nil)
+				req.Header.Set("Accept", "image/webp, image/png, */*;q=0.8")
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.ImageResult{ContentType: "image/png"}
+				sdt.EXPECT().DownloadResult(ectx.Request().Context(), &model.DownloadSDTestResultInput{
+					ID:     id,
+					Format: model.SDResultFormatPNG,
+					Width:  640,
+					DPI:    96,
+				}).Times(1).Return(res, &common.Error{Type: nil})
+
+				err := restService.handleDownloadTestResult()(ectx)
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+			},
+		},
+		{
+			Name:   "ok pdf negotiated from accept header",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Accept", "application/pdf, image/*;q=0.8")
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.ImageResult{ContentType: "application/pdf"}
+				res.Buffer.WriteString("%PDF-1.4")
+
+				sdt.EXPECT().DownloadResult(ectx.Request().Context(), &model.DownloadSDTestResultInput{ID: id, Format: model.SDResultFormatPDF}).Times(1).Return(res, &common.Error{Type: nil})
 
 				err := restService.handleDownloadTestResult()(ectx)
 				assert.NoError(t, err)
 				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+				assert.Equal(t, "%PDF-1.4", rec.Body.String())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleDownloadTestResultReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	sdt := mock.NewMockSDTestUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "default to pdf",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Accept", "*/*")
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.ImageResult{ContentType: "application/pdf"}
+				sdt.EXPECT().DownloadResult(ectx.Request().Context(), &model.DownloadSDTestResultInput{ID: id, Format: model.SDResultFormatPDF}).Times(1).Return(res, &common.Error{Type: nil})
+
+				err := restService.handleDownloadTestResultReport()(ectx)
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+			},
+		},
+		{
+			Name:   "format query take precedence over the accept header",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?format=jpeg", nil)
+				req.Header.Set("Accept", "application/pdf")
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.ImageResult{ContentType: "image/jpeg"}
+				sdt.EXPECT().DownloadResult(ectx.Request().Context(), &model.DownloadSDTestResultInput{ID: id, Format: model.SDResultFormatJPEG}).Times(1).Return(res, &common.Error{Type: nil})
+
+				err := restService.handleDownloadTestResultReport()(ectx)
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "image/jpeg", rec.Header().Get("Content-Type"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleSaveSDTestDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockSDTestUc := mock.NewMockSDTestUsecase(ctrl)
+
+	input := &model.SaveSDTestDraftInput{
+		TestID:    uuid.MustParse("f5849b4c-a92e-4d6e-a578-909445c17996"),
+		SubmitKey: "key",
+		Answers: &model.SDTestAnswer{TestAnswers: []*model.TestAnswer{
+			{
+				GroupName: "test",
+				Answers: []model.Answer{
+					{
+						Question: "test1",
+						Answer:   "test2",
+					},
+				},
+			},
+		}},
+	}
+
+	body := `
+		{
+			"request": {
+				"testID": "f5849b4c-a92e-4d6e-a578-909445c17996",
+				"submitKey": "key",
+				"answers": {
+					"testAnswers": [
+						{
+							"groupName": "test",
+							"answers": [
+								{
+									"question": "test1",
+									"answer": "test2"
+								}
+							]
+						}
+					]
+				}
+			},
+			"signature": "sig"
+		}
+	`
+
+	tests := []common.TestStructure{
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPut, "/sdt/tests/drafts/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				res := &model.SDTestDraftOutput{
+					TestID: input.TestID,
+				}
+
+				mockSDTestUc.EXPECT().SaveDraft(ectx.Request().Context(), input).Times(1).Return(res, &common.Error{
+					Type: nil,
+				})
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleSaveSDTestDraft()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "empty request / nil",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPut, "/sdt/tests/drafts/", strings.NewReader(`
+					{
+						"signature": "sig"
+					}
+				`))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleSaveSDTestDraft()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return error internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPut, "/sdt/tests/drafts/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockSDTestUc.EXPECT().SaveDraft(ectx.Request().Context(), input).Times(1).Return(nil, &common.Error{
+					Type: usecase.ErrInternal,
+				})
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleSaveSDTestDraft()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return error non internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPut, "/sdt/tests/drafts/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				cerr := &common.Error{
+					Message: "invalid submit key",
+					Cause:   errors.New("invalid submit key"),
+					Code:    http.StatusBadRequest,
+					Type:    usecase.ErrInvalidSubmitKey,
+				}
+				mockSDTestUc.EXPECT().SaveDraft(ectx.Request().Context(), input).Times(1).Return(nil, cerr)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleSaveSDTestDraft()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleViewSDTestDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockSDTestUc := mock.NewMockSDTestUsecase(ctrl)
+
+	tid := uuid.New()
+	input := &model.ViewSDTestDraftInput{
+		TestID:    tid,
+		SubmitKey: "key",
+	}
+	body := fmt.Sprintf(`{"request": {"testID": "%s", "submitKey": "key"}}`, tid.String())
+
+	tests := []common.TestStructure{
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/tests/drafts/views/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				res := &model.SDTestDraftOutput{
+					TestID: tid,
+				}
+
+				mockSDTestUc.EXPECT().ViewDraft(ectx.Request().Context(), input).Times(1).Return(res, &common.Error{
+					Type: nil,
+				})
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleViewSDTestDraft()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "submit key in query is not accepted",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/tests/drafts/views/", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.QueryParams().Add("testID", tid.String())
+				ectx.QueryParams().Add("submitKey", "key")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleViewSDTestDraft()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "invalid test id",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/tests/drafts/views/", strings.NewReader(`{"request": {"testID": "invalid", "submitKey": "key"}}`))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleViewSDTestDraft()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return error internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				group := e.Group("")
+				restService := service{
+					rootGroup:            group,
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/tests/drafts/views/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockSDTestUc.EXPECT().ViewDraft(ectx.Request().Context(), input).Times(1).Return(nil, &common.Error{
+					Type: usecase.ErrInternal,
+				})
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleViewSDTestDraft()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleClaimSDTest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	mockSDTestUc := mock.NewMockSDTestUsecase(ctrl)
+
+	input := &model.ClaimSDTestInput{
+		TestID:    uuid.MustParse("f5849b4c-a92e-4d6e-a578-909445c17996"),
+		SubmitKey: "key",
+	}
+	body := `
+		{
+			"request": {
+				"testID": "f5849b4c-a92e-4d6e-a578-909445c17996",
+				"submitKey": "key"
+			},
+			"signature": "sig"
+		}
+	`
+
+	tests := []common.TestStructure{
+		{
+			Name:   "binding json failed",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/tests/claims/", strings.NewReader(`{"request": invalid}`))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleClaimSDTest()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return specific err",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/tests/claims/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				cerr := &common.Error{
+					Message: "sd test is already owned by an account",
+					Code:    http.StatusForbidden,
+					Type:    usecase.ErrSDTestAlreadyOwned,
+				}
+				mockSDTestUc.EXPECT().Claim(ectx.Request().Context(), input).Times(1).Return(nil, cerr)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleClaimSDTest()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "uc return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/tests/claims/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				mockSDTestUc.EXPECT().Claim(ectx.Request().Context(), input).Times(1).Return(nil, &common.Error{
+					Type: usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil).Times(1).Return(nil)
+
+				err := restService.handleClaimSDTest()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        mockSDTestUc,
+				}
+				req := httptest.NewRequest(http.MethodPost, "/sdt/tests/claims/", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				res := &model.ViewHistoriesOutput{
+					ID:     input.TestID,
+					UserID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				}
+				mockSDTestUc.EXPECT().Claim(ectx.Request().Context(), input).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil).Times(1).Return(nil)
+
+				err := restService.handleClaimSDTest()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleGetSDTestProgressChart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	sdt := mock.NewMockSDTestUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "template id is invalid",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("user_id", "template_id")
+				ectx.SetParamValues(uuid.NewString(), "invalid")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleGetSDTestProgressChart()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "width is invalid",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?width=wide", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("user_id", "template_id")
+				ectx.SetParamValues(uuid.NewString(), uuid.NewString())
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleGetSDTestProgressChart()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "format is unsupported",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?format=gif", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("user_id", "template_id")
+				ectx.SetParamValues(uuid.NewString(), uuid.NewString())
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleGetSDTestProgressChart()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				uid := uuid.New()
+				tid := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("user_id", "template_id")
+				ectx.SetParamValues(uid.String(), tid.String())
+
+				sdt.EXPECT().ProgressChart(ectx.Request().Context(), &model.SDTestProgressChartInput{UserID: uid, TemplateID: tid, Format: model.SDResultFormatPNG}).Times(1).Return(nil, &common.Error{
+					Message: "err internal",
+					Cause:   errors.New("err internal"),
+					Code:    http.StatusInternalServerError,
+					Type:    usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleGetSDTestProgressChart()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return not found",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+				uid := uuid.New()
+				tid := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("user_id", "template_id")
+				ectx.SetParamValues(uid.String(), tid.String())
+
+				cerr := &common.Error{
+					Message: "not found",
+					Cause:   errors.New("not found"),
+					Code:    http.StatusNotFound,
+					Type:    usecase.ErrResourceNotFound,
+				}
+				sdt.EXPECT().ProgressChart(ectx.Request().Context(), &model.SDTestProgressChartInput{UserID: uid, TemplateID: tid, Format: model.SDResultFormatPNG}).Times(1).Return(nil, cerr)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleGetSDTestProgressChart()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok, jpeg negotiated from the accept header with the filters",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?width=640&dpi=96", nil)
+				req.Header.Set("Accept", "image/jpeg")
+
+				uid := uuid.New()
+				tid := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("user_id", "template_id")
+				ectx.SetParamValues(uid.String(), tid.String())
+
+				res := &model.ImageResult{ContentType: "image/jpeg"}
+				sdt.EXPECT().ProgressChart(ectx.Request().Context(), &model.SDTestProgressChartInput{
+					UserID:     uid,
+					TemplateID: tid,
+					Format:     model.SDResultFormatJPEG,
+					Width:      640,
+					DPI:        96,
+				}).Times(1).Return(res, &common.Error{Type: nil})
+
+				err := restService.handleGetSDTestProgressChart()(ectx)
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "image/jpeg", rec.Header().Get("Content-Type"))
+			},
+		},
+		{
+			Name:   "ok, non raster accept header fallback to png",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Accept", "application/pdf")
+
+				uid := uuid.New()
+				tid := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("user_id", "template_id")
+				ectx.SetParamValues(uid.String(), tid.String())
+
+				res := &model.ImageResult{ContentType: "image/png"}
+				sdt.EXPECT().ProgressChart(ectx.Request().Context(), &model.SDTestProgressChartInput{UserID: uid, TemplateID: tid, Format: model.SDResultFormatPNG}).Times(1).Return(res, &common.Error{Type: nil})
+
+				err := restService.handleGetSDTestProgressChart()(ectx)
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleVerifySDTestResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	sdt := mock.NewMockSDTestUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "id is invalid",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?total=5&signature=abc", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues("invalid")
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleVerifySDTestResult()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "total is invalid",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?total=five&signature=abc", nil)
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(uuid.NewString())
+
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrBadRequest.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleVerifySDTestResult()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?total=5&signature=abc", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				sdt.EXPECT().VerifyResult(ectx.Request().Context(), &model.VerifySDTestResultInput{ID: id, Total: 5, Signature: "abc"}).Times(1).Return(nil, &common.Error{
+					Message: "err internal",
+					Cause:   errors.New("err internal"),
+					Code:    http.StatusInternalServerError,
+					Type:    usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleVerifySDTestResult()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "usecase return bad request",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?total=5", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				cerr := &common.Error{
+					Message: "invalid input",
+					Cause:   errors.New("invalid input"),
+					Code:    http.StatusBadRequest,
+					Type:    usecase.ErrInvalidVerifySDTestResultInput,
+				}
+				sdt.EXPECT().VerifyResult(ectx.Request().Context(), &model.VerifySDTestResultInput{ID: id, Total: 5}).Times(1).Return(nil, cerr)
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, cerr.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleVerifySDTestResult()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/?total=5&signature=abc", nil)
+
+				id := uuid.New()
+
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+				ectx.SetParamNames("id")
+				ectx.SetParamValues(id.String())
+
+				res := &model.VerifySDTestResultOutput{Valid: true, TestID: id}
+				sdt.EXPECT().VerifyResult(ectx.Request().Context(), &model.VerifySDTestResultInput{ID: id, Total: 5, Signature: "abc"}).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil)
+
+				err := restService.handleVerifySDTestResult()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestRest_handleGetSDTestResultPublicKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAPIRespGen := httpMock.NewMockAPIResponseGenerator(ctrl)
+	sdt := mock.NewMockSDTestUsecase(ctrl)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "usecase return err internal",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				sdt.EXPECT().ResultPublicKey(ectx.Request().Context()).Times(1).Return(nil, &common.Error{
+					Message: "err internal",
+					Cause:   errors.New("err internal"),
+					Code:    http.StatusInternalServerError,
+					Type:    usecase.ErrInternal,
+				})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, ErrInternal.GenerateStdlibHTTPResponse(nil), nil)
+
+				err := restService.handleGetSDTestResultPublicKey()(ectx)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name:   "ok",
+			MockFn: func() {},
+			Run: func() {
+				e := echo.New()
+				restService := service{
+					rootGroup:            e.Group(""),
+					apiResponseGenerator: mockAPIRespGen,
+					sdtestUsecase:        sdt,
+				}
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				rec := httptest.NewRecorder()
+				ectx := e.NewContext(req, rec)
+
+				res := &model.SDResultPublicKeyOutput{Algorithm: "RS256", PublicKey: "public key"}
+				sdt.EXPECT().ResultPublicKey(ectx.Request().Context()).Times(1).Return(res, &common.Error{Type: nil})
+				mockAPIRespGen.EXPECT().GenerateEchoAPIResponse(ectx, &stdhttp.StandardResponse{
+					Success: true,
+					Message: "success",
+					Status:  http.StatusOK,
+					Data:    res,
+				}, nil)
+
+				err := restService.handleGetSDTestResultPublicKey()(ectx)
+				assert.NoError(t, err)
 			},
 		},
 	}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"gorm.io/gorm"
+)
+
+// ChildSex is the sex of the child
+type ChildSex string
+
+// list of child sex
+const (
+	ChildSexMale   ChildSex = "male"
+	ChildSexFemale ChildSex = "female"
+)
+
+// ChildProfile represent child_profiles table. A child profile is owned by a user (usually the parent),
+// so the tests of siblings taken under the same account can be told apart
+type ChildProfile struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Name      string
+	BirthDate time.Time
+	Sex       ChildSex
+	Notes     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt
+}
+
+// TableName define the table name for gorm
+func (cp ChildProfile) TableName() string {
+	return "child_profiles"
+}
+
+// IsOwnedBy report whether the child profile is owned by the user
+func (cp *ChildProfile) IsOwnedBy(userID uuid.UUID) bool {
+	return cp.UserID == userID
+}
+
+// ToRESTResponse convert to GeneratedChildProfile
+func (cp *ChildProfile) ToRESTResponse() *GeneratedChildProfile {
+	return &GeneratedChildProfile{
+		ID:        cp.ID,
+		UserID:    cp.UserID,
+		Name:      cp.Name,
+		BirthDate: cp.BirthDate,
+		Sex:       cp.Sex,
+		Notes:     cp.Notes,
+		CreatedAt: cp.CreatedAt,
+		UpdatedAt: cp.UpdatedAt,
+		DeletedAt: cp.DeletedAt,
+	}
+}
+
+// GeneratedChildProfile will be used to define the child profile as the returned value as REST API responses
+type GeneratedChildProfile struct {
+	ID        uuid.UUID      `json:"id"`
+	UserID    uuid.UUID      `json:"userID"`
+	Name      string         `json:"name"`
+	BirthDate time.Time      `json:"birthDate"`
+	Sex       ChildSex       `json:"sex"`
+	Notes     string         `json:"notes"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	DeletedAt gorm.DeletedAt `json:"deletedAt,omitempty"`
+}
+
+// ChildProfileInput input to create or update a child profile
+type ChildProfileInput struct {
+	Name      string    `json:"name" validate:"required,max=255"`
+	BirthDate time.Time `json:"birthDate" validate:"required"`
+	Sex       ChildSex  `json:"sex" validate:"required,oneof=male female"`
+	Notes     string    `json:"notes" validate:"max=1000"`
+}
+
+// Validate validate struct. BirthDate must not be in the future
+func (i *ChildProfileInput) Validate() error {
+	if err := validator.Struct(i); err != nil {
+		return err
+	}
+
+	if i.BirthDate.After(time.Now()) {
+		return errors.New("birthDate must not be in the future")
+	}
+
+	return nil
+}
+
+// SearchChildProfileInput input to search child profiles
+type SearchChildProfileInput struct {
+	UserID uuid.NullUUID `query:"userID"`
+	Limit  int           `query:"limit"`
+	Offset int           `query:"offset"`
+}
+
+// ToWhereQuery convert input to search query. If limit is unset / set over 100, will be set to 100.
+// If offset is unset / set under 0, will be set to 0.
+func (i *SearchChildProfileInput) ToWhereQuery() ([]interface{}, []interface{}) {
+	var whereQuery []interface{}
+	var conds []interface{}
+
+	if i.Limit <= 0 || i.Limit > 100 {
+		i.Limit = 100
+	}
+
+	if i.Offset < 0 {
+		i.Offset = 0
+	}
+
+	if i.UserID.Valid {
+		whereQuery = append(whereQuery, "user_id = ?")
+		conds = append(conds, i.UserID)
+	}
+
+	return whereQuery, conds
+}
+
+// ChildProfileRepository repository for child profile
+type ChildProfileRepository interface {
+	Create(ctx context.Context, child *ChildProfile) error
+	FindByID(ctx context.Context, id uuid.UUID) (*ChildProfile, error)
+	Search(ctx context.Context, input *SearchChildProfileInput) ([]*ChildProfile, error)
+	Update(ctx context.Context, child *ChildProfile) error
+	Delete(ctx context.Context, id uuid.UUID) (*ChildProfile, error)
+}
+
+// ChildProfileUsecase usecase for child profile
+type ChildProfileUsecase interface {
+	Create(ctx context.Context, input *ChildProfileInput) (*GeneratedChildProfile, *common.Error)
+	FindByID(ctx context.Context, id uuid.UUID) (*GeneratedChildProfile, *common.Error)
+	Search(ctx context.Context, input *SearchChildProfileInput) ([]*GeneratedChildProfile, *common.Error)
+	Update(ctx context.Context, id uuid.UUID, input *ChildProfileInput) (*GeneratedChildProfile, *common.Error)
+	Delete(ctx context.Context, id uuid.UUID) (*GeneratedChildProfile, *common.Error)
+}
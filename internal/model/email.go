@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"github.com/luckyAkbar/atec-api/internal/common"
 	"github.com/sweet-go/stdlib/mail"
 	"gopkg.in/guregu/null.v4"
 	"gorm.io/gorm"
@@ -27,6 +28,32 @@ type Email struct {
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	DeletedAt       gorm.DeletedAt
+
+	// Attachments are stored on email_attachments table, thus must be written and read separately
+	Attachments []EmailAttachment `gorm:"-"`
+}
+
+// EmailAttachment represent email_attachments table structure from database
+type EmailAttachment struct {
+	ID          uuid.UUID
+	EmailID     uuid.UUID
+	Filename    string
+	ContentType string
+	Content     []byte
+	CreatedAt   time.Time
+}
+
+// MailAttachments convert Attachments to common.MailAttachment
+func (e *Email) MailAttachments() []common.MailAttachment {
+	var attachments []common.MailAttachment
+	for _, a := range e.Attachments {
+		attachments = append(attachments, common.MailAttachment{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Content:     a.Content,
+		})
+	}
+	return attachments
 }
 
 // GenericReceipientsTo convert To to model.GenericReceipient
@@ -86,6 +113,14 @@ type RegisterEmailInput struct {
 	Cc             []string `validate:"omitempty,unique,dive,email"`
 	Bcc            []string `validate:"omitempty,unique,dive,email"`
 	DeadlineSecond int64
+	Attachments    []RegisterEmailAttachmentInput `validate:"omitempty,dive"`
+}
+
+// RegisterEmailAttachmentInput input to attach a file on the registered email. Content is limited to 10MB
+type RegisterEmailAttachmentInput struct {
+	Filename    string `validate:"required"`
+	ContentType string `validate:"required"`
+	Content     []byte `validate:"required,max=10485760"`
 }
 
 // Validate run all the validation function to ensure all the input values are following the defined rules here
@@ -103,4 +138,5 @@ type EmailRepository interface {
 	Create(ctx context.Context, email *Email) error
 	FindByID(ctx context.Context, id uuid.UUID) (*Email, error)
 	Update(ctx context.Context, email *Email) error
+	FindAttachmentsByEmailID(ctx context.Context, emailID uuid.UUID) ([]EmailAttachment, error)
 }
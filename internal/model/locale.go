@@ -0,0 +1,124 @@
+package model
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale define the language of the content, written as the lower case ISO 639-1 code, e.g id, en
+type Locale string
+
+// list of locales with built in result labels
+const (
+	LocaleIndonesian Locale = "id"
+	LocaleEnglish    Locale = "en"
+
+	// DefaultLocale is the language of the original content on the templates and packages
+	DefaultLocale = LocaleIndonesian
+)
+
+// ParseLocale will normalize a language tag such as en-US or EN into its base locale
+func ParseLocale(tag string) Locale {
+	tag = strings.TrimSpace(tag)
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	return Locale(strings.ToLower(tag))
+}
+
+// OrDefault will return DefaultLocale when the locale is empty
+func (l Locale) OrDefault() Locale {
+	if l == "" {
+		return DefaultLocale
+	}
+
+	return l
+}
+
+// LocaleFromAcceptLanguage will return the most preferred locale from the Accept-Language header value.
+// Return empty locale if the header is empty or only contains wildcard
+func LocaleFromAcceptLanguage(header string) Locale {
+	type preference struct {
+		locale Locale
+		q      float64
+	}
+
+	prefs := []preference{}
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		locale := ParseLocale(fields[0])
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		q := float64(1)
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if !strings.HasPrefix(f, "q=") {
+				continue
+			}
+
+			if v, err := strconv.ParseFloat(strings.TrimPrefix(f, "q="), 64); err == nil {
+				q = v
+			}
+		}
+
+		prefs = append(prefs, preference{locale: locale, q: q})
+	}
+
+	if len(prefs) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(prefs, func(i, j int) bool {
+		return prefs[i].q > prefs[j].q
+	})
+
+	return prefs[0].locale
+}
+
+// Translations hold the translated text of a content keyed by the locale.
+// The original content is always written in DefaultLocale, thus no need to be translated
+type Translations map[Locale]string
+
+// Translate will return the translation for the locale, or the fallback if no translation is available
+func (t Translations) Translate(locale Locale, fallback string) string {
+	if text, ok := t[locale]; ok && text != "" {
+		return text
+	}
+
+	return fallback
+}
+
+// contains reports whether the text is one of the translations
+func (t Translations) contains(text string) bool {
+	for _, v := range t {
+		if v == text {
+			return true
+		}
+	}
+
+	return false
+}
+
+type localeCtxKey string
+
+const localeCtxKeyValue localeCtxKey = "github.com/luckyAkbar/atec-api/internal/model:Locale"
+
+// SetLocaleToCtx set the requested locale to context
+func SetLocaleToCtx(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeCtxKeyValue, locale)
+}
+
+// GetLocaleFromCtx get the requested locale from context. Return DefaultLocale if not set
+func GetLocaleFromCtx(ctx context.Context) Locale {
+	locale, ok := ctx.Value(localeCtxKeyValue).(Locale)
+	if !ok {
+		return DefaultLocale
+	}
+
+	return locale.OrDefault()
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocale_ParseLocale(t *testing.T) {
+	assert.Equal(t, ParseLocale("en-US"), LocaleEnglish)
+	assert.Equal(t, ParseLocale(" ID "), LocaleIndonesian)
+	assert.Equal(t, ParseLocale("en_GB"), LocaleEnglish)
+	assert.Equal(t, ParseLocale(""), Locale(""))
+}
+
+func TestLocale_LocaleFromAcceptLanguage(t *testing.T) {
+	assert.Equal(t, LocaleFromAcceptLanguage(""), Locale(""))
+	assert.Equal(t, LocaleFromAcceptLanguage("*"), Locale(""))
+	assert.Equal(t, LocaleFromAcceptLanguage("en-US,en;q=0.9,id;q=0.8"), LocaleEnglish)
+	assert.Equal(t, LocaleFromAcceptLanguage("en;q=0.5, id-ID;q=0.7"), LocaleIndonesian)
+	assert.Equal(t, LocaleFromAcceptLanguage("en;q=invalid, id;q=0.7"), LocaleEnglish)
+}
+
+func TestLocale_Translations(t *testing.T) {
+	tr := Translations{LocaleEnglish: "hello", "fr": ""}
+
+	assert.Equal(t, tr.Translate(LocaleEnglish, "halo"), "hello")
+	assert.Equal(t, tr.Translate(LocaleIndonesian, "halo"), "halo")
+	assert.Equal(t, tr.Translate("fr", "halo"), "halo")
+	assert.Equal(t, Translations(nil).Translate(LocaleEnglish, "halo"), "halo")
+}
+
+func TestLocale_Ctx(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, GetLocaleFromCtx(ctx), DefaultLocale)
+
+	ctx = SetLocaleToCtx(ctx, LocaleEnglish)
+	assert.Equal(t, GetLocaleFromCtx(ctx), LocaleEnglish)
+
+	ctx = SetLocaleToCtx(ctx, "")
+	assert.Equal(t, GetLocaleFromCtx(ctx), DefaultLocale)
+}
@@ -50,6 +50,21 @@ func (mr *MockEmailRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEmailRepository)(nil).Create), arg0, arg1)
 }
 
+// FindAttachmentsByEmailID mocks base method.
+func (m *MockEmailRepository) FindAttachmentsByEmailID(arg0 context.Context, arg1 uuid.UUID) ([]model.EmailAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAttachmentsByEmailID", arg0, arg1)
+	ret0, _ := ret[0].([]model.EmailAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAttachmentsByEmailID indicates an expected call of FindAttachmentsByEmailID.
+func (mr *MockEmailRepositoryMockRecorder) FindAttachmentsByEmailID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAttachmentsByEmailID", reflect.TypeOf((*MockEmailRepository)(nil).FindAttachmentsByEmailID), arg0, arg1)
+}
+
 // FindByID mocks base method.
 func (m *MockEmailRepository) FindByID(arg0 context.Context, arg1 uuid.UUID) (*model.Email, error) {
 	m.ctrl.T.Helper()
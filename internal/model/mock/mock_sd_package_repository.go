@@ -38,17 +38,17 @@ func (m *MockSDPackageRepository) EXPECT() *MockSDPackageRepositoryMockRecorder
 }
 
 // Create mocks base method.
-func (m *MockSDPackageRepository) Create(arg0 context.Context, arg1 *model.SpeechDelayPackage) error {
+func (m *MockSDPackageRepository) Create(arg0 context.Context, arg1 *model.SpeechDelayPackage, arg2 *gorm.DB) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockSDPackageRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockSDPackageRepositoryMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSDPackageRepository)(nil).Create), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSDPackageRepository)(nil).Create), arg0, arg1, arg2)
 }
 
 // Delete mocks base method.
@@ -66,6 +66,21 @@ func (mr *MockSDPackageRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSDPackageRepository)(nil).Delete), arg0, arg1)
 }
 
+// FindActive mocks base method.
+func (m *MockSDPackageRepository) FindActive(arg0 context.Context, arg1 uuid.NullUUID) ([]*model.SpeechDelayPackage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActive", arg0, arg1)
+	ret0, _ := ret[0].([]*model.SpeechDelayPackage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActive indicates an expected call of FindActive.
+func (mr *MockSDPackageRepositoryMockRecorder) FindActive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActive", reflect.TypeOf((*MockSDPackageRepository)(nil).FindActive), arg0, arg1)
+}
+
 // FindByID mocks base method.
 func (m *MockSDPackageRepository) FindByID(arg0 context.Context, arg1 uuid.UUID, arg2 bool) (*model.SpeechDelayPackage, error) {
 	m.ctrl.T.Helper()
@@ -81,34 +96,34 @@ func (mr *MockSDPackageRepositoryMockRecorder) FindByID(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSDPackageRepository)(nil).FindByID), arg0, arg1, arg2)
 }
 
-// FindLeastUsedPackageIDByUserID mocks base method.
-func (m *MockSDPackageRepository) FindLeastUsedPackageIDByUserID(arg0 context.Context, arg1 uuid.UUID) (uuid.UUID, error) {
+// FindVersion mocks base method.
+func (m *MockSDPackageRepository) FindVersion(arg0 context.Context, arg1 uuid.UUID, arg2 int) (*model.SDPackageVersion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindLeastUsedPackageIDByUserID", arg0, arg1)
-	ret0, _ := ret[0].(uuid.UUID)
+	ret := m.ctrl.Call(m, "FindVersion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.SDPackageVersion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindLeastUsedPackageIDByUserID indicates an expected call of FindLeastUsedPackageIDByUserID.
-func (mr *MockSDPackageRepositoryMockRecorder) FindLeastUsedPackageIDByUserID(arg0, arg1 interface{}) *gomock.Call {
+// FindVersion indicates an expected call of FindVersion.
+func (mr *MockSDPackageRepositoryMockRecorder) FindVersion(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLeastUsedPackageIDByUserID", reflect.TypeOf((*MockSDPackageRepository)(nil).FindLeastUsedPackageIDByUserID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersion", reflect.TypeOf((*MockSDPackageRepository)(nil).FindVersion), arg0, arg1, arg2)
 }
 
-// FindRandomActivePackage mocks base method.
-func (m *MockSDPackageRepository) FindRandomActivePackage(arg0 context.Context) (*model.SpeechDelayPackage, error) {
+// FindVersions mocks base method.
+func (m *MockSDPackageRepository) FindVersions(arg0 context.Context, arg1 uuid.UUID) ([]*model.SDPackageVersion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindRandomActivePackage", arg0)
-	ret0, _ := ret[0].(*model.SpeechDelayPackage)
+	ret := m.ctrl.Call(m, "FindVersions", arg0, arg1)
+	ret0, _ := ret[0].([]*model.SDPackageVersion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindRandomActivePackage indicates an expected call of FindRandomActivePackage.
-func (mr *MockSDPackageRepositoryMockRecorder) FindRandomActivePackage(arg0 interface{}) *gomock.Call {
+// FindVersions indicates an expected call of FindVersions.
+func (mr *MockSDPackageRepositoryMockRecorder) FindVersions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRandomActivePackage", reflect.TypeOf((*MockSDPackageRepository)(nil).FindRandomActivePackage), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersions", reflect.TypeOf((*MockSDPackageRepository)(nil).FindVersions), arg0, arg1)
 }
 
 // GetTemplateByPackageID mocks base method.
@@ -169,3 +184,17 @@ func (mr *MockSDPackageRepositoryMockRecorder) Update(arg0, arg1, arg2 interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSDPackageRepository)(nil).Update), arg0, arg1, arg2)
 }
+
+// UpdateWithVersion mocks base method.
+func (m *MockSDPackageRepository) UpdateWithVersion(arg0 context.Context, arg1 *model.SpeechDelayPackage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithVersion", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWithVersion indicates an expected call of UpdateWithVersion.
+func (mr *MockSDPackageRepositoryMockRecorder) UpdateWithVersion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithVersion", reflect.TypeOf((*MockSDPackageRepository)(nil).UpdateWithVersion), arg0, arg1)
+}
@@ -52,6 +52,21 @@ func (mr *MockSDPackageUsecaseMockRecorder) ChangeSDPackageActiveStatus(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeSDPackageActiveStatus", reflect.TypeOf((*MockSDPackageUsecase)(nil).ChangeSDPackageActiveStatus), arg0, arg1, arg2)
 }
 
+// Clone mocks base method.
+func (m *MockSDPackageUsecase) Clone(arg0 context.Context, arg1 uuid.UUID, arg2 *model.CloneSDPackageInput) (*model.CloneSDPackageOutput, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clone", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.CloneSDPackageOutput)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// Clone indicates an expected call of Clone.
+func (mr *MockSDPackageUsecaseMockRecorder) Clone(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockSDPackageUsecase)(nil).Clone), arg0, arg1, arg2)
+}
+
 // Create mocks base method.
 func (m *MockSDPackageUsecase) Create(arg0 context.Context, arg1 *model.SDPackage) (*model.GeneratedSDPackage, *common.Error) {
 	m.ctrl.T.Helper()
@@ -112,6 +127,36 @@ func (mr *MockSDPackageUsecaseMockRecorder) FindReadyToUse(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReadyToUse", reflect.TypeOf((*MockSDPackageUsecase)(nil).FindReadyToUse), arg0, arg1, arg2)
 }
 
+// FindVersion mocks base method.
+func (m *MockSDPackageUsecase) FindVersion(arg0 context.Context, arg1 uuid.UUID, arg2 int) (*model.GeneratedSDPackageVersion, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.GeneratedSDPackageVersion)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// FindVersion indicates an expected call of FindVersion.
+func (mr *MockSDPackageUsecaseMockRecorder) FindVersion(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersion", reflect.TypeOf((*MockSDPackageUsecase)(nil).FindVersion), arg0, arg1, arg2)
+}
+
+// FindVersions mocks base method.
+func (m *MockSDPackageUsecase) FindVersions(arg0 context.Context, arg1 uuid.UUID) (*model.SDPackageVersionsOutput, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersions", arg0, arg1)
+	ret0, _ := ret[0].(*model.SDPackageVersionsOutput)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// FindVersions indicates an expected call of FindVersions.
+func (mr *MockSDPackageUsecaseMockRecorder) FindVersions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersions", reflect.TypeOf((*MockSDPackageUsecase)(nil).FindVersions), arg0, arg1)
+}
+
 // Search mocks base method.
 func (m *MockSDPackageUsecase) Search(arg0 context.Context, arg1 *model.SearchSDPackageInput) (*model.SearchPackageOutput, *common.Error) {
 	m.ctrl.T.Helper()
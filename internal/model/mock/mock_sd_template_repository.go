@@ -38,17 +38,17 @@ func (m *MockSDTemplateRepository) EXPECT() *MockSDTemplateRepositoryMockRecorde
 }
 
 // Create mocks base method.
-func (m *MockSDTemplateRepository) Create(arg0 context.Context, arg1 *model.SpeechDelayTemplate) error {
+func (m *MockSDTemplateRepository) Create(arg0 context.Context, arg1 *model.SpeechDelayTemplate, arg2 *gorm.DB) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockSDTemplateRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockSDTemplateRepositoryMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSDTemplateRepository)(nil).Create), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSDTemplateRepository)(nil).Create), arg0, arg1, arg2)
 }
 
 // Delete mocks base method.
@@ -81,6 +81,36 @@ func (mr *MockSDTemplateRepositoryMockRecorder) FindByID(arg0, arg1, arg2 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSDTemplateRepository)(nil).FindByID), arg0, arg1, arg2)
 }
 
+// FindVersion mocks base method.
+func (m *MockSDTemplateRepository) FindVersion(arg0 context.Context, arg1 uuid.UUID, arg2 int) (*model.SDTemplateVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.SDTemplateVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersion indicates an expected call of FindVersion.
+func (mr *MockSDTemplateRepositoryMockRecorder) FindVersion(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersion", reflect.TypeOf((*MockSDTemplateRepository)(nil).FindVersion), arg0, arg1, arg2)
+}
+
+// FindVersions mocks base method.
+func (m *MockSDTemplateRepository) FindVersions(arg0 context.Context, arg1 uuid.UUID) ([]*model.SDTemplateVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersions", arg0, arg1)
+	ret0, _ := ret[0].([]*model.SDTemplateVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersions indicates an expected call of FindVersions.
+func (mr *MockSDTemplateRepositoryMockRecorder) FindVersions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersions", reflect.TypeOf((*MockSDTemplateRepository)(nil).FindVersions), arg0, arg1)
+}
+
 // Search mocks base method.
 func (m *MockSDTemplateRepository) Search(arg0 context.Context, arg1 *model.SearchSDTemplateInput) ([]*model.SpeechDelayTemplate, error) {
 	m.ctrl.T.Helper()
@@ -124,3 +154,17 @@ func (mr *MockSDTemplateRepositoryMockRecorder) Update(arg0, arg1, arg2 interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSDTemplateRepository)(nil).Update), arg0, arg1, arg2)
 }
+
+// UpdateWithVersion mocks base method.
+func (m *MockSDTemplateRepository) UpdateWithVersion(arg0 context.Context, arg1 *model.SpeechDelayTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithVersion", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWithVersion indicates an expected call of UpdateWithVersion.
+func (mr *MockSDTemplateRepositoryMockRecorder) UpdateWithVersion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithVersion", reflect.TypeOf((*MockSDTemplateRepository)(nil).UpdateWithVersion), arg0, arg1)
+}
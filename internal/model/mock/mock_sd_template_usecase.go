@@ -52,6 +52,21 @@ func (mr *MockSDTemplateUsecaseMockRecorder) ChangeSDTemplateActiveStatus(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeSDTemplateActiveStatus", reflect.TypeOf((*MockSDTemplateUsecase)(nil).ChangeSDTemplateActiveStatus), arg0, arg1, arg2)
 }
 
+// Clone mocks base method.
+func (m *MockSDTemplateUsecase) Clone(arg0 context.Context, arg1 uuid.UUID, arg2 *model.CloneSDTemplateInput) (*model.GeneratedSDTemplate, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clone", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.GeneratedSDTemplate)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// Clone indicates an expected call of Clone.
+func (mr *MockSDTemplateUsecaseMockRecorder) Clone(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockSDTemplateUsecase)(nil).Clone), arg0, arg1, arg2)
+}
+
 // Create mocks base method.
 func (m *MockSDTemplateUsecase) Create(arg0 context.Context, arg1 *model.SDTemplate) (*model.GeneratedSDTemplate, *common.Error) {
 	m.ctrl.T.Helper()
@@ -97,6 +112,36 @@ func (mr *MockSDTemplateUsecaseMockRecorder) FindByID(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSDTemplateUsecase)(nil).FindByID), arg0, arg1)
 }
 
+// FindVersion mocks base method.
+func (m *MockSDTemplateUsecase) FindVersion(arg0 context.Context, arg1 uuid.UUID, arg2 int) (*model.GeneratedSDTemplateVersion, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.GeneratedSDTemplateVersion)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// FindVersion indicates an expected call of FindVersion.
+func (mr *MockSDTemplateUsecaseMockRecorder) FindVersion(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersion", reflect.TypeOf((*MockSDTemplateUsecase)(nil).FindVersion), arg0, arg1, arg2)
+}
+
+// FindVersions mocks base method.
+func (m *MockSDTemplateUsecase) FindVersions(arg0 context.Context, arg1 uuid.UUID) (*model.SDTemplateVersionsOutput, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersions", arg0, arg1)
+	ret0, _ := ret[0].(*model.SDTemplateVersionsOutput)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// FindVersions indicates an expected call of FindVersions.
+func (mr *MockSDTemplateUsecaseMockRecorder) FindVersions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersions", reflect.TypeOf((*MockSDTemplateUsecase)(nil).FindVersions), arg0, arg1)
+}
+
 // Search mocks base method.
 func (m *MockSDTemplateUsecase) Search(arg0 context.Context, arg1 *model.SearchSDTemplateInput) (*model.SearchSDTemplateOutput, *common.Error) {
 	m.ctrl.T.Helper()
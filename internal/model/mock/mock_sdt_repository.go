@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -37,6 +38,35 @@ func (m *MockSDTestRepository) EXPECT() *MockSDTestRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Claim mocks base method.
+func (m *MockSDTestRepository) Claim(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockSDTestRepositoryMockRecorder) Claim(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockSDTestRepository)(nil).Claim), arg0, arg1, arg2, arg3)
+}
+
+// CountPackageUsage mocks base method.
+func (m *MockSDTestRepository) CountPackageUsage(arg0 context.Context, arg1 uuid.UUID, arg2 []uuid.UUID) (map[uuid.UUID]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPackageUsage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[uuid.UUID]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPackageUsage indicates an expected call of CountPackageUsage.
+func (mr *MockSDTestRepositoryMockRecorder) CountPackageUsage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPackageUsage", reflect.TypeOf((*MockSDTestRepository)(nil).CountPackageUsage), arg0, arg1, arg2)
+}
+
 // Create mocks base method.
 func (m *MockSDTestRepository) Create(arg0 context.Context, arg1 *model.SDTest, arg2 *gorm.DB) error {
 	m.ctrl.T.Helper()
@@ -66,6 +96,65 @@ func (mr *MockSDTestRepositoryMockRecorder) FindByID(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSDTestRepository)(nil).FindByID), arg0, arg1)
 }
 
+// FindLatest mocks base method.
+func (m *MockSDTestRepository) FindLatest(arg0 context.Context, arg1 *model.FindLatestSDTestInput) (*model.SDTest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatest", arg0, arg1)
+	ret0, _ := ret[0].(*model.SDTest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatest indicates an expected call of FindLatest.
+func (mr *MockSDTestRepositoryMockRecorder) FindLatest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatest", reflect.TypeOf((*MockSDTestRepository)(nil).FindLatest), arg0, arg1)
+}
+
+// FindRetakeStat mocks base method.
+func (m *MockSDTestRepository) FindRetakeStat(arg0 context.Context, arg1 *model.SDRetakeStatInput, arg2 *gorm.DB) (*model.SDRetakeStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRetakeStat", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.SDRetakeStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRetakeStat indicates an expected call of FindRetakeStat.
+func (mr *MockSDTestRepositoryMockRecorder) FindRetakeStat(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRetakeStat", reflect.TypeOf((*MockSDTestRepository)(nil).FindRetakeStat), arg0, arg1, arg2)
+}
+
+// MarkExpired mocks base method.
+func (m *MockSDTestRepository) MarkExpired(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkExpired", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkExpired indicates an expected call of MarkExpired.
+func (mr *MockSDTestRepositoryMockRecorder) MarkExpired(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkExpired", reflect.TypeOf((*MockSDTestRepository)(nil).MarkExpired), arg0, arg1)
+}
+
+// SaveDraft mocks base method.
+func (m *MockSDTestRepository) SaveDraft(arg0 context.Context, arg1 uuid.UUID, arg2 model.SDTestAnswer, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDraft", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDraft indicates an expected call of SaveDraft.
+func (mr *MockSDTestRepositoryMockRecorder) SaveDraft(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDraft", reflect.TypeOf((*MockSDTestRepository)(nil).SaveDraft), arg0, arg1, arg2, arg3)
+}
+
 // Search mocks base method.
 func (m *MockSDTestRepository) Search(arg0 context.Context, arg1 *model.ViewHistoriesInput) ([]*model.SDTest, error) {
 	m.ctrl.T.Helper()
@@ -82,7 +171,7 @@ func (mr *MockSDTestRepositoryMockRecorder) Search(arg0, arg1 interface{}) *gomo
 }
 
 // Statistic mocks base method.
-func (m *MockSDTestRepository) Statistic(arg0 context.Context, arg1 uuid.UUID) ([]model.SDTestStatistic, error) {
+func (m *MockSDTestRepository) Statistic(arg0 context.Context, arg1 *model.SDTestStatisticInput) ([]model.SDTestStatistic, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Statistic", arg0, arg1)
 	ret0, _ := ret[0].([]model.SDTestStatistic)
@@ -37,8 +37,52 @@ func (m *MockSDTestUsecase) EXPECT() *MockSDTestUsecaseMockRecorder {
 	return m.recorder
 }
 
+// Claim mocks base method.
+func (m *MockSDTestUsecase) Claim(arg0 context.Context, arg1 *model.ClaimSDTestInput) (*model.ViewHistoriesOutput, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", arg0, arg1)
+	ret0, _ := ret[0].(*model.ViewHistoriesOutput)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockSDTestUsecaseMockRecorder) Claim(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockSDTestUsecase)(nil).Claim), arg0, arg1)
+}
+
+// CreateShare mocks base method.
+func (m *MockSDTestUsecase) CreateShare(arg0 context.Context, arg1 *model.CreateSDResultShareInput) (*model.GeneratedSDResultShare, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShare", arg0, arg1)
+	ret0, _ := ret[0].(*model.GeneratedSDResultShare)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// CreateShare indicates an expected call of CreateShare.
+func (mr *MockSDTestUsecaseMockRecorder) CreateShare(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShare", reflect.TypeOf((*MockSDTestUsecase)(nil).CreateShare), arg0, arg1)
+}
+
+// DeliverResult mocks base method.
+func (m *MockSDTestUsecase) DeliverResult(arg0 context.Context, arg1 uuid.UUID) *common.Error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverResult", arg0, arg1)
+	ret0, _ := ret[0].(*common.Error)
+	return ret0
+}
+
+// DeliverResult indicates an expected call of DeliverResult.
+func (mr *MockSDTestUsecaseMockRecorder) DeliverResult(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverResult", reflect.TypeOf((*MockSDTestUsecase)(nil).DeliverResult), arg0, arg1)
+}
+
 // DownloadResult mocks base method.
-func (m *MockSDTestUsecase) DownloadResult(arg0 context.Context, arg1 uuid.UUID) (*model.ImageResult, *common.Error) {
+func (m *MockSDTestUsecase) DownloadResult(arg0 context.Context, arg1 *model.DownloadSDTestResultInput) (*model.ImageResult, *common.Error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DownloadResult", arg0, arg1)
 	ret0, _ := ret[0].(*model.ImageResult)
@@ -52,6 +96,21 @@ func (mr *MockSDTestUsecaseMockRecorder) DownloadResult(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadResult", reflect.TypeOf((*MockSDTestUsecase)(nil).DownloadResult), arg0, arg1)
 }
 
+// FindShares mocks base method.
+func (m *MockSDTestUsecase) FindShares(arg0 context.Context, arg1 uuid.UUID) ([]*model.GeneratedSDResultShare, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindShares", arg0, arg1)
+	ret0, _ := ret[0].([]*model.GeneratedSDResultShare)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// FindShares indicates an expected call of FindShares.
+func (mr *MockSDTestUsecaseMockRecorder) FindShares(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShares", reflect.TypeOf((*MockSDTestUsecase)(nil).FindShares), arg0, arg1)
+}
+
 // Histories mocks base method.
 func (m *MockSDTestUsecase) Histories(arg0 context.Context, arg1 *model.ViewHistoriesInput) ([]model.ViewHistoriesOutput, *common.Error) {
 	m.ctrl.T.Helper()
@@ -68,12 +127,13 @@ func (mr *MockSDTestUsecaseMockRecorder) Histories(arg0, arg1 interface{}) *gomo
 }
 
 // Initiate mocks base method.
-func (m *MockSDTestUsecase) Initiate(arg0 context.Context, arg1 *model.InitiateSDTestInput) (*model.InitiateSDTestOutput, *common.Error) {
+func (m *MockSDTestUsecase) Initiate(arg0 context.Context, arg1 *model.InitiateSDTestInput) (*model.InitiateSDTestOutput, *model.SDRetakeNotAllowedOutput, *common.Error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Initiate", arg0, arg1)
 	ret0, _ := ret[0].(*model.InitiateSDTestOutput)
-	ret1, _ := ret[1].(*common.Error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*model.SDRetakeNotAllowedOutput)
+	ret2, _ := ret[2].(*common.Error)
+	return ret0, ret1, ret2
 }
 
 // Initiate indicates an expected call of Initiate.
@@ -82,8 +142,68 @@ func (mr *MockSDTestUsecaseMockRecorder) Initiate(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Initiate", reflect.TypeOf((*MockSDTestUsecase)(nil).Initiate), arg0, arg1)
 }
 
+// ProgressChart mocks base method.
+func (m *MockSDTestUsecase) ProgressChart(arg0 context.Context, arg1 *model.SDTestProgressChartInput) (*model.ImageResult, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProgressChart", arg0, arg1)
+	ret0, _ := ret[0].(*model.ImageResult)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// ProgressChart indicates an expected call of ProgressChart.
+func (mr *MockSDTestUsecaseMockRecorder) ProgressChart(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProgressChart", reflect.TypeOf((*MockSDTestUsecase)(nil).ProgressChart), arg0, arg1)
+}
+
+// ResultPublicKey mocks base method.
+func (m *MockSDTestUsecase) ResultPublicKey(arg0 context.Context) (*model.SDResultPublicKeyOutput, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResultPublicKey", arg0)
+	ret0, _ := ret[0].(*model.SDResultPublicKeyOutput)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// ResultPublicKey indicates an expected call of ResultPublicKey.
+func (mr *MockSDTestUsecaseMockRecorder) ResultPublicKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResultPublicKey", reflect.TypeOf((*MockSDTestUsecase)(nil).ResultPublicKey), arg0)
+}
+
+// RevokeShare mocks base method.
+func (m *MockSDTestUsecase) RevokeShare(arg0 context.Context, arg1 *model.RevokeSDResultShareInput) (*model.GeneratedSDResultShare, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", arg0, arg1)
+	ret0, _ := ret[0].(*model.GeneratedSDResultShare)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockSDTestUsecaseMockRecorder) RevokeShare(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockSDTestUsecase)(nil).RevokeShare), arg0, arg1)
+}
+
+// SaveDraft mocks base method.
+func (m *MockSDTestUsecase) SaveDraft(arg0 context.Context, arg1 *model.SaveSDTestDraftInput) (*model.SDTestDraftOutput, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDraft", arg0, arg1)
+	ret0, _ := ret[0].(*model.SDTestDraftOutput)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// SaveDraft indicates an expected call of SaveDraft.
+func (mr *MockSDTestUsecaseMockRecorder) SaveDraft(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDraft", reflect.TypeOf((*MockSDTestUsecase)(nil).SaveDraft), arg0, arg1)
+}
+
 // Statistic mocks base method.
-func (m *MockSDTestUsecase) Statistic(arg0 context.Context, arg1 uuid.UUID) ([]model.SDTestStatistic, *common.Error) {
+func (m *MockSDTestUsecase) Statistic(arg0 context.Context, arg1 *model.SDTestStatisticInput) ([]model.SDTestStatistic, *common.Error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Statistic", arg0, arg1)
 	ret0, _ := ret[0].([]model.SDTestStatistic)
@@ -111,3 +231,63 @@ func (mr *MockSDTestUsecaseMockRecorder) Submit(arg0, arg1 interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockSDTestUsecase)(nil).Submit), arg0, arg1)
 }
+
+// VerifyResult mocks base method.
+func (m *MockSDTestUsecase) VerifyResult(arg0 context.Context, arg1 *model.VerifySDTestResultInput) (*model.VerifySDTestResultOutput, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyResult", arg0, arg1)
+	ret0, _ := ret[0].(*model.VerifySDTestResultOutput)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// VerifyResult indicates an expected call of VerifyResult.
+func (mr *MockSDTestUsecaseMockRecorder) VerifyResult(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyResult", reflect.TypeOf((*MockSDTestUsecase)(nil).VerifyResult), arg0, arg1)
+}
+
+// ViewDraft mocks base method.
+func (m *MockSDTestUsecase) ViewDraft(arg0 context.Context, arg1 *model.ViewSDTestDraftInput) (*model.SDTestDraftOutput, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewDraft", arg0, arg1)
+	ret0, _ := ret[0].(*model.SDTestDraftOutput)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// ViewDraft indicates an expected call of ViewDraft.
+func (mr *MockSDTestUsecaseMockRecorder) ViewDraft(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewDraft", reflect.TypeOf((*MockSDTestUsecase)(nil).ViewDraft), arg0, arg1)
+}
+
+// ViewSharedResult mocks base method.
+func (m *MockSDTestUsecase) ViewSharedResult(arg0 context.Context, arg1 *model.ViewSharedSDTestResultInput) (*model.SharedSDTestResultOutput, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewSharedResult", arg0, arg1)
+	ret0, _ := ret[0].(*model.SharedSDTestResultOutput)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// ViewSharedResult indicates an expected call of ViewSharedResult.
+func (mr *MockSDTestUsecaseMockRecorder) ViewSharedResult(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewSharedResult", reflect.TypeOf((*MockSDTestUsecase)(nil).ViewSharedResult), arg0, arg1)
+}
+
+// ViewSharedResultImage mocks base method.
+func (m *MockSDTestUsecase) ViewSharedResultImage(arg0 context.Context, arg1 *model.ViewSharedSDTestResultInput) (*model.ImageResult, *common.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewSharedResultImage", arg0, arg1)
+	ret0, _ := ret[0].(*model.ImageResult)
+	ret1, _ := ret[1].(*common.Error)
+	return ret0, ret1
+}
+
+// ViewSharedResultImage indicates an expected call of ViewSharedResultImage.
+func (mr *MockSDTestUsecaseMockRecorder) ViewSharedResultImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewSharedResultImage", reflect.TypeOf((*MockSDTestUsecase)(nil).ViewSharedResultImage), arg0, arg1)
+}
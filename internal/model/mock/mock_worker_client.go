@@ -36,6 +36,21 @@ func (m *MockWorkerClient) EXPECT() *MockWorkerClientMockRecorder {
 	return m.recorder
 }
 
+// EnqueueDeliverSDTestResultTask mocks base method.
+func (m *MockWorkerClient) EnqueueDeliverSDTestResultTask(arg0 context.Context, arg1 uuid.UUID) (*asynq.TaskInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueDeliverSDTestResultTask", arg0, arg1)
+	ret0, _ := ret[0].(*asynq.TaskInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueDeliverSDTestResultTask indicates an expected call of EnqueueDeliverSDTestResultTask.
+func (mr *MockWorkerClientMockRecorder) EnqueueDeliverSDTestResultTask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueDeliverSDTestResultTask", reflect.TypeOf((*MockWorkerClient)(nil).EnqueueDeliverSDTestResultTask), arg0, arg1)
+}
+
 // EnqueueEnforceActiveTokenLimiterTask mocks base method.
 func (m *MockWorkerClient) EnqueueEnforceActiveTokenLimiterTask(arg0 context.Context, arg1 uuid.UUID) (*asynq.TaskInfo, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// PackageSelectionStrategy define how the sd package is chosen when the test is initiated without a package id
+type PackageSelectionStrategy string
+
+// list of known package selection strategies
+const (
+	// PackageSelectionLeastUsed choose the package least used by the user. Anonymous user will get a random package
+	PackageSelectionLeastUsed PackageSelectionStrategy = "least_used"
+	// PackageSelectionRoundRobin choose the package next to the one used by the latest test, across all users
+	PackageSelectionRoundRobin PackageSelectionStrategy = "round_robin"
+	// PackageSelectionWeightedRandom choose a random package, weighted by SDPackage.SelectionWeight
+	PackageSelectionWeightedRandom PackageSelectionStrategy = "weighted_random"
+	// PackageSelectionSameTemplate choose the least used package from the same template used by the user latest test
+	PackageSelectionSameTemplate PackageSelectionStrategy = "same_template"
+	// PackageSelectionRandom choose a random package
+	PackageSelectionRandom PackageSelectionStrategy = "random"
+)
+
+// OrDefault return the strategy used when none is configured. Logged in user will get
+// PackageSelectionLeastUsed, while anonymous user will get PackageSelectionRandom
+func (s PackageSelectionStrategy) OrDefault(userID uuid.NullUUID) PackageSelectionStrategy {
+	if s != "" {
+		return s
+	}
+
+	if userID.Valid {
+		return PackageSelectionLeastUsed
+	}
+
+	return PackageSelectionRandom
+}
+
+// PackageSelectionInput input to choose the sd package
+type PackageSelectionInput struct {
+	UserID uuid.NullUUID
+
+	// Candidates are the active packages to choose from, ordered by the creation time ascending. Never empty.
+	Candidates []*SpeechDelayPackage
+}
+
+// PackageSelector choose the sd package to be used when initiating the sd test
+type PackageSelector interface {
+	// Strategy return the strategy implemented by the selector
+	Strategy() PackageSelectionStrategy
+
+	// Select will choose one package from the input candidates
+	Select(ctx context.Context, input *PackageSelectionInput) (*SpeechDelayPackage, error)
+}
@@ -0,0 +1,216 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+)
+
+// SDAnalyticsInput input to get the analytics of a template or a package
+type SDAnalyticsInput struct {
+	ID uuid.UUID `param:"id"`
+
+	// From and To are optional, only the tests finished within the range are counted
+	From time.Time `query:"from"`
+	To   time.Time `query:"to"`
+}
+
+// Validate validate the input. To must not be before From
+func (i *SDAnalyticsInput) Validate() error {
+	if !i.From.IsZero() && !i.To.IsZero() && i.To.Before(i.From) {
+		return errors.New("to must not be before from")
+	}
+
+	return nil
+}
+
+// SDAnalyticsFilter filter on the finished tests to be aggregated. PackageID is optional
+type SDAnalyticsFilter struct {
+	TemplateID uuid.UUID
+	PackageID  uuid.NullUUID
+	From       time.Time
+	To         time.Time
+}
+
+// ToWhereQuery convert the filter to where query and conditions on test_results table aliased as tr,
+// joined with test_packages table aliased as tp
+func (f *SDAnalyticsFilter) ToWhereQuery() ([]string, []interface{}) {
+	whereQuery := []string{"tr.finished_at IS NOT NULL", "tr.deleted_at IS NULL", "tr.template_id = ?"}
+	conds := []interface{}{f.TemplateID}
+
+	if f.PackageID.Valid {
+		whereQuery = append(whereQuery, "tr.package_id = ?")
+		conds = append(conds, f.PackageID.UUID)
+	}
+
+	if !f.From.IsZero() {
+		whereQuery = append(whereQuery, "tr.finished_at >= ?")
+		conds = append(conds, f.From)
+	}
+
+	if !f.To.IsZero() {
+		whereQuery = append(whereQuery, "tr.finished_at <= ?")
+		conds = append(conds, f.To)
+	}
+
+	return whereQuery, conds
+}
+
+// SDScoreDistributionRow the number of finished tests having the point, aggregated by the database.
+// GroupName is empty for the total point. Positive is the number of those tests interpreted as positive,
+// only counted for the total point
+type SDScoreDistributionRow struct {
+	Registered bool   `gorm:"column:registered"`
+	GroupName  string `gorm:"column:group_name"`
+	Point      int    `gorm:"column:point"`
+	Count      int    `gorm:"column:count"`
+	Positive   int    `gorm:"column:positive"`
+}
+
+// SDScoreBucket the number of finished tests having the point
+type SDScoreBucket struct {
+	Point int `json:"point"`
+	Count int `json:"count"`
+}
+
+// SDSubGroupDistribution the score distribution of a sub group
+type SDSubGroupDistribution struct {
+	GroupName    string          `json:"groupName"`
+	Distribution []SDScoreBucket `json:"distribution"`
+}
+
+// SDAnalyticsSegment the analytics of a group of users
+type SDAnalyticsSegment struct {
+	FinishedTests          int                      `json:"finishedTests"`
+	PositiveIndications    int                      `json:"positiveIndications"`
+	PositiveIndicationRate float64                  `json:"positiveIndicationRate"`
+	TotalDistribution      []SDScoreBucket          `json:"totalDistribution"`
+	SubGroupDistributions  []SDSubGroupDistribution `json:"subGroupDistributions"`
+}
+
+// SDAnalytics the population analytics of a template, or a package when PackageID is set
+type SDAnalytics struct {
+	TemplateID          uuid.UUID     `json:"templateID"`
+	TemplateName        string        `json:"templateName"`
+	PackageID           uuid.NullUUID `json:"packageID,omitempty"`
+	PackageName         string        `json:"packageName,omitempty"`
+	From                time.Time     `json:"from,omitempty"`
+	To                  time.Time     `json:"to,omitempty"`
+	IndicationThreshold int           `json:"indicationThreshold"`
+
+	Overall    SDAnalyticsSegment `json:"overall"`
+	Anonymous  SDAnalyticsSegment `json:"anonymous"`
+	Registered SDAnalyticsSegment `json:"registered"`
+}
+
+// Aggregate will fill the segments from the total and the sub group score distributions.
+// The positive indication is counted from the interpretation stored on each result, thus it is the same
+// as what the users were told even when IndicationThreshold is changed afterward
+func (a *SDAnalytics) Aggregate(total, subGroups []SDScoreDistributionRow) {
+	overall, anonymous, registered := newSegmentBuilder(), newSegmentBuilder(), newSegmentBuilder()
+	segmentOf := func(row SDScoreDistributionRow) *segmentBuilder {
+		if row.Registered {
+			return registered
+		}
+
+		return anonymous
+	}
+
+	for _, row := range total {
+		overall.addTotal(row)
+		segmentOf(row).addTotal(row)
+	}
+
+	for _, row := range subGroups {
+		overall.addSubGroup(row)
+		segmentOf(row).addSubGroup(row)
+	}
+
+	a.Overall = overall.build()
+	a.Anonymous = anonymous.build()
+	a.Registered = registered.build()
+}
+
+type segmentBuilder struct {
+	finished   int
+	positive   int
+	total      map[int]int
+	groupOrder []string
+	groups     map[string]map[int]int
+}
+
+func newSegmentBuilder() *segmentBuilder {
+	return &segmentBuilder{
+		total:  make(map[int]int),
+		groups: make(map[string]map[int]int),
+	}
+}
+
+func (b *segmentBuilder) addTotal(row SDScoreDistributionRow) {
+	b.finished += row.Count
+	b.positive += row.Positive
+	b.total[row.Point] += row.Count
+}
+
+func (b *segmentBuilder) addSubGroup(row SDScoreDistributionRow) {
+	if _, ok := b.groups[row.GroupName]; !ok {
+		b.groupOrder = append(b.groupOrder, row.GroupName)
+		b.groups[row.GroupName] = make(map[int]int)
+	}
+
+	b.groups[row.GroupName][row.Point] += row.Count
+}
+
+func (b *segmentBuilder) build() SDAnalyticsSegment {
+	segment := SDAnalyticsSegment{
+		FinishedTests:         b.finished,
+		PositiveIndications:   b.positive,
+		TotalDistribution:     toScoreBuckets(b.total),
+		SubGroupDistributions: []SDSubGroupDistribution{},
+	}
+
+	if b.finished > 0 {
+		segment.PositiveIndicationRate = float64(b.positive) / float64(b.finished)
+	}
+
+	for _, name := range b.groupOrder {
+		segment.SubGroupDistributions = append(segment.SubGroupDistributions, SDSubGroupDistribution{
+			GroupName:    name,
+			Distribution: toScoreBuckets(b.groups[name]),
+		})
+	}
+
+	return segment
+}
+
+// toScoreBuckets convert the point counter to buckets ordered by the point ascending
+func toScoreBuckets(counter map[int]int) []SDScoreBucket {
+	buckets := []SDScoreBucket{}
+	for point, count := range counter {
+		buckets = append(buckets, SDScoreBucket{Point: point, Count: count})
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Point < buckets[j].Point
+	})
+
+	return buckets
+}
+
+// SDAnalyticsUsecase usecase
+type SDAnalyticsUsecase interface {
+	TemplateAnalytics(ctx context.Context, input *SDAnalyticsInput) (*SDAnalytics, *common.Error)
+	PackageAnalytics(ctx context.Context, input *SDAnalyticsInput) (*SDAnalytics, *common.Error)
+	PackageItemAnalysis(ctx context.Context, input *SDItemAnalysisInput) (*SDItemAnalysisReport, *common.Error)
+}
+
+// SDAnalyticsRepository repository
+type SDAnalyticsRepository interface {
+	TotalScoreDistribution(ctx context.Context, filter *SDAnalyticsFilter) ([]SDScoreDistributionRow, error)
+	SubGroupScoreDistribution(ctx context.Context, filter *SDAnalyticsFilter) ([]SDScoreDistributionRow, error)
+	FindFinishedAnswers(ctx context.Context, filter *SDAnalyticsFilter) ([]SDTestAnswer, error)
+}
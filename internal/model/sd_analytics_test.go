@@ -0,0 +1,116 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSDAnalyticsInput_Validate(t *testing.T) {
+	now := time.Now().UTC()
+
+	assert.NoError(t, (&SDAnalyticsInput{}).Validate())
+	assert.NoError(t, (&SDAnalyticsInput{From: now}).Validate())
+	assert.NoError(t, (&SDAnalyticsInput{From: now, To: now}).Validate())
+	assert.Error(t, (&SDAnalyticsInput{From: now, To: now.Add(-time.Hour)}).Validate())
+}
+
+func TestSDAnalyticsFilter_ToWhereQuery(t *testing.T) {
+	templateID := uuid.New()
+	packageID := uuid.New()
+	now := time.Now().UTC()
+
+	where, conds := (&SDAnalyticsFilter{TemplateID: templateID}).ToWhereQuery()
+	assert.Equal(t, where, []string{"tr.finished_at IS NOT NULL", "tr.deleted_at IS NULL", "tr.template_id = ?"})
+	assert.Equal(t, conds, []interface{}{templateID})
+
+	where, conds = (&SDAnalyticsFilter{
+		TemplateID: templateID,
+		PackageID:  uuid.NullUUID{UUID: packageID, Valid: true},
+		From:       now.Add(-time.Hour),
+		To:         now,
+	}).ToWhereQuery()
+	assert.Equal(t, where, []string{
+		"tr.finished_at IS NOT NULL",
+		"tr.deleted_at IS NULL",
+		"tr.template_id = ?",
+		"tr.package_id = ?",
+		"tr.finished_at >= ?",
+		"tr.finished_at <= ?",
+	})
+	assert.Equal(t, conds, []interface{}{templateID, packageID, now.Add(-time.Hour), now})
+}
+
+func TestSDAnalytics_Aggregate(t *testing.T) {
+	t.Run("no finished tests", func(t *testing.T) {
+		a := &SDAnalytics{IndicationThreshold: 10}
+		a.Aggregate(nil, nil)
+
+		empty := SDAnalyticsSegment{
+			TotalDistribution:     []SDScoreBucket{},
+			SubGroupDistributions: []SDSubGroupDistribution{},
+		}
+		assert.Equal(t, a.Overall, empty)
+		assert.Equal(t, a.Anonymous, empty)
+		assert.Equal(t, a.Registered, empty)
+	})
+
+	t.Run("split by anonymous and registered", func(t *testing.T) {
+		a := &SDAnalytics{IndicationThreshold: 10}
+		a.Aggregate([]SDScoreDistributionRow{
+			{Registered: false, Point: 12, Count: 1, Positive: 1},
+			{Registered: false, Point: 3, Count: 2},
+			{Registered: true, Point: 3, Count: 1},
+			{Registered: true, Point: 10, Count: 3, Positive: 3},
+		}, []SDScoreDistributionRow{
+			{Registered: false, GroupName: "a", Point: 1, Count: 3},
+			{Registered: false, GroupName: "b", Point: 2, Count: 3},
+			{Registered: true, GroupName: "a", Point: 1, Count: 4},
+			{Registered: true, GroupName: "b", Point: 5, Count: 4},
+		})
+
+		assert.Equal(t, a.Overall, SDAnalyticsSegment{
+			FinishedTests:          7,
+			PositiveIndications:    4,
+			PositiveIndicationRate: float64(4) / float64(7),
+			TotalDistribution:      []SDScoreBucket{{Point: 3, Count: 3}, {Point: 10, Count: 3}, {Point: 12, Count: 1}},
+			SubGroupDistributions: []SDSubGroupDistribution{
+				{GroupName: "a", Distribution: []SDScoreBucket{{Point: 1, Count: 7}}},
+				{GroupName: "b", Distribution: []SDScoreBucket{{Point: 2, Count: 3}, {Point: 5, Count: 4}}},
+			},
+		})
+		assert.Equal(t, a.Anonymous, SDAnalyticsSegment{
+			FinishedTests:          3,
+			PositiveIndications:    1,
+			PositiveIndicationRate: float64(1) / float64(3),
+			TotalDistribution:      []SDScoreBucket{{Point: 3, Count: 2}, {Point: 12, Count: 1}},
+			SubGroupDistributions: []SDSubGroupDistribution{
+				{GroupName: "a", Distribution: []SDScoreBucket{{Point: 1, Count: 3}}},
+				{GroupName: "b", Distribution: []SDScoreBucket{{Point: 2, Count: 3}}},
+			},
+		})
+		assert.Equal(t, a.Registered, SDAnalyticsSegment{
+			FinishedTests:          4,
+			PositiveIndications:    3,
+			PositiveIndicationRate: 0.75,
+			TotalDistribution:      []SDScoreBucket{{Point: 3, Count: 1}, {Point: 10, Count: 3}},
+			SubGroupDistributions: []SDSubGroupDistribution{
+				{GroupName: "a", Distribution: []SDScoreBucket{{Point: 1, Count: 4}}},
+				{GroupName: "b", Distribution: []SDScoreBucket{{Point: 5, Count: 4}}},
+			},
+		})
+	})
+
+	t.Run("positive indication follows the stored interpretation instead of the current threshold", func(t *testing.T) {
+		a := &SDAnalytics{IndicationThreshold: 10}
+		a.Aggregate([]SDScoreDistributionRow{
+			{Registered: true, Point: 20, Count: 2, Positive: 1},
+			{Registered: true, Point: 5, Count: 2, Positive: 2},
+		}, nil)
+		assert.Equal(t, a.Overall.FinishedTests, 4)
+		assert.Equal(t, a.Overall.PositiveIndications, 3)
+		assert.Equal(t, a.Overall.PositiveIndicationRate, 0.75)
+	})
+}
@@ -0,0 +1,193 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+)
+
+// SDAssignmentStatus define the progress of the sd test assignment
+type SDAssignmentStatus string
+
+// list of sd test assignment status
+const (
+	SDAssignmentStatusAssigned   SDAssignmentStatus = "assigned"
+	SDAssignmentStatusInProgress SDAssignmentStatus = "in_progress"
+	SDAssignmentStatusCompleted  SDAssignmentStatus = "completed"
+	SDAssignmentStatusCancelled  SDAssignmentStatus = "cancelled"
+)
+
+// SDAssignment represent test_assignments table. An assignment is created by the clinician / admin
+// to ask the assignee to take the test using the package, or any active package of the template, before DueAt.
+// Only one of PackageID or TemplateID is set. TestID is the latest test started from this assignment
+type SDAssignment struct {
+	ID          uuid.UUID
+	AssigneeID  uuid.UUID
+	PackageID   uuid.NullUUID
+	TemplateID  uuid.NullUUID
+	DueAt       time.Time
+	Note        string
+	Status      SDAssignmentStatus
+	TestID      uuid.NullUUID
+	CreatedBy   uuid.UUID
+	CompletedAt null.Time
+	CancelledAt null.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt
+}
+
+// TableName define the table name for gorm
+func (sda SDAssignment) TableName() string {
+	return "test_assignments"
+}
+
+// IsOpen report whether a test can still be started from this assignment
+func (sda *SDAssignment) IsOpen() bool {
+	return sda.Status == SDAssignmentStatusAssigned || sda.Status == SDAssignmentStatusInProgress
+}
+
+// IsOverdue report whether the assignment is still open while already past the due date
+func (sda *SDAssignment) IsOverdue() bool {
+	return sda.IsOpen() && sda.DueAt.Before(time.Now())
+}
+
+// Start mark the assignment as in progress by the test started from it
+func (sda *SDAssignment) Start(test *SDTest) {
+	sda.Status = SDAssignmentStatusInProgress
+	sda.TestID = uuid.NullUUID{UUID: test.ID, Valid: true}
+	sda.UpdatedAt = test.CreatedAt
+}
+
+// Complete mark the assignment as completed by the submitted test
+func (sda *SDAssignment) Complete(test *SDTest) {
+	sda.Status = SDAssignmentStatusCompleted
+	sda.TestID = uuid.NullUUID{UUID: test.ID, Valid: true}
+	sda.CompletedAt = test.FinishedAt
+	sda.UpdatedAt = test.UpdatedAt
+}
+
+// ToRESTResponse convert to GeneratedSDAssignment
+func (sda *SDAssignment) ToRESTResponse() *GeneratedSDAssignment {
+	return &GeneratedSDAssignment{
+		ID:          sda.ID,
+		AssigneeID:  sda.AssigneeID,
+		PackageID:   sda.PackageID,
+		TemplateID:  sda.TemplateID,
+		DueAt:       sda.DueAt,
+		Note:        sda.Note,
+		Status:      sda.Status,
+		IsOverdue:   sda.IsOverdue(),
+		TestID:      sda.TestID,
+		CreatedBy:   sda.CreatedBy,
+		CompletedAt: sda.CompletedAt,
+		CancelledAt: sda.CancelledAt,
+		CreatedAt:   sda.CreatedAt,
+		UpdatedAt:   sda.UpdatedAt,
+	}
+}
+
+// GeneratedSDAssignment will be used to define the sd test assignment as the returned value as REST API responses
+type GeneratedSDAssignment struct {
+	ID          uuid.UUID          `json:"id"`
+	AssigneeID  uuid.UUID          `json:"assigneeID"`
+	PackageID   uuid.NullUUID      `json:"packageID"`
+	TemplateID  uuid.NullUUID      `json:"templateID"`
+	DueAt       time.Time          `json:"dueAt"`
+	Note        string             `json:"note"`
+	Status      SDAssignmentStatus `json:"status"`
+	IsOverdue   bool               `json:"isOverdue"`
+	TestID      uuid.NullUUID      `json:"testID"`
+	CreatedBy   uuid.UUID          `json:"createdBy"`
+	CompletedAt null.Time          `json:"completedAt"`
+	CancelledAt null.Time          `json:"cancelledAt"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+}
+
+// CreateSDAssignmentInput input to assign a package or template to the assignee
+type CreateSDAssignmentInput struct {
+	AssigneeID uuid.UUID     `json:"assigneeID" validate:"required"`
+	PackageID  uuid.NullUUID `json:"packageID"`
+	TemplateID uuid.NullUUID `json:"templateID"`
+	DueAt      time.Time     `json:"dueAt" validate:"required"`
+	Note       string        `json:"note" validate:"max=1000"`
+}
+
+// Validate validate struct. Exactly one of PackageID or TemplateID must be set, and DueAt must be in the future
+func (i *CreateSDAssignmentInput) Validate() error {
+	if err := validator.Struct(i); err != nil {
+		return err
+	}
+
+	if i.PackageID.Valid == i.TemplateID.Valid {
+		return errors.New("exactly one of packageID or templateID must be set")
+	}
+
+	if !i.DueAt.After(time.Now()) {
+		return errors.New("dueAt must be in the future")
+	}
+
+	return nil
+}
+
+// SearchSDAssignmentInput input to search sd test assignment
+type SearchSDAssignmentInput struct {
+	AssigneeID uuid.NullUUID      `query:"assigneeID"`
+	Status     SDAssignmentStatus `query:"status"`
+	DueBefore  null.Time          `query:"dueBefore"`
+	Limit      int                `query:"limit"`
+	Offset     int                `query:"offset"`
+}
+
+// ToWhereQuery convert input to search query. If limit is unset / set over 100, will be set to 100.
+// If offset is unset / set under 0, will be set to 0.
+func (i *SearchSDAssignmentInput) ToWhereQuery() ([]interface{}, []interface{}) {
+	var whereQuery []interface{}
+	var conds []interface{}
+
+	if i.Limit <= 0 || i.Limit > 100 {
+		i.Limit = 100
+	}
+
+	if i.Offset < 0 {
+		i.Offset = 0
+	}
+
+	if i.AssigneeID.Valid {
+		whereQuery = append(whereQuery, "assignee_id = ?")
+		conds = append(conds, i.AssigneeID)
+	}
+
+	if i.Status != "" {
+		whereQuery = append(whereQuery, "status = ?")
+		conds = append(conds, i.Status)
+	}
+
+	if i.DueBefore.Valid {
+		whereQuery = append(whereQuery, "due_at < ?")
+		conds = append(conds, i.DueBefore.Time)
+	}
+
+	return whereQuery, conds
+}
+
+// SDAssignmentRepository repository for sd test assignment
+type SDAssignmentRepository interface {
+	Create(ctx context.Context, assignment *SDAssignment, tx *gorm.DB) error
+	FindByID(ctx context.Context, id uuid.UUID) (*SDAssignment, error)
+	Update(ctx context.Context, assignment *SDAssignment, tx *gorm.DB) error
+	Search(ctx context.Context, input *SearchSDAssignmentInput) ([]*SDAssignment, error)
+}
+
+// SDAssignmentUsecase usecase for sd test assignment
+type SDAssignmentUsecase interface {
+	Create(ctx context.Context, input *CreateSDAssignmentInput) (*GeneratedSDAssignment, *common.Error)
+	Search(ctx context.Context, input *SearchSDAssignmentInput) ([]*GeneratedSDAssignment, *common.Error)
+	Cancel(ctx context.Context, id uuid.UUID) (*GeneratedSDAssignment, *common.Error)
+}
@@ -0,0 +1,248 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"gopkg.in/yaml.v3"
+)
+
+// SDBundleFormat define the file format of the exported SDBundle
+type SDBundleFormat string
+
+// list of supported bundle formats
+const (
+	SDBundleFormatJSON SDBundleFormat = "json"
+	SDBundleFormatYAML SDBundleFormat = "yaml"
+)
+
+// SDBundleVersion is the version of the bundle structure written on every exported bundle
+const SDBundleVersion = 1
+
+// ParseSDBundleFormat will parse the format name. Empty format will be treated as SDBundleFormatJSON
+func ParseSDBundleFormat(format string) (SDBundleFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "json":
+		return SDBundleFormatJSON, nil
+	case "yaml", "yml":
+		return SDBundleFormatYAML, nil
+	default:
+		return "", fmt.Errorf("unsupported bundle format: %s", format)
+	}
+}
+
+// ContentType return the MIME type of the format
+func (f SDBundleFormat) ContentType() string {
+	if f == SDBundleFormatYAML {
+		return "application/yaml"
+	}
+
+	return "application/json"
+}
+
+// SDBundle is a portable bundle of the sd templates with their packages, used to keep the instruments outside
+// of the database, e.g on a git repository, and replay them on any environment
+type SDBundle struct {
+	Version    int                `json:"version"`
+	ExportedAt time.Time          `json:"exportedAt"`
+	Templates  []SDBundleTemplate `json:"templates" validate:"required,min=1"`
+}
+
+// SDBundleTemplate is a template on the bundle. The ID is the template id on the exporting environment,
+// and will be replaced by a new id when imported
+type SDBundleTemplate struct {
+	ID       uuid.UUID         `json:"id"`
+	IsActive bool              `json:"isActive"`
+	Template *SDTemplate       `json:"template"`
+	Packages []SDBundlePackage `json:"packages"`
+}
+
+// SDBundlePackage is a package on the bundle. The ID is the package id on the exporting environment,
+// and will be replaced by a new id when imported
+type SDBundlePackage struct {
+	ID       uuid.UUID  `json:"id"`
+	IsActive bool       `json:"isActive"`
+	Package  *SDPackage `json:"package"`
+}
+
+// NewSDBundle create the bundle of the templates, each with their own packages keyed by the template id
+func NewSDBundle(templates []*SpeechDelayTemplate, packages map[uuid.UUID][]*SpeechDelayPackage) *SDBundle {
+	bundle := &SDBundle{
+		Version:    SDBundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Templates:  []SDBundleTemplate{},
+	}
+
+	for _, t := range templates {
+		content := *t.Template
+		content.Type = t.Type.OrDefault()
+		bt := SDBundleTemplate{
+			ID:       t.ID,
+			IsActive: t.IsActive,
+			Template: &content,
+			Packages: []SDBundlePackage{},
+		}
+
+		for _, p := range packages[t.ID] {
+			bt.Packages = append(bt.Packages, SDBundlePackage{
+				ID:       p.ID,
+				IsActive: p.IsActive,
+				Package:  p.Package,
+			})
+		}
+
+		bundle.Templates = append(bundle.Templates, bt)
+	}
+
+	return bundle
+}
+
+// Encode will write the bundle in the format. YAML bundle use the same keys as the JSON bundle
+func (b *SDBundle) Encode(format SDBundleFormat) ([]byte, error) {
+	raw, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if format != SDBundleFormatYAML {
+		return raw, nil
+	}
+
+	var content interface{}
+	if err := json.Unmarshal(raw, &content); err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(content)
+}
+
+// DecodeSDBundle will read the bundle written in the format
+func DecodeSDBundle(raw []byte, format SDBundleFormat) (*SDBundle, error) {
+	if format == SDBundleFormatYAML {
+		var content interface{}
+		if err := yaml.Unmarshal(raw, &content); err != nil {
+			return nil, err
+		}
+
+		converted, err := json.Marshal(content)
+		if err != nil {
+			return nil, err
+		}
+
+		raw = converted
+	}
+
+	bundle := &SDBundle{}
+	if err := json.Unmarshal(raw, bundle); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// Instantiate will create the templates and packages of the bundle as new records owned by createdBy.
+// Every template and package get a new id, and the package's template id is remapped to the new template id.
+// The templates having any active package are locked, the same as when a package is activated.
+// Return the mapping from the ids on the bundle to the new ids
+func (b *SDBundle) Instantiate(createdBy uuid.UUID, now time.Time) ([]*SpeechDelayTemplate, []*SpeechDelayPackage, map[uuid.UUID]uuid.UUID) {
+	templates := []*SpeechDelayTemplate{}
+	packages := []*SpeechDelayPackage{}
+	mapping := make(map[uuid.UUID]uuid.UUID)
+
+	for _, bt := range b.Templates {
+		template := &SpeechDelayTemplate{
+			ID:        uuid.New(),
+			CreatedBy: createdBy,
+			IsActive:  bt.IsActive,
+			CreatedAt: now,
+			UpdatedAt: now,
+			Template:  bt.Template,
+
+			CurrentVersion: 1,
+		}
+
+		if bt.Template != nil {
+			template.Name = bt.Template.Name
+			template.Type = bt.Template.Type.OrDefault()
+			bt.Template.Type = template.Type
+		}
+
+		if bt.ID != uuid.Nil {
+			mapping[bt.ID] = template.ID
+		}
+
+		for _, bp := range bt.Packages {
+			pack := &SpeechDelayPackage{
+				ID:         uuid.New(),
+				TemplateID: template.ID,
+				Type:       template.Type,
+				CreatedBy:  createdBy,
+				Package:    bp.Package,
+				IsActive:   bp.IsActive,
+				CreatedAt:  now,
+				UpdatedAt:  now,
+
+				CurrentVersion:  1,
+				TemplateVersion: template.CurrentVersion,
+			}
+
+			if bp.Package != nil {
+				pack.Name = bp.Package.PackageName
+				bp.Package.TemplateID = template.ID
+			}
+
+			if bp.ID != uuid.Nil {
+				mapping[bp.ID] = pack.ID
+			}
+
+			template.IsLocked = template.IsLocked || bp.IsActive
+			packages = append(packages, pack)
+		}
+
+		templates = append(templates, template)
+	}
+
+	return templates, packages, mapping
+}
+
+// ExportSDBundleInput input to export the templates with their packages as a bundle
+type ExportSDBundleInput struct {
+	TemplateIDs []uuid.UUID `validate:"required,min=1,unique,dive,required"`
+}
+
+// Validate validate struct
+func (i *ExportSDBundleInput) Validate() error {
+	return validator.Struct(i)
+}
+
+// ImportSDBundleInput input to import the bundle. When DryRun is set, the bundle is only validated
+type ImportSDBundleInput struct {
+	Bundle *SDBundle `validate:"required"`
+	DryRun bool
+}
+
+// Validate validate struct
+func (i *ImportSDBundleInput) Validate() error {
+	return validator.Struct(i)
+}
+
+// ImportSDBundleOutput output of importing the bundle
+type ImportSDBundleOutput struct {
+	DryRun    bool                   `json:"dryRun"`
+	Templates []*GeneratedSDTemplate `json:"templates"`
+	Packages  []*GeneratedSDPackage  `json:"packages"`
+
+	// IDMapping map the template and package ids on the bundle to the new ids
+	IDMapping map[uuid.UUID]uuid.UUID `json:"idMapping"`
+}
+
+// SDBundleUsecase usecase to export and import the sd templates and packages bundle
+type SDBundleUsecase interface {
+	Export(ctx context.Context, input *ExportSDBundleInput) (*SDBundle, *common.Error)
+	Import(ctx context.Context, input *ImportSDBundleInput) (*ImportSDBundleOutput, *common.Error)
+}
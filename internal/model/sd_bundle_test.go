@@ -0,0 +1,124 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSDBundle_ParseSDBundleFormat(t *testing.T) {
+	format, err := ParseSDBundleFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, format, SDBundleFormatJSON)
+
+	format, err = ParseSDBundleFormat(" YML ")
+	assert.NoError(t, err)
+	assert.Equal(t, format, SDBundleFormatYAML)
+	assert.Equal(t, format.ContentType(), "application/yaml")
+
+	_, err = ParseSDBundleFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestSDBundle_EncodeDecode(t *testing.T) {
+	templateID := uuid.New()
+	packageID := uuid.New()
+	bundle := NewSDBundle([]*SpeechDelayTemplate{
+		{
+			ID:       templateID,
+			IsActive: true,
+			Template: &SDTemplate{
+				Name:                "template",
+				IndicationThreshold: 10,
+				SubGroupDetails: []SDTemplateSubGroupDetail{
+					{Name: "group", QuestionCount: 1, AnswerOptionCount: 2},
+				},
+			},
+		},
+	}, map[uuid.UUID][]*SpeechDelayPackage{
+		templateID: {
+			{
+				ID:         packageID,
+				TemplateID: templateID,
+				IsActive:   true,
+				Package: &SDPackage{
+					PackageName: "package",
+					TemplateID:  templateID,
+				},
+			},
+		},
+	})
+
+	for _, format := range []SDBundleFormat{SDBundleFormatJSON, SDBundleFormatYAML} {
+		raw, err := bundle.Encode(format)
+		assert.NoError(t, err)
+
+		decoded, err := DecodeSDBundle(raw, format)
+		assert.NoError(t, err)
+		assert.Equal(t, decoded.Version, SDBundleVersion)
+		assert.True(t, decoded.ExportedAt.Equal(bundle.ExportedAt))
+		assert.Equal(t, decoded.Templates[0].ID, templateID)
+		assert.Equal(t, decoded.Templates[0].Template.Type, TestTypeSpeechDelay)
+		assert.Equal(t, decoded.Templates[0].Template.SubGroupDetails, bundle.Templates[0].Template.SubGroupDetails)
+		assert.Equal(t, decoded.Templates[0].Packages[0].ID, packageID)
+		assert.Equal(t, decoded.Templates[0].Packages[0].Package.PackageName, "package")
+	}
+
+	_, err := DecodeSDBundle([]byte("templates: [\n"), SDBundleFormatYAML)
+	assert.Error(t, err)
+}
+
+func TestSDBundle_Instantiate(t *testing.T) {
+	createdBy := uuid.New()
+	now := time.Now().UTC()
+	oldTemplateID := uuid.New()
+	oldActivePackageID := uuid.New()
+	oldInactivePackageID := uuid.New()
+
+	bundle := &SDBundle{
+		Templates: []SDBundleTemplate{
+			{
+				ID:       oldTemplateID,
+				IsActive: true,
+				Template: &SDTemplate{Name: "locked"},
+				Packages: []SDBundlePackage{
+					{ID: oldActivePackageID, IsActive: true, Package: &SDPackage{PackageName: "active", TemplateID: oldTemplateID}},
+					{ID: oldInactivePackageID, Package: &SDPackage{PackageName: "inactive", TemplateID: oldTemplateID}},
+				},
+			},
+			{
+				Template: &SDTemplate{Name: "unlocked", Type: TestTypeSociability},
+			},
+		},
+	}
+
+	templates, packages, mapping := bundle.Instantiate(createdBy, now)
+	assert.Equal(t, len(templates), 2)
+	assert.Equal(t, len(packages), 2)
+	assert.Equal(t, len(mapping), 3)
+
+	assert.Equal(t, mapping[oldTemplateID], templates[0].ID)
+	assert.NotEqual(t, templates[0].ID, oldTemplateID)
+	assert.Equal(t, templates[0].Name, "locked")
+	assert.Equal(t, templates[0].Type, TestTypeSpeechDelay)
+	assert.Equal(t, templates[0].CreatedBy, createdBy)
+	assert.Equal(t, templates[0].CurrentVersion, 1)
+	assert.True(t, templates[0].IsLocked)
+
+	assert.Equal(t, templates[1].Type, TestTypeSociability)
+	assert.False(t, templates[1].IsLocked)
+
+	assert.Equal(t, mapping[oldActivePackageID], packages[0].ID)
+	assert.Equal(t, mapping[oldInactivePackageID], packages[1].ID)
+	for _, p := range packages {
+		assert.Equal(t, p.TemplateID, templates[0].ID)
+		assert.Equal(t, p.Package.TemplateID, templates[0].ID)
+		assert.Equal(t, p.CreatedAt, now)
+		assert.Equal(t, p.TemplateVersion, 1)
+	}
+	assert.Equal(t, packages[0].Name, "active")
+	assert.True(t, packages[0].IsActive)
+	assert.False(t, packages[1].IsActive)
+}
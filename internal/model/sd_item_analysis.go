@@ -0,0 +1,353 @@
+package model
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/guregu/null.v4"
+)
+
+// DefaultSDItemDominanceThreshold is the ratio of the answers taken by a single option
+// above which the question is flagged as not discriminating
+const DefaultSDItemDominanceThreshold = 0.9
+
+// SDItemAnalysisInput input to get the item analysis report of a package
+type SDItemAnalysisInput struct {
+	ID uuid.UUID `param:"id"`
+
+	// From and To are optional, only the tests finished within the range are analysed
+	From time.Time `query:"from"`
+	To   time.Time `query:"to"`
+
+	// DominanceThreshold is optional, default to DefaultSDItemDominanceThreshold
+	DominanceThreshold float64 `query:"dominanceThreshold"`
+}
+
+// Validate validate the input and set the default dominance threshold when not set
+func (i *SDItemAnalysisInput) Validate() error {
+	if !i.From.IsZero() && !i.To.IsZero() && i.To.Before(i.From) {
+		return errors.New("to must not be before from")
+	}
+
+	if i.DominanceThreshold == 0 {
+		i.DominanceThreshold = DefaultSDItemDominanceThreshold
+	}
+
+	if i.DominanceThreshold < 0 || i.DominanceThreshold > 1 {
+		return errors.New("dominance threshold must be between 0 and 1")
+	}
+
+	return nil
+}
+
+// SDItemOptionStat the number of answers choosing the option
+type SDItemOptionStat struct {
+	AnswerID uuid.UUID `json:"answerID"`
+	Text     string    `json:"text"`
+	Value    int       `json:"value"`
+	Count    int       `json:"count"`
+	Ratio    float64   `json:"ratio"`
+}
+
+// SDItemStat the analysis of a question. ItemTotalCorrelation is the correlation between the question point
+// and the point of the rest of the sub group questions, null when it can't be computed
+type SDItemStat struct {
+	QuestionID           uuid.UUID          `json:"questionID"`
+	Question             string             `json:"question"`
+	Responses            int                `json:"responses"`
+	Options              []SDItemOptionStat `json:"options"`
+	ItemTotalCorrelation null.Float         `json:"itemTotalCorrelation"`
+	DominantOptionRatio  float64            `json:"dominantOptionRatio"`
+	Flagged              bool               `json:"flagged"`
+}
+
+// SDSubGroupItemAnalysis the analysis of a sub group. Only the tests answering all the questions of the sub group
+// are counted as CompleteResponses and used to compute the correlations and CronbachAlpha
+type SDSubGroupItemAnalysis struct {
+	GroupID           uuid.UUID    `json:"groupID"`
+	GroupName         string       `json:"groupName"`
+	CompleteResponses int          `json:"completeResponses"`
+	CronbachAlpha     null.Float   `json:"cronbachAlpha"`
+	Items             []SDItemStat `json:"items"`
+}
+
+// SDItemAnalysisReport the item analysis report of a package
+type SDItemAnalysisReport struct {
+	PackageID          uuid.UUID                `json:"packageID"`
+	PackageName        string                   `json:"packageName"`
+	TemplateID         uuid.UUID                `json:"templateID"`
+	From               time.Time                `json:"from,omitempty"`
+	To                 time.Time                `json:"to,omitempty"`
+	DominanceThreshold float64                  `json:"dominanceThreshold"`
+	FinishedTests      int                      `json:"finishedTests"`
+	SubGroups          []SDSubGroupItemAnalysis `json:"subGroups"`
+}
+
+// NewSDItemAnalysisReport analyse the answers of the finished tests against the package questions.
+// The answers are canonicalized first, so the answers stored before the ids exist are matched using the text.
+// The answers are then matched by the question and answer ids, thus answers to questions no longer on the package are ignored
+func NewSDItemAnalysisReport(pack *SpeechDelayPackage, answers []SDTestAnswer, dominanceThreshold float64) *SDItemAnalysisReport {
+	pack.Package.EnsureIDs()
+	for i := range answers {
+		answers[i].Canonicalize(pack.Package)
+	}
+
+	report := &SDItemAnalysisReport{
+		PackageID:          pack.ID,
+		PackageName:        pack.Name,
+		TemplateID:         pack.TemplateID,
+		DominanceThreshold: dominanceThreshold,
+		FinishedTests:      len(answers),
+		SubGroups:          []SDSubGroupItemAnalysis{},
+	}
+
+	for _, g := range pack.Package.SubGroupDetails {
+		report.SubGroups = append(report.SubGroups, analyseSubGroup(g, answers, dominanceThreshold))
+	}
+
+	return report
+}
+
+// analyseSubGroup compute the option distribution of every question, and the correlations and
+// the Cronbach's alpha from the points of the tests answering all the questions of the sub group
+func analyseSubGroup(g SDSubGroupDetail, answers []SDTestAnswer, dominanceThreshold float64) SDSubGroupItemAnalysis {
+	counts := make([]map[uuid.UUID]int, len(g.QuestionAndAnswerLists))
+	for i := range counts {
+		counts[i] = make(map[uuid.UUID]int)
+	}
+
+	// points[n][i] is the point of the i-th question on the n-th complete response
+	points := [][]float64{}
+	for _, a := range answers {
+		row, complete := make([]float64, len(g.QuestionAndAnswerLists)), true
+		for i, qna := range g.QuestionAndAnswerLists {
+			opt, ok := findChosenOption(a, g.ID, qna)
+			if !ok {
+				complete = false
+				continue
+			}
+
+			counts[i][opt.ID]++
+			row[i] = float64(qna.Point(opt.Value))
+		}
+
+		if complete {
+			points = append(points, row)
+		}
+	}
+
+	result := SDSubGroupItemAnalysis{
+		GroupID:           g.ID,
+		GroupName:         g.Name,
+		CompleteResponses: len(points),
+		CronbachAlpha:     cronbachAlpha(points),
+		Items:             []SDItemStat{},
+	}
+
+	for i, qna := range g.QuestionAndAnswerLists {
+		item := SDItemStat{
+			QuestionID:           qna.ID,
+			Question:             qna.Question,
+			Options:              []SDItemOptionStat{},
+			ItemTotalCorrelation: itemRestCorrelation(points, i),
+		}
+
+		for _, c := range counts[i] {
+			item.Responses += c
+		}
+
+		for _, o := range qna.AnswersAndValue {
+			stat := SDItemOptionStat{AnswerID: o.ID, Text: o.Text, Value: o.Value, Count: counts[i][o.ID]}
+			if item.Responses > 0 {
+				stat.Ratio = float64(stat.Count) / float64(item.Responses)
+			}
+
+			item.DominantOptionRatio = math.Max(item.DominantOptionRatio, stat.Ratio)
+			item.Options = append(item.Options, stat)
+		}
+
+		item.Flagged = item.Responses > 0 && item.DominantOptionRatio >= dominanceThreshold
+		result.Items = append(result.Items, item)
+	}
+
+	return result
+}
+
+// findChosenOption return the option chosen for the question on the group, if any
+func findChosenOption(a SDTestAnswer, groupID uuid.UUID, qna SDQuestionAndAnswers) (SDAnswerAndValue, bool) {
+	for _, ta := range a.TestAnswers {
+		if ta == nil || ta.GroupID != groupID {
+			continue
+		}
+
+		for _, ans := range ta.Answers {
+			if ans.QuestionID != qna.ID {
+				continue
+			}
+
+			for _, o := range qna.AnswersAndValue {
+				if o.ID == ans.AnswerID {
+					return o, true
+				}
+			}
+		}
+	}
+
+	return SDAnswerAndValue{}, false
+}
+
+// cronbachAlpha compute k/(k-1) * (1 - sum of the item variances / variance of the total).
+// Null when there are less than 2 questions or 2 responses, or the total has no variance
+func cronbachAlpha(points [][]float64) null.Float {
+	if len(points) < 2 || len(points[0]) < 2 {
+		return null.Float{}
+	}
+
+	k := len(points[0])
+	totals := make([]float64, len(points))
+	itemVariances := 0.0
+	for i := 0; i < k; i++ {
+		itemVariances += variance(column(points, i))
+	}
+
+	for n, row := range points {
+		for _, p := range row {
+			totals[n] += p
+		}
+	}
+
+	totalVariance := variance(totals)
+	if totalVariance == 0 {
+		return null.Float{}
+	}
+
+	return null.FloatFrom(float64(k) / float64(k-1) * (1 - itemVariances/totalVariance))
+}
+
+// itemRestCorrelation compute the pearson correlation between the i-th question point and the sum of the
+// other questions point. Null when there are less than 2 responses or either side has no variance
+func itemRestCorrelation(points [][]float64, i int) null.Float {
+	if len(points) < 2 || len(points[0]) < 2 {
+		return null.Float{}
+	}
+
+	item := column(points, i)
+	rest := make([]float64, len(points))
+	for n, row := range points {
+		for j, p := range row {
+			if j != i {
+				rest[n] += p
+			}
+		}
+	}
+
+	itemMean, restMean := mean(item), mean(rest)
+	cov, itemSq, restSq := 0.0, 0.0, 0.0
+	for n := range points {
+		di, dr := item[n]-itemMean, rest[n]-restMean
+		cov += di * dr
+		itemSq += di * di
+		restSq += dr * dr
+	}
+
+	if itemSq == 0 || restSq == 0 {
+		return null.Float{}
+	}
+
+	return null.FloatFrom(cov / math.Sqrt(itemSq*restSq))
+}
+
+func column(points [][]float64, i int) []float64 {
+	col := make([]float64, len(points))
+	for n, row := range points {
+		col[n] = row[i]
+	}
+
+	return col
+}
+
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
+
+// variance compute the sample variance
+func variance(values []float64) float64 {
+	m, sum := mean(values), 0.0
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+
+	return sum / float64(len(values)-1)
+}
+
+// sdItemAnalysisCSVHeader the header of the item analysis report csv, one row per question
+var sdItemAnalysisCSVHeader = []string{
+	"sub_group",
+	"sub_group_complete_responses",
+	"sub_group_cronbach_alpha",
+	"question_id",
+	"question",
+	"responses",
+	"option_distribution",
+	"item_total_correlation",
+	"dominant_option_ratio",
+	"flagged",
+}
+
+// WriteCSV write the report as csv, one row per question. The option distribution is written as
+// "text (value): count" separated by " | "
+func (r *SDItemAnalysisReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(sdItemAnalysisCSVHeader); err != nil {
+		return err
+	}
+
+	for _, g := range r.SubGroups {
+		for _, item := range g.Items {
+			options := []string{}
+			for _, o := range item.Options {
+				options = append(options, fmt.Sprintf("%s (%d): %d", o.Text, o.Value, o.Count))
+			}
+
+			err := writer.Write([]string{
+				g.GroupName,
+				strconv.Itoa(g.CompleteResponses),
+				formatNullFloat(g.CronbachAlpha),
+				item.QuestionID.String(),
+				item.Question,
+				strconv.Itoa(item.Responses),
+				strings.Join(options, " | "),
+				formatNullFloat(item.ItemTotalCorrelation),
+				strconv.FormatFloat(item.DominantOptionRatio, 'f', 4, 64),
+				strconv.FormatBool(item.Flagged),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatNullFloat format the float with 4 decimals, or empty string when null
+func formatNullFloat(f null.Float) string {
+	if !f.Valid {
+		return ""
+	}
+
+	return strconv.FormatFloat(f.Float64, 'f', 4, 64)
+}
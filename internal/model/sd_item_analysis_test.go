@@ -0,0 +1,147 @@
+package model
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSDItemAnalysisInput_Validate(t *testing.T) {
+	now := time.Now().UTC()
+
+	input := &SDItemAnalysisInput{}
+	assert.NoError(t, input.Validate())
+	assert.Equal(t, input.DominanceThreshold, DefaultSDItemDominanceThreshold)
+
+	assert.NoError(t, (&SDItemAnalysisInput{DominanceThreshold: 0.8}).Validate())
+	assert.Error(t, (&SDItemAnalysisInput{DominanceThreshold: 1.5}).Validate())
+	assert.Error(t, (&SDItemAnalysisInput{DominanceThreshold: -0.1}).Validate())
+	assert.Error(t, (&SDItemAnalysisInput{From: now, To: now.Add(-time.Hour)}).Validate())
+}
+
+func TestNewSDItemAnalysisReport(t *testing.T) {
+	groupID := uuid.New()
+	optA, optB := uuid.New(), uuid.New()
+	question := func(text string) SDQuestionAndAnswers {
+		return SDQuestionAndAnswers{
+			ID:       uuid.New(),
+			Question: text,
+			AnswersAndValue: []SDAnswerAndValue{
+				{ID: optA, Text: "a", Value: 1},
+				{ID: optB, Text: "b", Value: 2},
+			},
+		}
+	}
+	q1, q2, q3 := question("q1"), question("q2"), question("q3")
+	pack := &SpeechDelayPackage{
+		ID:         uuid.New(),
+		TemplateID: uuid.New(),
+		Name:       "package",
+		Package: &SDPackage{
+			SubGroupDetails: []SDSubGroupDetail{
+				{ID: groupID, Name: "group", QuestionAndAnswerLists: []SDQuestionAndAnswers{q1, q2, q3}},
+			},
+		},
+	}
+
+	answer := func(chosen map[uuid.UUID]uuid.UUID) SDTestAnswer {
+		ta := &TestAnswer{GroupID: groupID, GroupName: "group"}
+		for _, q := range []SDQuestionAndAnswers{q1, q2, q3} {
+			if opt, ok := chosen[q.ID]; ok {
+				ta.Answers = append(ta.Answers, Answer{QuestionID: q.ID, AnswerID: opt})
+			}
+		}
+
+		return SDTestAnswer{TestAnswers: []*TestAnswer{ta}}
+	}
+
+	t.Run("no finished tests", func(t *testing.T) {
+		report := NewSDItemAnalysisReport(pack, nil, DefaultSDItemDominanceThreshold)
+		assert.Equal(t, report.FinishedTests, 0)
+		assert.Len(t, report.SubGroups, 1)
+		assert.Equal(t, report.SubGroups[0].CompleteResponses, 0)
+		assert.False(t, report.SubGroups[0].CronbachAlpha.Valid)
+		for _, item := range report.SubGroups[0].Items {
+			assert.Equal(t, item.Responses, 0)
+			assert.False(t, item.Flagged)
+			assert.False(t, item.ItemTotalCorrelation.Valid)
+		}
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		report := NewSDItemAnalysisReport(pack, []SDTestAnswer{
+			answer(map[uuid.UUID]uuid.UUID{q1.ID: optA, q2.ID: optA, q3.ID: optA}),
+			answer(map[uuid.UUID]uuid.UUID{q1.ID: optB, q2.ID: optB, q3.ID: optA}),
+			answer(map[uuid.UUID]uuid.UUID{q1.ID: optB, q2.ID: optA, q3.ID: optA}),
+			// incomplete, only counted on the option distribution
+			answer(map[uuid.UUID]uuid.UUID{q1.ID: optA}),
+		}, DefaultSDItemDominanceThreshold)
+
+		assert.Equal(t, report.PackageID, pack.ID)
+		assert.Equal(t, report.TemplateID, pack.TemplateID)
+		assert.Equal(t, report.FinishedTests, 4)
+
+		group := report.SubGroups[0]
+		assert.Equal(t, group.GroupID, groupID)
+		assert.Equal(t, group.CompleteResponses, 3)
+		assert.True(t, group.CronbachAlpha.Valid)
+		assert.InDelta(t, group.CronbachAlpha.Float64, 0.5, 1e-9)
+
+		item1 := group.Items[0]
+		assert.Equal(t, item1.Responses, 4)
+		assert.Equal(t, item1.Options, []SDItemOptionStat{
+			{AnswerID: optA, Text: "a", Value: 1, Count: 2, Ratio: 0.5},
+			{AnswerID: optB, Text: "b", Value: 2, Count: 2, Ratio: 0.5},
+		})
+		assert.True(t, item1.ItemTotalCorrelation.Valid)
+		assert.InDelta(t, item1.ItemTotalCorrelation.Float64, 0.5, 1e-9)
+		assert.Equal(t, item1.DominantOptionRatio, 0.5)
+		assert.False(t, item1.Flagged)
+
+		item3 := group.Items[2]
+		assert.Equal(t, item3.Responses, 3)
+		assert.Equal(t, item3.DominantOptionRatio, float64(1))
+		assert.True(t, item3.Flagged)
+		assert.False(t, item3.ItemTotalCorrelation.Valid)
+
+		buf := &bytes.Buffer{}
+		assert.NoError(t, report.WriteCSV(buf))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Len(t, lines, 4)
+		assert.Equal(t, lines[0], strings.Join(sdItemAnalysisCSVHeader, ","))
+		assert.Equal(t, lines[1], "group,3,0.5000,"+q1.ID.String()+",q1,4,a (1): 2 | b (2): 2,0.5000,0.5000,false")
+		assert.Equal(t, lines[3], "group,3,0.5000,"+q3.ID.String()+",q3,3,a (1): 3 | b (2): 0,,1.0000,true")
+	})
+
+	t.Run("ok with legacy answers having only the text", func(t *testing.T) {
+		legacy := func(chosen map[string]string) SDTestAnswer {
+			ta := &TestAnswer{GroupName: "group"}
+			for _, q := range []string{"q1", "q2", "q3"} {
+				ta.Answers = append(ta.Answers, Answer{Question: q, Answer: chosen[q]})
+			}
+
+			return SDTestAnswer{TestAnswers: []*TestAnswer{ta}}
+		}
+
+		report := NewSDItemAnalysisReport(pack, []SDTestAnswer{
+			legacy(map[string]string{"q1": "a", "q2": "a", "q3": "a"}),
+			legacy(map[string]string{"q1": "b", "q2": "b", "q3": "a"}),
+			answer(map[uuid.UUID]uuid.UUID{q1.ID: optB, q2.ID: optA, q3.ID: optA}),
+		}, DefaultSDItemDominanceThreshold)
+
+		assert.Equal(t, report.FinishedTests, 3)
+
+		group := report.SubGroups[0]
+		assert.Equal(t, group.CompleteResponses, 3)
+		assert.True(t, group.CronbachAlpha.Valid)
+		assert.InDelta(t, group.CronbachAlpha.Float64, 0.5, 1e-9)
+		assert.Equal(t, group.Items[0].Responses, 3)
+		assert.Equal(t, group.Items[0].Options[0].Count, 1)
+		assert.Equal(t, group.Items[0].Options[1].Count, 2)
+	})
+}
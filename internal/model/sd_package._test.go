@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -1637,6 +1638,208 @@ func TestModel_SDPackage_FullValidation(t *testing.T) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			Name: "scoring rule on package is not match with the template",
+			Run: func() {
+				in := &SDPackage{
+					PackageName: "ok",
+					TemplateID:  uuid.New(),
+					SubGroupDetails: []SDSubGroupDetail{
+						{
+							Name: "kepribadian diri",
+							QuestionAndAnswerLists: []SDQuestionAndAnswers{
+								{
+									Question: "valid question?",
+									AnswersAndValue: []SDAnswerAndValue{
+										{
+											Text:  "pilihan pertama",
+											Value: 1,
+										},
+										{
+											Text:  "pilihan kedua",
+											Value: 2,
+										},
+									},
+									ScoringRule: &SDScoringRule{
+										Weight: 2,
+									},
+								},
+							},
+						},
+					},
+				}
+				err := in.FullValidation(&SpeechDelayTemplate{
+					IsActive: true,
+					Template: &SDTemplate{
+						Name:                   "ok",
+						IndicationThreshold:    1,
+						PositiveIndiationText:  "ok",
+						NegativeIndicationText: "ok jg",
+						SubGroupDetails: []SDTemplateSubGroupDetail{
+							{
+								Name:              "kepribadian diri",
+								QuestionCount:     1,
+								AnswerOptionCount: 2,
+								ScoringRules: []SDScoringRule{
+									{
+										Reverse: true,
+									},
+								},
+							},
+						},
+					},
+				})
+				assert.Error(t, err)
+				assert.Equal(t, err.Error(), "the scoring rule on the package is not match with the template, group name: kepribadian diri question: valid question?")
+			},
+		},
+		{
+			Name: "value is not defined on the custom value map",
+			Run: func() {
+				in := &SDPackage{
+					PackageName: "ok",
+					TemplateID:  uuid.New(),
+					SubGroupDetails: []SDSubGroupDetail{
+						{
+							Name: "kepribadian diri",
+							QuestionAndAnswerLists: []SDQuestionAndAnswers{
+								{
+									Question: "valid question?",
+									AnswersAndValue: []SDAnswerAndValue{
+										{
+											Text:  "pilihan pertama",
+											Value: 10,
+										},
+										{
+											Text:  "pilihan kedua",
+											Value: 30,
+										},
+									},
+									ScoringRule: &SDScoringRule{
+										ValueMap: map[int]int{10: 0, 20: 3},
+									},
+								},
+							},
+						},
+					},
+				}
+				err := in.FullValidation(&SpeechDelayTemplate{
+					IsActive: true,
+					Template: &SDTemplate{
+						Name:                   "ok",
+						IndicationThreshold:    1,
+						PositiveIndiationText:  "ok",
+						NegativeIndicationText: "ok jg",
+						SubGroupDetails: []SDTemplateSubGroupDetail{
+							{
+								Name:              "kepribadian diri",
+								QuestionCount:     1,
+								AnswerOptionCount: 2,
+								ScoringRules: []SDScoringRule{
+									{
+										ValueMap: map[int]int{10: 0, 20: 3},
+									},
+								},
+							},
+						},
+					},
+				})
+				assert.Error(t, err)
+				assert.Equal(t, err.Error(), "value 30 on group kepribadian diri question valid question? is not defined on the scoring rule value map")
+			},
+		},
+		{
+			Name: "ok: using scoring rules, value map allow non ordered values",
+			Run: func() {
+				in := &SDPackage{
+					PackageName: "ok",
+					TemplateID:  uuid.New(),
+					SubGroupDetails: []SDSubGroupDetail{
+						{
+							Name: "kepribadian diri",
+							QuestionAndAnswerLists: []SDQuestionAndAnswers{
+								{
+									Question: "valid question?",
+									AnswersAndValue: []SDAnswerAndValue{
+										{
+											Text:  "pilihan pertama",
+											Value: 10,
+										},
+										{
+											Text:  "pilihan kedua",
+											Value: 20,
+										},
+									},
+									ScoringRule: &SDScoringRule{
+										ValueMap: map[int]int{10: 0, 20: 3},
+									},
+								},
+								{
+									Question: "valid question 2?",
+									AnswersAndValue: []SDAnswerAndValue{
+										{
+											Text:  "pilihan pertama",
+											Value: 1,
+										},
+										{
+											Text:  "pilihan kedua",
+											Value: 2,
+										},
+									},
+									ScoringRule: &SDScoringRule{
+										Reverse: true,
+										Weight:  2,
+									},
+								},
+								{
+									Question: "valid question 3?",
+									AnswersAndValue: []SDAnswerAndValue{
+										{
+											Text:  "pilihan pertama",
+											Value: 1,
+										},
+										{
+											Text:  "pilihan kedua",
+											Value: 2,
+										},
+									},
+									ScoringRule: &SDScoringRule{
+										Weight: 1,
+									},
+								},
+							},
+						},
+					},
+				}
+				err := in.FullValidation(&SpeechDelayTemplate{
+					IsActive: true,
+					Template: &SDTemplate{
+						Name:                   "ok",
+						IndicationThreshold:    1,
+						PositiveIndiationText:  "ok",
+						NegativeIndicationText: "ok jg",
+						SubGroupDetails: []SDTemplateSubGroupDetail{
+							{
+								Name:              "kepribadian diri",
+								QuestionCount:     3,
+								AnswerOptionCount: 2,
+								ScoringRules: []SDScoringRule{
+									{
+										ValueMap: map[int]int{10: 0, 20: 3},
+									},
+									{
+										Reverse: true,
+										Weight:  2,
+									},
+									{},
+								},
+							},
+						},
+					},
+				})
+				assert.NoError(t, err)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1645,3 +1848,11 @@ func TestModel_SDPackage_FullValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestCloneSDPackageInput_Validate(t *testing.T) {
+	assert.NoError(t, (&CloneSDPackageInput{}).Validate())
+	assert.NoError(t, (&CloneSDPackageInput{PackageName: "cloned"}).Validate())
+	assert.NoError(t, (&CloneSDPackageInput{PackageName: strings.Repeat("a", 255)}).Validate())
+	assert.Error(t, (&CloneSDPackageInput{PackageName: "   "}).Validate())
+	assert.Error(t, (&CloneSDPackageInput{PackageName: strings.Repeat("a", 256)}).Validate())
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,22 +18,39 @@ import (
 
 // SDAnswerAndValue sd answer and value
 type SDAnswerAndValue struct {
-	Text  string `json:"text" validate:"required"`
-	Value int    `json:"value" validate:"required,min=1"`
+	ID    uuid.UUID `json:"id"`
+	Text  string    `json:"text" validate:"required"`
+	Value int       `json:"value" validate:"required,min=1"`
+
+	// TextTranslations is optional, hold the translated Text keyed by the locale
+	TextTranslations Translations `json:"textTranslations,omitempty" validate:"omitempty,dive,keys,required,endkeys,required"`
 }
 
 // SDQuestionAndAnswers sd question and answer
 type SDQuestionAndAnswers struct {
+	ID              uuid.UUID          `json:"id"`
 	Question        string             `json:"question" validate:"required"`
-	AnswersAndValue []SDAnswerAndValue `json:"answerAndValue" validate:"required,min=1,unique=Value,dive"`
+	AnswersAndValue []SDAnswerAndValue `json:"answerAndValue" validate:"required,min=1,unique=Value,unique=ID,dive"`
+	ScoringRule     *SDScoringRule     `json:"scoringRule,omitempty"`
+
+	// QuestionTranslations is optional, hold the translated Question keyed by the locale
+	QuestionTranslations Translations `json:"questionTranslations,omitempty" validate:"omitempty,dive,keys,required,endkeys,required"`
+}
+
+// Point will convert the chosen answer value to the point using the question's scoring rule
+func (sdq SDQuestionAndAnswers) Point(value int) int {
+	return sdq.ScoringRule.Point(value, len(sdq.AnswersAndValue))
 }
 
-// QuestionAndAnswers will return the SDTestQuestion
-func (sdq SDQuestionAndAnswers) QuestionAndAnswers() SDTestQuestion {
-	result := SDTestQuestion{}
-	result.Question = sdq.Question
+// QuestionAndAnswers will return the SDTestQuestion written in the locale.
+// Untranslated question or answer will be written in the original language
+func (sdq SDQuestionAndAnswers) QuestionAndAnswers(locale Locale) SDTestQuestion {
+	result := SDTestQuestion{ID: sdq.ID}
+	result.Question = sdq.QuestionTranslations.Translate(locale, sdq.Question)
 	for _, a := range sdq.AnswersAndValue {
-		result.Answers = append(result.Answers, a.Text)
+		text := a.TextTranslations.Translate(locale, a.Text)
+		result.Answers = append(result.Answers, text)
+		result.AnswerOptions = append(result.AnswerOptions, SDTestAnswerOption{ID: a.ID, Text: text})
 	}
 
 	return result
@@ -39,30 +58,108 @@ func (sdq SDQuestionAndAnswers) QuestionAndAnswers() SDTestQuestion {
 
 // SDSubGroupDetail sd sub group detail
 type SDSubGroupDetail struct {
+	ID                     uuid.UUID              `json:"id"`
 	Name                   string                 `json:"name" validate:"required"`
-	QuestionAndAnswerLists []SDQuestionAndAnswers `json:"questionAndAnswerLists" validate:"required,min=1,dive"`
+	QuestionAndAnswerLists []SDQuestionAndAnswers `json:"questionAndAnswerLists" validate:"required,min=1,unique=ID,dive"`
+
+	// NameTranslations is optional, hold the translated Name keyed by the locale
+	NameTranslations Translations `json:"nameTranslations,omitempty" validate:"omitempty,dive,keys,required,endkeys,required"`
 }
 
 // SDPackage sd package
 type SDPackage struct {
 	PackageName     string             `json:"packageName" validate:"required"`
 	TemplateID      uuid.UUID          `json:"templateID" validate:"required"`
-	SubGroupDetails []SDSubGroupDetail `json:"subGroupDetails" validate:"required,min=1,unique=Name,dive"`
+	SubGroupDetails []SDSubGroupDetail `json:"subGroupDetails" validate:"required,min=1,unique=Name,unique=ID,dive"`
+
+	// SelectionWeight is optional, used by the weighted_random package selection. Zero weight is treated as 1
+	SelectionWeight int `json:"selectionWeight,omitempty" validate:"omitempty,min=0"`
+}
+
+// sdPackageIDNamespace is the namespace used to derive the sub group ids from the sub group name
+var sdPackageIDNamespace = uuid.MustParse("5b1c6f0e-8d0a-4c47-9a55-2f3f1d8c7e21")
+
+// EnsureIDs will generate the ids of the sub groups, questions and answers which still don't have one.
+// The generated id is derived from the parent id and the text, thus packages stored before the ids exist
+// will always get the same ids each time they are read.
+func (sdp *SDPackage) EnsureIDs() {
+	groupIDs := make(map[uuid.UUID]bool)
+	for _, g := range sdp.SubGroupDetails {
+		groupIDs[g.ID] = true
+	}
+
+	for i := range sdp.SubGroupDetails {
+		g := &sdp.SubGroupDetails[i]
+		if g.ID == uuid.Nil {
+			g.ID = deriveID(sdPackageIDNamespace, g.Name, groupIDs)
+		}
+
+		questionIDs := make(map[uuid.UUID]bool)
+		for _, q := range g.QuestionAndAnswerLists {
+			questionIDs[q.ID] = true
+		}
+
+		for j := range g.QuestionAndAnswerLists {
+			q := &g.QuestionAndAnswerLists[j]
+			if q.ID == uuid.Nil {
+				q.ID = deriveID(g.ID, q.Question, questionIDs)
+			}
+
+			answerIDs := make(map[uuid.UUID]bool)
+			for _, a := range q.AnswersAndValue {
+				answerIDs[a.ID] = true
+			}
+
+			for k := range q.AnswersAndValue {
+				a := &q.AnswersAndValue[k]
+				if a.ID == uuid.Nil {
+					a.ID = deriveID(q.ID, a.Text, answerIDs)
+				}
+			}
+		}
+	}
+}
+
+// deriveID will derive the id from the parent id and the text. When the same text is used more than once
+// under the same parent, the occurrence number is added so the derived ids will not collide
+func deriveID(parent uuid.UUID, text string, used map[uuid.UUID]bool) uuid.UUID {
+	id := uuid.NewSHA1(parent, []byte(text))
+	for n := 1; used[id]; n++ {
+		id = uuid.NewSHA1(parent, []byte(fmt.Sprintf("%s#%d", text, n)))
+	}
+
+	used[id] = true
+
+	return id
+}
+
+// SDTestAnswerOption answer option rendered on the test
+type SDTestAnswerOption struct {
+	ID   uuid.UUID `json:"id"`
+	Text string    `json:"text"`
 }
 
-// SDTestQuestion test question and the answer options
+// SDTestQuestion test question and the answer options. Answers is kept for the clients which still
+// submit the answer by the text, while AnswerOptions carry the ids to be submitted instead
 type SDTestQuestion struct {
-	Question string   `json:"question"`
-	Answers  []string `json:"answers"`
+	ID            uuid.UUID            `json:"id"`
+	GroupID       uuid.UUID            `json:"groupID"`
+	Question      string               `json:"question"`
+	Answers       []string             `json:"answers"`
+	AnswerOptions []SDTestAnswerOption `json:"answerOptions"`
 }
 
-// RenderTestQuestions will return the sd test question per group
-func (sdp *SDPackage) RenderTestQuestions() map[string][]SDTestQuestion {
+// RenderTestQuestions will return the sd test question per group, written in the locale.
+// Untranslated content will be written in the original language
+func (sdp *SDPackage) RenderTestQuestions(locale Locale) map[string][]SDTestQuestion {
 	result := make(map[string][]SDTestQuestion)
 
 	for _, s := range sdp.SubGroupDetails {
+		name := s.NameTranslations.Translate(locale, s.Name)
 		for _, q := range s.QuestionAndAnswerLists {
-			result[s.Name] = append(result[s.Name], q.QuestionAndAnswers())
+			question := q.QuestionAndAnswers(locale)
+			question.GroupID = s.ID
+			result[name] = append(result[name], question)
 		}
 	}
 
@@ -70,8 +167,125 @@ func (sdp *SDPackage) RenderTestQuestions() map[string][]SDTestQuestion {
 
 }
 
-// PartialValidation will validate the SD Package. enough to be used for first time creating / just updating the SD Template
+// SDTestGroupQuestions the sd test questions of a group, rendered in order
+type SDTestGroupQuestions struct {
+	GroupID   uuid.UUID        `json:"groupID"`
+	GroupName string           `json:"groupName"`
+	Questions []SDTestQuestion `json:"questions"`
+}
+
+// GenerateTestOrder will generate the order of the groups, questions and answer options to be rendered on a new test.
+// The parts enabled on the randomization are shuffled using the shuffle func, e.g rand.Shuffle,
+// while the rest keep the package order. Nil randomization will keep the package order
+func (sdp *SDPackage) GenerateTestOrder(r *SDRandomization, shuffle func(n int, swap func(i, j int))) SDTestOrder {
+	if r == nil {
+		r = &SDRandomization{}
+	}
+
+	order := SDTestOrder{}
+	for _, g := range sdp.SubGroupDetails {
+		group := SDTestGroupOrder{GroupID: g.ID}
+		for _, q := range g.QuestionAndAnswerLists {
+			question := SDTestQuestionOrder{QuestionID: q.ID}
+			for _, a := range q.AnswersAndValue {
+				question.AnswerIDs = append(question.AnswerIDs, a.ID)
+			}
+
+			if r.ShuffleAnswers {
+				shuffle(len(question.AnswerIDs), func(i, j int) {
+					question.AnswerIDs[i], question.AnswerIDs[j] = question.AnswerIDs[j], question.AnswerIDs[i]
+				})
+			}
+
+			group.Questions = append(group.Questions, question)
+		}
+
+		if r.ShuffleQuestions {
+			shuffle(len(group.Questions), func(i, j int) {
+				group.Questions[i], group.Questions[j] = group.Questions[j], group.Questions[i]
+			})
+		}
+
+		order.Groups = append(order.Groups, group)
+	}
+
+	if r.ShuffleGroups {
+		shuffle(len(order.Groups), func(i, j int) {
+			order.Groups[i], order.Groups[j] = order.Groups[j], order.Groups[i]
+		})
+	}
+
+	return order
+}
+
+// RenderOrderedTestQuestions will return the sd test questions per group written in the locale, following the order.
+// Groups, questions and answer options missing from the order, e.g on tests created before the order is stored,
+// are rendered after the ordered ones following the package order
+func (sdp *SDPackage) RenderOrderedTestQuestions(locale Locale, order SDTestOrder) []SDTestGroupQuestions {
+	result := []SDTestGroupQuestions{}
+
+	groups := sdp.SubGroupDetails
+	for _, gi := range sortByOrder(len(groups), func(i int) uuid.UUID { return groups[i].ID }, order.groupIDs()) {
+		g := groups[gi]
+		groupOrder := order.group(g.ID)
+		rendered := SDTestGroupQuestions{
+			GroupID:   g.ID,
+			GroupName: g.NameTranslations.Translate(locale, g.Name),
+		}
+
+		qnas := g.QuestionAndAnswerLists
+		for _, qi := range sortByOrder(len(qnas), func(i int) uuid.UUID { return qnas[i].ID }, groupOrder.questionIDs()) {
+			q := qnas[qi]
+			answers := q.AnswersAndValue
+			q.AnswersAndValue = []SDAnswerAndValue{}
+			for _, ai := range sortByOrder(len(answers), func(i int) uuid.UUID { return answers[i].ID }, groupOrder.question(q.ID).AnswerIDs) {
+				q.AnswersAndValue = append(q.AnswersAndValue, answers[ai])
+			}
+
+			question := q.QuestionAndAnswers(locale)
+			question.GroupID = g.ID
+			rendered.Questions = append(rendered.Questions, question)
+		}
+
+		result = append(result, rendered)
+	}
+
+	return result
+}
+
+// sortByOrder will return the indexes of n items sorted following the order of the ids.
+// Items which id is not on the order are placed last, keeping their original position relative to each other
+func sortByOrder(n int, id func(i int) uuid.UUID, order []uuid.UUID) []int {
+	rank := make(map[uuid.UUID]int, len(order))
+	for i, o := range order {
+		rank[o] = i
+	}
+
+	rankOf := func(i int) int {
+		if r, ok := rank[id(i)]; ok {
+			return r
+		}
+
+		return len(order) + i
+	}
+
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return rankOf(indexes[a]) < rankOf(indexes[b])
+	})
+
+	return indexes
+}
+
+// PartialValidation will validate the SD Package. enough to be used for first time creating / just updating the SD Template.
+// Missing sub group, question and answer ids will be generated before validating
 func (sdp *SDPackage) PartialValidation() error {
+	sdp.EnsureIDs()
+
 	return validator.Struct(sdp)
 }
 
@@ -90,7 +304,7 @@ func (sdp *SDPackage) FullValidation(t *SpeechDelayTemplate) error {
 		return errors.New("template is not active or already deleted")
 	}
 
-	if err := sdp.ensureSubGroupPackageExistsOnTemplate(t); err != nil {
+	if _, err := sdp.ensureSubGroupPackageExistsOnTemplate(t); err != nil {
 		return err
 	}
 
@@ -102,22 +316,36 @@ func (sdp *SDPackage) FullValidation(t *SpeechDelayTemplate) error {
 }
 
 // this process ensure all the sub group on package also exists on template
-// prevent an unregistered sub group on package
-func (sdp *SDPackage) ensureSubGroupPackageExistsOnTemplate(t *SpeechDelayTemplate) error {
-	matchCount := 0
+// prevent an unregistered sub group on package. The names of the unregistered sub groups are also returned
+func (sdp *SDPackage) ensureSubGroupPackageExistsOnTemplate(t *SpeechDelayTemplate) ([]string, error) {
+	mismatched := []string{}
 	for _, s := range sdp.SubGroupDetails {
+		found := false
 		for _, q := range t.Template.SubGroupDetails {
 			if s.Name == q.Name {
-				matchCount++
+				found = true
+				break
 			}
 		}
+
+		if !found {
+			mismatched = append(mismatched, s.Name)
+		}
 	}
 
-	if matchCount != len(sdp.SubGroupDetails) {
-		return errors.New("at least one sub group package details exists, but not present on the template")
+	if len(mismatched) > 0 {
+		return mismatched, errors.New("at least one sub group package details exists, but not present on the template")
 	}
 
-	return nil
+	return mismatched, nil
+}
+
+// RetargetTemplate will point the package to the given template, and return the names of
+// the package sub groups which are not present on the template
+func (sdp *SDPackage) RetargetTemplate(t *SpeechDelayTemplate) []string {
+	sdp.TemplateID = t.ID
+	mismatched, _ := sdp.ensureSubGroupPackageExistsOnTemplate(t)
+	return mismatched
 }
 
 // this ensure that all sub group details on template also present on the package
@@ -175,6 +403,13 @@ func (sdp *SDPackage) validateQuestionCountAndAnswerCount(m []matcher) error {
 			}
 		}
 
+		// ensure that every question's scoring rule match the one defined in template
+		for i, q := range d.pack.QuestionAndAnswerLists {
+			if !q.ScoringRule.Equal(d.template.ScoringRuleAt(i)) {
+				return fmt.Errorf("the scoring rule on the package is not match with the template, group name: %s question: %s", d.template.Name, q.Question)
+			}
+		}
+
 		if err := sdp.ensureAllValuesAreOrdered(d); err != nil {
 			return err
 		}
@@ -184,9 +419,20 @@ func (sdp *SDPackage) validateQuestionCountAndAnswerCount(m []matcher) error {
 }
 
 // this func ensure that the value list are ordered, from 1 to the number of specified question count
-// e.g ensure 1, 2, 3, 4 are the Value when AnswerOptionCount are 4, not 1, 2, 3, 5, 6
+// e.g ensure 1, 2, 3, 4 are the Value when AnswerOptionCount are 4, not 1, 2, 3, 5, 6.
+// If the question is using custom value map, the values only need to be defined on the value map instead.
 func (sdp *SDPackage) ensureAllValuesAreOrdered(d matcher) error {
 	for _, a := range d.pack.QuestionAndAnswerLists {
+		if a.ScoringRule != nil && len(a.ScoringRule.ValueMap) > 0 {
+			for _, b := range a.AnswersAndValue {
+				if _, ok := a.ScoringRule.ValueMap[b.Value]; !ok {
+					return fmt.Errorf("value %d on group %s question %s is not defined on the scoring rule value map", b.Value, d.template.Name, a.Question)
+				}
+			}
+
+			continue
+		}
+
 		for _, b := range a.AnswersAndValue {
 			found := false
 			missing := 0
@@ -227,6 +473,8 @@ func (sdp *SDPackage) Scan(_ context.Context, _ *schema.Field, _ reflect.Value,
 		return
 	}
 
+	sdp.EnsureIDs()
+
 	return
 }
 
@@ -237,22 +485,26 @@ func (sdp SDPackage) Value(_ context.Context, _ *schema.Field, _ reflect.Value,
 
 // GeneratedSDPackage will be used to define the generated SD package as the returned value as REST API responses
 type GeneratedSDPackage struct {
-	ID         uuid.UUID      `json:"id"`
-	TemplateID uuid.UUID      `json:"templateID"`
-	Name       string         `json:"name"`
-	CreatedBy  uuid.UUID      `json:"createdBy"`
-	Package    *SDPackage     `json:"package"`
-	IsActive   bool           `json:"isActive"`
-	IsLocked   bool           `json:"isLocked"`
-	CreatedAt  time.Time      `json:"createdAt"`
-	UpdatedAt  time.Time      `json:"updatedAt"`
-	DeletedAt  gorm.DeletedAt `json:"deletedAt"`
-}
-
-// SpeechDelayPackage will represent speech delay only test packages on db table.
-// Because the actual table's column datatype for package is JSONB, every ATEC test type must
-// define a specific struct and make sure the Package is support JSONB implementation.
-// Also make sure to customize function TableName to return "test_packages".
+	ID              uuid.UUID      `json:"id"`
+	TemplateID      uuid.UUID      `json:"templateID"`
+	Name            string         `json:"name"`
+	Type            TestType       `json:"type"`
+	CreatedBy       uuid.UUID      `json:"createdBy"`
+	Package         *SDPackage     `json:"package"`
+	IsActive        bool           `json:"isActive"`
+	IsLocked        bool           `json:"isLocked"`
+	CurrentVersion  int            `json:"currentVersion"`
+	TemplateVersion int            `json:"templateVersion"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+	DeletedAt       gorm.DeletedAt `json:"deletedAt"`
+}
+
+// SpeechDelayPackage will represent test packages on db table.
+// Every ATEC test type share the same package structure, and the Type column must always
+// follow the Type of the template used by the package.
+// The row always hold the content of CurrentVersion, while every version is kept on test_package_versions table.
+// TemplateVersion is the template version this package was validated against when activated.
 type SpeechDelayPackage struct {
 	ID         uuid.UUID
 	TemplateID uuid.UUID
@@ -264,6 +516,10 @@ type SpeechDelayPackage struct {
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 	DeletedAt  gorm.DeletedAt
+	Type       TestType
+
+	CurrentVersion  int
+	TemplateVersion int
 }
 
 // TableName define the table name for gorm
@@ -274,21 +530,89 @@ func (sdp SpeechDelayPackage) TableName() string {
 // ToRESTResponse convert SpeechDelayPackage to GeneratedSDPackage which ease rest response generation
 func (sdp *SpeechDelayPackage) ToRESTResponse() *GeneratedSDPackage {
 	return &GeneratedSDPackage{
-		ID:         sdp.ID,
-		TemplateID: sdp.TemplateID,
-		Name:       sdp.Name,
-		CreatedBy:  sdp.CreatedBy,
-		Package:    sdp.Package,
-		IsActive:   sdp.IsActive,
-		IsLocked:   sdp.IsLocked,
-		CreatedAt:  sdp.CreatedAt,
-		UpdatedAt:  sdp.UpdatedAt,
-		DeletedAt:  sdp.DeletedAt,
+		ID:              sdp.ID,
+		TemplateID:      sdp.TemplateID,
+		Name:            sdp.Name,
+		Type:            sdp.Type.OrDefault(),
+		CreatedBy:       sdp.CreatedBy,
+		Package:         sdp.Package,
+		IsActive:        sdp.IsActive,
+		IsLocked:        sdp.IsLocked,
+		CurrentVersion:  sdp.CurrentVersion,
+		TemplateVersion: sdp.TemplateVersion,
+		CreatedAt:       sdp.CreatedAt,
+		UpdatedAt:       sdp.UpdatedAt,
+		DeletedAt:       sdp.DeletedAt,
+	}
+}
+
+// ToVersion create the snapshot of the current version of this package
+func (sdp *SpeechDelayPackage) ToVersion() *SDPackageVersion {
+	return &SDPackageVersion{
+		PackageID: sdp.ID,
+		Version:   sdp.CurrentVersion,
+		Name:      sdp.Name,
+		Package:   sdp.Package,
+		CreatedBy: sdp.CreatedBy,
+		CreatedAt: sdp.UpdatedAt,
 	}
 }
 
+// UseVersion replace the package content with the content of the given version
+func (sdp *SpeechDelayPackage) UseVersion(v *SDPackageVersion) {
+	sdp.CurrentVersion = v.Version
+	sdp.Name = v.Name
+	sdp.Package = v.Package
+}
+
+// SDPackageVersion represent an immutable snapshot of a package version on test_package_versions table.
+// Once the version is locked, any edit on the package will create a new version instead.
+type SDPackageVersion struct {
+	PackageID uuid.UUID
+	Version   int
+	Name      string
+	Package   *SDPackage
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+}
+
+// TableName define the table name for gorm
+func (sdpv SDPackageVersion) TableName() string {
+	return "test_package_versions"
+}
+
+// ToRESTResponse convert SDPackageVersion to GeneratedSDPackageVersion
+func (sdpv *SDPackageVersion) ToRESTResponse() *GeneratedSDPackageVersion {
+	return &GeneratedSDPackageVersion{
+		PackageID: sdpv.PackageID,
+		Version:   sdpv.Version,
+		Name:      sdpv.Name,
+		Package:   sdpv.Package,
+		CreatedBy: sdpv.CreatedBy,
+		CreatedAt: sdpv.CreatedAt,
+	}
+}
+
+// GeneratedSDPackageVersion will be used to define the SD package version as the returned value as REST API responses
+type GeneratedSDPackageVersion struct {
+	PackageID uuid.UUID  `json:"packageID"`
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	Package   *SDPackage `json:"package"`
+	CreatedBy uuid.UUID  `json:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// SDPackageVersionsOutput output for listing all the versions of a SD package
+type SDPackageVersionsOutput struct {
+	PackageID      uuid.UUID                    `json:"packageID"`
+	CurrentVersion int                          `json:"currentVersion"`
+	Versions       []*GeneratedSDPackageVersion `json:"versions"`
+}
+
 // SearchSDPackageInput input to search sd package
 type SearchSDPackageInput struct {
+	Type           TestType  `query:"type"`
 	TemplateID     uuid.UUID `query:"templateID"`
 	CreatedBy      uuid.UUID `query:"createdBy"`
 	CreatedAfter   time.Time `query:"createdAfter"`
@@ -313,6 +637,11 @@ func (sdpi *SearchSDPackageInput) ToWhereQuery() ([]interface{}, []interface{})
 		sdpi.Offset = 0
 	}
 
+	if sdpi.Type != "" {
+		whereQuery = append(whereQuery, "type = ?")
+		conds = append(conds, sdpi.Type)
+	}
+
 	if sdpi.TemplateID != uuid.Nil {
 		whereQuery = append(whereQuery, "template_id = ?")
 		conds = append(conds, sdpi.TemplateID)
@@ -356,6 +685,30 @@ type FindReadyToUseOutput struct {
 	Count int `json:"count"`
 }
 
+// CloneSDPackageInput input to clone an existing SD package
+type CloneSDPackageInput struct {
+	// TemplateID is optional. If set, the cloned package will use this template instead of the original one
+	TemplateID uuid.NullUUID `json:"templateID"`
+	// PackageName is optional. If empty, the original package name will be used
+	PackageName string `json:"packageName" validate:"omitempty,max=255"`
+}
+
+// Validate validate struct. The optional PackageName must not be blank when set
+func (i *CloneSDPackageInput) Validate() error {
+	if i.PackageName != "" && strings.TrimSpace(i.PackageName) == "" {
+		return errors.New("packageName must not be blank")
+	}
+
+	return validator.Struct(i)
+}
+
+// CloneSDPackageOutput output from cloning SD package
+type CloneSDPackageOutput struct {
+	Package *GeneratedSDPackage `json:"package"`
+	// MismatchedSubGroups list the package sub groups which are not present on the template used by the cloned package
+	MismatchedSubGroups []string `json:"mismatchedSubGroups"`
+}
+
 // SDPackageUsecase interface for SD package usecase
 type SDPackageUsecase interface {
 	Create(ctx context.Context, input *SDPackage) (*GeneratedSDPackage, *common.Error)
@@ -366,17 +719,24 @@ type SDPackageUsecase interface {
 	UndoDelete(ctx context.Context, id uuid.UUID) (*GeneratedSDPackage, *common.Error)
 	ChangeSDPackageActiveStatus(ctx context.Context, id uuid.UUID, isActive bool) (*GeneratedSDPackage, *common.Error)
 	FindReadyToUse(ctx context.Context, limit, offset int) (*FindReadyToUseOutput, *common.Error)
+	FindVersions(ctx context.Context, id uuid.UUID) (*SDPackageVersionsOutput, *common.Error)
+	FindVersion(ctx context.Context, id uuid.UUID, version int) (*GeneratedSDPackageVersion, *common.Error)
+	Clone(ctx context.Context, id uuid.UUID, input *CloneSDPackageInput) (*CloneSDPackageOutput, *common.Error)
 }
 
 // SDPackageRepository interface for SD package repository
 type SDPackageRepository interface {
-	Create(ctx context.Context, input *SpeechDelayPackage) error
+	Create(ctx context.Context, input *SpeechDelayPackage, tx *gorm.DB) error
 	FindByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*SpeechDelayPackage, error)
 	Search(ctx context.Context, input *SearchSDPackageInput) ([]*SpeechDelayPackage, error)
 	Update(ctx context.Context, pack *SpeechDelayPackage, tx *gorm.DB) error
 	Delete(ctx context.Context, id uuid.UUID) (*SpeechDelayPackage, error)
 	UndoDelete(ctx context.Context, id uuid.UUID) (*SpeechDelayPackage, error)
-	FindLeastUsedPackageIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
-	FindRandomActivePackage(ctx context.Context) (*SpeechDelayPackage, error)
+	FindActive(ctx context.Context, templateID uuid.NullUUID) ([]*SpeechDelayPackage, error)
 	GetTemplateByPackageID(ctx context.Context, packageID uuid.UUID) (*SpeechDelayTemplate, error)
+
+	// UpdateWithVersion will update the package and save its content as the CurrentVersion snapshot
+	UpdateWithVersion(ctx context.Context, pack *SpeechDelayPackage) error
+	FindVersions(ctx context.Context, packageID uuid.UUID) ([]*SDPackageVersion, error)
+	FindVersion(ctx context.Context, packageID uuid.UUID, version int) (*SDPackageVersion, error)
 }
@@ -3,8 +3,10 @@ package model
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,20 +15,371 @@ import (
 	"gorm.io/gorm/schema"
 )
 
+// SDScoringRule define how the chosen answer value on a question is converted into point.
+// When ValueMap is set, the answer value is used as the key to find the point. Otherwise, when Reverse is set
+// the point will be AnswerOptionCount + 1 - value. The resulting point is then multiplied by Weight.
+// Zero value means the answer value is used as is.
+type SDScoringRule struct {
+	Reverse  bool        `json:"reverse,omitempty"`
+	Weight   int         `json:"weight,omitempty" validate:"omitempty,min=1"`
+	ValueMap map[int]int `json:"valueMap,omitempty"`
+}
+
+// Point will convert the answer value to the point based on this rule. Safe to be called on nil rule.
+func (r *SDScoringRule) Point(value, answerOptionCount int) int {
+	if r == nil {
+		return value
+	}
+
+	point := value
+	switch {
+	case len(r.ValueMap) > 0:
+		point = r.ValueMap[value]
+	case r.Reverse:
+		point = answerOptionCount + 1 - value
+	}
+
+	return point * r.weight()
+}
+
+// PointRange will return the minimum and maximum point possible to be achieved by a question using this rule.
+// Safe to be called on nil rule.
+func (r *SDScoringRule) PointRange(answerOptionCount int) (int, int) {
+	if r == nil || len(r.ValueMap) == 0 {
+		return r.weight(), answerOptionCount * r.weight()
+	}
+
+	first := true
+	minPoint, maxPoint := 0, 0
+	for _, v := range r.ValueMap {
+		if first || v < minPoint {
+			minPoint = v
+		}
+		if first || v > maxPoint {
+			maxPoint = v
+		}
+		first = false
+	}
+
+	return minPoint * r.weight(), maxPoint * r.weight()
+}
+
+// Equal reports whether both rules will produce the same point. Nil rule is equal to the zero value rule.
+func (r *SDScoringRule) Equal(other *SDScoringRule) bool {
+	return reflect.DeepEqual(r.normalize(), other.normalize())
+}
+
+// validate will ensure the rule can be applied to a question with the given number of answer options
+func (r *SDScoringRule) validate(answerOptionCount int) error {
+	if err := validator.Struct(r); err != nil {
+		return err
+	}
+
+	if len(r.ValueMap) == 0 {
+		return nil
+	}
+
+	if r.Reverse {
+		return errors.New("reverse scoring can't be combined with custom value map")
+	}
+
+	if len(r.ValueMap) != answerOptionCount {
+		return fmt.Errorf("custom value map must define exactly %d values, got %d", answerOptionCount, len(r.ValueMap))
+	}
+
+	for k, v := range r.ValueMap {
+		if k < 1 {
+			return fmt.Errorf("custom value map key must be greater than 0, got %d", k)
+		}
+
+		if v < 0 {
+			return fmt.Errorf("custom value map point must not be negative, got %d on key %d", v, k)
+		}
+	}
+
+	return nil
+}
+
+func (r *SDScoringRule) weight() int {
+	if r == nil || r.Weight == 0 {
+		return 1
+	}
+
+	return r.Weight
+}
+
+func (r *SDScoringRule) normalize() SDScoringRule {
+	if r == nil {
+		return SDScoringRule{Weight: 1}
+	}
+
+	n := SDScoringRule{
+		Reverse: r.Reverse,
+		Weight:  r.weight(),
+	}
+
+	if len(r.ValueMap) > 0 {
+		n.ValueMap = r.ValueMap
+	}
+
+	return n
+}
+
+// SDSeverityBand define the interpretation of a point range. Both MinPoint and MaxPoint are inclusive
+type SDSeverityBand struct {
+	Name     string `json:"name" validate:"required"`
+	MinPoint int    `json:"minPoint" validate:"min=0"`
+	MaxPoint int    `json:"maxPoint" validate:"gtefield=MinPoint"`
+	Text     string `json:"text" validate:"required"`
+
+	// NameTranslations and TextTranslations are optional, hold the translated Name and Text keyed by the locale
+	NameTranslations Translations `json:"nameTranslations,omitempty" validate:"omitempty,dive,keys,required,endkeys,required"`
+	TextTranslations Translations `json:"textTranslations,omitempty" validate:"omitempty,dive,keys,required,endkeys,required"`
+}
+
+// validateSeverityBands will ensure the bands are ordered ascending by their point range, not overlapping each other
+// and not exceeding the maximum point
+func validateSeverityBands(bands []SDSeverityBand, maxPoint int) error {
+	for i, band := range bands {
+		if band.MaxPoint > maxPoint {
+			return fmt.Errorf("severity band %s must not exceed the maximum point (max: %d)", band.Name, maxPoint)
+		}
+
+		if i > 0 && band.MinPoint <= bands[i-1].MaxPoint {
+			return fmt.Errorf("severity band %s must be ordered after and not overlapping with severity band %s", band.Name, bands[i-1].Name)
+		}
+	}
+
+	return nil
+}
+
+// findSeverityBandByName will return the severity band with the name. Return nil if none match
+func findSeverityBandByName(bands []SDSeverityBand, name string) *SDSeverityBand {
+	for i := range bands {
+		if bands[i].Name == name {
+			return &bands[i]
+		}
+	}
+
+	return nil
+}
+
+// findSeverityBand will return the severity band containing the point. Return nil if none match
+func findSeverityBand(bands []SDSeverityBand, point int) *SDSeverityBand {
+	for i := range bands {
+		if point >= bands[i].MinPoint && point <= bands[i].MaxPoint {
+			return &bands[i]
+		}
+	}
+
+	return nil
+}
+
+// interpretPoint will build the interpretation of the point based on the threshold and the severity bands.
+// Zero threshold means no indication threshold is defined
+func interpretPoint(point, threshold int, positiveText, negativeText string, bands []SDSeverityBand) *SDTestInterpretation {
+	interpretation := &SDTestInterpretation{}
+	if threshold > 0 {
+		interpretation.IsPositive = point >= threshold
+		interpretation.IndicationText = negativeText
+		if interpretation.IsPositive {
+			interpretation.IndicationText = positiveText
+		}
+	}
+
+	if band := findSeverityBand(bands, point); band != nil {
+		interpretation.Severity = band.Name
+		interpretation.SeverityText = band.Text
+	}
+
+	return interpretation
+}
+
 // SDTemplateSubGroupDetail define what the details of every sub group used by this template
 type SDTemplateSubGroupDetail struct {
 	Name              string `json:"name" validate:"required"`
 	QuestionCount     int    `json:"questionCount" validate:"required,min=1"`
 	AnswerOptionCount int    `json:"answerOptionCount" validate:"required,min=2"`
+
+	// ScoringRules define the scoring rule for each question on this sub group, ordered by the question position.
+	// If empty, every question will use the default rule. Otherwise, must have exactly QuestionCount elements.
+	ScoringRules []SDScoringRule `json:"scoringRules,omitempty" validate:"omitempty,dive"`
+
+	// IndicationThreshold is optional. When set, the sub group result will be interpreted
+	// as positive if the point is greater than or equal to this threshold
+	IndicationThreshold    int    `json:"indicationThreshold,omitempty" validate:"omitempty,min=1"`
+	PositiveIndicationText string `json:"positiveIndicationText,omitempty" validate:"required_with=IndicationThreshold"`
+	NegativeIndicationText string `json:"negativeIndicationText,omitempty" validate:"required_with=IndicationThreshold"`
+
+	// SeverityBands is optional, and must be ordered ascending by the point range
+	SeverityBands []SDSeverityBand `json:"severityBands,omitempty" validate:"omitempty,dive"`
+
+	// NameTranslations, PositiveIndicationTextTranslations and NegativeIndicationTextTranslations are optional,
+	// hold the translated texts keyed by the locale
+	NameTranslations                   Translations `json:"nameTranslations,omitempty" validate:"omitempty,dive,keys,required,endkeys,required"`
+	PositiveIndicationTextTranslations Translations `json:"positiveIndicationTextTranslations,omitempty" validate:"omitempty,dive,keys,required,endkeys,required"`
+	NegativeIndicationTextTranslations Translations `json:"negativeIndicationTextTranslations,omitempty" validate:"omitempty,dive,keys,required,endkeys,required"`
+}
+
+// ScoringRuleAt return the scoring rule for the question at index i. Will return nil if no specific rule defined
+func (sgd *SDTemplateSubGroupDetail) ScoringRuleAt(i int) *SDScoringRule {
+	if i < 0 || i >= len(sgd.ScoringRules) {
+		return nil
+	}
+
+	return &sgd.ScoringRules[i]
+}
+
+// pointRange will return the lowest minimum point of a single question and the maximum point possible
+// to be achieved by this sub group
+func (sgd *SDTemplateSubGroupDetail) pointRange() (int, int) {
+	var minPoint, maxPoint int
+	for i := 0; i < sgd.QuestionCount; i++ {
+		qmin, qmax := sgd.ScoringRuleAt(i).PointRange(sgd.AnswerOptionCount)
+		if i == 0 || qmin < minPoint {
+			minPoint = qmin
+		}
+		maxPoint += qmax
+	}
+
+	return minPoint, maxPoint
+}
+
+// validateInterpretation will ensure the indication threshold and the severity bands are within the point range of this sub group
+func (sgd *SDTemplateSubGroupDetail) validateInterpretation() error {
+	_, maxPoint := sgd.pointRange()
+	if sgd.IndicationThreshold > maxPoint {
+		return fmt.Errorf("indicationThreshold on sub group %s must be less than or equal to the maximum point (max: %d)", sgd.Name, maxPoint)
+	}
+
+	if err := validateSeverityBands(sgd.SeverityBands, maxPoint); err != nil {
+		return fmt.Errorf("invalid severity bands on sub group %s: %s", sgd.Name, err.Error())
+	}
+
+	return nil
+}
+
+// interpret will return the interpretation of the sub group point. Return nil if neither
+// the indication threshold nor the severity bands are defined
+func (sgd *SDTemplateSubGroupDetail) interpret(point int) *SDTestInterpretation {
+	if sgd.IndicationThreshold == 0 && len(sgd.SeverityBands) == 0 {
+		return nil
+	}
+
+	return interpretPoint(point, sgd.IndicationThreshold, sgd.PositiveIndicationText, sgd.NegativeIndicationText, sgd.SeverityBands)
+}
+
+func (sgd *SDTemplateSubGroupDetail) validateScoringRules() error {
+	if len(sgd.ScoringRules) == 0 {
+		return nil
+	}
+
+	if len(sgd.ScoringRules) != sgd.QuestionCount {
+		return fmt.Errorf("scoring rules on sub group %s must be defined for all %d questions, got %d", sgd.Name, sgd.QuestionCount, len(sgd.ScoringRules))
+	}
+
+	for i := range sgd.ScoringRules {
+		if err := sgd.ScoringRules[i].validate(sgd.AnswerOptionCount); err != nil {
+			return fmt.Errorf("invalid scoring rule on sub group %s question number %d: %s", sgd.Name, i+1, err.Error())
+		}
+	}
+
+	return nil
 }
 
 // SDTemplate define what the full SD test template will look like
 type SDTemplate struct {
+	Type                   TestType                   `json:"type,omitempty"`
 	Name                   string                     `json:"name" validate:"required,max=255"`
 	IndicationThreshold    int                        `json:"indicationThreshold" validate:"required,min=0"`
 	PositiveIndiationText  string                     `json:"positiveIndicationText" validate:"required"`
 	NegativeIndicationText string                     `json:"negativeIndicationText" validate:"required"`
 	SubGroupDetails        []SDTemplateSubGroupDetail `json:"subGroupDetails" validate:"min=1,dive"`
+
+	// SeverityBands is optional, used to interpret the total point. Must be ordered ascending by the point range
+	SeverityBands []SDSeverityBand `json:"severityBands,omitempty" validate:"omitempty,dive"`
+
+	// PositiveIndicationTextTranslations and NegativeIndicationTextTranslations are optional,
+	// hold the translated indication texts keyed by the locale
+	PositiveIndicationTextTranslations Translations `json:"positiveIndicationTextTranslations,omitempty" validate:"omitempty,dive,keys,required,endkeys,required"`
+	NegativeIndicationTextTranslations Translations `json:"negativeIndicationTextTranslations,omitempty" validate:"omitempty,dive,keys,required,endkeys,required"`
+
+	// Randomization is optional, define which parts of the test are rendered in random order
+	Randomization *SDRandomization `json:"randomization,omitempty"`
+
+	// PackageSelection is optional, define how the package is chosen when the test is initiated without a package id,
+	// either for this template or, when no template is requested, for the user whose latest test used this template.
+	// Empty value will use the default strategy, see PackageSelectionStrategy.OrDefault
+	PackageSelection PackageSelectionStrategy `json:"packageSelection,omitempty" validate:"omitempty,oneof=least_used round_robin weighted_random same_template random"`
+
+	// RetakePolicy is optional, limit how often a logged in user can take the test of this template
+	RetakePolicy *SDRetakePolicy `json:"retakePolicy,omitempty"`
+}
+
+// SDRetakePolicy define how often a user can take the test of a template. Zero value rule means no limit.
+// The rules are counted per child when the test is taken for a child profile
+type SDRetakePolicy struct {
+	// MinIntervalMinutes minimum interval between the last finished test and the next initiated test
+	MinIntervalMinutes int `json:"minIntervalMinutes,omitempty" validate:"min=0"`
+
+	// MaxOpenTests maximum unfinished tests which are still open at the same time
+	MaxOpenTests int `json:"maxOpenTests,omitempty" validate:"min=0"`
+
+	// MaxAttempts maximum initiated tests within the last AttemptPeriodHours
+	MaxAttempts        int `json:"maxAttempts,omitempty" validate:"min=0"`
+	AttemptPeriodHours int `json:"attemptPeriodHours,omitempty" validate:"required_with=MaxAttempts,min=0"`
+}
+
+// SDRetakeRule name of the retake policy rule
+type SDRetakeRule string
+
+// list of the retake policy rules
+const (
+	SDRetakeRuleMinInterval  SDRetakeRule = "min_interval"
+	SDRetakeRuleMaxOpenTests SDRetakeRule = "max_open_tests"
+	SDRetakeRuleMaxAttempts  SDRetakeRule = "max_attempts"
+)
+
+// AttemptPeriod return the period used to count the attempts
+func (p *SDRetakePolicy) AttemptPeriod() time.Duration {
+	return time.Duration(p.AttemptPeriodHours) * time.Hour
+}
+
+// Check will check the stat against the policy. Return nil when a new test is allowed, otherwise
+// return the violated rule which blocks the longest and the time when the next test is allowed
+func (p *SDRetakePolicy) Check(stat *SDRetakeStat, now time.Time) *SDRetakeNotAllowedOutput {
+	var blocked *SDRetakeNotAllowedOutput
+	block := func(rule SDRetakeRule, until time.Time) {
+		if !until.After(now) {
+			return
+		}
+
+		if blocked == nil || until.After(blocked.NextAllowedAt) {
+			blocked = &SDRetakeNotAllowedOutput{Rule: rule, NextAllowedAt: until}
+		}
+	}
+
+	if p.MinIntervalMinutes > 0 && stat.LastFinishedAt.Valid {
+		block(SDRetakeRuleMinInterval, stat.LastFinishedAt.Time.Add(time.Duration(p.MinIntervalMinutes)*time.Minute))
+	}
+
+	if p.MaxOpenTests > 0 && stat.OpenTests >= p.MaxOpenTests && stat.EarliestOpenUntil.Valid {
+		block(SDRetakeRuleMaxOpenTests, stat.EarliestOpenUntil.Time)
+	}
+
+	if p.MaxAttempts > 0 && stat.Attempts >= p.MaxAttempts && stat.OldestAttemptAt.Valid {
+		block(SDRetakeRuleMaxAttempts, stat.OldestAttemptAt.Time.Add(p.AttemptPeriod()))
+	}
+
+	return blocked
+}
+
+// SDRandomization define which parts of the test are rendered in random order. The order is generated once
+// when the test is initiated, and stored on the test so the test is always rendered the same way
+type SDRandomization struct {
+	ShuffleGroups    bool `json:"shuffleGroups,omitempty"`
+	ShuffleQuestions bool `json:"shuffleQuestions,omitempty"`
+	ShuffleAnswers   bool `json:"shuffleAnswers,omitempty"`
 }
 
 // PartialValidation will validate the SD Template. enough to be used for first time creating / just updating the SD Template
@@ -34,20 +387,29 @@ func (csdti *SDTemplate) PartialValidation() error {
 	return validator.Struct(csdti)
 }
 
-// CountMaximumPoint will count the maximum point possible that can be achieved by this SD Template.
+// CountMaximumPoint will count the maximum point possible that can be achieved by this SD Template,
+// taking the scoring rules into account.
 func (csdti *SDTemplate) CountMaximumPoint() int {
 	var maximumPoint int
 	for _, subGroupDetail := range csdti.SubGroupDetails {
-		maximumPoint += subGroupDetail.QuestionCount * subGroupDetail.AnswerOptionCount
+		_, maxPoint := subGroupDetail.pointRange()
+		maximumPoint += maxPoint
 	}
 
 	return maximumPoint
 }
 
-// CountMinimumPoint will count the minimum point possible that can be achieved by this SD Template.
-// Will always equal to the length of SubGroupDetails
+// CountMinimumPoint will count the minimum point used as the lower bound of the IndicationThreshold.
+// Every sub group contribute the lowest point possible from a single question, taking the scoring rules into account.
+// Without any scoring rules, will equal to the length of SubGroupDetails
 func (csdti *SDTemplate) CountMinimumPoint() int {
-	return len(csdti.SubGroupDetails)
+	var minimumPoint int
+	for _, subGroupDetail := range csdti.SubGroupDetails {
+		minPoint, _ := subGroupDetail.pointRange()
+		minimumPoint += minPoint
+	}
+
+	return minimumPoint
 }
 
 // FullValidation will validate the SD Template to ensure all rules are satisfied. Suitable to be used to activate the SD Template
@@ -56,17 +418,95 @@ func (csdti *SDTemplate) FullValidation() error {
 		return err
 	}
 
+	for _, subGroupDetail := range csdti.SubGroupDetails {
+		if err := subGroupDetail.validateScoringRules(); err != nil {
+			return err
+		}
+
+		if err := subGroupDetail.validateInterpretation(); err != nil {
+			return err
+		}
+	}
+
 	if csdti.IndicationThreshold < csdti.CountMinimumPoint() {
-		return fmt.Errorf("indicationThreshold must be greater than or equal to the number of sub group details (min: %d)", csdti.CountMinimumPoint())
+		return fmt.Errorf("indicationThreshold must be greater than or equal to the minimum point (min: %d)", csdti.CountMinimumPoint())
 	}
 
 	if csdti.IndicationThreshold > csdti.CountMaximumPoint() {
 		return fmt.Errorf("indicationThreshold must be less than or equal to the maximum point (max: %d)", csdti.CountMaximumPoint())
 	}
 
+	if err := validateSeverityBands(csdti.SeverityBands, csdti.CountMaximumPoint()); err != nil {
+		return fmt.Errorf("invalid severity bands: %s", err.Error())
+	}
+
 	return nil
 }
 
+// Interpret will fill the interpretation of the total point and every sub group result
+// based on the indication thresholds and severity bands defined on this SD Template
+func (csdti *SDTemplate) Interpret(result *SDTestResult) {
+	result.Interpretation = interpretPoint(result.Total, csdti.IndicationThreshold, csdti.PositiveIndiationText, csdti.NegativeIndicationText, csdti.SeverityBands)
+
+	for i := range result.Result {
+		for j := range csdti.SubGroupDetails {
+			if csdti.SubGroupDetails[j].Name == result.Result[i].GroupName {
+				result.Result[i].Interpretation = csdti.SubGroupDetails[j].interpret(result.Result[i].Result)
+				break
+			}
+		}
+	}
+}
+
+// TranslateResult will return a copy of the result with the group names and the interpretation texts
+// written in the locale. Untranslated texts are kept as is. The points are never changed
+func (csdti *SDTemplate) TranslateResult(result SDTestResult, locale Locale) SDTestResult {
+	translated := SDTestResult{
+		Total:          result.Total,
+		Interpretation: translateInterpretation(result.Interpretation, locale, csdti.PositiveIndicationTextTranslations, csdti.NegativeIndicationTextTranslations, csdti.SeverityBands),
+	}
+
+	for _, r := range result.Result {
+		groupResult := r
+		for _, sg := range csdti.SubGroupDetails {
+			if sg.Name != r.GroupName {
+				continue
+			}
+
+			groupResult.GroupName = sg.NameTranslations.Translate(locale, sg.Name)
+			groupResult.Interpretation = translateInterpretation(r.Interpretation, locale, sg.PositiveIndicationTextTranslations, sg.NegativeIndicationTextTranslations, sg.SeverityBands)
+			break
+		}
+
+		translated.Result = append(translated.Result, groupResult)
+	}
+
+	return translated
+}
+
+// translateInterpretation will return a copy of the interpretation written in the locale. Safe to be called on nil interpretation
+func translateInterpretation(in *SDTestInterpretation, locale Locale, positiveText, negativeText Translations, bands []SDSeverityBand) *SDTestInterpretation {
+	if in == nil {
+		return nil
+	}
+
+	out := *in
+	if in.IndicationText != "" {
+		if in.IsPositive {
+			out.IndicationText = positiveText.Translate(locale, in.IndicationText)
+		} else {
+			out.IndicationText = negativeText.Translate(locale, in.IndicationText)
+		}
+	}
+
+	if band := findSeverityBandByName(bands, in.Severity); band != nil {
+		out.Severity = band.NameTranslations.Translate(locale, in.Severity)
+		out.SeverityText = band.TextTranslations.Translate(locale, in.SeverityText)
+	}
+
+	return &out
+}
+
 // Scan is a function to scan database value to CreateSDTemplateInput
 func (csdti *SDTemplate) Scan(_ context.Context, _ *schema.Field, _ reflect.Value, dbValue interface{}) (err error) {
 	if dbValue == nil {
@@ -97,21 +537,23 @@ func (csdti SDTemplate) Value(_ context.Context, _ *schema.Field, _ reflect.Valu
 
 // GeneratedSDTemplate will be used to define the generated SD template as the returned value as REST API responses
 type GeneratedSDTemplate struct {
-	ID        uuid.UUID      `json:"id"`
-	CreatedBy uuid.UUID      `json:"createdBy"`
-	Name      string         `json:"name"`
-	Template  *SDTemplate    `json:"template"`
-	IsActive  bool           `json:"isActive"`
-	IsLocked  bool           `json:"isLocked"`
-	CreatedAt time.Time      `json:"createdAt"`
-	UpdatedAt time.Time      `json:"updatedAt"`
-	DeletedAt gorm.DeletedAt `json:"deletedAt,omitempty"`
-}
-
-// SpeechDelayTemplate will represent speech delay only test templates on db table.
-// Because the actual table's column datatype for template is JSONB, every ATEC test type must
-// define a specific struct and make sure the Template is support JSONB implementation.
-// Also make sure to customize function TableName to return "test_templates".
+	ID             uuid.UUID      `json:"id"`
+	CreatedBy      uuid.UUID      `json:"createdBy"`
+	Name           string         `json:"name"`
+	Type           TestType       `json:"type"`
+	Template       *SDTemplate    `json:"template"`
+	IsActive       bool           `json:"isActive"`
+	IsLocked       bool           `json:"isLocked"`
+	CurrentVersion int            `json:"currentVersion"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+	DeletedAt      gorm.DeletedAt `json:"deletedAt,omitempty"`
+}
+
+// SpeechDelayTemplate will represent test templates on db table.
+// Every ATEC test type share the same template structure, and the Type column is used
+// to determine which TestTypeDefinition rules are applied to the template.
+// The row always hold the content of CurrentVersion, while every version is kept on test_template_versions table.
 type SpeechDelayTemplate struct {
 	ID        uuid.UUID
 	CreatedBy uuid.UUID
@@ -122,6 +564,9 @@ type SpeechDelayTemplate struct {
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt
 	Template  *SDTemplate
+	Type      TestType
+
+	CurrentVersion int
 }
 
 // TableName define the table name for gorm
@@ -132,20 +577,96 @@ func (sdt SpeechDelayTemplate) TableName() string {
 // ToRESTResponse convert SpeechDelayTemplate to GeneratedSDTemplate which ease rest response generation
 func (sdt *SpeechDelayTemplate) ToRESTResponse() *GeneratedSDTemplate {
 	return &GeneratedSDTemplate{
-		ID:        sdt.ID,
-		CreatedBy: sdt.CreatedBy,
-		Name:      sdt.Name,
-		Template:  sdt.Template,
-		IsActive:  sdt.IsActive,
-		IsLocked:  sdt.IsLocked,
-		CreatedAt: sdt.CreatedAt,
-		UpdatedAt: sdt.UpdatedAt,
-		DeletedAt: sdt.DeletedAt,
+		ID:             sdt.ID,
+		CreatedBy:      sdt.CreatedBy,
+		Name:           sdt.Name,
+		Type:           sdt.Type.OrDefault(),
+		Template:       sdt.Template,
+		IsActive:       sdt.IsActive,
+		IsLocked:       sdt.IsLocked,
+		CurrentVersion: sdt.CurrentVersion,
+		CreatedAt:      sdt.CreatedAt,
+		UpdatedAt:      sdt.UpdatedAt,
+		DeletedAt:      sdt.DeletedAt,
+	}
+}
+
+// ToVersion create the snapshot of the current version of this template
+func (sdt *SpeechDelayTemplate) ToVersion() *SDTemplateVersion {
+	return &SDTemplateVersion{
+		TemplateID: sdt.ID,
+		Version:    sdt.CurrentVersion,
+		Name:       sdt.Name,
+		Template:   sdt.Template,
+		CreatedBy:  sdt.CreatedBy,
+		CreatedAt:  sdt.UpdatedAt,
+	}
+}
+
+// PackageSelectionStrategy return the package selection strategy configured on the template, if any
+func (sdt *SpeechDelayTemplate) PackageSelectionStrategy() PackageSelectionStrategy {
+	if sdt.Template == nil {
+		return ""
+	}
+
+	return sdt.Template.PackageSelection
+}
+
+// UseVersion replace the template content with the content of the given version
+func (sdt *SpeechDelayTemplate) UseVersion(v *SDTemplateVersion) {
+	sdt.CurrentVersion = v.Version
+	sdt.Name = v.Name
+	sdt.Template = v.Template
+}
+
+// SDTemplateVersion represent an immutable snapshot of a template version on test_template_versions table.
+// Once the version is locked, any edit on the template will create a new version instead.
+type SDTemplateVersion struct {
+	TemplateID uuid.UUID
+	Version    int
+	Name       string
+	Template   *SDTemplate
+	CreatedBy  uuid.UUID
+	CreatedAt  time.Time
+}
+
+// TableName define the table name for gorm
+func (sdtv SDTemplateVersion) TableName() string {
+	return "test_template_versions"
+}
+
+// ToRESTResponse convert SDTemplateVersion to GeneratedSDTemplateVersion
+func (sdtv *SDTemplateVersion) ToRESTResponse() *GeneratedSDTemplateVersion {
+	return &GeneratedSDTemplateVersion{
+		TemplateID: sdtv.TemplateID,
+		Version:    sdtv.Version,
+		Name:       sdtv.Name,
+		Template:   sdtv.Template,
+		CreatedBy:  sdtv.CreatedBy,
+		CreatedAt:  sdtv.CreatedAt,
 	}
 }
 
+// GeneratedSDTemplateVersion will be used to define the SD template version as the returned value as REST API responses
+type GeneratedSDTemplateVersion struct {
+	TemplateID uuid.UUID   `json:"templateID"`
+	Version    int         `json:"version"`
+	Name       string      `json:"name"`
+	Template   *SDTemplate `json:"template"`
+	CreatedBy  uuid.UUID   `json:"createdBy"`
+	CreatedAt  time.Time   `json:"createdAt"`
+}
+
+// SDTemplateVersionsOutput output for listing all the versions of a SD template
+type SDTemplateVersionsOutput struct {
+	TemplateID     uuid.UUID                     `json:"templateID"`
+	CurrentVersion int                           `json:"currentVersion"`
+	Versions       []*GeneratedSDTemplateVersion `json:"versions"`
+}
+
 // SearchSDTemplateInput input for searching SDTemplate input
 type SearchSDTemplateInput struct {
+	Type           TestType  `query:"type"`
 	CreatedBy      uuid.UUID `query:"createdBy"`
 	CreatedAfter   time.Time `query:"createdAfter"`
 	IsActive       *bool     `query:"isActive"`
@@ -169,6 +690,11 @@ func (sdti *SearchSDTemplateInput) ToWhereQuery() ([]interface{}, []interface{})
 		sdti.Offset = 0
 	}
 
+	if sdti.Type != "" {
+		whereQuery = append(whereQuery, "type = ?")
+		conds = append(conds, sdti.Type)
+	}
+
 	if sdti.CreatedBy != uuid.Nil {
 		whereQuery = append(whereQuery, "created_by = ?")
 		conds = append(conds, sdti.CreatedBy)
@@ -198,6 +724,21 @@ type SearchSDTemplateOutput struct {
 	Count     int                    `json:"count"`
 }
 
+// CloneSDTemplateInput input to clone an existing SD template
+type CloneSDTemplateInput struct {
+	// Name is optional. If empty, the original template name will be used
+	Name string `json:"name" validate:"omitempty,max=255"`
+}
+
+// Validate validate struct. The optional Name must not be blank when set
+func (i *CloneSDTemplateInput) Validate() error {
+	if i.Name != "" && strings.TrimSpace(i.Name) == "" {
+		return errors.New("name must not be blank")
+	}
+
+	return validator.Struct(i)
+}
+
 // SDTemplateUsecase speech delay test template usecase
 type SDTemplateUsecase interface {
 	Create(ctx context.Context, input *SDTemplate) (*GeneratedSDTemplate, *common.Error)
@@ -207,14 +748,22 @@ type SDTemplateUsecase interface {
 	Delete(ctx context.Context, id uuid.UUID) (*GeneratedSDTemplate, *common.Error)
 	UndoDelete(ctx context.Context, id uuid.UUID) (*GeneratedSDTemplate, *common.Error)
 	ChangeSDTemplateActiveStatus(ctx context.Context, id uuid.UUID, isActive bool) (*GeneratedSDTemplate, *common.Error)
+	FindVersions(ctx context.Context, id uuid.UUID) (*SDTemplateVersionsOutput, *common.Error)
+	FindVersion(ctx context.Context, id uuid.UUID, version int) (*GeneratedSDTemplateVersion, *common.Error)
+	Clone(ctx context.Context, id uuid.UUID, input *CloneSDTemplateInput) (*GeneratedSDTemplate, *common.Error)
 }
 
 // SDTemplateRepository speech delay test template repository
 type SDTemplateRepository interface {
-	Create(ctx context.Context, template *SpeechDelayTemplate) error
+	Create(ctx context.Context, template *SpeechDelayTemplate, tx *gorm.DB) error
 	FindByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*SpeechDelayTemplate, error)
 	Search(ctx context.Context, input *SearchSDTemplateInput) ([]*SpeechDelayTemplate, error)
 	Update(ctx context.Context, template *SpeechDelayTemplate, tx *gorm.DB) error
 	Delete(ctx context.Context, id uuid.UUID) (*SpeechDelayTemplate, error)
 	UndoDelete(ctx context.Context, id uuid.UUID) (*SpeechDelayTemplate, error)
+
+	// UpdateWithVersion will update the template and save its content as the CurrentVersion snapshot
+	UpdateWithVersion(ctx context.Context, template *SpeechDelayTemplate) error
+	FindVersions(ctx context.Context, templateID uuid.UUID) ([]*SDTemplateVersion, error)
+	FindVersion(ctx context.Context, templateID uuid.UUID, version int) (*SDTemplateVersion, error)
 }
@@ -1,12 +1,14 @@
 package model
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/luckyAkbar/atec-api/internal/common"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v4"
 )
 
 func TestSDTemplate_PartialValidation(t *testing.T) {
@@ -295,6 +297,18 @@ func TestSDTemplate_SearchSDTemplateInput_ToWhereQuery(t *testing.T) {
 
 			},
 		},
+		{
+			Name: "4",
+			Run: func() {
+				in := &SearchSDTemplateInput{
+					Type:  TestTypeSociability,
+					Limit: 10,
+				}
+				where, conds := in.ToWhereQuery()
+				assert.Equal(t, where, []interface{}{"type = ?"})
+				assert.Equal(t, conds, []interface{}{TestTypeSociability})
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -590,6 +604,355 @@ func TestSDTemplate_FullValidation(t *testing.T) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			Name:   "scoring rules count is not match with the question count",
+			MockFn: func() {},
+			Run: func() {
+				in := &SDTemplate{
+					Name:                   "ok",
+					IndicationThreshold:    2,
+					PositiveIndiationText:  "ok",
+					NegativeIndicationText: "ok jg",
+					SubGroupDetails: []SDTemplateSubGroupDetail{
+						{
+							Name:              "okelah",
+							QuestionCount:     2,
+							AnswerOptionCount: 3,
+							ScoringRules: []SDScoringRule{
+								{
+									Reverse: true,
+								},
+							},
+						},
+					},
+				}
+				err := in.FullValidation()
+				assert.Error(t, err)
+				assert.Equal(t, err.Error(), "scoring rules on sub group okelah must be defined for all 2 questions, got 1")
+			},
+		},
+		{
+			Name:   "scoring rules combining reverse and value map",
+			MockFn: func() {},
+			Run: func() {
+				in := &SDTemplate{
+					Name:                   "ok",
+					IndicationThreshold:    2,
+					PositiveIndiationText:  "ok",
+					NegativeIndicationText: "ok jg",
+					SubGroupDetails: []SDTemplateSubGroupDetail{
+						{
+							Name:              "okelah",
+							QuestionCount:     1,
+							AnswerOptionCount: 2,
+							ScoringRules: []SDScoringRule{
+								{
+									Reverse:  true,
+									ValueMap: map[int]int{1: 1, 2: 0},
+								},
+							},
+						},
+					},
+				}
+				err := in.FullValidation()
+				assert.Error(t, err)
+			},
+		},
+		{
+			Name:   "scoring rules value map incomplete",
+			MockFn: func() {},
+			Run: func() {
+				in := &SDTemplate{
+					Name:                   "ok",
+					IndicationThreshold:    2,
+					PositiveIndiationText:  "ok",
+					NegativeIndicationText: "ok jg",
+					SubGroupDetails: []SDTemplateSubGroupDetail{
+						{
+							Name:              "okelah",
+							QuestionCount:     1,
+							AnswerOptionCount: 3,
+							ScoringRules: []SDScoringRule{
+								{
+									ValueMap: map[int]int{1: 1, 2: 0},
+								},
+							},
+						},
+					},
+				}
+				err := in.FullValidation()
+				assert.Error(t, err)
+			},
+		},
+		{
+			Name:   "scoring rules value map has negative point",
+			MockFn: func() {},
+			Run: func() {
+				in := &SDTemplate{
+					Name:                   "ok",
+					IndicationThreshold:    2,
+					PositiveIndiationText:  "ok",
+					NegativeIndicationText: "ok jg",
+					SubGroupDetails: []SDTemplateSubGroupDetail{
+						{
+							Name:              "okelah",
+							QuestionCount:     1,
+							AnswerOptionCount: 2,
+							ScoringRules: []SDScoringRule{
+								{
+									ValueMap: map[int]int{1: -1, 2: 0},
+								},
+							},
+						},
+					},
+				}
+				err := in.FullValidation()
+				assert.Error(t, err)
+			},
+		},
+		{
+			Name:   "scoring rules negative weight",
+			MockFn: func() {},
+			Run: func() {
+				in := &SDTemplate{
+					Name:                   "ok",
+					IndicationThreshold:    2,
+					PositiveIndiationText:  "ok",
+					NegativeIndicationText: "ok jg",
+					SubGroupDetails: []SDTemplateSubGroupDetail{
+						{
+							Name:              "okelah",
+							QuestionCount:     1,
+							AnswerOptionCount: 2,
+							ScoringRules: []SDScoringRule{
+								{
+									Weight: -2,
+								},
+							},
+						},
+					},
+				}
+				err := in.FullValidation()
+				assert.Error(t, err)
+			},
+		},
+		{
+			Name:   "indication threshold are too high after weight applied",
+			MockFn: func() {},
+			Run: func() {
+				in := &SDTemplate{
+					Name:                   "ok",
+					IndicationThreshold:    9,
+					PositiveIndiationText:  "ok",
+					NegativeIndicationText: "ok jg",
+					SubGroupDetails: []SDTemplateSubGroupDetail{
+						{
+							Name:              "okelah",
+							QuestionCount:     2,
+							AnswerOptionCount: 3,
+							ScoringRules: []SDScoringRule{
+								{
+									Weight: 2,
+								},
+								{
+									ValueMap: map[int]int{1: 0, 2: 1, 3: 2},
+								},
+							},
+						},
+					},
+				}
+				err := in.FullValidation()
+				assert.Error(t, err)
+				assert.Equal(t, err.Error(), "indicationThreshold must be less than or equal to the maximum point (max: 8)")
+			},
+		},
+		{
+			Name:   "ok with scoring rules",
+			MockFn: func() {},
+			Run: func() {
+				in := &SDTemplate{
+					Name:                   "ok",
+					IndicationThreshold:    8,
+					PositiveIndiationText:  "ok",
+					NegativeIndicationText: "ok jg",
+					SubGroupDetails: []SDTemplateSubGroupDetail{
+						{
+							Name:              "okelah",
+							QuestionCount:     2,
+							AnswerOptionCount: 3,
+							ScoringRules: []SDScoringRule{
+								{
+									Weight: 2,
+								},
+								{
+									ValueMap: map[int]int{1: 0, 2: 1, 3: 2},
+								},
+							},
+						},
+					},
+				}
+				err := in.FullValidation()
+				assert.NoError(t, err)
+				assert.Equal(t, in.CountMaximumPoint(), 8)
+				assert.Equal(t, in.CountMinimumPoint(), 0)
+			},
+		},
+		{
+			Name:   "sub group indication threshold set without the indication texts",
+			MockFn: func() {},
+			Run: func() {
+				in := &SDTemplate{
+					Name:                   "ok",
+					IndicationThreshold:    4,
+					PositiveIndiationText:  "ok",
+					NegativeIndicationText: "ok jg",
+					SubGroupDetails: []SDTemplateSubGroupDetail{
+						{
+							Name:                "okelah",
+							QuestionCount:       2,
+							AnswerOptionCount:   4,
+							IndicationThreshold: 3,
+						},
+					},
+				}
+				err := in.FullValidation()
+				assert.Error(t, err)
+			},
+		},
+		{
+			Name:   "sub group indication threshold exceeding the sub group maximum point",
+			MockFn: func() {},
+			Run: func() {
+				in := &SDTemplate{
+					Name:                   "ok",
+					IndicationThreshold:    4,
+					PositiveIndiationText:  "ok",
+					NegativeIndicationText: "ok jg",
+					SubGroupDetails: []SDTemplateSubGroupDetail{
+						{
+							Name:                   "okelah",
+							QuestionCount:          2,
+							AnswerOptionCount:      4,
+							IndicationThreshold:    9,
+							PositiveIndicationText: "positive",
+							NegativeIndicationText: "negative",
+						},
+					},
+				}
+				err := in.FullValidation()
+				assert.Error(t, err)
+				assert.Equal(t, err.Error(), "indicationThreshold on sub group okelah must be less than or equal to the maximum point (max: 8)")
+			},
+		},
+		{
+			Name:   "sub group severity bands overlapping",
+			MockFn: func() {},
+			Run: func() {
+				in := &SDTemplate{
+					Name:                   "ok",
+					IndicationThreshold:    4,
+					PositiveIndiationText:  "ok",
+					NegativeIndicationText: "ok jg",
+					SubGroupDetails: []SDTemplateSubGroupDetail{
+						{
+							Name:              "okelah",
+							QuestionCount:     2,
+							AnswerOptionCount: 4,
+							SeverityBands: []SDSeverityBand{
+								{Name: "mild", MinPoint: 2, MaxPoint: 4, Text: "mild"},
+								{Name: "severe", MinPoint: 4, MaxPoint: 8, Text: "severe"},
+							},
+						},
+					},
+				}
+				err := in.FullValidation()
+				assert.Error(t, err)
+				assert.Equal(t, err.Error(), "invalid severity bands on sub group okelah: severity band severe must be ordered after and not overlapping with severity band mild")
+			},
+		},
+		{
+			Name:   "severity band max point lower than the min point",
+			MockFn: func() {},
+			Run: func() {
+				in := &SDTemplate{
+					Name:                   "ok",
+					IndicationThreshold:    4,
+					PositiveIndiationText:  "ok",
+					NegativeIndicationText: "ok jg",
+					SubGroupDetails: []SDTemplateSubGroupDetail{
+						{
+							Name:              "okelah",
+							QuestionCount:     2,
+							AnswerOptionCount: 4,
+						},
+					},
+					SeverityBands: []SDSeverityBand{
+						{Name: "mild", MinPoint: 5, MaxPoint: 4, Text: "mild"},
+					},
+				}
+				err := in.FullValidation()
+				assert.Error(t, err)
+			},
+		},
+		{
+			Name:   "total severity bands exceeding the maximum point",
+			MockFn: func() {},
+			Run: func() {
+				in := &SDTemplate{
+					Name:                   "ok",
+					IndicationThreshold:    4,
+					PositiveIndiationText:  "ok",
+					NegativeIndicationText: "ok jg",
+					SubGroupDetails: []SDTemplateSubGroupDetail{
+						{
+							Name:              "okelah",
+							QuestionCount:     2,
+							AnswerOptionCount: 4,
+						},
+					},
+					SeverityBands: []SDSeverityBand{
+						{Name: "mild", MinPoint: 0, MaxPoint: 4, Text: "mild"},
+						{Name: "severe", MinPoint: 5, MaxPoint: 9, Text: "severe"},
+					},
+				}
+				err := in.FullValidation()
+				assert.Error(t, err)
+				assert.Equal(t, err.Error(), "invalid severity bands: severity band severe must not exceed the maximum point (max: 8)")
+			},
+		},
+		{
+			Name:   "ok with sub group thresholds and severity bands",
+			MockFn: func() {},
+			Run: func() {
+				in := &SDTemplate{
+					Name:                   "ok",
+					IndicationThreshold:    4,
+					PositiveIndiationText:  "ok",
+					NegativeIndicationText: "ok jg",
+					SubGroupDetails: []SDTemplateSubGroupDetail{
+						{
+							Name:                   "okelah",
+							QuestionCount:          2,
+							AnswerOptionCount:      4,
+							IndicationThreshold:    5,
+							PositiveIndicationText: "positive",
+							NegativeIndicationText: "negative",
+							SeverityBands: []SDSeverityBand{
+								{Name: "mild", MinPoint: 2, MaxPoint: 4, Text: "mild"},
+								{Name: "severe", MinPoint: 5, MaxPoint: 8, Text: "severe"},
+							},
+						},
+					},
+					SeverityBands: []SDSeverityBand{
+						{Name: "mild", MinPoint: 0, MaxPoint: 3, Text: "mild"},
+						{Name: "moderate", MinPoint: 4, MaxPoint: 6, Text: "moderate"},
+						{Name: "severe", MinPoint: 7, MaxPoint: 8, Text: "severe"},
+					},
+				}
+				err := in.FullValidation()
+				assert.NoError(t, err)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -599,3 +962,242 @@ func TestSDTemplate_FullValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestSDTemplate_SDScoringRule(t *testing.T) {
+	t.Run("nil rule use the answer value as is", func(t *testing.T) {
+		var r *SDScoringRule
+		assert.Equal(t, r.Point(3, 4), 3)
+		minPoint, maxPoint := r.PointRange(4)
+		assert.Equal(t, minPoint, 1)
+		assert.Equal(t, maxPoint, 4)
+	})
+
+	t.Run("reverse", func(t *testing.T) {
+		r := &SDScoringRule{Reverse: true}
+		assert.Equal(t, r.Point(1, 4), 4)
+		assert.Equal(t, r.Point(4, 4), 1)
+		minPoint, maxPoint := r.PointRange(4)
+		assert.Equal(t, minPoint, 1)
+		assert.Equal(t, maxPoint, 4)
+	})
+
+	t.Run("reverse and weighted", func(t *testing.T) {
+		r := &SDScoringRule{Reverse: true, Weight: 3}
+		assert.Equal(t, r.Point(1, 4), 12)
+		minPoint, maxPoint := r.PointRange(4)
+		assert.Equal(t, minPoint, 3)
+		assert.Equal(t, maxPoint, 12)
+	})
+
+	t.Run("custom value map", func(t *testing.T) {
+		r := &SDScoringRule{ValueMap: map[int]int{1: 0, 2: 5, 3: 2}, Weight: 2}
+		assert.Equal(t, r.Point(2, 3), 10)
+		assert.Equal(t, r.Point(1, 3), 0)
+		minPoint, maxPoint := r.PointRange(3)
+		assert.Equal(t, minPoint, 0)
+		assert.Equal(t, maxPoint, 10)
+	})
+
+	t.Run("equal", func(t *testing.T) {
+		var r *SDScoringRule
+		assert.True(t, r.Equal(&SDScoringRule{}))
+		assert.True(t, r.Equal(&SDScoringRule{Weight: 1}))
+		assert.True(t, (&SDScoringRule{ValueMap: map[int]int{}}).Equal(nil))
+		assert.False(t, r.Equal(&SDScoringRule{Reverse: true}))
+		assert.False(t, (&SDScoringRule{ValueMap: map[int]int{1: 1}}).Equal(&SDScoringRule{ValueMap: map[int]int{1: 2}}))
+	})
+}
+
+func TestSDTemplate_Interpret(t *testing.T) {
+	tem := &SDTemplate{
+		IndicationThreshold:    5,
+		PositiveIndiationText:  "positive",
+		NegativeIndicationText: "negative",
+		SubGroupDetails: []SDTemplateSubGroupDetail{
+			{
+				Name:                   "with threshold",
+				QuestionCount:          2,
+				AnswerOptionCount:      4,
+				IndicationThreshold:    3,
+				PositiveIndicationText: "group positive",
+				NegativeIndicationText: "group negative",
+			},
+			{
+				Name:              "with bands",
+				QuestionCount:     2,
+				AnswerOptionCount: 4,
+				SeverityBands: []SDSeverityBand{
+					{Name: "mild", MinPoint: 2, MaxPoint: 4, Text: "mild text"},
+					{Name: "severe", MinPoint: 5, MaxPoint: 8, Text: "severe text"},
+				},
+			},
+			{
+				Name:              "without interpretation",
+				QuestionCount:     2,
+				AnswerOptionCount: 4,
+			},
+		},
+		SeverityBands: []SDSeverityBand{
+			{Name: "mild", MinPoint: 0, MaxPoint: 7, Text: "mild text"},
+			{Name: "severe", MinPoint: 8, MaxPoint: 24, Text: "severe text"},
+		},
+	}
+
+	res := &SDTestResult{
+		Result: []SDTestGroupResult{
+			{GroupName: "with threshold", Result: 3},
+			{GroupName: "with bands", Result: 2},
+			{GroupName: "without interpretation", Result: 2},
+			{GroupName: "unknown", Result: 8},
+		},
+		Total: 7,
+	}
+
+	tem.Interpret(res)
+
+	assert.Equal(t, res.Interpretation, &SDTestInterpretation{
+		IsPositive:     true,
+		IndicationText: "positive",
+		Severity:       "mild",
+		SeverityText:   "mild text",
+	})
+	assert.Equal(t, res.Result[0].Interpretation, &SDTestInterpretation{
+		IsPositive:     true,
+		IndicationText: "group positive",
+	})
+	assert.Equal(t, res.Result[1].Interpretation, &SDTestInterpretation{
+		Severity:     "mild",
+		SeverityText: "mild text",
+	})
+	assert.Nil(t, res.Result[2].Interpretation)
+	assert.Nil(t, res.Result[3].Interpretation)
+}
+
+func TestSDTemplate_TranslateResult(t *testing.T) {
+	tem := &SDTemplate{
+		IndicationThreshold:                5,
+		PositiveIndiationText:              "positif",
+		NegativeIndicationText:             "negatif",
+		PositiveIndicationTextTranslations: Translations{LocaleEnglish: "positive"},
+		SubGroupDetails: []SDTemplateSubGroupDetail{
+			{
+				Name:                   "bicara",
+				NameTranslations:       Translations{LocaleEnglish: "speech"},
+				IndicationThreshold:    3,
+				PositiveIndicationText: "grup positif",
+				NegativeIndicationText: "grup negatif",
+				NegativeIndicationTextTranslations: Translations{
+					LocaleEnglish: "group negative",
+				},
+				SeverityBands: []SDSeverityBand{
+					{
+						Name:             "ringan",
+						MinPoint:         0,
+						MaxPoint:         4,
+						Text:             "teks ringan",
+						NameTranslations: Translations{LocaleEnglish: "mild"},
+						TextTranslations: Translations{LocaleEnglish: "mild text"},
+					},
+				},
+			},
+			{
+				Name: "tanpa terjemahan",
+			},
+		},
+	}
+
+	res := SDTestResult{
+		Result: []SDTestGroupResult{
+			{GroupName: "bicara", Result: 2},
+			{GroupName: "tanpa terjemahan", Result: 3},
+			{GroupName: "unknown", Result: 1},
+		},
+		Total: 6,
+	}
+	tem.Interpret(&res)
+
+	t.Run("translated to the locale", func(t *testing.T) {
+		translated := tem.TranslateResult(res, LocaleEnglish)
+
+		assert.Equal(t, translated.Total, 6)
+		assert.Equal(t, translated.Interpretation, &SDTestInterpretation{
+			IsPositive:     true,
+			IndicationText: "positive",
+		})
+		assert.Equal(t, translated.Result[0].GroupName, "speech")
+		assert.Equal(t, translated.Result[0].Result, 2)
+		assert.Equal(t, translated.Result[0].Interpretation, &SDTestInterpretation{
+			IsPositive:     false,
+			IndicationText: "group negative",
+			Severity:       "mild",
+			SeverityText:   "mild text",
+		})
+		assert.Equal(t, translated.Result[1].GroupName, "tanpa terjemahan")
+		assert.Equal(t, translated.Result[2].GroupName, "unknown")
+
+		// the original result must not be changed
+		assert.Equal(t, res.Result[0].GroupName, "bicara")
+		assert.Equal(t, res.Result[0].Interpretation.Severity, "ringan")
+		assert.Equal(t, res.Interpretation.IndicationText, "positif")
+	})
+
+	t.Run("untranslated locale keep the original text", func(t *testing.T) {
+		translated := tem.TranslateResult(res, "fr")
+		assert.Equal(t, translated, res)
+	})
+}
+
+func TestSDTemplate_SDRetakePolicy_Check(t *testing.T) {
+	now := time.Now().UTC()
+
+	t.Run("no previous test", func(t *testing.T) {
+		policy := &SDRetakePolicy{MinIntervalMinutes: 60, MaxOpenTests: 1, MaxAttempts: 1, AttemptPeriodHours: 24}
+		assert.Nil(t, policy.Check(&SDRetakeStat{}, now))
+	})
+
+	t.Run("min interval already passed", func(t *testing.T) {
+		policy := &SDRetakePolicy{MinIntervalMinutes: 60}
+		assert.Nil(t, policy.Check(&SDRetakeStat{LastFinishedAt: null.TimeFrom(now.Add(-time.Hour * 2))}, now))
+	})
+
+	t.Run("min interval not yet passed", func(t *testing.T) {
+		policy := &SDRetakePolicy{MinIntervalMinutes: 60}
+		res := policy.Check(&SDRetakeStat{LastFinishedAt: null.TimeFrom(now.Add(-time.Minute * 20))}, now)
+		assert.Equal(t, res, &SDRetakeNotAllowedOutput{
+			Rule:          SDRetakeRuleMinInterval,
+			NextAllowedAt: now.Add(time.Minute * 40),
+		})
+	})
+
+	t.Run("too many open tests", func(t *testing.T) {
+		policy := &SDRetakePolicy{MaxOpenTests: 2}
+		assert.Nil(t, policy.Check(&SDRetakeStat{OpenTests: 1, EarliestOpenUntil: null.TimeFrom(now.Add(time.Hour))}, now))
+
+		res := policy.Check(&SDRetakeStat{OpenTests: 2, EarliestOpenUntil: null.TimeFrom(now.Add(time.Hour))}, now)
+		assert.Equal(t, res, &SDRetakeNotAllowedOutput{
+			Rule:          SDRetakeRuleMaxOpenTests,
+			NextAllowedAt: now.Add(time.Hour),
+		})
+	})
+
+	t.Run("too many attempts must return the rule blocking the longest", func(t *testing.T) {
+		policy := &SDRetakePolicy{MinIntervalMinutes: 10, MaxAttempts: 3, AttemptPeriodHours: 24}
+		res := policy.Check(&SDRetakeStat{
+			Attempts:        3,
+			OldestAttemptAt: null.TimeFrom(now.Add(-time.Hour * 20)),
+			LastFinishedAt:  null.TimeFrom(now.Add(-time.Minute)),
+		}, now)
+		assert.Equal(t, res, &SDRetakeNotAllowedOutput{
+			Rule:          SDRetakeRuleMaxAttempts,
+			NextAllowedAt: now.Add(time.Hour * 4),
+		})
+	})
+}
+
+func TestCloneSDTemplateInput_Validate(t *testing.T) {
+	assert.NoError(t, (&CloneSDTemplateInput{}).Validate())
+	assert.NoError(t, (&CloneSDTemplateInput{Name: "cloned"}).Validate())
+	assert.NoError(t, (&CloneSDTemplateInput{Name: strings.Repeat("a", 255)}).Validate())
+	assert.Error(t, (&CloneSDTemplateInput{Name: "   "}).Validate())
+	assert.Error(t, (&CloneSDTemplateInput{Name: strings.Repeat("a", 256)}).Validate())
+}
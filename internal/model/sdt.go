@@ -18,19 +18,50 @@ import (
 
 // SDTest the actual db table representation
 type SDTest struct {
-	ID         uuid.UUID
-	PackageID  uuid.UUID
-	UserID     uuid.NullUUID
-	Answer     SDTestAnswer
-	Result     SDTestResult
-	FinishedAt null.Time
-	OpenUntil  time.Time
-	SubmitKey  string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	DeletedAt  gorm.DeletedAt
+	ID          uuid.UUID
+	PackageID   uuid.UUID
+	UserID      uuid.NullUUID
+	Answer      SDTestAnswer
+	DraftAnswer SDTestAnswer
+	Result      SDTestResult
+	FinishedAt  null.Time
+	OpenUntil   time.Time
+	SubmitKey   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt
+
+	// TemplateID is the template of the package when this test was taken, because the package may be moved to other template
+	TemplateID uuid.UUID
+
+	// PackageVersion and TemplateVersion record the exact versions this test was taken on.
+	// Zero value means the test was created before versioning exists, and the current version must be used
+	PackageVersion  int
+	TemplateVersion int
+
+	// QuestionOrder is the order of the groups, questions and answer options rendered on this test
+	QuestionOrder SDTestOrder
+
+	// AssignmentID is the assignment this test was started from, if any
+	AssignmentID uuid.NullUUID
+
+	// ChildID is the child profile of the user this test was taken for, if any
+	ChildID uuid.NullUUID
+
+	Status SDTestStatus
 }
 
+// SDTestStatus is the status of the sd test
+type SDTestStatus string
+
+// list of sd test status. A test will be marked as expired by the scheduled sweeper
+// when the OpenUntil is already passed without being answered
+const (
+	SDTestStatusInProgress SDTestStatus = "in_progress"
+	SDTestStatusFinished   SDTestStatus = "finished"
+	SDTestStatusExpired    SDTestStatus = "expired"
+)
+
 // IsStillAcceptingAnswer will return error if the OpenUntil is pass now
 // or the FinishedAt is already set. Return nil otherwise
 func (sdt *SDTest) IsStillAcceptingAnswer() error {
@@ -46,66 +77,174 @@ func (sdt *SDTest) IsStillAcceptingAnswer() error {
 }
 
 // ToInitiateSDTestOutput will convert to sd test to api response
-func (sdt *SDTest) ToInitiateSDTestOutput(plainSubmitKey, packageName string, testQuestion map[string][]SDTestQuestion) *InitiateSDTestOutput {
+func (sdt *SDTest) ToInitiateSDTestOutput(plainSubmitKey, packageName string, testQuestion map[string][]SDTestQuestion, testQuestions []SDTestGroupQuestions) *InitiateSDTestOutput {
 	return &InitiateSDTestOutput{
-		ID:           sdt.ID,
-		PackageID:    sdt.PackageID,
-		PackageName:  packageName,
-		UserID:       sdt.UserID,
-		OpenUntil:    sdt.OpenUntil,
-		SubmitKey:    plainSubmitKey,
-		CreatedAt:    sdt.CreatedAt,
-		UpdatedAt:    sdt.UpdatedAt,
-		TestQuestion: testQuestion,
-		DeletedAt:    sdt.DeletedAt,
+		ID:             sdt.ID,
+		PackageID:      sdt.PackageID,
+		PackageName:    packageName,
+		PackageVersion: sdt.PackageVersion,
+		UserID:         sdt.UserID,
+		OpenUntil:      sdt.OpenUntil,
+		SubmitKey:      plainSubmitKey,
+		CreatedAt:      sdt.CreatedAt,
+		UpdatedAt:      sdt.UpdatedAt,
+		TestQuestion:   testQuestion,
+		TestQuestions:  testQuestions,
+		DeletedAt:      sdt.DeletedAt,
+		AssignmentID:   sdt.AssignmentID,
+		ChildID:        sdt.ChildID,
 	}
 }
 
 // ToSubmitTestOutput will convert to SubmitSDTestOutput
-func (sdt *SDTest) ToSubmitTestOutput(packageName, plainSubmitKey string, testQuestion map[string][]SDTestQuestion) *SubmitSDTestOutput {
+func (sdt *SDTest) ToSubmitTestOutput(packageName, plainSubmitKey string, testQuestion map[string][]SDTestQuestion, testQuestions []SDTestGroupQuestions) *SubmitSDTestOutput {
 	return &SubmitSDTestOutput{
-		ID:           sdt.ID,
-		PackageID:    sdt.PackageID,
-		PackageName:  packageName,
-		UserID:       sdt.UserID,
-		Answer:       sdt.Answer,
-		Result:       sdt.Result,
-		OpenUntil:    sdt.OpenUntil,
-		SubmitKey:    plainSubmitKey,
-		FinishedAt:   sdt.FinishedAt.Time.UTC(),
-		CreatedAt:    sdt.CreatedAt,
-		UpdatedAt:    sdt.UpdatedAt,
-		TestQuestion: testQuestion,
-		DeletedAt:    sdt.DeletedAt,
+		ID:             sdt.ID,
+		PackageID:      sdt.PackageID,
+		PackageName:    packageName,
+		PackageVersion: sdt.PackageVersion,
+		UserID:         sdt.UserID,
+		ChildID:        sdt.ChildID,
+		Answer:         sdt.Answer,
+		Result:         sdt.Result,
+		OpenUntil:      sdt.OpenUntil,
+		SubmitKey:      plainSubmitKey,
+		FinishedAt:     sdt.FinishedAt.Time.UTC(),
+		CreatedAt:      sdt.CreatedAt,
+		UpdatedAt:      sdt.UpdatedAt,
+		TestQuestion:   testQuestion,
+		TestQuestions:  testQuestions,
+		DeletedAt:      sdt.DeletedAt,
 	}
 }
 
 // ToViewHistoriesOutput convert SDTest to ViewHistoriesOutput
 func (sdt *SDTest) ToViewHistoriesOutput() ViewHistoriesOutput {
 	return ViewHistoriesOutput{
-		ID:         sdt.ID,
-		PackageID:  sdt.PackageID,
-		UserID:     sdt.UserID,
-		OpenUntil:  sdt.OpenUntil,
-		FinishedAt: sdt.FinishedAt.Time.UTC(),
-		CreatedAt:  sdt.CreatedAt,
-		UpdatedAt:  sdt.UpdatedAt,
-		DeletedAt:  sdt.DeletedAt,
-		Answer:     sdt.Answer,
-		Result:     sdt.Result,
+		ID:             sdt.ID,
+		PackageID:      sdt.PackageID,
+		PackageVersion: sdt.PackageVersion,
+		UserID:         sdt.UserID,
+		ChildID:        sdt.ChildID,
+		Status:         sdt.Status,
+		OpenUntil:      sdt.OpenUntil,
+		FinishedAt:     sdt.FinishedAt.Time.UTC(),
+		CreatedAt:      sdt.CreatedAt,
+		UpdatedAt:      sdt.UpdatedAt,
+		DeletedAt:      sdt.DeletedAt,
+		Answer:         sdt.Answer,
+		Result:         sdt.Result,
 	}
 
 }
 
+// ToSDTestDraftOutput convert SDTest to SDTestDraftOutput
+func (sdt *SDTest) ToSDTestDraftOutput(testQuestions []SDTestGroupQuestions) *SDTestDraftOutput {
+	return &SDTestDraftOutput{
+		TestID:        sdt.ID,
+		PackageID:     sdt.PackageID,
+		Answers:       sdt.DraftAnswer,
+		OpenUntil:     sdt.OpenUntil,
+		UpdatedAt:     sdt.UpdatedAt,
+		TestQuestions: testQuestions,
+	}
+}
+
 // TableName must be implemented to correctly safe the sd test result to test_result table
 func (sdt SDTest) TableName() string {
 	return "test_results"
 }
 
-// Answer singular answer per question
+// SDTestOrder hold the order of the groups, questions and answer options rendered on a test, referenced by the ids
+type SDTestOrder struct {
+	Groups []SDTestGroupOrder `json:"groups"`
+}
+
+// SDTestGroupOrder hold the order of the questions and answer options of a group
+type SDTestGroupOrder struct {
+	GroupID   uuid.UUID             `json:"groupID"`
+	Questions []SDTestQuestionOrder `json:"questions"`
+}
+
+// SDTestQuestionOrder hold the order of the answer options of a question
+type SDTestQuestionOrder struct {
+	QuestionID uuid.UUID   `json:"questionID"`
+	AnswerIDs  []uuid.UUID `json:"answerIDs"`
+}
+
+func (o SDTestOrder) groupIDs() []uuid.UUID {
+	ids := []uuid.UUID{}
+	for _, g := range o.Groups {
+		ids = append(ids, g.GroupID)
+	}
+
+	return ids
+}
+
+// group will return the order of the group, or the zero value if the group is not on the order
+func (o SDTestOrder) group(id uuid.UUID) SDTestGroupOrder {
+	for _, g := range o.Groups {
+		if g.GroupID == id {
+			return g
+		}
+	}
+
+	return SDTestGroupOrder{}
+}
+
+func (o SDTestGroupOrder) questionIDs() []uuid.UUID {
+	ids := []uuid.UUID{}
+	for _, q := range o.Questions {
+		ids = append(ids, q.QuestionID)
+	}
+
+	return ids
+}
+
+// question will return the order of the question, or the zero value if the question is not on the order
+func (o SDTestGroupOrder) question(id uuid.UUID) SDTestQuestionOrder {
+	for _, q := range o.Questions {
+		if q.QuestionID == id {
+			return q
+		}
+	}
+
+	return SDTestQuestionOrder{}
+}
+
+// Scan is a function to scan database value to SDTestOrder
+func (o *SDTestOrder) Scan(_ context.Context, _ *schema.Field, _ reflect.Value, dbValue interface{}) (err error) {
+	if dbValue == nil {
+		return
+	}
+
+	var bytes []byte
+	switch v := dbValue.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("failed to unmarshal JSONB value: %#v", dbValue)
+	}
+
+	return json.Unmarshal(bytes, o)
+}
+
+// Value is a function to convert SDTestOrder to json
+func (o SDTestOrder) Value(_ context.Context, _ *schema.Field, _ reflect.Value, fieldValue interface{}) (interface{}, error) {
+	return json.Marshal(fieldValue)
+}
+
+// Answer singular answer per question. The question and the answer can be submitted either using
+// the stable ids or the text written on the package, in any of the translated locales
 type Answer struct {
-	Question string `json:"question" validate:"required"`
-	Answer   string `json:"answer" validate:"required"`
+	Question string `json:"question" validate:"required_without=QuestionID"`
+	Answer   string `json:"answer" validate:"required_without=AnswerID"`
+
+	// QuestionID and AnswerID take precedence over the Question and Answer text when set
+	QuestionID uuid.UUID `json:"questionID"`
+	AnswerID   uuid.UUID `json:"answerID"`
 
 	options []SDAnswerAndValue `json:"-"`
 }
@@ -114,19 +253,97 @@ type Answer struct {
 // if the answer is not found on the options, will return error
 func (a *Answer) getAnswerValue() (int, error) {
 	for _, o := range a.options {
-		if o.Text == a.Answer {
+		if o.ID == a.AnswerID {
 			return o.Value, nil
 		}
 	}
 
-	return 0, fmt.Errorf("answer %s is not found on package", a.Answer)
+	return 0, fmt.Errorf("answer %s is not found on package", textOrID(a.Answer, a.AnswerID))
+}
+
+// ensureExistsOnPackage will ensure the question and the answer are exists on the given question list
+func (a *Answer) ensureExistsOnPackage(qnas []SDQuestionAndAnswers) error {
+	for _, qna := range qnas {
+		if qna.ID != a.QuestionID {
+			continue
+		}
+
+		a.options = qna.AnswersAndValue
+		_, err := a.getAnswerValue()
+		return err
+	}
+
+	return fmt.Errorf("question %s is not found on package", textOrID(a.Question, a.QuestionID))
+}
+
+// resolve will fill both the id and the original text of the question and the answer,
+// found either by the submitted id or the (translated) text. Unknown question or answer are left as is
+func (a *Answer) resolve(qnas []SDQuestionAndAnswers) {
+	qi := resolveIndex(len(qnas), a.QuestionID, a.Question, func(i int) (uuid.UUID, string, Translations) {
+		return qnas[i].ID, qnas[i].Question, qnas[i].QuestionTranslations
+	})
+	if qi < 0 {
+		return
+	}
+
+	qna := qnas[qi]
+	a.QuestionID = qna.ID
+	a.Question = qna.Question
+	ai := resolveIndex(len(qna.AnswersAndValue), a.AnswerID, a.Answer, func(i int) (uuid.UUID, string, Translations) {
+		return qna.AnswersAndValue[i].ID, qna.AnswersAndValue[i].Text, qna.AnswersAndValue[i].TextTranslations
+	})
+	if ai >= 0 {
+		a.AnswerID = qna.AnswersAndValue[ai].ID
+		a.Answer = qna.AnswersAndValue[ai].Text
+	}
+}
+
+// resolveIndex will return the index of the item with the id. When the id is not set, will return the index of the item
+// which original text or one of its translations equal to text, with the original text always take precedence over the translations.
+// Return -1 if none match
+func resolveIndex(n int, id uuid.UUID, text string, item func(i int) (uuid.UUID, string, Translations)) int {
+	if id != uuid.Nil {
+		for i := 0; i < n; i++ {
+			if itemID, _, _ := item(i); itemID == id {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	for i := 0; i < n; i++ {
+		if _, original, _ := item(i); original == text {
+			return i
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if _, _, translations := item(i); translations.contains(text) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// textOrID will return the text if set, or the id otherwise. Used to describe the unknown submitted item
+func textOrID(text string, id uuid.UUID) string {
+	if text != "" {
+		return text
+	}
+
+	return id.String()
 }
 
-// TestAnswer will hold per group test answer
+// TestAnswer will hold per group test answer. The group can be submitted either using the stable id or the name
 type TestAnswer struct {
-	GroupName string   `json:"groupName"  validate:"required"`
+	GroupName string   `json:"groupName"  validate:"required_without=GroupID"`
 	Answers   []Answer `json:"answers"  validate:"required,dive"`
 
+	// GroupID take precedence over the GroupName when set
+	GroupID uuid.UUID `json:"groupID"`
+
 	sdqna []SDQuestionAndAnswers `json:"-"`
 }
 
@@ -143,7 +360,7 @@ func (ta *TestAnswer) gradeGroupResult() (SDTestGroupResult, error) {
 	for _, a := range ta.Answers {
 		found := false
 		for _, qna := range ta.sdqna {
-			if qna.Question == a.Question {
+			if qna.ID == a.QuestionID {
 				found = true
 				a.options = qna.AnswersAndValue
 				val, err := a.getAnswerValue()
@@ -151,13 +368,13 @@ func (ta *TestAnswer) gradeGroupResult() (SDTestGroupResult, error) {
 					return SDTestGroupResult{}, err
 				}
 
-				result += val
+				result += qna.Point(val)
 				break
 			}
 		}
 
 		if !found {
-			return SDTestGroupResult{}, fmt.Errorf("question %s is not found on package", a.Question)
+			return SDTestGroupResult{}, fmt.Errorf("question %s is not found on package", textOrID(a.Question, a.QuestionID))
 		}
 	}
 
@@ -172,7 +389,7 @@ func (ta *TestAnswer) ensureAllQuestionOnPackageAnswered() error {
 	for _, qna := range ta.sdqna {
 		found := false
 		for _, a := range ta.Answers {
-			if qna.Question == a.Question {
+			if qna.ID == a.QuestionID {
 				found = true
 				break
 			}
@@ -197,6 +414,8 @@ func (sdta *SDTestAnswer) DoGradingProcess(p *SDPackage) ([]SDTestGroupResult, e
 		return nil, err
 	}
 
+	sdta.Canonicalize(p)
+
 	if err := sdta.ensureAllSubGroupArePresent(p); err != nil {
 		return nil, err
 	}
@@ -206,7 +425,7 @@ func (sdta *SDTestAnswer) DoGradingProcess(p *SDPackage) ([]SDTestGroupResult, e
 	for _, ta := range sdta.TestAnswers {
 		found := false
 		for _, g := range p.SubGroupDetails {
-			if ta.GroupName == g.Name {
+			if ta.GroupID == g.ID {
 				found = true
 				ta.sdqna = g.QuestionAndAnswerLists
 				break
@@ -214,7 +433,7 @@ func (sdta *SDTestAnswer) DoGradingProcess(p *SDPackage) ([]SDTestGroupResult, e
 		}
 
 		if !found {
-			return nil, fmt.Errorf("unknown group: %s is not required on package", ta.GroupName)
+			return nil, fmt.Errorf("unknown group: %s is not required on package", textOrID(ta.GroupName, ta.GroupID))
 		}
 	}
 
@@ -232,12 +451,126 @@ func (sdta *SDTestAnswer) DoGradingProcess(p *SDPackage) ([]SDTestGroupResult, e
 	return groupResults, nil
 }
 
+// ValidateDraft will validate a partial answer against the package. Unlike DoGradingProcess,
+// not all the groups and questions are required to be present, but every present group, question
+// and answer must exist on the package.
+func (sdta *SDTestAnswer) ValidateDraft(p *SDPackage) error {
+	if err := validator.Struct(sdta); err != nil {
+		return err
+	}
+
+	sdta.Canonicalize(p)
+
+	for _, ta := range sdta.TestAnswers {
+		found := false
+		for _, g := range p.SubGroupDetails {
+			if ta.GroupID != g.ID {
+				continue
+			}
+
+			found = true
+			for _, a := range ta.Answers {
+				if err := a.ensureExistsOnPackage(g.QuestionAndAnswerLists); err != nil {
+					return err
+				}
+			}
+			break
+		}
+
+		if !found {
+			return fmt.Errorf("unknown group: %s is not required on package", textOrID(ta.GroupName, ta.GroupID))
+		}
+	}
+
+	return nil
+}
+
+// Canonicalize will resolve the id and the original text written on the package of every submitted group,
+// question and answer, so the answer can be graded by the ids regardless the locale used to render the test
+// or whether the client submit the ids or the texts. Unknown group, question or answer are left as is,
+// and will be rejected by the grading process
+func (sdta *SDTestAnswer) Canonicalize(p *SDPackage) {
+	p.EnsureIDs()
+
+	for _, ta := range sdta.TestAnswers {
+		if ta == nil {
+			continue
+		}
+
+		idx := resolveIndex(len(p.SubGroupDetails), ta.GroupID, ta.GroupName, func(i int) (uuid.UUID, string, Translations) {
+			return p.SubGroupDetails[i].ID, p.SubGroupDetails[i].Name, p.SubGroupDetails[i].NameTranslations
+		})
+		if idx < 0 {
+			continue
+		}
+
+		g := p.SubGroupDetails[idx]
+		ta.GroupID = g.ID
+		ta.GroupName = g.Name
+		for i := range ta.Answers {
+			ta.Answers[i].resolve(g.QuestionAndAnswerLists)
+		}
+	}
+}
+
+// Merge will merge the other answer into this answer and return the merged result as a new SDTestAnswer.
+// Answers from other will take precedence when the same group and question are present on both.
+// Both answers are expected to be canonicalized, thus the group and question can be matched by the original text.
+func (sdta *SDTestAnswer) Merge(other *SDTestAnswer) *SDTestAnswer {
+	merged := &SDTestAnswer{}
+	groupIndex := make(map[string]int)
+
+	appendAnswers := func(source *SDTestAnswer) {
+		if source == nil {
+			return
+		}
+
+		for _, ta := range source.TestAnswers {
+			if ta == nil {
+				continue
+			}
+
+			idx, ok := groupIndex[ta.GroupName]
+			if !ok {
+				groupIndex[ta.GroupName] = len(merged.TestAnswers)
+				merged.TestAnswers = append(merged.TestAnswers, &TestAnswer{
+					GroupName: ta.GroupName,
+					GroupID:   ta.GroupID,
+					Answers:   append([]Answer{}, ta.Answers...),
+				})
+				continue
+			}
+
+			group := merged.TestAnswers[idx]
+			for _, a := range ta.Answers {
+				replaced := false
+				for i := range group.Answers {
+					if group.Answers[i].Question == a.Question {
+						group.Answers[i] = a
+						replaced = true
+						break
+					}
+				}
+
+				if !replaced {
+					group.Answers = append(group.Answers, a)
+				}
+			}
+		}
+	}
+
+	appendAnswers(sdta)
+	appendAnswers(other)
+
+	return merged
+}
+
 func (sdta *SDTestAnswer) ensureAllSubGroupArePresent(p *SDPackage) error {
 	// ensure that all the sub group details are present
 	for _, g := range p.SubGroupDetails {
 		found := false
 		for _, ta := range sdta.TestAnswers {
-			if ta.GroupName == g.Name {
+			if ta.GroupID == g.ID {
 				found = true
 				break
 			}
@@ -279,16 +612,42 @@ func (sdta SDTestAnswer) Value(_ context.Context, _ *schema.Field, _ reflect.Val
 	return json.Marshal(fieldValue)
 }
 
+// SDTestInterpretation hold the interpretation of a point, computed from the template
+// indication threshold and severity bands when the test is submitted
+type SDTestInterpretation struct {
+	IsPositive     bool   `json:"isPositive"`
+	IndicationText string `json:"indicationText,omitempty"`
+	Severity       string `json:"severity,omitempty"`
+	SeverityText   string `json:"severityText,omitempty"`
+}
+
 // SDTestGroupResult sd test result per group
 type SDTestGroupResult struct {
-	GroupName string `json:"groupName"`
-	Result    int    `json:"result"`
+	GroupName      string                `json:"groupName"`
+	Result         int                   `json:"result"`
+	Interpretation *SDTestInterpretation `json:"interpretation,omitempty"`
 }
 
 // SDTestResult will hold the total result of sd test
 type SDTestResult struct {
-	Result []SDTestGroupResult `json:"result"`
-	Total  int                 `json:"total"`
+	Result         []SDTestGroupResult   `json:"result"`
+	Total          int                   `json:"total"`
+	Interpretation *SDTestInterpretation `json:"interpretation,omitempty"`
+}
+
+// findGroup return the result of the group by its name. Return nil if not found, or the result itself is nil
+func (sdtr *SDTestResult) findGroup(name string) *SDTestGroupResult {
+	if sdtr == nil {
+		return nil
+	}
+
+	for i := range sdtr.Result {
+		if sdtr.Result[i].GroupName == name {
+			return &sdtr.Result[i]
+		}
+	}
+
+	return nil
 }
 
 // Scan is a function to scan database value to CreateSDTemplateInput
@@ -324,6 +683,9 @@ type SubmitSDTestInput struct {
 	TestID    uuid.UUID     `json:"testID" validate:"required"`
 	SubmitKey string        `json:"submitKey" validate:"required"`
 	Answers   *SDTestAnswer `json:"answers" validate:"required"`
+
+	// SendResultEmail when set, the result image will be emailed to the test owner after submitted
+	SendResultEmail bool `json:"sendResultEmail"`
 }
 
 // Validate validate struct
@@ -331,21 +693,72 @@ func (sdtti *SubmitSDTestInput) Validate() error {
 	return validator.Struct(sdtti)
 }
 
+// ClaimSDTestInput input to claim an anonymous sd test into the requester account
+type ClaimSDTestInput struct {
+	TestID    uuid.UUID `json:"testID" validate:"required"`
+	SubmitKey string    `json:"submitKey" validate:"required"`
+}
+
+// Validate validate struct
+func (csdti *ClaimSDTestInput) Validate() error {
+	return validator.Struct(csdti)
+}
+
+// SaveSDTestDraftInput input to save partial sd test answer as draft
+type SaveSDTestDraftInput struct {
+	TestID    uuid.UUID     `json:"testID" validate:"required"`
+	SubmitKey string        `json:"submitKey" validate:"required"`
+	Answers   *SDTestAnswer `json:"answers" validate:"required"`
+}
+
+// Validate validate struct
+func (sdtdi *SaveSDTestDraftInput) Validate() error {
+	return validator.Struct(sdtdi)
+}
+
+// ViewSDTestDraftInput input to view saved sd test draft
+type ViewSDTestDraftInput struct {
+	TestID    uuid.UUID `json:"testID" validate:"required"`
+	SubmitKey string    `json:"submitKey" validate:"required"`
+}
+
+// Validate validate struct
+func (vsdtdi *ViewSDTestDraftInput) Validate() error {
+	return validator.Struct(vsdtdi)
+}
+
+// SDTestDraftOutput output from saving or viewing sd test draft
+type SDTestDraftOutput struct {
+	TestID    uuid.UUID    `json:"testID"`
+	PackageID uuid.UUID    `json:"packageID"`
+	Answers   SDTestAnswer `json:"answers"`
+	OpenUntil time.Time    `json:"openUntil"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+
+	// TestQuestions is the test questions rendered in the same order as when the test is initiated
+	TestQuestions []SDTestGroupQuestions `json:"testQuestions"`
+}
+
 // SubmitSDTestOutput output from submit sd test
 type SubmitSDTestOutput struct {
-	ID           uuid.UUID                   `json:"id"`
-	PackageID    uuid.UUID                   `json:"packageID"`
-	PackageName  string                      `json:"packageName"`
-	UserID       uuid.NullUUID               `json:"userID,omitempty"`
-	Answer       SDTestAnswer                `json:"answer"`
-	Result       SDTestResult                `json:"result"`
-	OpenUntil    time.Time                   `json:"openUntil"`
-	FinishedAt   time.Time                   `json:"finishedAt"`
-	SubmitKey    string                      `json:"submitKey"`
-	CreatedAt    time.Time                   `json:"createdAt"`
-	UpdatedAt    time.Time                   `json:"updatedAt"`
-	TestQuestion map[string][]SDTestQuestion `json:"testQuestion"`
-	DeletedAt    gorm.DeletedAt              `json:"deletedAt,omitempty"`
+	ID             uuid.UUID                   `json:"id"`
+	PackageID      uuid.UUID                   `json:"packageID"`
+	PackageName    string                      `json:"packageName"`
+	PackageVersion int                         `json:"packageVersion"`
+	UserID         uuid.NullUUID               `json:"userID,omitempty"`
+	ChildID        uuid.NullUUID               `json:"childID,omitempty"`
+	Answer         SDTestAnswer                `json:"answer"`
+	Result         SDTestResult                `json:"result"`
+	OpenUntil      time.Time                   `json:"openUntil"`
+	FinishedAt     time.Time                   `json:"finishedAt"`
+	SubmitKey      string                      `json:"submitKey"`
+	CreatedAt      time.Time                   `json:"createdAt"`
+	UpdatedAt      time.Time                   `json:"updatedAt"`
+	TestQuestion   map[string][]SDTestQuestion `json:"testQuestion"`
+	DeletedAt      gorm.DeletedAt              `json:"deletedAt,omitempty"`
+
+	// TestQuestions hold the same questions as TestQuestion, rendered in the order stored on the test
+	TestQuestions []SDTestGroupQuestions `json:"testQuestions"`
 }
 
 // InitiateSDTestInput input when initiating the sd test
@@ -353,25 +766,42 @@ type InitiateSDTestInput struct {
 	UserID          uuid.NullUUID `json:"userID,omitempty"`
 	PackageID       uuid.NullUUID `json:"packageID"`
 	DurationMinutes time.Duration `json:"durationMinutes"`
+
+	// AssignmentID when set, the test is started from the assignment and the package is decided by the assignment
+	AssignmentID uuid.NullUUID `json:"assignmentID,omitempty"`
+
+	// ChildID when set, the test is taken for the child profile. Only the owner of the child profile can set this
+	ChildID uuid.NullUUID `json:"childID,omitempty"`
+
+	// TemplateID when set, the chosen package always belongs to this template
+	TemplateID uuid.NullUUID `json:"templateID,omitempty"`
 }
 
 // InitiateSDTestOutput output when initiating the sd test
 type InitiateSDTestOutput struct {
-	ID           uuid.UUID                   `json:"id"`
-	PackageID    uuid.UUID                   `json:"packageID"`
-	PackageName  string                      `json:"packageName"`
-	UserID       uuid.NullUUID               `json:"userID,omitempty"`
-	OpenUntil    time.Time                   `json:"openUntil"`
-	SubmitKey    string                      `json:"submitKey"`
-	CreatedAt    time.Time                   `json:"createdAt"`
-	UpdatedAt    time.Time                   `json:"updatedAt"`
-	TestQuestion map[string][]SDTestQuestion `json:"testQuestion"`
-	DeletedAt    gorm.DeletedAt              `json:"deletedAt,omitempty"`
+	ID             uuid.UUID                   `json:"id"`
+	PackageID      uuid.UUID                   `json:"packageID"`
+	PackageName    string                      `json:"packageName"`
+	PackageVersion int                         `json:"packageVersion"`
+	UserID         uuid.NullUUID               `json:"userID,omitempty"`
+	OpenUntil      time.Time                   `json:"openUntil"`
+	SubmitKey      string                      `json:"submitKey"`
+	CreatedAt      time.Time                   `json:"createdAt"`
+	UpdatedAt      time.Time                   `json:"updatedAt"`
+	TestQuestion   map[string][]SDTestQuestion `json:"testQuestion"`
+	DeletedAt      gorm.DeletedAt              `json:"deletedAt,omitempty"`
+
+	// TestQuestions hold the same questions as TestQuestion, rendered in the order stored on the test
+	TestQuestions []SDTestGroupQuestions `json:"testQuestions"`
+
+	AssignmentID uuid.NullUUID `json:"assignmentID,omitempty"`
+	ChildID      uuid.NullUUID `json:"childID,omitempty"`
 }
 
 // ViewHistoriesInput input
 type ViewHistoriesInput struct {
 	UserID            uuid.NullUUID `query:"userID"`
+	ChildID           uuid.NullUUID `query:"childID"`
 	PackageID         uuid.NullUUID `query:"packageID"`
 	CreatedAfter      null.Time     `query:"createdAfter"`
 	IncludeUnfinished bool          `query:"includeUnfinished"`
@@ -398,6 +828,11 @@ func (vhi *ViewHistoriesInput) ToWhereQuery() ([]interface{}, []interface{}) {
 		conds = append(conds, vhi.UserID)
 	}
 
+	if vhi.ChildID.Valid {
+		whereQuery = append(whereQuery, "child_id = ?")
+		conds = append(conds, vhi.ChildID)
+	}
+
 	if vhi.PackageID.Valid {
 		whereQuery = append(whereQuery, "package_id = ?")
 		conds = append(conds, vhi.PackageID)
@@ -413,16 +848,19 @@ func (vhi *ViewHistoriesInput) ToWhereQuery() ([]interface{}, []interface{}) {
 
 // ViewHistoriesOutput output from submit sd test
 type ViewHistoriesOutput struct {
-	ID         uuid.UUID      `json:"id"`
-	PackageID  uuid.UUID      `json:"packageID"`
-	UserID     uuid.NullUUID  `json:"userID,omitempty"`
-	Answer     SDTestAnswer   `json:"answer"`
-	Result     SDTestResult   `json:"result"`
-	OpenUntil  time.Time      `json:"openUntil"`
-	FinishedAt time.Time      `json:"finishedAt"`
-	CreatedAt  time.Time      `json:"createdAt"`
-	UpdatedAt  time.Time      `json:"updatedAt"`
-	DeletedAt  gorm.DeletedAt `json:"deletedAt,omitempty"`
+	ID             uuid.UUID      `json:"id"`
+	PackageID      uuid.UUID      `json:"packageID"`
+	PackageVersion int            `json:"packageVersion"`
+	UserID         uuid.NullUUID  `json:"userID,omitempty"`
+	ChildID        uuid.NullUUID  `json:"childID,omitempty"`
+	Status         SDTestStatus   `json:"status"`
+	Answer         SDTestAnswer   `json:"answer"`
+	Result         SDTestResult   `json:"result"`
+	OpenUntil      time.Time      `json:"openUntil"`
+	FinishedAt     time.Time      `json:"finishedAt"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+	DeletedAt      gorm.DeletedAt `json:"deletedAt,omitempty"`
 }
 
 // StatsComponent will define what will be the statistic component
@@ -432,6 +870,119 @@ type StatsComponent struct {
 	ResultPoint    int       `json:"resultPoint"`
 	PackageName    string    `json:"packageName"`
 	TestFinishedAt time.Time `json:"testFinishedAt"`
+
+	// Interpretation will be nil for tests submitted before the interpretation is stored
+	Interpretation *SDTestInterpretation `json:"interpretation,omitempty"`
+
+	// Change is the total point difference against the previous test of the same template, nil for the first test
+	Change *int `json:"change,omitempty"`
+
+	// SubGroups is the point of each sub group on this test
+	SubGroups []SDTestGroupResult `json:"subGroups,omitempty"`
+
+	// PreviousResult is the result of the previous test of the same template, used to compute the changes
+	PreviousResult *SDTestResult `json:"-"`
+}
+
+// SDTestStatisticInput input to get the sd test statistic of a user
+type SDTestStatisticInput struct {
+	UserID uuid.UUID `param:"user_id"`
+
+	// ChildID when set, only the tests taken for the child profile are counted. Otherwise only the tests
+	// taken by the user itself are counted, thus the tests of different children are never compared
+	ChildID uuid.NullUUID `query:"childID"`
+
+	// TemplateID when set, only the tests taken on the template are counted
+	TemplateID uuid.NullUUID `query:"templateID"`
+
+	// From and To are optional, only the tests finished within the range are counted
+	From time.Time `query:"from"`
+	To   time.Time `query:"to"`
+
+	// Limit and Offset paginate the finished tests, not the templates
+	Limit  int `query:"limit"`
+	Offset int `query:"offset"`
+
+	// Unlimited when true, every finished test is returned ignoring the pagination. Never bound from the request
+	Unlimited bool `json:"-"`
+}
+
+// ToWhereQuery convert input to the additional filter on test_results table aliased as tr.
+// If limit is unset / set over 100, will be set to 100. If offset is unset / set under 0, will be set to 0.
+func (i *SDTestStatisticInput) ToWhereQuery() ([]string, []interface{}) {
+	if i.Limit <= 0 || i.Limit > 100 {
+		i.Limit = 100
+	}
+
+	if i.Offset < 0 {
+		i.Offset = 0
+	}
+
+	var whereQuery []string
+	var conds []interface{}
+
+	if i.ChildID.Valid {
+		whereQuery = append(whereQuery, "tr.child_id = ?")
+		conds = append(conds, i.ChildID)
+	} else {
+		whereQuery = append(whereQuery, "tr.child_id IS NULL")
+	}
+
+	if i.TemplateID.Valid {
+		whereQuery = append(whereQuery, "tr.template_id = ?")
+		conds = append(conds, i.TemplateID)
+	}
+
+	if !i.From.IsZero() {
+		whereQuery = append(whereQuery, "tr.finished_at >= ?")
+		conds = append(conds, i.From)
+	}
+
+	if !i.To.IsZero() {
+		whereQuery = append(whereQuery, "tr.finished_at <= ?")
+		conds = append(conds, i.To)
+	}
+
+	return whereQuery, conds
+}
+
+// SDTrendDirection the direction of the points over time. On ATEC, lower point means improvement
+type SDTrendDirection string
+
+// list of trend directions
+const (
+	SDTrendIncreasing SDTrendDirection = "increasing"
+	SDTrendDecreasing SDTrendDirection = "decreasing"
+	SDTrendStable     SDTrendDirection = "stable"
+)
+
+// NewSDTrendDirection return the trend direction from the first to the last point
+func NewSDTrendDirection(first, last int) SDTrendDirection {
+	switch {
+	case last > first:
+		return SDTrendIncreasing
+	case last < first:
+		return SDTrendDecreasing
+	default:
+		return SDTrendStable
+	}
+}
+
+// SDSubGroupTrendPoint the point of a sub group on a test
+type SDSubGroupTrendPoint struct {
+	TestResultID   uuid.UUID `json:"testResultID"`
+	TestFinishedAt time.Time `json:"testFinishedAt"`
+	ResultPoint    int       `json:"resultPoint"`
+
+	// Change is the point difference against the previous test, nil when the previous test doesn't have this sub group
+	Change *int `json:"change,omitempty"`
+}
+
+// SDSubGroupTrend the time series of a sub group points
+type SDSubGroupTrend struct {
+	GroupName string                 `json:"groupName"`
+	Trend     SDTrendDirection       `json:"trend"`
+	Series    []SDSubGroupTrendPoint `json:"series"`
 }
 
 // SDTestStatistic will hold the structure of sd test statistic
@@ -442,15 +993,160 @@ type SDTestStatistic struct {
 	PositiveIndiationText  string           `json:"positiveIndicationText"`
 	NegativeIndicationText string           `json:"negativeIndicationText"`
 	Stats                  []StatsComponent `json:"stats"`
+
+	// Trend is the direction of the total point within the returned stats
+	Trend SDTrendDirection `json:"trend"`
+
+	// SubGroupTrends is the time series of each sub group, ordered by the first appearance
+	SubGroupTrends []SDSubGroupTrend `json:"subGroupTrends"`
+}
+
+// ComputeTrends will fill the changes and the trends from the Stats. Stats must be ordered by the finished time ascending
+func (s *SDTestStatistic) ComputeTrends() {
+	s.Trend = SDTrendStable
+	s.SubGroupTrends = []SDSubGroupTrend{}
+	if len(s.Stats) == 0 {
+		return
+	}
+
+	trendIndex := make(map[string]int)
+	for i := range s.Stats {
+		stat := &s.Stats[i]
+		if stat.PreviousResult != nil {
+			stat.Change = intDiff(stat.ResultPoint, stat.PreviousResult.Total)
+		}
+
+		for _, group := range stat.SubGroups {
+			point := SDSubGroupTrendPoint{
+				TestResultID:   stat.TestResultID,
+				TestFinishedAt: stat.TestFinishedAt,
+				ResultPoint:    group.Result,
+			}
+
+			if prev := stat.PreviousResult.findGroup(group.GroupName); prev != nil {
+				point.Change = intDiff(group.Result, prev.Result)
+			}
+
+			idx, ok := trendIndex[group.GroupName]
+			if !ok {
+				idx = len(s.SubGroupTrends)
+				trendIndex[group.GroupName] = idx
+				s.SubGroupTrends = append(s.SubGroupTrends, SDSubGroupTrend{GroupName: group.GroupName})
+			}
+
+			s.SubGroupTrends[idx].Series = append(s.SubGroupTrends[idx].Series, point)
+		}
+	}
+
+	s.Trend = NewSDTrendDirection(s.Stats[0].ResultPoint, s.Stats[len(s.Stats)-1].ResultPoint)
+	for i, trend := range s.SubGroupTrends {
+		s.SubGroupTrends[i].Trend = NewSDTrendDirection(trend.Series[0].ResultPoint, trend.Series[len(trend.Series)-1].ResultPoint)
+	}
+}
+
+func intDiff(current, previous int) *int {
+	diff := current - previous
+	return &diff
+}
+
+// DownloadSDTestResultInput input to download the sd test result. Empty Format is treated as SDResultFormatJPEG.
+// Width and DPI are optional, limited by the MinSDResultImageWidth until MaxSDResultImageWidth and
+// MinSDResultImageDPI until MaxSDResultImageDPI
+type DownloadSDTestResultInput struct {
+	ID     uuid.UUID
+	Format SDResultFormat
+	Width  int `validate:"omitempty,min=320,max=4096"`
+	DPI    int `validate:"omitempty,min=72,max=600"`
+}
+
+// Validate validate the input
+func (i *DownloadSDTestResultInput) Validate() error {
+	return validator.Struct(i)
+}
+
+// SDTestProgressChartInput input to draw the progress chart of a user on a sd template.
+// Only the raster formats are supported, empty Format is treated as SDResultFormatPNG.
+// Width and DPI are limited the same as DownloadSDTestResultInput
+type SDTestProgressChartInput struct {
+	UserID     uuid.UUID `param:"user_id"`
+	TemplateID uuid.UUID `param:"template_id"`
+
+	// ChildID, From and To are the same filter as SDTestStatisticInput
+	ChildID uuid.NullUUID `query:"childID"`
+	From    time.Time     `query:"from"`
+	To      time.Time     `query:"to"`
+
+	Format SDResultFormat `validate:"omitempty,oneof=png jpeg"`
+	Width  int            `query:"width" validate:"omitempty,min=320,max=4096"`
+	DPI    int            `query:"dpi" validate:"omitempty,min=72,max=600"`
+}
+
+// Validate validate the input
+func (i *SDTestProgressChartInput) Validate() error {
+	return validator.Struct(i)
+}
+
+// ToStatisticInput convert the input to get the statistic drawn on the chart
+func (i *SDTestProgressChartInput) ToStatisticInput() *SDTestStatisticInput {
+	return &SDTestStatisticInput{
+		UserID:     i.UserID,
+		ChildID:    i.ChildID,
+		TemplateID: uuid.NullUUID{UUID: i.TemplateID, Valid: true},
+		From:       i.From,
+		To:         i.To,
+		Unlimited:  true,
+	}
 }
 
 // SDTestUsecase usecase
 type SDTestUsecase interface {
-	Initiate(ctx context.Context, input *InitiateSDTestInput) (*InitiateSDTestOutput, *common.Error)
+	Initiate(ctx context.Context, input *InitiateSDTestInput) (*InitiateSDTestOutput, *SDRetakeNotAllowedOutput, *common.Error)
 	Submit(ctx context.Context, input *SubmitSDTestInput) (*SubmitSDTestOutput, *common.Error)
+	SaveDraft(ctx context.Context, input *SaveSDTestDraftInput) (*SDTestDraftOutput, *common.Error)
+	ViewDraft(ctx context.Context, input *ViewSDTestDraftInput) (*SDTestDraftOutput, *common.Error)
 	Histories(ctx context.Context, input *ViewHistoriesInput) ([]ViewHistoriesOutput, *common.Error)
-	Statistic(ctx context.Context, userID uuid.UUID) ([]SDTestStatistic, *common.Error)
-	DownloadResult(ctx context.Context, tid uuid.UUID) (*ImageResult, *common.Error)
+	Statistic(ctx context.Context, input *SDTestStatisticInput) ([]SDTestStatistic, *common.Error)
+	DownloadResult(ctx context.Context, input *DownloadSDTestResultInput) (*ImageResult, *common.Error)
+	ProgressChart(ctx context.Context, input *SDTestProgressChartInput) (*ImageResult, *common.Error)
+	VerifyResult(ctx context.Context, input *VerifySDTestResultInput) (*VerifySDTestResultOutput, *common.Error)
+	ResultPublicKey(ctx context.Context) (*SDResultPublicKeyOutput, *common.Error)
+	DeliverResult(ctx context.Context, testID uuid.UUID) *common.Error
+	CreateShare(ctx context.Context, input *CreateSDResultShareInput) (*GeneratedSDResultShare, *common.Error)
+	FindShares(ctx context.Context, testID uuid.UUID) ([]*GeneratedSDResultShare, *common.Error)
+	RevokeShare(ctx context.Context, input *RevokeSDResultShareInput) (*GeneratedSDResultShare, *common.Error)
+	ViewSharedResultImage(ctx context.Context, input *ViewSharedSDTestResultInput) (*ImageResult, *common.Error)
+	ViewSharedResult(ctx context.Context, input *ViewSharedSDTestResultInput) (*SharedSDTestResultOutput, *common.Error)
+	Claim(ctx context.Context, input *ClaimSDTestInput) (*ViewHistoriesOutput, *common.Error)
+}
+
+// FindLatestSDTestInput input to find the latest initiated sd test. Zero value fields are not used as filter
+type FindLatestSDTestInput struct {
+	UserID     uuid.NullUUID
+	PackageIDs []uuid.UUID
+}
+
+// SDRetakeStatInput input to count the sd tests of a user on a template
+type SDRetakeStatInput struct {
+	UserID        uuid.UUID
+	ChildID       uuid.NullUUID
+	TemplateID    uuid.UUID
+	AttemptsSince time.Time
+	Now           time.Time
+}
+
+// SDRetakeStat summary of the sd tests of a user on a template, used to enforce SDRetakePolicy
+type SDRetakeStat struct {
+	OpenTests         int       `gorm:"column:open_tests"`
+	EarliestOpenUntil null.Time `gorm:"column:earliest_open_until"`
+	Attempts          int       `gorm:"column:attempts"`
+	OldestAttemptAt   null.Time `gorm:"column:oldest_attempt_at"`
+	LastFinishedAt    null.Time `gorm:"column:last_finished_at"`
+}
+
+// SDRetakeNotAllowedOutput returned when the sd test can't be initiated because of the template retake policy
+type SDRetakeNotAllowedOutput struct {
+	Rule          SDRetakeRule `json:"rule"`
+	NextAllowedAt time.Time    `json:"nextAllowedAt"`
 }
 
 // SDTestRepository repository
@@ -459,5 +1155,15 @@ type SDTestRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*SDTest, error)
 	Update(ctx context.Context, test *SDTest, tx *gorm.DB) error
 	Search(ctx context.Context, input *ViewHistoriesInput) ([]*SDTest, error)
-	Statistic(ctx context.Context, userID uuid.UUID) ([]SDTestStatistic, error)
+	Statistic(ctx context.Context, input *SDTestStatisticInput) ([]SDTestStatistic, error)
+	MarkExpired(ctx context.Context, now time.Time) (int64, error)
+	CountPackageUsage(ctx context.Context, userID uuid.UUID, packageIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	FindLatest(ctx context.Context, input *FindLatestSDTestInput) (*SDTest, error)
+	FindRetakeStat(ctx context.Context, input *SDRetakeStatInput, tx *gorm.DB) (*SDRetakeStat, error)
+
+	// Claim set the owner of the test only when the test is still not owned by anyone
+	Claim(ctx context.Context, id, userID uuid.UUID, now time.Time) error
+
+	// SaveDraft write only the draft answer of the test when the test is still not finished
+	SaveDraft(ctx context.Context, id uuid.UUID, draft SDTestAnswer, now time.Time) error
 }
@@ -0,0 +1,374 @@
+package model
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/google/uuid"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// the progress chart is drawn smaller than the result image text, thus the axis labels
+// still fit when a lot of tests are drawn
+const (
+	progressChartTextSize    = 7
+	progressChartTitleSize   = 11
+	progressChartHeightRatio = 0.6
+	progressChartYTicks      = 5
+)
+
+// progressChartDateLayout is the layout of the test date written below each bar
+const progressChartDateLayout = "02/01/06"
+
+var (
+	progressChartTotalColor     = color.RGBA{R: 0x90, G: 0xca, B: 0xf9, A: 0xff}
+	progressChartThresholdColor = color.RGBA{R: 0xd3, G: 0x2f, B: 0x2f, A: 0xff}
+	progressChartAxisColor      = color.RGBA{R: 0x42, G: 0x42, B: 0x42, A: 0xff}
+	progressChartGridColor      = color.RGBA{R: 0xe0, G: 0xe0, B: 0xe0, A: 0xff}
+
+	// progressChartPalette is used to draw the sub group lines, reused when the sub groups are more than the palette
+	progressChartPalette = []color.RGBA{
+		{R: 0x1e, G: 0x88, B: 0xe5, A: 0xff},
+		{R: 0x43, G: 0xa0, B: 0x47, A: 0xff},
+		{R: 0xfb, G: 0x8c, B: 0x00, A: 0xff},
+		{R: 0x8e, G: 0x24, B: 0xaa, A: 0xff},
+		{R: 0x00, G: 0x89, B: 0x7b, A: 0xff},
+		{R: 0x6d, G: 0x4c, B: 0x41, A: 0xff},
+	}
+)
+
+// SDProgressChartGenerator interface
+type SDProgressChartGenerator interface {
+	GenerateJPEG() *ImageResult
+	GeneratePNG() *ImageResult
+}
+
+// SDProgressChartOpts options to draw the progress chart of a sd template statistic.
+// The total point of each test is drawn as bars, each sub group as lines and the indication threshold as dashed line
+type SDProgressChartOpts struct {
+	Statistic SDTestStatistic
+
+	// Labels is optional, default to the DefaultLocale labels
+	Labels SDResultLabels
+
+	// Width and DPI are optional, default to DefaultSDResultImageWidth and DefaultSDResultImageDPI
+	Width int
+	DPI   int
+
+	rgba       *image.RGBA
+	textFace   font.Face
+	titleFace  font.Face
+	textHeight int
+	lineHeight int
+	margin     int
+	stroke     int
+	plot       image.Rectangle
+	maxPoint   int
+	legends    []progressChartLegend
+	legendRows [][]progressChartLegend
+}
+
+type progressChartLegend struct {
+	text   string
+	color  color.RGBA
+	dashed bool
+}
+
+// NewProgressChartGenerator factory to make the progress chart generator. The chart is drawn once on creation
+func NewProgressChartGenerator(f *truetype.Font, opts *SDProgressChartOpts) SDProgressChartGenerator {
+	dpi := float64(DefaultSDResultImageDPI)
+	if opts.DPI > 0 {
+		dpi = float64(opts.DPI)
+	}
+
+	width := DefaultSDResultImageWidth
+	if opts.Width > 0 {
+		width = opts.Width
+	}
+
+	labels := opts.Labels
+	if labels == (SDResultLabels{}) {
+		labels = GetSDResultLabels(DefaultLocale)
+	}
+
+	chart := &SDProgressChartOpts{
+		Statistic: opts.Statistic,
+		Labels:    labels,
+		Width:     width,
+		DPI:       int(dpi),
+		textFace: truetype.NewFace(f, &truetype.Options{
+			Size:    progressChartTextSize,
+			DPI:     dpi,
+			Hinting: font.HintingNone,
+		}),
+		titleFace: truetype.NewFace(f, &truetype.Options{
+			Size:    progressChartTitleSize,
+			DPI:     dpi,
+			Hinting: font.HintingFull,
+		}),
+		textHeight: int(math.Ceil(progressChartTextSize * dpi / 72)),
+		margin:     width / 30,
+		stroke:     width / 360,
+	}
+
+	chart.lineHeight = chart.textHeight * 3 / 2
+	if chart.stroke < 2 {
+		chart.stroke = 2
+	}
+
+	chart.countMaxPoint()
+	chart.layoutLegends()
+	chart.layoutPlot()
+	chart.drawChart()
+
+	return chart
+}
+
+// GenerateJPEG will generate jpeg image of the progress chart
+func (o *SDProgressChartOpts) GenerateJPEG() *ImageResult {
+	return encodeJPEG(o.rgba)
+}
+
+// GeneratePNG will generate png image of the progress chart
+func (o *SDProgressChartOpts) GeneratePNG() *ImageResult {
+	return encodePNG(o.rgba)
+}
+
+// countMaxPoint will find the highest point drawn, rounded up to be divisible by the y axis ticks
+func (o *SDProgressChartOpts) countMaxPoint() {
+	maxPoint := o.Statistic.IndicationThreshold
+	for _, stat := range o.Statistic.Stats {
+		if stat.ResultPoint > maxPoint {
+			maxPoint = stat.ResultPoint
+		}
+
+		for _, group := range stat.SubGroups {
+			if group.Result > maxPoint {
+				maxPoint = group.Result
+			}
+		}
+	}
+
+	step := int(math.Ceil(float64(maxPoint) / progressChartYTicks))
+	if step < 1 {
+		step = 1
+	}
+
+	o.maxPoint = step * progressChartYTicks
+}
+
+// layoutLegends will split the legends into rows fitting the image width
+func (o *SDProgressChartOpts) layoutLegends() {
+	o.legends = []progressChartLegend{{text: o.Labels.Total, color: progressChartTotalColor}}
+	for i, trend := range o.Statistic.SubGroupTrends {
+		o.legends = append(o.legends, progressChartLegend{text: trend.GroupName, color: progressChartPalette[i%len(progressChartPalette)]})
+	}
+
+	if o.Statistic.IndicationThreshold > 0 {
+		o.legends = append(o.legends, progressChartLegend{
+			text:   fmt.Sprintf("%s (%d)", o.Labels.Threshold, o.Statistic.IndicationThreshold),
+			color:  progressChartThresholdColor,
+			dashed: true,
+		})
+	}
+
+	row := []progressChartLegend{}
+	x := o.margin
+	for _, legend := range o.legends {
+		w := o.legendWidth(legend)
+		if len(row) > 0 && x+w > o.Width-o.margin {
+			o.legendRows = append(o.legendRows, row)
+			row = []progressChartLegend{}
+			x = o.margin
+		}
+
+		row = append(row, legend)
+		x += w
+	}
+
+	o.legendRows = append(o.legendRows, row)
+}
+
+func (o *SDProgressChartOpts) legendWidth(legend progressChartLegend) int {
+	return o.textHeight*2 + font.MeasureString(o.textFace, legend.text).Ceil() + o.textHeight
+}
+
+// layoutPlot will count the image height and the area where the bars and lines are drawn
+func (o *SDProgressChartOpts) layoutPlot() {
+	height := int(float64(o.Width)*progressChartHeightRatio) + len(o.legendRows)*o.lineHeight
+	titleHeight := int(math.Ceil(progressChartTitleSize * float64(o.DPI) / 72))
+	yLabelWidth := font.MeasureString(o.textFace, fmt.Sprintf("%d", o.maxPoint)).Ceil()
+
+	top := o.margin + titleHeight + o.lineHeight
+	bottom := height - o.margin - len(o.legendRows)*o.lineHeight - o.lineHeight*2
+	if minBottom := top + o.lineHeight*progressChartYTicks; bottom < minBottom {
+		height += minBottom - bottom
+		bottom = minBottom
+	}
+
+	o.plot = image.Rect(o.margin+yLabelWidth+o.textHeight/2, top, o.Width-o.margin, bottom)
+	o.rgba = image.NewRGBA(image.Rect(0, 0, o.Width, height))
+	draw.Draw(o.rgba, o.rgba.Bounds(), image.White, image.Point{}, draw.Src)
+}
+
+func (o *SDProgressChartOpts) drawChart() {
+	o.drawText(o.titleFace, o.Statistic.TemplateName, (o.Width-font.MeasureString(o.titleFace, o.Statistic.TemplateName).Ceil())/2, o.margin+int(math.Ceil(progressChartTitleSize*float64(o.DPI)/72)), progressChartAxisColor)
+	o.drawYAxis()
+
+	slot := 0
+	if len(o.Statistic.Stats) > 0 {
+		slot = o.plot.Dx() / len(o.Statistic.Stats)
+	}
+
+	o.drawBars(slot)
+	o.drawXLabels(slot)
+	o.drawSubGroupLines(slot)
+
+	if o.Statistic.IndicationThreshold > 0 {
+		y := o.pointY(o.Statistic.IndicationThreshold)
+		drawChartLine(o.rgba, o.plot.Min.X, y, o.plot.Max.X, y, o.stroke, o.stroke*4, progressChartThresholdColor)
+	}
+
+	o.drawLegends()
+}
+
+// drawYAxis will draw the horizontal grid lines and their points, followed by the axis lines
+func (o *SDProgressChartOpts) drawYAxis() {
+	step := o.maxPoint / progressChartYTicks
+	for i := 0; i <= progressChartYTicks; i++ {
+		point := step * i
+		y := o.pointY(point)
+		drawChartLine(o.rgba, o.plot.Min.X, y, o.plot.Max.X, y, 1, 0, progressChartGridColor)
+
+		label := fmt.Sprintf("%d", point)
+		x := o.plot.Min.X - o.textHeight/2 - font.MeasureString(o.textFace, label).Ceil()
+		o.drawText(o.textFace, label, x, y+o.textHeight/2, progressChartAxisColor)
+	}
+
+	drawChartLine(o.rgba, o.plot.Min.X, o.plot.Min.Y, o.plot.Min.X, o.plot.Max.Y, o.stroke, 0, progressChartAxisColor)
+	drawChartLine(o.rgba, o.plot.Min.X, o.plot.Max.Y, o.plot.Max.X, o.plot.Max.Y, o.stroke, 0, progressChartAxisColor)
+}
+
+func (o *SDProgressChartOpts) drawBars(slot int) {
+	barWidth := slot / 2
+	if barWidth < 1 {
+		barWidth = 1
+	}
+
+	for i, stat := range o.Statistic.Stats {
+		x := o.slotX(slot, i)
+		bar := image.Rect(x-barWidth/2, o.pointY(stat.ResultPoint), x-barWidth/2+barWidth, o.plot.Max.Y)
+		draw.Draw(o.rgba, bar, image.NewUniform(progressChartTotalColor), image.Point{}, draw.Src)
+	}
+}
+
+// drawXLabels will write the test date below the bars. Some dates are skipped when they can't fit
+func (o *SDProgressChartOpts) drawXLabels(slot int) {
+	if slot == 0 {
+		return
+	}
+
+	labelWidth := font.MeasureString(o.textFace, progressChartDateLayout).Ceil() + o.textHeight
+	every := (labelWidth + slot - 1) / slot
+	for i, stat := range o.Statistic.Stats {
+		if i%every != 0 {
+			continue
+		}
+
+		label := stat.TestFinishedAt.Format(progressChartDateLayout)
+		x := o.slotX(slot, i) - font.MeasureString(o.textFace, label).Ceil()/2
+		o.drawText(o.textFace, label, x, o.plot.Max.Y+o.lineHeight, progressChartAxisColor)
+	}
+}
+
+func (o *SDProgressChartOpts) drawSubGroupLines(slot int) {
+	index := make(map[uuid.UUID]int)
+	for i, stat := range o.Statistic.Stats {
+		index[stat.TestResultID] = i
+	}
+
+	for i, trend := range o.Statistic.SubGroupTrends {
+		c := progressChartPalette[i%len(progressChartPalette)]
+		prevX, prevY := -1, -1
+		for _, point := range trend.Series {
+			idx, ok := index[point.TestResultID]
+			if !ok {
+				continue
+			}
+
+			x, y := o.slotX(slot, idx), o.pointY(point.ResultPoint)
+			if prevX >= 0 {
+				drawChartLine(o.rgba, prevX, prevY, x, y, o.stroke, 0, c)
+			}
+
+			marker := o.stroke * 2
+			draw.Draw(o.rgba, image.Rect(x-marker, y-marker, x+marker, y+marker), image.NewUniform(c), image.Point{}, draw.Src)
+			prevX, prevY = x, y
+		}
+	}
+}
+
+func (o *SDProgressChartOpts) drawLegends() {
+	y := o.plot.Max.Y + o.lineHeight*2
+	for _, row := range o.legendRows {
+		y += o.lineHeight
+		x := o.margin
+		for _, legend := range row {
+			midY := y - o.textHeight/3
+			if legend.dashed {
+				drawChartLine(o.rgba, x, midY, x+o.textHeight*3/2, midY, o.stroke, o.stroke*2, legend.color)
+			} else {
+				draw.Draw(o.rgba, image.Rect(x, y-o.textHeight*2/3, x+o.textHeight*3/2, y), image.NewUniform(legend.color), image.Point{}, draw.Src)
+			}
+
+			o.drawText(o.textFace, legend.text, x+o.textHeight*2, y, progressChartAxisColor)
+			x += o.legendWidth(legend)
+		}
+	}
+}
+
+func (o *SDProgressChartOpts) drawText(face font.Face, text string, x, y int, c color.Color) {
+	drawer := &font.Drawer{
+		Dst:  o.rgba,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+
+	drawer.DrawString(text)
+}
+
+// slotX return the horizontal center of the i-th test
+func (o *SDProgressChartOpts) slotX(slot, i int) int {
+	return o.plot.Min.X + slot*i + slot/2
+}
+
+// pointY return the vertical position of the point on the plot
+func (o *SDProgressChartOpts) pointY(point int) int {
+	return o.plot.Max.Y - point*o.plot.Dy()/o.maxPoint
+}
+
+// drawChartLine will draw a line having the thickness. When dash is set, the line is drawn
+// alternating between dash long segment and dash long gap
+func drawChartLine(img *image.RGBA, x0, y0, x1, y1, thickness, dash int, c color.Color) {
+	dx, dy := x1-x0, y1-y0
+	steps := int(math.Max(math.Abs(float64(dx)), math.Abs(float64(dy))))
+	src := image.NewUniform(c)
+	for i := 0; i <= steps; i++ {
+		if dash > 0 && (i/dash)%2 == 1 {
+			continue
+		}
+
+		x, y := x0, y0
+		if steps > 0 {
+			x, y = x0+dx*i/steps, y0+dy*i/steps
+		}
+
+		draw.Draw(img, image.Rect(x-thickness/2, y-thickness/2, x-thickness/2+thickness, y-thickness/2+thickness), src, image.Point{}, draw.Src)
+	}
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"bytes"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSDProgressChartOpts_Generate(t *testing.T) {
+	fontBytes, err := os.ReadFile("../../assets/font.ttf")
+	assert.NoError(t, err)
+	f, err := truetype.Parse(fontBytes)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+	stat := SDTestStatistic{
+		TemplateName:        "template",
+		IndicationThreshold: 20,
+	}
+	for i := 0; i < 30; i++ {
+		stat.Stats = append(stat.Stats, StatsComponent{
+			TestResultID:   uuid.New(),
+			ResultPoint:    10 + i,
+			TestFinishedAt: now.Add(time.Hour * time.Duration(24*i)),
+			SubGroups: []SDTestGroupResult{
+				{GroupName: "group a", Result: i},
+				{GroupName: "group b", Result: 10},
+			},
+		})
+	}
+	stat.ComputeTrends()
+
+	t.Run("default size", func(t *testing.T) {
+		gen := NewProgressChartGenerator(f, &SDProgressChartOpts{Statistic: stat})
+
+		res := gen.GeneratePNG()
+		assert.Equal(t, res.ContentType, "image/png")
+		cfg, err := png.DecodeConfig(bytes.NewReader(res.Buffer.Bytes()))
+		assert.NoError(t, err)
+		assert.Equal(t, cfg.Width, DefaultSDResultImageWidth)
+		assert.Greater(t, cfg.Height, int(DefaultSDResultImageWidth*progressChartHeightRatio))
+
+		res = gen.GenerateJPEG()
+		assert.Equal(t, res.ContentType, "image/jpeg")
+		_, err = jpeg.DecodeConfig(bytes.NewReader(res.Buffer.Bytes()))
+		assert.NoError(t, err)
+	})
+
+	t.Run("smallest size and highest dpi", func(t *testing.T) {
+		res := NewProgressChartGenerator(f, &SDProgressChartOpts{
+			Statistic: stat,
+			Width:     MinSDResultImageWidth,
+			DPI:       MaxSDResultImageDPI,
+		}).GeneratePNG()
+
+		cfg, err := png.DecodeConfig(bytes.NewReader(res.Buffer.Bytes()))
+		assert.NoError(t, err)
+		assert.Equal(t, cfg.Width, MinSDResultImageWidth)
+	})
+
+	t.Run("no test and no threshold", func(t *testing.T) {
+		res := NewProgressChartGenerator(f, &SDProgressChartOpts{Statistic: SDTestStatistic{TemplateName: "empty"}}).GeneratePNG()
+
+		_, err := png.DecodeConfig(bytes.NewReader(res.Buffer.Bytes()))
+		assert.NoError(t, err)
+	})
+}
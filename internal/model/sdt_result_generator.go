@@ -6,35 +6,237 @@ import (
 	"image"
 	"image/draw"
 	"image/jpeg"
+	"image/png"
 	"math"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/freetype/truetype"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/skip2/go-qrcode"
 	"golang.org/x/image/font"
 	"golang.org/x/image/math/fixed"
 )
 
-// maxImageWidth are made to limit the maximum image output width
-const maxImageWitdh = 1080
+// list of the result image size limits. The default is used when the size is not set
+const (
+	DefaultSDResultImageWidth = 1080
+	MinSDResultImageWidth     = 320
+	MaxSDResultImageWidth     = 4096
+	DefaultSDResultImageDPI   = 208
+	MinSDResultImageDPI       = 72
+	MaxSDResultImageDPI       = 600
+)
 
-// optimumTextLength was calculated by counting how much chars can be written on maxImageWidth
-// without overflowing. The original is 70, but made to 65 to give the room between text and image
-// border
+// optimumTextLength was calculated by counting how much chars can be written on DefaultSDResultImageWidth
+// at DefaultSDResultImageDPI without overflowing. The original is 70, but made to 65 to give the room between
+// text and image border. It is scaled proportionally for the other image width and dpi
 const optimumTextLength = 65
 
+// minTextLength is the minimum number of chars written on a line, regardless the image width and dpi
+const minTextLength = 20
+
+// minQRModuleSize is the minimum pixel size of each verification qr code module, thus the qr code stays scannable
+// on the small images. The qr code is drawn about a third of the image width
+const minQRModuleSize = 2
+
 // ImageResult will be the result of image generation.
-// Needed to be able to return the content-type if more than 1 image type
-// generation is supported.
+// Carry the content-type of the generated format, see SDResultFormat
 type ImageResult struct {
 	ContentType string
 	Buffer      bytes.Buffer
 }
 
+// SDResultFormat define the file format of the generated sd test result
+type SDResultFormat string
+
+// list of supported result formats
+const (
+	SDResultFormatJPEG SDResultFormat = "jpeg"
+	SDResultFormatPNG  SDResultFormat = "png"
+	SDResultFormatSVG  SDResultFormat = "svg"
+	SDResultFormatPDF  SDResultFormat = "pdf"
+)
+
+var sdResultFormatContentTypes = map[SDResultFormat]string{
+	SDResultFormatJPEG: "image/jpeg",
+	SDResultFormatPNG:  "image/png",
+	SDResultFormatSVG:  "image/svg+xml",
+	SDResultFormatPDF:  "application/pdf",
+}
+
+// ParseSDResultFormat will parse the format name or its MIME type. Empty format will be treated as the fallback
+func ParseSDResultFormat(format string, fallback SDResultFormat) (SDResultFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "":
+		return fallback, nil
+	case "jpeg", "jpg", "image/jpeg":
+		return SDResultFormatJPEG, nil
+	case "png", "image/png":
+		return SDResultFormatPNG, nil
+	case "svg", "image/svg+xml":
+		return SDResultFormatSVG, nil
+	case "pdf", "application/pdf":
+		return SDResultFormatPDF, nil
+	default:
+		return "", fmt.Errorf("unsupported result format: %s", format)
+	}
+}
+
+// NegotiateSDResultFormat will choose the supported format having the highest quality on the Accept header.
+// The fallback is kept unless a supported format is explicitly listed with higher quality than the fallback,
+// including the quality given to the fallback by the wildcards. Empty or unsupported Accept header will be treated as the fallback
+func NegotiateSDResultFormat(accept string, fallback SDResultFormat) SDResultFormat {
+	mediaRanges := parseAcceptHeader(accept)
+
+	chosen := fallback
+	chosenQuality := acceptedQuality(mediaRanges, fallback.ContentType())
+	for _, mr := range mediaRanges {
+		format, err := ParseSDResultFormat(mr.mediaType, fallback)
+		if err != nil || format == chosen {
+			continue
+		}
+
+		if mr.quality > chosenQuality {
+			chosen = format
+			chosenQuality = mr.quality
+		}
+	}
+
+	return chosen
+}
+
+type acceptMediaRange struct {
+	mediaType string
+	quality   float64
+}
+
+// parseAcceptHeader will parse the media ranges listed on the Accept header along with their quality.
+// Missing or invalid quality is treated as 1
+func parseAcceptHeader(accept string) []acceptMediaRange {
+	var mediaRanges []acceptMediaRange
+	for _, v := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(v, ";")
+		mr := acceptMediaRange{mediaType: strings.ToLower(strings.TrimSpace(mediaType)), quality: 1}
+		if mr.mediaType == "" {
+			continue
+		}
+
+		for _, param := range strings.Split(params, ";") {
+			key, value, _ := strings.Cut(param, "=")
+			if strings.TrimSpace(key) != "q" {
+				continue
+			}
+
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && q >= 0 && q <= 1 {
+				mr.quality = q
+			}
+		}
+
+		mediaRanges = append(mediaRanges, mr)
+	}
+
+	return mediaRanges
+}
+
+// acceptedQuality return the quality of the content type given by the most specific matching media range,
+// or 0 when no media range matches
+func acceptedQuality(mediaRanges []acceptMediaRange, contentType string) float64 {
+	mainType, _, _ := strings.Cut(contentType, "/")
+
+	quality, specificity := 0.0, 0
+	for _, mr := range mediaRanges {
+		matched := 0
+		switch mr.mediaType {
+		case contentType:
+			matched = 3
+		case mainType + "/*":
+			matched = 2
+		case "*/*":
+			matched = 1
+		}
+
+		if matched > specificity {
+			quality, specificity = mr.quality, matched
+		}
+	}
+
+	return quality
+}
+
+// ContentType return the MIME type of the format. Unknown format is treated as SDResultFormatJPEG
+func (f SDResultFormat) ContentType() string {
+	if contentType, ok := sdResultFormatContentTypes[f]; ok {
+		return contentType
+	}
+
+	return sdResultFormatContentTypes[SDResultFormatJPEG]
+}
+
+// Generate will generate the result in the format using the generator. Unknown format is treated as SDResultFormatJPEG
+func (f SDResultFormat) Generate(g SDResultImageGenerator) *ImageResult {
+	switch f {
+	case SDResultFormatPNG:
+		return g.GeneratePNG()
+	case SDResultFormatSVG:
+		return g.GenerateSVG()
+	case SDResultFormatPDF:
+		return g.GeneratePDF()
+	default:
+		return g.GenerateJPEG()
+	}
+}
+
+// SDResultLabels hold the labels written on the sd test result image
+type SDResultLabels struct {
+	Total       string
+	Indication  string
+	Severity    string
+	TestID      string
+	PackageName string
+	TestDate    string
+	Threshold   string
+}
+
+var sdResultLabels = map[Locale]SDResultLabels{
+	LocaleIndonesian: {
+		Total:       "Total",
+		Indication:  "Indikasi",
+		Severity:    "Tingkat Keparahan",
+		TestID:      "Test ID",
+		PackageName: "Paket",
+		TestDate:    "Tanggal Tes",
+		Threshold:   "Ambang Indikasi",
+	},
+	LocaleEnglish: {
+		Total:       "Total",
+		Indication:  "Indication",
+		Severity:    "Severity",
+		TestID:      "Test ID",
+		PackageName: "Package",
+		TestDate:    "Test Date",
+		Threshold:   "Indication Threshold",
+	},
+}
+
+// GetSDResultLabels return the result image labels written in the locale.
+// Will fallback to the DefaultLocale labels when the locale is not supported
+func GetSDResultLabels(locale Locale) SDResultLabels {
+	if labels, ok := sdResultLabels[locale]; ok {
+		return labels
+	}
+
+	return sdResultLabels[DefaultLocale]
+}
+
 // SDResultImageGenerator interface
 type SDResultImageGenerator interface {
 	GenerateJPEG() *ImageResult
+	GeneratePNG() *ImageResult
+	GenerateSVG() *ImageResult
+	GeneratePDF() *ImageResult
 }
 
 // SDResultImageGenerationOpts options to generate image for sd test result
@@ -44,10 +246,28 @@ type SDResultImageGenerationOpts struct {
 	TestID         uuid.UUID
 	IndicationText string
 
+	// Labels is optional, default to the DefaultLocale labels
+	Labels SDResultLabels
+
+	// PackageName and TestDate are optional, only written on the pdf document
+	PackageName string
+	TestDate    time.Time
+
+	// Width and DPI are optional, default to DefaultSDResultImageWidth and DefaultSDResultImageDPI
+	Width int
+	DPI   int
+
+	// VerificationContent is optional, written as qr code below the result. See SDResultVerificationContent
+	VerificationContent string
+
 	rgba         *image.RGBA
+	qrBitmap     [][]bool
 	ttp          []string
 	width        int
 	height       int
+	maxWidth     int
+	textLength   int
+	drawn        bool
 	titleDrawer  *font.Drawer
 	textDrawer   *font.Drawer
 	sampleDrawer *font.Drawer
@@ -60,7 +280,21 @@ type SDResultImageGenerationOpts struct {
 
 // NewResultGenerator factory to make image generator
 func NewResultGenerator(f *truetype.Font, opts *SDResultImageGenerationOpts) SDResultImageGenerator {
-	dpi := float64(208)
+	dpi := float64(DefaultSDResultImageDPI)
+	if opts.DPI > 0 {
+		dpi = float64(opts.DPI)
+	}
+
+	maxWidth := DefaultSDResultImageWidth
+	if opts.Width > 0 {
+		maxWidth = opts.Width
+	}
+
+	textLength := int(float64(optimumTextLength) * float64(maxWidth) / DefaultSDResultImageWidth * DefaultSDResultImageDPI / dpi)
+	if textLength < minTextLength {
+		textLength = minTextLength
+	}
+
 	size := float64(12)
 	spacing := float64(1.5)
 	titleSize := float64(18)
@@ -81,17 +315,39 @@ func NewResultGenerator(f *truetype.Font, opts *SDResultImageGenerationOpts) SDR
 		}),
 	}
 
+	labels := opts.Labels
+	if labels == (SDResultLabels{}) {
+		labels = GetSDResultLabels(DefaultLocale)
+	}
+
 	genOpts := &SDResultImageGenerationOpts{
-		Title:          opts.Title,
-		Result:         opts.Result,
-		TestID:         opts.TestID,
-		IndicationText: opts.IndicationText,
-		sampleDrawer:   initialTextDrawer,
-		spacing:        spacing,
-		font:           f,
-		textSize:       size,
-		titleSize:      titleSize,
-		dpi:            dpi,
+		Title:               opts.Title,
+		Result:              opts.Result,
+		TestID:              opts.TestID,
+		IndicationText:      opts.IndicationText,
+		Labels:              labels,
+		PackageName:         opts.PackageName,
+		TestDate:            opts.TestDate,
+		Width:               maxWidth,
+		DPI:                 int(dpi),
+		VerificationContent: opts.VerificationContent,
+		maxWidth:            maxWidth,
+		textLength:          textLength,
+		sampleDrawer:        initialTextDrawer,
+		spacing:             spacing,
+		font:                f,
+		textSize:            size,
+		titleSize:           titleSize,
+		dpi:                 dpi,
+	}
+
+	if opts.VerificationContent != "" {
+		qr, err := qrcode.New(opts.VerificationContent, qrcode.Medium)
+		if err != nil {
+			logrus.WithError(err).Error("failed to encode the verification qr code")
+		} else {
+			genOpts.qrBitmap = qr.Bitmap()
+		}
 	}
 
 	genOpts.generateTTP()
@@ -109,6 +365,50 @@ func NewResultGenerator(f *truetype.Font, opts *SDResultImageGenerationOpts) SDR
 
 // GenerateJPEG will generate jpeg image for the test result
 func (o *SDResultImageGenerationOpts) GenerateJPEG() *ImageResult {
+	o.drawResult()
+	return encodeJPEG(o.rgba)
+}
+
+// GeneratePNG will generate png image for the test result. Unlike jpeg, the text is kept sharp
+func (o *SDResultImageGenerationOpts) GeneratePNG() *ImageResult {
+	o.drawResult()
+	return encodePNG(o.rgba)
+}
+
+// encodeJPEG will encode the drawn image as jpeg
+func encodeJPEG(img image.Image) *ImageResult {
+	var imgBuf bytes.Buffer
+	if err := jpeg.Encode(&imgBuf, img, nil); err != nil {
+		logrus.WithError(err).Error("failed to encode image")
+	}
+
+	return &ImageResult{
+		ContentType: SDResultFormatJPEG.ContentType(),
+		Buffer:      imgBuf,
+	}
+}
+
+// encodePNG will encode the drawn image as png
+func encodePNG(img image.Image) *ImageResult {
+	var imgBuf bytes.Buffer
+	if err := png.Encode(&imgBuf, img); err != nil {
+		logrus.WithError(err).Error("failed to encode image")
+	}
+
+	return &ImageResult{
+		ContentType: SDResultFormatPNG.ContentType(),
+		Buffer:      imgBuf,
+	}
+}
+
+// drawResult will draw the title and the result text on the image. Only drawn once,
+// thus the image can be encoded to more than one format
+func (o *SDResultImageGenerationOpts) drawResult() {
+	if o.drawn {
+		return
+	}
+
+	o.drawn = true
 	y := 10 + int(math.Ceil(o.textSize*o.dpi/72))
 	dy := int(math.Ceil(o.textSize * o.spacing * o.dpi / 72))
 	o.textDrawer.Dot = fixed.Point26_6{
@@ -135,24 +435,59 @@ func (o *SDResultImageGenerationOpts) GenerateJPEG() *ImageResult {
 		y += dy
 	}
 
-	var imgBuf bytes.Buffer
-	if err := jpeg.Encode(&imgBuf, o.rgba, nil); err != nil {
-		logrus.WithError(err).Error("failed to encode image")
+	if len(o.qrBitmap) > 0 {
+		drawQRCode(o.rgba, o.qrBitmap, image.Pt((o.width-o.qrSize())/2, o.height-o.qrSize()), o.qrModuleSize())
 	}
+}
 
-	return &ImageResult{
-		ContentType: "image/jpeg",
-		Buffer:      imgBuf,
+// qrModuleSize return the pixel size of each verification qr code module
+func (o *SDResultImageGenerationOpts) qrModuleSize() int {
+	if len(o.qrBitmap) == 0 {
+		return 0
+	}
+
+	size := o.width / 3 / len(o.qrBitmap)
+	if size < minQRModuleSize {
+		return minQRModuleSize
+	}
+
+	return size
+}
+
+// qrSize return the pixel size of the verification qr code, including its quiet zone
+func (o *SDResultImageGenerationOpts) qrSize() int {
+	return o.qrModuleSize() * len(o.qrBitmap)
+}
+
+// drawQRCode will draw the dark modules of the qr code bitmap starting from the origin
+func drawQRCode(dst draw.Image, bitmap [][]bool, origin image.Point, moduleSize int) {
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+
+			module := image.Rect(x*moduleSize, y*moduleSize, (x+1)*moduleSize, (y+1)*moduleSize).Add(origin)
+			draw.Draw(dst, module, image.Black, image.Point{}, draw.Src)
+		}
 	}
 }
 
 func (o *SDResultImageGenerationOpts) generateTTP() {
 	for _, r := range o.Result.Result {
-		o.appendTTP(fmt.Sprintf("%s: %d", r.GroupName, r.Result))
+		if r.Interpretation != nil && r.Interpretation.Severity != "" {
+			o.appendTTP(fmt.Sprintf("%s: %d (%s)", r.GroupName, r.Result, r.Interpretation.Severity))
+		} else {
+			o.appendTTP(fmt.Sprintf("%s: %d", r.GroupName, r.Result))
+		}
 	}
-	o.appendTTP(fmt.Sprintf("Total: %d", o.Result.Total))
-	o.appendTTP(fmt.Sprintf("Indikasi: %s", o.IndicationText))
-	o.appendTTP(fmt.Sprintf("Test ID: %s", o.TestID))
+	o.appendTTP(fmt.Sprintf("%s: %d", o.Labels.Total, o.Result.Total))
+	o.appendTTP(fmt.Sprintf("%s: %s", o.Labels.Indication, o.IndicationText))
+	if o.Result.Interpretation != nil && o.Result.Interpretation.Severity != "" {
+		o.appendTTP(fmt.Sprintf("%s: %s", o.Labels.Severity, o.Result.Interpretation.Severity))
+		o.appendTTP(o.Result.Interpretation.SeverityText)
+	}
+	o.appendTTP(fmt.Sprintf("%s: %s", o.Labels.TestID, o.TestID))
 }
 
 func (o *SDResultImageGenerationOpts) appendTTP(s string) {
@@ -169,26 +504,30 @@ func (o *SDResultImageGenerationOpts) countOptimumImageWidth(initialTextDrawer,
 		}
 	}
 
-	if maxWidth >= maxImageWitdh {
-		o.width = maxImageWitdh
+	if maxWidth >= fixed.Int26_6(o.maxWidth) {
+		o.width = o.maxWidth
 	} else {
 		o.width = maxWidth.Ceil() + 5*maxWidth.Ceil()/100
 	}
+
+	if qrSize := o.qrSize(); qrSize > o.width {
+		o.width = qrSize
+	}
 }
 
 // ensureSafeLongText will try to check if writing s will cause text overflow
-// or if the text length more than optimumTextLength.
+// or if the text length more than the text length allowed on the image width.
 // If text deemed to long, will call wordWrapper to wrap the text and prevent overflow
 func (o *SDResultImageGenerationOpts) ensureSafeLongText(s string, drawer *font.Drawer) []string {
 	width := drawer.MeasureString(s)
-	if width.Ceil() >= maxImageWitdh || len(s) >= optimumTextLength {
-		return wordWrapper(s)
+	if width.Ceil() >= o.maxWidth || len(s) >= o.textLength {
+		return wordWrapper(s, o.textLength)
 	}
 
 	return []string{s}
 }
 
-func wordWrapper(s string) []string {
+func wordWrapper(s string, textLength int) []string {
 	if strings.TrimSpace(s) == "" {
 		return []string{s}
 	}
@@ -202,7 +541,7 @@ func wordWrapper(s string) []string {
 	appended := false
 	for {
 		temp += ss[lastIdx] + " "
-		if len(temp) >= optimumTextLength {
+		if len(temp) >= textLength {
 			result = append(result, temp)
 			temp = ""
 			appended = true
@@ -228,7 +567,7 @@ func (o *SDResultImageGenerationOpts) countOptimumImageHeight() {
 	incrementor := int(math.Ceil(o.textSize * o.spacing * o.dpi / 72))
 	y += incrementor * len(o.ttp)
 
-	o.height = y
+	o.height = y + o.qrSize()
 }
 
 func (o *SDResultImageGenerationOpts) generateTextDrawer() {
@@ -0,0 +1,183 @@
+package model
+
+import (
+	"bytes"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSDResultFormat_Parse(t *testing.T) {
+	format, err := ParseSDResultFormat("", SDResultFormatPDF)
+	assert.NoError(t, err)
+	assert.Equal(t, format, SDResultFormatPDF)
+
+	format, err = ParseSDResultFormat(" JPG ", SDResultFormatPDF)
+	assert.NoError(t, err)
+	assert.Equal(t, format, SDResultFormatJPEG)
+	assert.Equal(t, format.ContentType(), "image/jpeg")
+
+	format, err = ParseSDResultFormat("application/pdf", SDResultFormatJPEG)
+	assert.NoError(t, err)
+	assert.Equal(t, format, SDResultFormatPDF)
+	assert.Equal(t, format.ContentType(), "application/pdf")
+
+	format, err = ParseSDResultFormat("image/png", SDResultFormatJPEG)
+	assert.NoError(t, err)
+	assert.Equal(t, format, SDResultFormatPNG)
+	assert.Equal(t, format.ContentType(), "image/png")
+
+	format, err = ParseSDResultFormat("svg", SDResultFormatJPEG)
+	assert.NoError(t, err)
+	assert.Equal(t, format, SDResultFormatSVG)
+	assert.Equal(t, format.ContentType(), "image/svg+xml")
+
+	_, err = ParseSDResultFormat("gif", SDResultFormatJPEG)
+	assert.Error(t, err)
+}
+
+func TestSDResultFormat_Negotiate(t *testing.T) {
+	assert.Equal(t, NegotiateSDResultFormat("", SDResultFormatJPEG), SDResultFormatJPEG)
+	assert.Equal(t, NegotiateSDResultFormat("*/*", SDResultFormatPDF), SDResultFormatPDF)
+	assert.Equal(t, NegotiateSDResultFormat("image/*", SDResultFormatPDF), SDResultFormatPDF)
+	assert.Equal(t, NegotiateSDResultFormat("text/html, application/pdf;q=0.9", SDResultFormatJPEG), SDResultFormatPDF)
+	assert.Equal(t, NegotiateSDResultFormat("application/pdf;q=0, image/jpeg", SDResultFormatPDF), SDResultFormatJPEG)
+	assert.Equal(t, NegotiateSDResultFormat("image/gif", SDResultFormatJPEG), SDResultFormatJPEG)
+	assert.Equal(t, NegotiateSDResultFormat("image/webp, image/png, image/*", SDResultFormatJPEG), SDResultFormatJPEG)
+	assert.Equal(t, NegotiateSDResultFormat("image/webp, image/png, image/*;q=0.8", SDResultFormatJPEG), SDResultFormatPNG)
+	assert.Equal(t, NegotiateSDResultFormat("image/svg+xml", SDResultFormatJPEG), SDResultFormatSVG)
+	assert.Equal(t, NegotiateSDResultFormat("image/avif,image/webp,image/apng,image/svg+xml,image/*,*/*;q=0.8", SDResultFormatJPEG), SDResultFormatJPEG)
+	assert.Equal(t, NegotiateSDResultFormat("image/png;q=0.5, application/pdf;q=0.9", SDResultFormatJPEG), SDResultFormatPDF)
+	assert.Equal(t, NegotiateSDResultFormat("image/png, application/pdf", SDResultFormatJPEG), SDResultFormatPNG)
+	assert.Equal(t, NegotiateSDResultFormat("image/jpeg;q=0.5, image/png;q=0.4, */*;q=0.1", SDResultFormatJPEG), SDResultFormatJPEG)
+	assert.Equal(t, NegotiateSDResultFormat("image/png;q=0.6, image/*;q=0.5, image/jpeg", SDResultFormatPDF), SDResultFormatJPEG)
+	assert.Equal(t, NegotiateSDResultFormat("image/png; level=1; q=0.9, image/*;q=0.5", SDResultFormatJPEG), SDResultFormatPNG)
+}
+
+func TestSDResultImageGenerationOpts_GenerateImages(t *testing.T) {
+	fontBytes, err := os.ReadFile("../../assets/font.ttf")
+	assert.NoError(t, err)
+	f, err := truetype.Parse(fontBytes)
+	assert.NoError(t, err)
+
+	opts := &SDResultImageGenerationOpts{
+		Title:          "title",
+		Result:         SDTestResult{Result: []SDTestGroupResult{{GroupName: "group <a>", Result: 3}}, Total: 3},
+		TestID:         uuid.New(),
+		IndicationText: "indication",
+	}
+
+	t.Run("default size", func(t *testing.T) {
+		gen := NewResultGenerator(f, opts)
+
+		res := gen.GenerateJPEG()
+		assert.Equal(t, res.ContentType, "image/jpeg")
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(res.Buffer.Bytes()))
+		assert.NoError(t, err)
+		assert.Equal(t, cfg.Width, DefaultSDResultImageWidth)
+
+		// the same generator can be encoded to the other formats
+		res = gen.GeneratePNG()
+		assert.Equal(t, res.ContentType, "image/png")
+		cfg, err = png.DecodeConfig(bytes.NewReader(res.Buffer.Bytes()))
+		assert.NoError(t, err)
+		assert.Equal(t, cfg.Width, DefaultSDResultImageWidth)
+
+		res = gen.GenerateSVG()
+		assert.Equal(t, res.ContentType, "image/svg+xml")
+		content := res.Buffer.String()
+		assert.True(t, strings.HasPrefix(content, `<svg xmlns="http://www.w3.org/2000/svg" width="1080"`))
+		assert.Contains(t, content, ">title</text>")
+		assert.Contains(t, content, ">group &lt;a&gt;: 3</text>")
+		assert.True(t, strings.HasSuffix(content, "</svg>"))
+	})
+
+	t.Run("custom width and dpi", func(t *testing.T) {
+		res := NewResultGenerator(f, &SDResultImageGenerationOpts{
+			Title:          opts.Title,
+			Result:         opts.Result,
+			TestID:         opts.TestID,
+			IndicationText: opts.IndicationText,
+			Width:          480,
+			DPI:            96,
+		}).GeneratePNG()
+
+		cfg, err := png.DecodeConfig(bytes.NewReader(res.Buffer.Bytes()))
+		assert.NoError(t, err)
+		assert.Equal(t, cfg.Width, 480)
+	})
+
+	t.Run("with verification qr code", func(t *testing.T) {
+		plain, err := png.DecodeConfig(bytes.NewReader(NewResultGenerator(f, opts).GeneratePNG().Buffer.Bytes()))
+		assert.NoError(t, err)
+
+		content, err := SDResultVerificationContent("https://atec.example/verify", opts.TestID, 3, []byte("signature"))
+		assert.NoError(t, err)
+
+		gen := NewResultGenerator(f, &SDResultImageGenerationOpts{
+			Title:               opts.Title,
+			Result:              opts.Result,
+			TestID:              opts.TestID,
+			IndicationText:      opts.IndicationText,
+			VerificationContent: content,
+		})
+
+		cfg, err := png.DecodeConfig(bytes.NewReader(gen.GeneratePNG().Buffer.Bytes()))
+		assert.NoError(t, err)
+		assert.Equal(t, cfg.Width, plain.Width)
+		assert.Greater(t, cfg.Height, plain.Height+DefaultSDResultImageWidth/4)
+
+		assert.Contains(t, gen.GenerateSVG().Buffer.String(), `<path fill="#000000" d="M`)
+		assert.Contains(t, gen.GeneratePDF().Buffer.String(), "re\nf Q")
+	})
+}
+
+func TestWordWrapper(t *testing.T) {
+	assert.Equal(t, wordWrapper("aaaa bbbb cccc", 10), []string{"aaaa bbbb ", "cccc "})
+	assert.Equal(t, wordWrapper("   ", 10), []string{"   "})
+}
+
+func TestSDResultImageGenerationOpts_GeneratePDF(t *testing.T) {
+	fontBytes, err := os.ReadFile("../../assets/font.ttf")
+	assert.NoError(t, err)
+	f, err := truetype.Parse(fontBytes)
+	assert.NoError(t, err)
+
+	groups := []SDTestGroupResult{}
+	for i := 0; i < 60; i++ {
+		groups = append(groups, SDTestGroupResult{GroupName: "group (a)", Result: i})
+	}
+
+	res := NewResultGenerator(f, &SDResultImageGenerationOpts{
+		Title:          "title",
+		Result:         SDTestResult{Result: groups, Total: 10},
+		TestID:         uuid.New(),
+		IndicationText: "indikasi é 中",
+		PackageName:    "package",
+	}).GeneratePDF()
+
+	content := res.Buffer.String()
+	assert.Equal(t, res.ContentType, "application/pdf")
+	assert.True(t, strings.HasPrefix(content, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(content, "%%EOF\n"))
+	assert.Contains(t, content, "/Count 2")
+	assert.Contains(t, content, `(group \(a\): 59)`)
+	assert.Contains(t, content, "(Paket: package)")
+	assert.Contains(t, content, "(Indikasi: indikasi \xe9 ?)")
+	assert.NotContains(t, content, "Tanggal Tes")
+}
+
+func TestPDFEscape(t *testing.T) {
+	assert.Equal(t, pdfEscape(`a\b (c)`), `a\\b \(c\)`)
+	assert.Equal(t, pdfEscape("tab\tline\n"), "tab line ")
+	assert.Equal(t, pdfEscape("é ü ñ"), "\xe9 \xfc \xf1")
+	assert.Equal(t, pdfEscape("€ “quoted” – ‘a’ … ™ Š œ"), "\x80 \x93quoted\x94 \x96 \x91a\x92 \x85 \x99 \x8a \x9c")
+	assert.Equal(t, pdfEscape("\u0080\u0081\u009f\u007f"), "????")
+	assert.Equal(t, pdfEscape("中 ő"), "? ?")
+}
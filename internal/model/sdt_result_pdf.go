@@ -0,0 +1,175 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// the pdf document is an A4 page measured in points, written using the standard Helvetica fonts
+// thus no font need to be embedded
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 56
+	pdfTitleSize  = 18
+	pdfTextSize   = 12
+	pdfLineHeight = 1.5
+	pdfQRSize     = 160
+)
+
+// pdfTestDateLayout is the layout of the test date written on the pdf document
+const pdfTestDateLayout = "2006-01-02 15:04 MST"
+
+// pdfLine is a single line of text written on the pdf document. Font is the font resource name
+type pdfLine struct {
+	text string
+	font string
+	size int
+}
+
+// GeneratePDF will generate pdf document for the test result. The document will span more than
+// one page when the result doesn't fit on a single page
+func (o *SDResultImageGenerationOpts) GeneratePDF() *ImageResult {
+	lines := []pdfLine{{text: o.Title, font: "F2", size: pdfTitleSize}}
+	appendText := func(s string) {
+		for _, l := range o.ensureSafeLongText(s, o.sampleDrawer) {
+			lines = append(lines, pdfLine{text: strings.TrimSpace(l), font: "F1", size: pdfTextSize})
+		}
+	}
+
+	if o.PackageName != "" {
+		appendText(fmt.Sprintf("%s: %s", o.Labels.PackageName, o.PackageName))
+	}
+
+	if !o.TestDate.IsZero() {
+		appendText(fmt.Sprintf("%s: %s", o.Labels.TestDate, o.TestDate.Format(pdfTestDateLayout)))
+	}
+
+	for _, s := range o.ttp {
+		lines = append(lines, pdfLine{text: strings.TrimSpace(s), font: "F1", size: pdfTextSize})
+	}
+
+	pages, y := paginatePDFLines(lines)
+	if len(o.qrBitmap) > 0 {
+		// the qr code is written below the last line, or on a new page when there is no room left
+		if y-pdfQRSize < pdfMargin {
+			pages = append(pages, "")
+			y = pdfPageHeight - pdfMargin
+		}
+
+		pages[len(pages)-1] += pdfQRCode(o.qrBitmap, pdfMargin, y-pdfQRSize)
+	}
+
+	return &ImageResult{
+		ContentType: SDResultFormatPDF.ContentType(),
+		Buffer:      writePDF(pages),
+	}
+}
+
+// paginatePDFLines will write the lines as the content stream of the pages, starting from the top left margin.
+// Also return the vertical position below the last line written
+func paginatePDFLines(lines []pdfLine) ([]string, float64) {
+	pages := []string{}
+	content := &strings.Builder{}
+	y := float64(pdfPageHeight - pdfMargin)
+	for _, l := range lines {
+		dy := float64(l.size) * pdfLineHeight
+		if y-dy < pdfMargin && content.Len() > 0 {
+			pages = append(pages, content.String())
+			content.Reset()
+			y = float64(pdfPageHeight - pdfMargin)
+		}
+
+		y -= dy
+		fmt.Fprintf(content, "BT /%s %d Tf 1 0 0 1 %d %.2f Tm (%s) Tj ET\n", l.font, l.size, pdfMargin, y, pdfEscape(l.text))
+	}
+
+	return append(pages, content.String()), y
+}
+
+// pdfQRCode will write the dark modules of the qr code bitmap as filled rectangles, with x and y as the bottom left
+// position. The bitmap rows are ordered from the top, while the pdf coordinate starts from the bottom
+func pdfQRCode(bitmap [][]bool, x, y float64) string {
+	module := float64(pdfQRSize) / float64(len(bitmap))
+	content := &strings.Builder{}
+	content.WriteString("q 0 g\n")
+	for row, modules := range bitmap {
+		top := y + float64(len(bitmap)-row-1)*module
+		for col, dark := range modules {
+			if dark {
+				fmt.Fprintf(content, "%.2f %.2f %.2f %.2f re\n", x+float64(col)*module, top, module, module)
+			}
+		}
+	}
+	content.WriteString("f Q\n")
+
+	return content.String()
+}
+
+// writePDF will write the pdf document having the pages content stream
+func writePDF(pages []string) bytes.Buffer {
+	var buf bytes.Buffer
+	offsets := []int{}
+	writeObject := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// object 1 until 4 are the catalog, the page tree and the fonts, followed by each page and its content
+	kids := []string{}
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 5+2*i))
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range pages {
+		writeObject(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*i,
+		))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf
+}
+
+// pdfEscape will escape the text to be written as pdf literal string. The standard fonts are written using
+// WinAnsiEncoding, thus each character is encoded to its WinAnsi byte and the unsupported ones are replaced with question mark
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r < 0x20:
+			b.WriteByte(' ')
+		case r >= 0x7f && r < 0xa0:
+			// DEL and the C1 control characters, which WinAnsiEncoding use for the other characters
+			b.WriteByte('?')
+		default:
+			if c, ok := charmap.Windows1252.EncodeRune(r); ok {
+				b.WriteByte(c)
+				continue
+			}
+
+			b.WriteByte('?')
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,195 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/guregu/null.v4"
+)
+
+// SDResultShareScope define what can be seen by the holder of the sd test result share token
+type SDResultShareScope string
+
+// list of sd test result share scope
+const (
+	// SDResultShareScopeImage only allow to see the result image
+	SDResultShareScopeImage SDResultShareScope = "image"
+
+	// SDResultShareScopeFull allow to see the result image and the full answers of the test
+	SDResultShareScopeFull SDResultShareScope = "full"
+)
+
+// MaxSDResultShareDuration is the longest time a share token can be used since it was created
+const MaxSDResultShareDuration = 30 * 24 * time.Hour
+
+// Allow report whether the scope includes the requested scope. The full scope includes the image scope
+func (s SDResultShareScope) Allow(requested SDResultShareScope) bool {
+	return s == requested || s == SDResultShareScopeFull
+}
+
+// SDResultShare represent test_result_shares table. The share is created by the test owner to let anyone holding
+// the token to see the test result without an account, until it is revoked, expired, or the views reach MaxViews.
+// Only the hashed token is stored, thus the plain token is only known when the share is created
+type SDResultShare struct {
+	ID        uuid.UUID
+	TestID    uuid.UUID
+	Token     string
+	Scope     SDResultShareScope
+	ExpiredAt time.Time
+	MaxViews  null.Int
+	ViewCount int
+	RevokedAt null.Time
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName define the table name for gorm
+func (s SDResultShare) TableName() string {
+	return "test_result_shares"
+}
+
+// IsViewable report whether the share can still be used to see the test result at the given time
+func (s *SDResultShare) IsViewable(now time.Time) bool {
+	if s.RevokedAt.Valid || !now.Before(s.ExpiredAt) {
+		return false
+	}
+
+	return !s.MaxViews.Valid || int64(s.ViewCount) < s.MaxViews.Int64
+}
+
+// ToRESTResponse convert to GeneratedSDResultShare. The plain token is only set when the share is just created
+func (s *SDResultShare) ToRESTResponse(plainToken string) *GeneratedSDResultShare {
+	return &GeneratedSDResultShare{
+		ID:         s.ID,
+		TestID:     s.TestID,
+		Token:      plainToken,
+		Scope:      s.Scope,
+		ExpiredAt:  s.ExpiredAt,
+		MaxViews:   s.MaxViews,
+		ViewCount:  s.ViewCount,
+		IsViewable: s.IsViewable(time.Now()),
+		RevokedAt:  s.RevokedAt,
+		CreatedBy:  s.CreatedBy,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+// GeneratedSDResultShare will be used to define the sd test result share as the returned value as REST API responses
+type GeneratedSDResultShare struct {
+	ID         uuid.UUID          `json:"id"`
+	TestID     uuid.UUID          `json:"testID"`
+	Token      string             `json:"token,omitempty"`
+	Scope      SDResultShareScope `json:"scope"`
+	ExpiredAt  time.Time          `json:"expiredAt"`
+	MaxViews   null.Int           `json:"maxViews"`
+	ViewCount  int                `json:"viewCount"`
+	IsViewable bool               `json:"isViewable"`
+	RevokedAt  null.Time          `json:"revokedAt"`
+	CreatedBy  uuid.UUID          `json:"createdBy"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	UpdatedAt  time.Time          `json:"updatedAt"`
+}
+
+// SDResultShareView represent test_result_share_views table. Written each time the share is used to see the result
+type SDResultShareView struct {
+	ID        uuid.UUID
+	ShareID   uuid.UUID
+	Scope     SDResultShareScope
+	IPAddress string
+	UserAgent string
+	ViewedAt  time.Time
+}
+
+// TableName define the table name for gorm
+func (v SDResultShareView) TableName() string {
+	return "test_result_share_views"
+}
+
+// CreateSDResultShareInput input to share the sd test result. MaxViews is optional, when unset the share
+// can be used until expired or revoked
+type CreateSDResultShareInput struct {
+	TestID    uuid.UUID          `json:"-" validate:"required"`
+	Scope     SDResultShareScope `json:"scope" validate:"required,oneof=image full"`
+	ExpiredAt time.Time          `json:"expiredAt" validate:"required"`
+	MaxViews  null.Int           `json:"maxViews"`
+}
+
+// Validate validate struct. ExpiredAt must be in the future, not later than MaxSDResultShareDuration from now,
+// and MaxViews must be positive when set
+func (i *CreateSDResultShareInput) Validate() error {
+	if err := validator.Struct(i); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if !i.ExpiredAt.After(now) {
+		return errors.New("expiredAt must be in the future")
+	}
+
+	if i.ExpiredAt.After(now.Add(MaxSDResultShareDuration)) {
+		return errors.New("expiredAt must not be later than 30 days from now")
+	}
+
+	if i.MaxViews.Valid && i.MaxViews.Int64 < 1 {
+		return errors.New("maxViews must be positive")
+	}
+
+	return nil
+}
+
+// RevokeSDResultShareInput input to revoke the sd test result share
+type RevokeSDResultShareInput struct {
+	TestID  uuid.UUID `param:"id" validate:"required"`
+	ShareID uuid.UUID `param:"share_id" validate:"required"`
+}
+
+// Validate validate struct
+func (i *RevokeSDResultShareInput) Validate() error {
+	return validator.Struct(i)
+}
+
+// ViewSharedSDTestResultInput input to see the sd test result using the share token. Format, Width and DPI
+// are only used to generate the result image, limited the same as DownloadSDTestResultInput
+type ViewSharedSDTestResultInput struct {
+	Token  string `validate:"required"`
+	Format SDResultFormat
+	Width  int `validate:"omitempty,min=320,max=4096"`
+	DPI    int `validate:"omitempty,min=72,max=600"`
+
+	// IPAddress and UserAgent are recorded as the viewer of the share
+	IPAddress string
+	UserAgent string
+}
+
+// Validate validate struct
+func (i *ViewSharedSDTestResultInput) Validate() error {
+	return validator.Struct(i)
+}
+
+// SharedSDTestResultOutput the full test result seen using the share token. The owner of the test is never returned
+type SharedSDTestResultOutput struct {
+	TestID         uuid.UUID              `json:"testID"`
+	PackageName    string                 `json:"packageName"`
+	PackageVersion int                    `json:"packageVersion"`
+	Answer         SDTestAnswer           `json:"answer"`
+	Result         SDTestResult           `json:"result"`
+	TestQuestions  []SDTestGroupQuestions `json:"testQuestions"`
+	FinishedAt     time.Time              `json:"finishedAt"`
+	ShareExpiredAt time.Time              `json:"shareExpiredAt"`
+}
+
+// SDResultShareRepository repository for sd test result share
+type SDResultShareRepository interface {
+	Create(ctx context.Context, share *SDResultShare) error
+	FindByID(ctx context.Context, id uuid.UUID) (*SDResultShare, error)
+	FindByToken(ctx context.Context, token string) (*SDResultShare, error)
+	FindByTestID(ctx context.Context, testID uuid.UUID) ([]*SDResultShare, error)
+	Update(ctx context.Context, share *SDResultShare) error
+
+	// RecordView will count the view on the share and write the view, only when the share is still viewable
+	RecordView(ctx context.Context, view *SDResultShareView) error
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestSDResultShareScope_Allow(t *testing.T) {
+	assert.True(t, SDResultShareScopeImage.Allow(SDResultShareScopeImage))
+	assert.False(t, SDResultShareScopeImage.Allow(SDResultShareScopeFull))
+	assert.True(t, SDResultShareScopeFull.Allow(SDResultShareScopeImage))
+	assert.True(t, SDResultShareScopeFull.Allow(SDResultShareScopeFull))
+}
+
+func TestSDResultShare_IsViewable(t *testing.T) {
+	now := time.Now()
+
+	share := &SDResultShare{ExpiredAt: now.Add(time.Hour)}
+	assert.True(t, share.IsViewable(now))
+	assert.False(t, share.IsViewable(now.Add(time.Hour)))
+
+	share.MaxViews = null.IntFrom(2)
+	share.ViewCount = 1
+	assert.True(t, share.IsViewable(now))
+
+	share.ViewCount = 2
+	assert.False(t, share.IsViewable(now))
+
+	share.ViewCount = 0
+	share.RevokedAt = null.TimeFrom(now)
+	assert.False(t, share.IsViewable(now))
+}
+
+func TestCreateSDResultShareInput_Validate(t *testing.T) {
+	now := time.Now()
+	valid := func() *CreateSDResultShareInput {
+		return &CreateSDResultShareInput{
+			TestID:    uuid.New(),
+			Scope:     SDResultShareScopeFull,
+			ExpiredAt: now.Add(24 * time.Hour),
+		}
+	}
+
+	assert.NoError(t, valid().Validate())
+
+	input := valid()
+	input.MaxViews = null.IntFrom(1)
+	assert.NoError(t, input.Validate())
+
+	input = valid()
+	input.Scope = "everything"
+	assert.Error(t, input.Validate())
+
+	input = valid()
+	input.ExpiredAt = now.Add(-time.Minute)
+	assert.Error(t, input.Validate())
+
+	input = valid()
+	input.ExpiredAt = now.Add(MaxSDResultShareDuration + time.Hour)
+	assert.Error(t, input.Validate())
+
+	input = valid()
+	input.MaxViews = null.IntFrom(0)
+	assert.Error(t, input.Validate())
+}
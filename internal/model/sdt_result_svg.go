@@ -0,0 +1,63 @@
+package model
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+)
+
+// GenerateSVG will generate svg image for the test result. The text is laid out on the same position as the
+// raster images, but rendered by the client using a generic sans-serif font, thus stays sharp on any scale
+func (o *SDResultImageGenerationOpts) GenerateSVG() *ImageResult {
+	titleSize := o.titleSize * o.dpi / 72
+	textSize := o.textSize * o.dpi / 72
+	y := 10 + int(math.Ceil(textSize))
+	dy := int(math.Ceil(textSize * o.spacing))
+	ty := 10 + int(math.Ceil(titleSize))
+	tdy := int(math.Ceil(titleSize * o.spacing))
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, o.width, o.height, o.width, o.height)
+	buf.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>`)
+	buf.WriteString(`<g font-family="sans-serif" fill="#000000" text-anchor="middle">`)
+	writeSVGText(&buf, o.width/2, ty, titleSize, o.Title)
+
+	y += tdy
+	for _, s := range o.ttp {
+		writeSVGText(&buf, o.width/2, y, textSize, s)
+		y += dy
+	}
+
+	buf.WriteString(`</g>`)
+
+	if len(o.qrBitmap) > 0 {
+		writeSVGQRCode(&buf, o.qrBitmap, (o.width-o.qrSize())/2, o.height-o.qrSize(), o.qrModuleSize())
+	}
+
+	buf.WriteString(`</svg>`)
+
+	return &ImageResult{
+		ContentType: SDResultFormatSVG.ContentType(),
+		Buffer:      buf,
+	}
+}
+
+func writeSVGText(buf *bytes.Buffer, x, y int, size float64, text string) {
+	fmt.Fprintf(buf, `<text x="%d" y="%d" font-size="%.2f">`, x, y, size)
+	_ = xml.EscapeText(buf, []byte(text))
+	buf.WriteString(`</text>`)
+}
+
+// writeSVGQRCode will write the dark modules of the qr code bitmap as a single path starting from x and y
+func writeSVGQRCode(buf *bytes.Buffer, bitmap [][]bool, x, y, moduleSize int) {
+	buf.WriteString(`<path fill="#000000" d="`)
+	for row, modules := range bitmap {
+		for col, dark := range modules {
+			if dark {
+				fmt.Fprintf(buf, "M%d %dh%dv%dh-%dz", x+col*moduleSize, y+row*moduleSize, moduleSize, moduleSize, moduleSize)
+			}
+		}
+	}
+	buf.WriteString(`"/>`)
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"gopkg.in/guregu/null.v4"
+)
+
+// SDResultSignatureMessageFormat is the format of the message signed as the proof of the sd test result
+const SDResultSignatureMessageFormat = "atec-sdt-result:{testID}:{total}"
+
+// SDResultSignatureMessage return the message signed as the proof of the sd test result. Only contain the test id
+// and the total point, thus the message can be rebuilt by the recipient using the qr code content
+func SDResultSignatureMessage(testID uuid.UUID, total int) []byte {
+	return []byte(fmt.Sprintf("atec-sdt-result:%s:%d", testID, total))
+}
+
+// EncodeSDResultSignature encode the signature to be written on the qr code and sent to the verification endpoint
+func EncodeSDResultSignature(signature []byte) string {
+	return base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// DecodeSDResultSignature decode the signature encoded using EncodeSDResultSignature
+func DecodeSDResultSignature(signature string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(signature)
+}
+
+// ValidateSDResultVerificationBaseURL ensure the verification base url is an absolute url, thus the qr code content can be opened
+func ValidateSDResultVerificationBaseURL(baseURL string) error {
+	if baseURL == "" {
+		return errors.New("sd test result verification base url is required")
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid sd test result verification base url: %w", err)
+	}
+
+	if !u.IsAbs() || u.Host == "" {
+		return errors.New("sd test result verification base url must be an absolute url")
+	}
+
+	return nil
+}
+
+// SDResultVerificationContent return the content of the qr code written on the sd test result.
+// The base url should point to the page calling the verification endpoint using the query. The query already
+// on the base url is kept
+func SDResultVerificationContent(baseURL string, testID uuid.UUID, total int, signature []byte) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Set("id", testID.String())
+	query.Set("total", strconv.Itoa(total))
+	query.Set("signature", EncodeSDResultSignature(signature))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// VerifySDTestResultInput input to verify the sd test result using the qr code content
+type VerifySDTestResultInput struct {
+	ID        uuid.UUID `param:"id" validate:"required"`
+	Total     int       `query:"total"`
+	Signature string    `query:"signature" validate:"required"`
+}
+
+// Validate validate the input
+func (i *VerifySDTestResultInput) Validate() error {
+	return validator.Struct(i)
+}
+
+// VerifySDTestResultOutput the verification result. Valid only when the signature is issued by the server
+// and the total point still match the stored test result
+type VerifySDTestResultOutput struct {
+	Valid  bool      `json:"valid"`
+	TestID uuid.UUID `json:"testID"`
+
+	// Result and FinishedAt are only returned when valid, thus the stored result can't be read without the signature
+	Result     *SDTestResult `json:"result,omitempty"`
+	FinishedAt null.Time     `json:"finishedAt"`
+}
+
+// SDResultPublicKeyOutput the public key to verify the sd test result signature offline
+type SDResultPublicKeyOutput struct {
+	Algorithm     string `json:"algorithm"`
+	PublicKey     string `json:"publicKey"`
+	MessageFormat string `json:"messageFormat"`
+}
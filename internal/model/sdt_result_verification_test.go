@@ -0,0 +1,74 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSDResultVerificationContent(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	signer := common.NewSigner(key)
+
+	testID := uuid.New()
+	signature, err := signer.Sign(SDResultSignatureMessage(testID, 12))
+	assert.NoError(t, err)
+
+	content, err := SDResultVerificationContent("https://atec.example/verify?", testID, 12, signature)
+	assert.NoError(t, err)
+	u, err := url.Parse(content)
+	assert.NoError(t, err)
+
+	query := u.Query()
+	assert.Equal(t, query.Get("id"), testID.String())
+
+	total, err := strconv.Atoi(query.Get("total"))
+	assert.NoError(t, err)
+	assert.Equal(t, total, 12)
+
+	decoded, err := DecodeSDResultSignature(query.Get("signature"))
+	assert.NoError(t, err)
+	assert.NoError(t, signer.Verify(SDResultSignatureMessage(testID, total), decoded))
+	assert.Error(t, signer.Verify(SDResultSignatureMessage(testID, total+1), decoded))
+
+	publicKey, err := signer.PublicKeyPEM()
+	assert.NoError(t, err)
+	assert.Contains(t, publicKey, "-----BEGIN PUBLIC KEY-----")
+}
+
+func TestSDResultVerificationContent_BaseURL(t *testing.T) {
+	testID := uuid.New()
+
+	content, err := SDResultVerificationContent("https://atec.example/verify", testID, 12, []byte("signature"))
+	assert.NoError(t, err)
+	u, err := url.Parse(content)
+	assert.NoError(t, err)
+	assert.Equal(t, u.Path, "/verify")
+	assert.Equal(t, u.Query().Get("id"), testID.String())
+	assert.Equal(t, u.Query().Get("total"), "12")
+
+	content, err = SDResultVerificationContent("https://atec.example/verify?lang=id", testID, 12, []byte("signature"))
+	assert.NoError(t, err)
+	u, err = url.Parse(content)
+	assert.NoError(t, err)
+	assert.Equal(t, u.Query().Get("lang"), "id")
+	assert.Equal(t, u.Query().Get("id"), testID.String())
+
+	_, err = SDResultVerificationContent("://invalid", testID, 12, []byte("signature"))
+	assert.Error(t, err)
+}
+
+func TestValidateSDResultVerificationBaseURL(t *testing.T) {
+	assert.NoError(t, ValidateSDResultVerificationBaseURL("https://atec.example/verify"))
+	assert.NoError(t, ValidateSDResultVerificationBaseURL("https://atec.example/verify?lang=id"))
+	assert.Error(t, ValidateSDResultVerificationBaseURL(""))
+	assert.Error(t, ValidateSDResultVerificationBaseURL("/verify"))
+	assert.Error(t, ValidateSDResultVerificationBaseURL("://invalid"))
+}
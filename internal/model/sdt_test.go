@@ -1,6 +1,12 @@
 package model
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -614,3 +620,900 @@ func TestSDT_SDTestAnswer_DoGradingProcess(t *testing.T) {
 		assert.Equal(t, res[2].Result, 6)
 	})
 }
+
+func TestSDT_SDTestAnswer_ValidateDraft(t *testing.T) {
+	p := &SDPackage{
+		PackageName: "test",
+		TemplateID:  uuid.New(),
+		SubGroupDetails: []SDSubGroupDetail{
+			{
+				Name: "kemampuan memasak",
+				QuestionAndAnswerLists: []SDQuestionAndAnswers{
+					{
+						Question: "apakah anak bisa memasak nasi?",
+						AnswersAndValue: []SDAnswerAndValue{
+							{
+								Text:  "bisa",
+								Value: 1,
+							},
+							{
+								Text:  "tidak bisa",
+								Value: 2,
+							},
+						},
+					},
+					{
+						Question: "apakah anak bisa memasak bubur?",
+						AnswersAndValue: []SDAnswerAndValue{
+							{
+								Text:  "bisa",
+								Value: 1,
+							},
+							{
+								Text:  "tidak bisa",
+								Value: 2,
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "kemampuan berhitung",
+				QuestionAndAnswerLists: []SDQuestionAndAnswers{
+					{
+						Question: "apakah anak bisa menghitung hingga 100?",
+						AnswersAndValue: []SDAnswerAndValue{
+							{
+								Text:  "bisa",
+								Value: 1,
+							},
+							{
+								Text:  "tidak bisa",
+								Value: 2,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("struct invalid", func(t *testing.T) {
+		s := &SDTestAnswer{}
+		err := s.ValidateDraft(p)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown group", func(t *testing.T) {
+		s := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "kemampuan menari",
+					Answers: []Answer{
+						{
+							Question: "apakah anak bisa memasak nasi?",
+							Answer:   "bisa",
+						},
+					},
+				},
+			},
+		}
+		err := s.ValidateDraft(p)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown question", func(t *testing.T) {
+		s := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "kemampuan memasak",
+					Answers: []Answer{
+						{
+							Question: "apakah anak bisa memasak mie?",
+							Answer:   "bisa",
+						},
+					},
+				},
+			},
+		}
+		err := s.ValidateDraft(p)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown answer", func(t *testing.T) {
+		s := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "kemampuan memasak",
+					Answers: []Answer{
+						{
+							Question: "apakah anak bisa memasak nasi?",
+							Answer:   "mungkin",
+						},
+					},
+				},
+			},
+		}
+		err := s.ValidateDraft(p)
+		assert.Error(t, err)
+	})
+
+	t.Run("ok even when not all questions and groups are answered", func(t *testing.T) {
+		s := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "kemampuan memasak",
+					Answers: []Answer{
+						{
+							Question: "apakah anak bisa memasak nasi?",
+							Answer:   "bisa",
+						},
+					},
+				},
+			},
+		}
+		err := s.ValidateDraft(p)
+		assert.NoError(t, err)
+	})
+}
+
+func TestSDT_SDTestAnswer_Merge(t *testing.T) {
+	t.Run("merging into empty answer", func(t *testing.T) {
+		s := &SDTestAnswer{}
+		other := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "a",
+					Answers: []Answer{
+						{
+							Question: "q1",
+							Answer:   "a1",
+						},
+					},
+				},
+			},
+		}
+
+		res := s.Merge(other)
+		assert.Equal(t, res.TestAnswers, other.TestAnswers)
+	})
+
+	t.Run("merging nil answer", func(t *testing.T) {
+		s := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "a",
+					Answers: []Answer{
+						{
+							Question: "q1",
+							Answer:   "a1",
+						},
+					},
+				},
+			},
+		}
+
+		res := s.Merge(nil)
+		assert.Equal(t, res.TestAnswers, s.TestAnswers)
+	})
+
+	t.Run("other answer must take precedence and new groups and questions are appended", func(t *testing.T) {
+		s := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "a",
+					Answers: []Answer{
+						{
+							Question: "q1",
+							Answer:   "a1",
+						},
+						{
+							Question: "q2",
+							Answer:   "a1",
+						},
+					},
+				},
+			},
+		}
+		other := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "a",
+					Answers: []Answer{
+						{
+							Question: "q2",
+							Answer:   "a2",
+						},
+						{
+							Question: "q3",
+							Answer:   "a3",
+						},
+					},
+				},
+				{
+					GroupName: "b",
+					Answers: []Answer{
+						{
+							Question: "q1",
+							Answer:   "a1",
+						},
+					},
+				},
+			},
+		}
+
+		res := s.Merge(other)
+		assert.Equal(t, len(res.TestAnswers), 2)
+		assert.Equal(t, res.TestAnswers[0].GroupName, "a")
+		assert.Equal(t, res.TestAnswers[0].Answers, []Answer{
+			{
+				Question: "q1",
+				Answer:   "a1",
+			},
+			{
+				Question: "q2",
+				Answer:   "a2",
+			},
+			{
+				Question: "q3",
+				Answer:   "a3",
+			},
+		})
+		assert.Equal(t, res.TestAnswers[1].GroupName, "b")
+
+		// the original answers must not be modified
+		assert.Equal(t, s.TestAnswers[0].Answers[1].Answer, "a1")
+		assert.Equal(t, len(s.TestAnswers[0].Answers), 2)
+	})
+}
+
+func TestSDT_SDTestAnswer_DoGradingProcessWithScoringRules(t *testing.T) {
+	p := &SDPackage{
+		PackageName: "test",
+		TemplateID:  uuid.New(),
+		SubGroupDetails: []SDSubGroupDetail{
+			{
+				Name: "kemampuan memasak",
+				QuestionAndAnswerLists: []SDQuestionAndAnswers{
+					{
+						Question: "apakah anak bisa memasak nasi?",
+						AnswersAndValue: []SDAnswerAndValue{
+							{
+								Text:  "bisa",
+								Value: 1,
+							},
+							{
+								Text:  "kadang",
+								Value: 2,
+							},
+							{
+								Text:  "tidak bisa",
+								Value: 3,
+							},
+						},
+						ScoringRule: &SDScoringRule{
+							Reverse: true,
+						},
+					},
+					{
+						Question: "apakah anak bisa memasak bubur?",
+						AnswersAndValue: []SDAnswerAndValue{
+							{
+								Text:  "bisa",
+								Value: 1,
+							},
+							{
+								Text:  "kadang",
+								Value: 2,
+							},
+							{
+								Text:  "tidak bisa",
+								Value: 3,
+							},
+						},
+						ScoringRule: &SDScoringRule{
+							Weight: 3,
+						},
+					},
+					{
+						Question: "apakah anak bisa memasak mie?",
+						AnswersAndValue: []SDAnswerAndValue{
+							{
+								Text:  "bisa",
+								Value: 10,
+							},
+							{
+								Text:  "kadang",
+								Value: 20,
+							},
+							{
+								Text:  "tidak bisa",
+								Value: 30,
+							},
+						},
+						ScoringRule: &SDScoringRule{
+							ValueMap: map[int]int{10: 0, 20: 0, 30: 5},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	s := &SDTestAnswer{
+		TestAnswers: []*TestAnswer{
+			{
+				GroupName: "kemampuan memasak",
+				Answers: []Answer{
+					{
+						Question: "apakah anak bisa memasak nasi?",
+						Answer:   "bisa",
+					},
+					{
+						Question: "apakah anak bisa memasak bubur?",
+						Answer:   "kadang",
+					},
+					{
+						Question: "apakah anak bisa memasak mie?",
+						Answer:   "tidak bisa",
+					},
+				},
+			},
+		},
+	}
+
+	res, err := s.DoGradingProcess(p)
+	assert.NoError(t, err)
+	assert.Equal(t, res, []SDTestGroupResult{
+		{
+			GroupName: "kemampuan memasak",
+			Result:    3 + 6 + 5,
+		},
+	})
+}
+
+func TestSDT_SDTestAnswer_Translated(t *testing.T) {
+	p := &SDPackage{
+		PackageName: "test",
+		TemplateID:  uuid.New(),
+		SubGroupDetails: []SDSubGroupDetail{
+			{
+				Name:             "kemampuan bicara",
+				NameTranslations: Translations{LocaleEnglish: "speech ability"},
+				QuestionAndAnswerLists: []SDQuestionAndAnswers{
+					{
+						Question:             "apakah anak bisa menyebut namanya?",
+						QuestionTranslations: Translations{LocaleEnglish: "can the child say their name?"},
+						AnswersAndValue: []SDAnswerAndValue{
+							{
+								Text:             "bisa",
+								TextTranslations: Translations{LocaleEnglish: "yes"},
+								Value:            1,
+							},
+							{
+								Text:             "tidak bisa",
+								TextTranslations: Translations{LocaleEnglish: "no"},
+								Value:            2,
+							},
+						},
+					},
+					{
+						Question: "apakah anak bisa menyebut angka?",
+						AnswersAndValue: []SDAnswerAndValue{
+							{
+								Text:  "bisa",
+								Value: 1,
+							},
+							{
+								Text:  "tidak bisa",
+								Value: 2,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	p.EnsureIDs()
+
+	g := p.SubGroupDetails[0]
+	q1, q2 := g.QuestionAndAnswerLists[0], g.QuestionAndAnswerLists[1]
+
+	t.Run("render the test question in the locale", func(t *testing.T) {
+		rendered := p.RenderTestQuestions(LocaleEnglish)
+		assert.Equal(t, rendered, map[string][]SDTestQuestion{
+			"speech ability": {
+				{
+					ID:       q1.ID,
+					GroupID:  g.ID,
+					Question: "can the child say their name?",
+					Answers:  []string{"yes", "no"},
+					AnswerOptions: []SDTestAnswerOption{
+						{ID: q1.AnswersAndValue[0].ID, Text: "yes"},
+						{ID: q1.AnswersAndValue[1].ID, Text: "no"},
+					},
+				},
+				{
+					ID:       q2.ID,
+					GroupID:  g.ID,
+					Question: "apakah anak bisa menyebut angka?",
+					Answers:  []string{"bisa", "tidak bisa"},
+					AnswerOptions: []SDTestAnswerOption{
+						{ID: q2.AnswersAndValue[0].ID, Text: "bisa"},
+						{ID: q2.AnswersAndValue[1].ID, Text: "tidak bisa"},
+					},
+				},
+			},
+		})
+
+		assert.Equal(t, p.RenderTestQuestions(DefaultLocale)["kemampuan bicara"][0].Question, "apakah anak bisa menyebut namanya?")
+	})
+
+	t.Run("translated answer graded the same as the original", func(t *testing.T) {
+		translated := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "speech ability",
+					Answers: []Answer{
+						{Question: "can the child say their name?", Answer: "no"},
+						{Question: "apakah anak bisa menyebut angka?", Answer: "bisa"},
+					},
+				},
+			},
+		}
+		original := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "kemampuan bicara",
+					Answers: []Answer{
+						{Question: "apakah anak bisa menyebut namanya?", Answer: "tidak bisa"},
+						{Question: "apakah anak bisa menyebut angka?", Answer: "bisa"},
+					},
+				},
+			},
+		}
+
+		translatedResult, err := translated.DoGradingProcess(p)
+		assert.NoError(t, err)
+		originalResult, err := original.DoGradingProcess(p)
+		assert.NoError(t, err)
+
+		assert.Equal(t, translatedResult, originalResult)
+		assert.Equal(t, translatedResult[0].GroupName, "kemampuan bicara")
+		assert.Equal(t, translatedResult[0].Result, 3)
+
+		// the answer is stored using the original text and the ids
+		assert.Equal(t, translated.TestAnswers[0].GroupID, g.ID)
+		assert.Equal(t, translated.TestAnswers[0].Answers[0], Answer{
+			Question:   "apakah anak bisa menyebut namanya?",
+			Answer:     "tidak bisa",
+			QuestionID: q1.ID,
+			AnswerID:   q1.AnswersAndValue[1].ID,
+		})
+	})
+
+	t.Run("translated draft merged with the original answer", func(t *testing.T) {
+		draft := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "kemampuan bicara",
+					Answers: []Answer{
+						{Question: "apakah anak bisa menyebut namanya?", Answer: "bisa"},
+					},
+				},
+			},
+		}
+		input := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "speech ability",
+					Answers: []Answer{
+						{Question: "can the child say their name?", Answer: "no"},
+					},
+				},
+			},
+		}
+
+		assert.NoError(t, input.ValidateDraft(p))
+
+		merged := draft.Merge(input)
+		assert.Equal(t, len(merged.TestAnswers), 1)
+		assert.Equal(t, merged.TestAnswers[0].Answers, []Answer{
+			{
+				Question:   "apakah anak bisa menyebut namanya?",
+				Answer:     "tidak bisa",
+				QuestionID: q1.ID,
+				AnswerID:   q1.AnswersAndValue[1].ID,
+			},
+		})
+	})
+
+	t.Run("unknown translated answer is rejected", func(t *testing.T) {
+		answer := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "speech ability",
+					Answers: []Answer{
+						{Question: "can the child say their name?", Answer: "maybe"},
+						{Question: "apakah anak bisa menyebut angka?", Answer: "bisa"},
+					},
+				},
+			},
+		}
+
+		_, err := answer.DoGradingProcess(p)
+		assert.Error(t, err)
+	})
+}
+
+func TestSDT_SDTestAnswer_ByID(t *testing.T) {
+	templateID := uuid.New()
+	newPackage := func() *SDPackage {
+		return &SDPackage{
+			PackageName: "test",
+			TemplateID:  templateID,
+			SubGroupDetails: []SDSubGroupDetail{
+				{
+					Name: "kemampuan bicara",
+					QuestionAndAnswerLists: []SDQuestionAndAnswers{
+						{
+							Question: "apakah anak bisa menyebut namanya?",
+							AnswersAndValue: []SDAnswerAndValue{
+								{Text: "bisa", Value: 1},
+								{Text: "tidak bisa", Value: 2},
+							},
+						},
+						{
+							Question: "apakah anak bisa menyebut angka?",
+							AnswersAndValue: []SDAnswerAndValue{
+								{Text: "bisa", Value: 1},
+								{Text: "tidak bisa", Value: 2},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	p := newPackage()
+	p.EnsureIDs()
+
+	g := p.SubGroupDetails[0]
+	q1, q2 := g.QuestionAndAnswerLists[0], g.QuestionAndAnswerLists[1]
+
+	t.Run("generated ids are stable", func(t *testing.T) {
+		other := newPackage()
+		other.EnsureIDs()
+		assert.Equal(t, other, p)
+
+		raw, err := json.Marshal(newPackage())
+		assert.NoError(t, err)
+
+		scanned := &SDPackage{}
+		assert.NoError(t, scanned.Scan(context.Background(), nil, reflect.Value{}, raw))
+		assert.Equal(t, scanned, p)
+
+		assert.NotEqual(t, q1.ID, q2.ID)
+		assert.NotEqual(t, q1.AnswersAndValue[0].ID, q2.AnswersAndValue[0].ID)
+	})
+
+	t.Run("existing ids are kept", func(t *testing.T) {
+		id := uuid.New()
+		other := newPackage()
+		other.SubGroupDetails[0].QuestionAndAnswerLists[0].ID = id
+		other.EnsureIDs()
+
+		assert.Equal(t, other.SubGroupDetails[0].QuestionAndAnswerLists[0].ID, id)
+		assert.Equal(t, other.SubGroupDetails[0].QuestionAndAnswerLists[1].ID, q2.ID)
+	})
+
+	t.Run("duplicate text get different ids", func(t *testing.T) {
+		other := newPackage()
+		other.SubGroupDetails[0].QuestionAndAnswerLists[1].Question = "apakah anak bisa menyebut namanya?"
+		other.EnsureIDs()
+
+		assert.NotEqual(t, other.SubGroupDetails[0].QuestionAndAnswerLists[0].ID, other.SubGroupDetails[0].QuestionAndAnswerLists[1].ID)
+	})
+
+	t.Run("graded by ids", func(t *testing.T) {
+		byID := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupID: g.ID,
+					Answers: []Answer{
+						{QuestionID: q1.ID, AnswerID: q1.AnswersAndValue[1].ID},
+						{QuestionID: q2.ID, AnswerID: q2.AnswersAndValue[0].ID},
+					},
+				},
+			},
+		}
+		byText := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "kemampuan bicara",
+					Answers: []Answer{
+						{Question: "apakah anak bisa menyebut namanya?", Answer: "tidak bisa"},
+						{Question: "apakah anak bisa menyebut angka?", Answer: "bisa"},
+					},
+				},
+			},
+		}
+
+		byIDResult, err := byID.DoGradingProcess(p)
+		assert.NoError(t, err)
+		byTextResult, err := byText.DoGradingProcess(p)
+		assert.NoError(t, err)
+
+		assert.Equal(t, byIDResult, byTextResult)
+		assert.Equal(t, byIDResult[0].Result, 3)
+		assert.Equal(t, byID.TestAnswers[0].GroupName, "kemampuan bicara")
+		assert.Equal(t, byID.TestAnswers[0].Answers, byText.TestAnswers[0].Answers)
+	})
+
+	t.Run("id take precedence over the text", func(t *testing.T) {
+		answer := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupID:   g.ID,
+					GroupName: "unknown",
+					Answers: []Answer{
+						{QuestionID: q1.ID, Question: "apakah anak bisa menyebut angka?", AnswerID: q1.AnswersAndValue[1].ID, Answer: "bisa"},
+						{Question: "apakah anak bisa menyebut angka?", Answer: "bisa"},
+					},
+				},
+			},
+		}
+
+		res, err := answer.DoGradingProcess(p)
+		assert.NoError(t, err)
+		assert.Equal(t, res[0].Result, 3)
+	})
+
+	t.Run("missing group and question are rejected", func(t *testing.T) {
+		answer := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					Answers: []Answer{
+						{QuestionID: q1.ID, AnswerID: q1.AnswersAndValue[1].ID},
+					},
+				},
+			},
+		}
+
+		_, err := answer.DoGradingProcess(p)
+		assert.Error(t, err)
+
+		answer = &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupID: g.ID,
+					Answers: []Answer{
+						{AnswerID: q1.AnswersAndValue[1].ID},
+					},
+				},
+			},
+		}
+
+		_, err = answer.DoGradingProcess(p)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown id is rejected", func(t *testing.T) {
+		unknown := uuid.New()
+		answer := &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupID: g.ID,
+					Answers: []Answer{
+						{QuestionID: q1.ID, AnswerID: unknown},
+						{QuestionID: q2.ID, AnswerID: q2.AnswersAndValue[0].ID},
+					},
+				},
+			},
+		}
+
+		_, err := answer.DoGradingProcess(p)
+		assert.EqualError(t, err, fmt.Sprintf("answer %s is not found on package", unknown))
+
+		answer = &SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupID: g.ID,
+					Answers: []Answer{
+						{QuestionID: unknown, AnswerID: q1.AnswersAndValue[1].ID},
+					},
+				},
+			},
+		}
+
+		assert.EqualError(t, answer.ValidateDraft(p), fmt.Sprintf("question %s is not found on package", unknown))
+	})
+
+	t.Run("duplicate ids are rejected", func(t *testing.T) {
+		other := newPackage()
+		other.SubGroupDetails[0].QuestionAndAnswerLists[1].ID = q1.ID
+		other.SubGroupDetails[0].QuestionAndAnswerLists[0].ID = q1.ID
+
+		assert.Error(t, other.PartialValidation())
+	})
+}
+
+func TestSDT_SDPackage_TestOrder(t *testing.T) {
+	p := &SDPackage{
+		PackageName: "test",
+		TemplateID:  uuid.New(),
+		SubGroupDetails: []SDSubGroupDetail{
+			{
+				Name:             "satu",
+				NameTranslations: Translations{LocaleEnglish: "one"},
+				QuestionAndAnswerLists: []SDQuestionAndAnswers{
+					{Question: "a?", AnswersAndValue: []SDAnswerAndValue{{Text: "ya", Value: 1}, {Text: "tidak", Value: 2}}},
+					{Question: "b?", AnswersAndValue: []SDAnswerAndValue{{Text: "ya", Value: 1}, {Text: "tidak", Value: 2}}},
+				},
+			},
+			{
+				Name: "dua",
+				QuestionAndAnswerLists: []SDQuestionAndAnswers{
+					{Question: "c?", AnswersAndValue: []SDAnswerAndValue{{Text: "ya", Value: 1}, {Text: "tidak", Value: 2}}},
+				},
+			},
+		},
+	}
+	p.EnsureIDs()
+
+	reverse := func(n int, swap func(i, j int)) {
+		for i := 0; i < n/2; i++ {
+			swap(i, n-1-i)
+		}
+	}
+
+	render := func(groups []SDTestGroupQuestions) []string {
+		rendered := []string{}
+		for _, g := range groups {
+			rendered = append(rendered, g.GroupName)
+			for _, q := range g.Questions {
+				rendered = append(rendered, q.Question+" "+strings.Join(q.Answers, "/"))
+			}
+		}
+
+		return rendered
+	}
+
+	t.Run("without randomization keep the package order", func(t *testing.T) {
+		order := p.GenerateTestOrder(nil, reverse)
+		assert.Equal(t, render(p.RenderOrderedTestQuestions(DefaultLocale, order)), []string{
+			"satu", "a? ya/tidak", "b? ya/tidak", "dua", "c? ya/tidak",
+		})
+	})
+
+	t.Run("only shuffle the enabled parts", func(t *testing.T) {
+		order := p.GenerateTestOrder(&SDRandomization{ShuffleQuestions: true}, reverse)
+		assert.Equal(t, render(p.RenderOrderedTestQuestions(DefaultLocale, order)), []string{
+			"satu", "b? ya/tidak", "a? ya/tidak", "dua", "c? ya/tidak",
+		})
+
+		order = p.GenerateTestOrder(&SDRandomization{ShuffleGroups: true, ShuffleAnswers: true}, reverse)
+		assert.Equal(t, render(p.RenderOrderedTestQuestions(LocaleEnglish, order)), []string{
+			"dua", "c? tidak/ya", "one", "a? tidak/ya", "b? tidak/ya",
+		})
+	})
+
+	t.Run("stored order is rendered the same after reload", func(t *testing.T) {
+		order := p.GenerateTestOrder(&SDRandomization{ShuffleGroups: true, ShuffleQuestions: true, ShuffleAnswers: true}, rand.Shuffle)
+
+		raw, err := json.Marshal(order)
+		assert.NoError(t, err)
+
+		stored := SDTestOrder{}
+		assert.NoError(t, stored.Scan(context.Background(), nil, reflect.Value{}, raw))
+		assert.Equal(t, p.RenderOrderedTestQuestions(DefaultLocale, stored), p.RenderOrderedTestQuestions(DefaultLocale, order))
+	})
+
+	t.Run("missing order keep the package order", func(t *testing.T) {
+		order := SDTestOrder{
+			Groups: []SDTestGroupOrder{
+				{
+					GroupID: p.SubGroupDetails[1].ID,
+				},
+			},
+		}
+
+		assert.Equal(t, render(p.RenderOrderedTestQuestions(DefaultLocale, order)), []string{
+			"dua", "c? ya/tidak", "satu", "a? ya/tidak", "b? ya/tidak",
+		})
+		assert.Equal(t, render(p.RenderOrderedTestQuestions(DefaultLocale, SDTestOrder{})), []string{
+			"satu", "a? ya/tidak", "b? ya/tidak", "dua", "c? ya/tidak",
+		})
+	})
+}
+
+func TestSDTestStatisticInput_ToWhereQuery(t *testing.T) {
+	childID := uuid.New()
+	templateID := uuid.New()
+	from := time.Now().UTC().Add(-time.Hour)
+	to := time.Now().UTC()
+
+	t.Run("no filter counts only the tests of the user itself and default pagination", func(t *testing.T) {
+		in := &SDTestStatisticInput{Limit: 1000, Offset: -1}
+		where, conds := in.ToWhereQuery()
+		assert.Equal(t, where, []string{"tr.child_id IS NULL"})
+		assert.Empty(t, conds)
+		assert.Equal(t, in.Limit, 100)
+		assert.Equal(t, in.Offset, 0)
+	})
+
+	t.Run("all filters", func(t *testing.T) {
+		in := &SDTestStatisticInput{
+			ChildID:    uuid.NullUUID{UUID: childID, Valid: true},
+			TemplateID: uuid.NullUUID{UUID: templateID, Valid: true},
+			From:       from,
+			To:         to,
+			Limit:      10,
+		}
+		where, conds := in.ToWhereQuery()
+		assert.Equal(t, where, []string{"tr.child_id = ?", "tr.template_id = ?", "tr.finished_at >= ?", "tr.finished_at <= ?"})
+		assert.Equal(t, conds, []interface{}{in.ChildID, in.TemplateID, from, to})
+		assert.Equal(t, in.Limit, 10)
+	})
+}
+
+func TestSDTestStatistic_ComputeTrends(t *testing.T) {
+	t.Run("empty stats", func(t *testing.T) {
+		s := &SDTestStatistic{}
+		s.ComputeTrends()
+		assert.Equal(t, s.Trend, SDTrendStable)
+		assert.Equal(t, s.SubGroupTrends, []SDSubGroupTrend{})
+	})
+
+	t.Run("changes and trends per sub group", func(t *testing.T) {
+		tid1, tid2, tid3 := uuid.New(), uuid.New(), uuid.New()
+		first := SDTestResult{Total: 10, Result: []SDTestGroupResult{{GroupName: "a", Result: 6}, {GroupName: "b", Result: 4}}}
+		second := SDTestResult{Total: 12, Result: []SDTestGroupResult{{GroupName: "a", Result: 6}, {GroupName: "b", Result: 6}}}
+		third := SDTestResult{Total: 11, Result: []SDTestGroupResult{{GroupName: "a", Result: 2}, {GroupName: "c", Result: 9}}}
+
+		s := &SDTestStatistic{
+			Stats: []StatsComponent{
+				{TestResultID: tid1, ResultPoint: first.Total, SubGroups: first.Result, PreviousResult: &SDTestResult{Total: 15, Result: []SDTestGroupResult{{GroupName: "a", Result: 8}}}},
+				{TestResultID: tid2, ResultPoint: second.Total, SubGroups: second.Result, PreviousResult: &first},
+				{TestResultID: tid3, ResultPoint: third.Total, SubGroups: third.Result, PreviousResult: &second},
+			},
+		}
+		s.ComputeTrends()
+
+		assert.Equal(t, *s.Stats[0].Change, -5)
+		assert.Equal(t, *s.Stats[1].Change, 2)
+		assert.Equal(t, *s.Stats[2].Change, -1)
+		assert.Equal(t, s.Trend, SDTrendIncreasing)
+
+		assert.Equal(t, len(s.SubGroupTrends), 3)
+
+		a := s.SubGroupTrends[0]
+		assert.Equal(t, a.GroupName, "a")
+		assert.Equal(t, a.Trend, SDTrendDecreasing)
+		assert.Equal(t, len(a.Series), 3)
+		assert.Equal(t, *a.Series[0].Change, -2)
+		assert.Equal(t, *a.Series[1].Change, 0)
+		assert.Equal(t, *a.Series[2].Change, -4)
+		assert.Equal(t, a.Series[2].TestResultID, tid3)
+
+		b := s.SubGroupTrends[1]
+		assert.Equal(t, b.GroupName, "b")
+		assert.Equal(t, b.Trend, SDTrendIncreasing)
+		assert.Nil(t, b.Series[0].Change)
+		assert.Equal(t, *b.Series[1].Change, 2)
+
+		c := s.SubGroupTrends[2]
+		assert.Equal(t, c.GroupName, "c")
+		assert.Equal(t, c.Trend, SDTrendStable)
+		assert.Nil(t, c.Series[0].Change)
+	})
+
+	t.Run("first test without previous result", func(t *testing.T) {
+		s := &SDTestStatistic{Stats: []StatsComponent{{ResultPoint: 3, SubGroups: []SDTestGroupResult{{GroupName: "a", Result: 3}}}}}
+		s.ComputeTrends()
+		assert.Nil(t, s.Stats[0].Change)
+		assert.Nil(t, s.SubGroupTrends[0].Series[0].Change)
+		assert.Equal(t, s.SubGroupTrends[0].Trend, SDTrendStable)
+	})
+}
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// TestType define the ATEC test type, used as the discriminator on test_templates and test_packages table
+type TestType string
+
+// list of known ATEC test types
+const (
+	TestTypeSpeechDelay               TestType = "speech_delay"
+	TestTypeSociability               TestType = "sociability"
+	TestTypeSensoryCognitiveAwareness TestType = "sensory_cognitive_awareness"
+	TestTypeHealthBehaviour           TestType = "health_behaviour"
+)
+
+// OrDefault will return TestTypeSpeechDelay when the test type is empty.
+// Records created before the type discriminator exists are all speech delay test.
+func (tt TestType) OrDefault() TestType {
+	if tt == "" {
+		return TestTypeSpeechDelay
+	}
+
+	return tt
+}
+
+// TestTypeDefinition define the behaviour of an ATEC test type. Every test type share the same sub group,
+// question and answer structure defined by SDTemplate and SDPackage, but each can define their own rules on top of it.
+type TestTypeDefinition interface {
+	// Type return the test type discriminator
+	Type() TestType
+
+	// ValidateTemplate will ensure the template satisfy all the rules of this test type
+	ValidateTemplate(t *SDTemplate) error
+
+	// ValidatePackage will ensure the package satisfy all the rules of this test type and the template
+	ValidatePackage(p *SDPackage, t *SpeechDelayTemplate) error
+
+	// Grade will grade the test answer against the package
+	Grade(answer *SDTestAnswer, p *SDPackage) (SDTestResult, error)
+
+	// ResultTitle return the title rendered on the test result, written in the locale
+	ResultTitle(locale Locale) string
+}
+
+type atecTestType struct {
+	testType          TestType
+	resultTitle       string
+	resultTitleByLang Translations
+}
+
+// NewATECTestType create the standard ATEC TestTypeDefinition which use the default template and package rules
+// and sum all the group results as the total. The resultTitle is written in DefaultLocale, and optionally
+// translated to other locales by resultTitleTranslations.
+func NewATECTestType(testType TestType, resultTitle string, resultTitleTranslations Translations) TestTypeDefinition {
+	return &atecTestType{
+		testType:          testType,
+		resultTitle:       resultTitle,
+		resultTitleByLang: resultTitleTranslations,
+	}
+}
+
+func (a *atecTestType) Type() TestType {
+	return a.testType
+}
+
+func (a *atecTestType) ValidateTemplate(t *SDTemplate) error {
+	return t.FullValidation()
+}
+
+func (a *atecTestType) ValidatePackage(p *SDPackage, t *SpeechDelayTemplate) error {
+	return p.FullValidation(t)
+}
+
+func (a *atecTestType) Grade(answer *SDTestAnswer, p *SDPackage) (SDTestResult, error) {
+	grade, err := answer.DoGradingProcess(p)
+	if err != nil {
+		return SDTestResult{}, err
+	}
+
+	total := 0
+	for _, v := range grade {
+		total += v.Result
+	}
+
+	return SDTestResult{
+		Result: grade,
+		Total:  total,
+	}, nil
+}
+
+func (a *atecTestType) ResultTitle(locale Locale) string {
+	return a.resultTitleByLang.Translate(locale, a.resultTitle)
+}
+
+var testTypeRegistry = struct {
+	sync.RWMutex
+	definitions map[TestType]TestTypeDefinition
+}{
+	definitions: make(map[TestType]TestTypeDefinition),
+}
+
+func init() {
+	RegisterTestType(NewATECTestType(TestTypeSpeechDelay, "Hasil Score ATEC", Translations{
+		LocaleEnglish: "ATEC Score Result",
+	}))
+	RegisterTestType(NewATECTestType(TestTypeSociability, "Hasil Score ATEC - Sosialisasi", Translations{
+		LocaleEnglish: "ATEC Score Result - Sociability",
+	}))
+	RegisterTestType(NewATECTestType(TestTypeSensoryCognitiveAwareness, "Hasil Score ATEC - Kesadaran Sensorik / Kognitif", Translations{
+		LocaleEnglish: "ATEC Score Result - Sensory / Cognitive Awareness",
+	}))
+	RegisterTestType(NewATECTestType(TestTypeHealthBehaviour, "Hasil Score ATEC - Kesehatan / Fisik / Perilaku", Translations{
+		LocaleEnglish: "ATEC Score Result - Health / Physical / Behavior",
+	}))
+}
+
+// RegisterTestType register the test type definition so it can be used by the templates and packages.
+// Registering the same test type twice will replace the previous definition.
+func RegisterTestType(def TestTypeDefinition) {
+	testTypeRegistry.Lock()
+	defer testTypeRegistry.Unlock()
+
+	testTypeRegistry.definitions[def.Type()] = def
+}
+
+// GetTestTypeDefinition return the registered test type definition. Empty test type will be treated as TestTypeSpeechDelay.
+// Return error if the test type is not registered
+func GetTestTypeDefinition(tt TestType) (TestTypeDefinition, error) {
+	testTypeRegistry.RLock()
+	defer testTypeRegistry.RUnlock()
+
+	def, ok := testTypeRegistry.definitions[tt.OrDefault()]
+	if !ok {
+		return nil, fmt.Errorf("test type %s is not registered", tt)
+	}
+
+	return def, nil
+}
+
+// RegisteredTestTypes return all the registered test types, sorted by name
+func RegisteredTestTypes() []TestType {
+	testTypeRegistry.RLock()
+	defer testTypeRegistry.RUnlock()
+
+	types := []TestType{}
+	for t := range testTypeRegistry.definitions {
+		types = append(types, t)
+	}
+
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+
+	return types
+}
@@ -0,0 +1,130 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestType_OrDefault(t *testing.T) {
+	assert.Equal(t, TestType("").OrDefault(), TestTypeSpeechDelay)
+	assert.Equal(t, TestTypeSociability.OrDefault(), TestTypeSociability)
+}
+
+func TestTestType_Registry(t *testing.T) {
+	t.Run("default test types are registered", func(t *testing.T) {
+		for _, tt := range []TestType{TestTypeSpeechDelay, TestTypeSociability, TestTypeSensoryCognitiveAwareness, TestTypeHealthBehaviour} {
+			def, err := GetTestTypeDefinition(tt)
+			assert.NoError(t, err)
+			assert.Equal(t, def.Type(), tt)
+		}
+	})
+
+	t.Run("empty test type is treated as speech delay", func(t *testing.T) {
+		def, err := GetTestTypeDefinition("")
+		assert.NoError(t, err)
+		assert.Equal(t, def.Type(), TestTypeSpeechDelay)
+		assert.Equal(t, def.ResultTitle(DefaultLocale), "Hasil Score ATEC")
+		assert.Equal(t, def.ResultTitle(LocaleEnglish), "ATEC Score Result")
+		assert.Equal(t, def.ResultTitle("fr"), "Hasil Score ATEC")
+	})
+
+	t.Run("unknown test type", func(t *testing.T) {
+		_, err := GetTestTypeDefinition("unknown")
+		assert.Error(t, err)
+	})
+
+	t.Run("register new test type", func(t *testing.T) {
+		tt := TestType("testing_only")
+		RegisterTestType(NewATECTestType(tt, "testing", nil))
+
+		def, err := GetTestTypeDefinition(tt)
+		assert.NoError(t, err)
+		assert.Equal(t, def.ResultTitle(LocaleEnglish), "testing")
+		assert.Contains(t, RegisteredTestTypes(), tt)
+
+		testTypeRegistry.Lock()
+		delete(testTypeRegistry.definitions, tt)
+		testTypeRegistry.Unlock()
+	})
+}
+
+func TestTestType_ATECGrade(t *testing.T) {
+	def := NewATECTestType(TestTypeSociability, "title", nil)
+	p := &SDPackage{
+		PackageName: "test",
+		TemplateID:  uuid.New(),
+		SubGroupDetails: []SDSubGroupDetail{
+			{
+				Name: "a",
+				QuestionAndAnswerLists: []SDQuestionAndAnswers{
+					{
+						Question: "q",
+						AnswersAndValue: []SDAnswerAndValue{
+							{
+								Text:  "x",
+								Value: 1,
+							},
+							{
+								Text:  "y",
+								Value: 2,
+							},
+						},
+					},
+				},
+			},
+			{
+				Name: "b",
+				QuestionAndAnswerLists: []SDQuestionAndAnswers{
+					{
+						Question: "q",
+						AnswersAndValue: []SDAnswerAndValue{
+							{
+								Text:  "x",
+								Value: 1,
+							},
+							{
+								Text:  "y",
+								Value: 2,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("invalid answer", func(t *testing.T) {
+		_, err := def.Grade(&SDTestAnswer{}, p)
+		assert.Error(t, err)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		res, err := def.Grade(&SDTestAnswer{
+			TestAnswers: []*TestAnswer{
+				{
+					GroupName: "a",
+					Answers: []Answer{
+						{
+							Question: "q",
+							Answer:   "y",
+						},
+					},
+				},
+				{
+					GroupName: "b",
+					Answers: []Answer{
+						{
+							Question: "q",
+							Answer:   "x",
+						},
+					},
+				},
+			},
+		}, p)
+		assert.NoError(t, err)
+		assert.Equal(t, res.Total, 3)
+		assert.Equal(t, len(res.Result), 2)
+	})
+}
@@ -14,10 +14,13 @@ type Task string
 const (
 	TaskSendEmail                 Task = "ATEC-API:sendEmail"
 	TaskEnforceActiveTokenLimiter Task = "ATEC-API:enforceActiveTokenLImiter"
+	TaskSweepExpiredSDTest        Task = "ATEC-API:sweepExpiredSDTest"
+	TaskDeliverSDTestResult       Task = "ATEC-API:deliverSDTestResult"
 )
 
 // WorkerClient is the interface for all worker client mainly to enqueue task
 type WorkerClient interface {
 	EnqueueSendEmailTask(ctx context.Context, id uuid.UUID) (*asynq.TaskInfo, error)
 	EnqueueEnforceActiveTokenLimiterTask(ctx context.Context, userID uuid.UUID) (*asynq.TaskInfo, error)
+	EnqueueDeliverSDTestResultTask(ctx context.Context, testID uuid.UUID) (*asynq.TaskInfo, error)
 }
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type cpRepo struct {
+	db *gorm.DB
+}
+
+// NewChildProfileRepository create new ChildProfileRepository
+func NewChildProfileRepository(db *gorm.DB) model.ChildProfileRepository {
+	return &cpRepo{db}
+}
+
+func (r *cpRepo) Create(ctx context.Context, child *model.ChildProfile) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "cpRepo.Create",
+		"input": helper.Dump(child),
+	})
+
+	if err := r.db.WithContext(ctx).Create(child).Error; err != nil {
+		logger.WithError(err).Error("failed to create child profile")
+		return err
+	}
+
+	return nil
+}
+
+func (r *cpRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.ChildProfile, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func": "cpRepo.FindByID",
+		"id":   id.String(),
+	})
+
+	child := &model.ChildProfile{}
+	err := r.db.WithContext(ctx).Take(child, "id = ?", id).Error
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find child profile")
+		return nil, err
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	case nil:
+		return child, nil
+	}
+}
+
+func (r *cpRepo) Search(ctx context.Context, input *model.SearchChildProfileInput) ([]*model.ChildProfile, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "cpRepo.Search",
+		"input": helper.Dump(input),
+	})
+
+	query := r.db.WithContext(ctx)
+	where, conds := input.ToWhereQuery()
+	for i := 0; i < len(where); i++ {
+		query = query.Where(where[i], conds[i])
+	}
+
+	var children []*model.ChildProfile
+	err := query.Limit(input.Limit).Offset(input.Offset).Order("created_at ASC").Find(&children).Error
+	if err != nil {
+		logger.WithError(err).Error("failed to search child profile")
+		return nil, err
+	}
+
+	return children, nil
+}
+
+func (r *cpRepo) Update(ctx context.Context, child *model.ChildProfile) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "cpRepo.Update",
+		"input": helper.Dump(child),
+	})
+
+	if err := r.db.WithContext(ctx).Save(child).Error; err != nil {
+		logger.WithError(err).Error("failed to update child profile")
+		return err
+	}
+
+	return nil
+}
+
+func (r *cpRepo) Delete(ctx context.Context, id uuid.UUID) (*model.ChildProfile, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "cpRepo.Delete",
+		"input": helper.Dump(id),
+	})
+
+	deleted := &model.ChildProfile{}
+	err := r.db.WithContext(ctx).Clauses(clause.Returning{}).Delete(deleted, "id = ?", id).Error
+	if err != nil {
+		logger.WithError(err).Error("failed to delete child profile")
+		return nil, err
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestChildProfileRepository_Create(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewChildProfileRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	now := time.Now().UTC()
+	c := &model.ChildProfile{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		Name:      "name",
+		BirthDate: now.AddDate(-3, 0, 0),
+		Sex:       model.ChildSexFemale,
+		Notes:     "notes",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^INSERT INTO "child_profiles"`).
+					WithArgs(c.ID, c.UserID, c.Name, c.BirthDate, c.Sex, c.Notes, c.CreatedAt, c.UpdatedAt, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				err := repo.Create(ctx, c)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^INSERT INTO "child_profiles"`).
+					WithArgs(c.ID, c.UserID, c.Name, c.BirthDate, c.Sex, c.Notes, c.CreatedAt, c.UpdatedAt, sqlmock.AnyArg()).
+					WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				err := repo.Create(ctx, c)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestChildProfileRepository_FindByID(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewChildProfileRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+	id := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "child_profiles" WHERE`).
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "sex"}).AddRow(id, model.ChildSexMale))
+			},
+			Run: func() {
+				res, err := repo.FindByID(ctx, id)
+				assert.NoError(t, err)
+				assert.Equal(t, res.ID, id)
+				assert.Equal(t, res.Sex, model.ChildSexMale)
+			},
+		},
+		{
+			Name: "not found",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "child_profiles" WHERE`).
+					WithArgs(id).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			Run: func() {
+				_, err := repo.FindByID(ctx, id)
+				assert.Equal(t, err, ErrNotFound)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "child_profiles" WHERE`).
+					WithArgs(id).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.FindByID(ctx, id)
+				assert.Error(t, err)
+				assert.Equal(t, err.Error(), "err db")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestChildProfileRepository_Search(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewChildProfileRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+	id := uuid.New()
+	userID := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok without filter",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "child_profiles" WHERE "child_profiles"."deleted_at" IS NULL ORDER BY created_at ASC LIMIT 100`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+			},
+			Run: func() {
+				res, err := repo.Search(ctx, &model.SearchChildProfileInput{})
+				assert.NoError(t, err)
+				assert.Equal(t, res[0].ID, id)
+			},
+		},
+		{
+			Name: "ok filtered by user",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "child_profiles" WHERE user_id = .+`).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+			},
+			Run: func() {
+				res, err := repo.Search(ctx, &model.SearchChildProfileInput{
+					UserID: uuid.NullUUID{UUID: userID, Valid: true},
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, res[0].ID, id)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "child_profiles"`).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.Search(ctx, &model.SearchChildProfileInput{})
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestChildProfileRepository_Update(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewChildProfileRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	now := time.Now().UTC()
+	c := &model.ChildProfile{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		Name:      "name",
+		BirthDate: now.AddDate(-3, 0, 0),
+		Sex:       model.ChildSexMale,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "child_profiles" SET`).
+					WithArgs(c.UserID, c.Name, c.BirthDate, c.Sex, c.Notes, c.CreatedAt, sqlmock.AnyArg(), sqlmock.AnyArg(), c.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				err := repo.Update(ctx, c)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "child_profiles" SET`).
+					WithArgs(c.UserID, c.Name, c.BirthDate, c.Sex, c.Notes, c.CreatedAt, sqlmock.AnyArg(), sqlmock.AnyArg(), c.ID).
+					WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				err := repo.Update(ctx, c)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestChildProfileRepository_Delete(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewChildProfileRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+	id := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE "child_profiles" SET`).WithArgs(sqlmock.AnyArg(), id).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				res, err := repo.Delete(ctx, id)
+				assert.NoError(t, err)
+				assert.Equal(t, res.ID, id)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE "child_profiles" SET`).WithArgs(sqlmock.AnyArg(), id).WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				_, err := repo.Delete(ctx, id)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
@@ -26,8 +26,25 @@ func (r *emailRepo) Create(ctx context.Context, email *model.Email) error {
 		"data": helper.Dump(email),
 	})
 
-	if err := r.db.WithContext(ctx).Create(email).Error; err != nil {
-		logger.WithError(err).Error("failed to write emails data to db")
+	if len(email.Attachments) == 0 {
+		if err := r.db.WithContext(ctx).Create(email).Error; err != nil {
+			logger.WithError(err).Error("failed to write emails data to db")
+			return err
+		}
+
+		return nil
+	}
+
+	// the email is only sent with all of its attachments, thus both are written on the same transaction
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(email).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&email.Attachments).Error
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to write emails data and its attachments to db")
 		return err
 	}
 
@@ -66,3 +83,19 @@ func (r *emailRepo) Update(ctx context.Context, email *model.Email) error {
 
 	return nil
 }
+
+func (r *emailRepo) FindAttachmentsByEmailID(ctx context.Context, emailID uuid.UUID) ([]model.EmailAttachment, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func": "emailRepo.FindAttachmentsByEmailID",
+		"data": helper.Dump(emailID),
+	})
+
+	attachments := []model.EmailAttachment{}
+	err := r.db.WithContext(ctx).Where("email_id = ?", emailID).Order("created_at ASC").Find(&attachments).Error
+	if err != nil {
+		logger.WithError(err).Error("failed to read email attachments data from db")
+		return nil, err
+	}
+
+	return attachments, nil
+}
@@ -32,6 +32,23 @@ func TestEmailRepository_Create(t *testing.T) {
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
 	}
+	attachment := model.EmailAttachment{
+		ID:          uuid.New(),
+		EmailID:     uuid.New(),
+		Filename:    "result.png",
+		ContentType: "image/png",
+		Content:     []byte("content"),
+		CreatedAt:   time.Now().UTC(),
+	}
+	withAttachments := &model.Email{
+		ID:          attachment.EmailID,
+		Subject:     "test subject",
+		Body:        "test body",
+		To:          pq.StringArray{"test1@gmail.com"},
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+		Attachments: []model.EmailAttachment{attachment},
+	}
 
 	tests := []common.TestStructure{
 		{
@@ -62,6 +79,40 @@ func TestEmailRepository_Create(t *testing.T) {
 				assert.Error(t, err)
 			},
 		},
+		{
+			Name: "success with attachments",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^INSERT INTO "emails"`).
+					WithArgs(withAttachments.ID, withAttachments.Subject, withAttachments.Body, withAttachments.To, withAttachments.Cc, withAttachments.Bcc, sqlmock.AnyArg(), withAttachments.Deadline, sqlmock.AnyArg(), sqlmock.AnyArg(), withAttachments.CreatedAt, withAttachments.UpdatedAt, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`^INSERT INTO "email_attachments"`).
+					WithArgs(attachment.ID, attachment.EmailID, attachment.Filename, attachment.ContentType, attachment.Content, attachment.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				err := repo.Create(ctx, withAttachments)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name: "failed on writing attachments",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^INSERT INTO "emails"`).
+					WithArgs(withAttachments.ID, withAttachments.Subject, withAttachments.Body, withAttachments.To, withAttachments.Cc, withAttachments.Bcc, sqlmock.AnyArg(), withAttachments.Deadline, sqlmock.AnyArg(), sqlmock.AnyArg(), withAttachments.CreatedAt, withAttachments.UpdatedAt, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`^INSERT INTO "email_attachments"`).
+					WithArgs(attachment.ID, attachment.EmailID, attachment.Filename, attachment.ContentType, attachment.Content, attachment.CreatedAt).
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				err := repo.Create(ctx, withAttachments)
+				assert.Error(t, err)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -190,3 +241,63 @@ func TestEmailRepository_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestEmailRepository_FindAttachmentsByEmailID(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewEmailRepository(kit.DB)
+	mock := kit.DBmock
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "email_attachments" WHERE`).
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email_id", "filename"}).AddRow(uuid.New(), id, "result.png"))
+			},
+			Run: func() {
+				res, err := repo.FindAttachmentsByEmailID(ctx, id)
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+				assert.Equal(t, res[0].EmailID, id)
+				assert.Equal(t, res[0].Filename, "result.png")
+			},
+		},
+		{
+			Name: "no attachments",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "email_attachments" WHERE`).
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email_id", "filename"}))
+			},
+			Run: func() {
+				res, err := repo.FindAttachmentsByEmailID(ctx, id)
+				assert.NoError(t, err)
+				assert.Len(t, res, 0)
+			},
+		},
+		{
+			Name: "db return error",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "email_attachments" WHERE`).
+					WithArgs(id).
+					WillReturnError(errors.New("db error"))
+			},
+			Run: func() {
+				_, err := repo.FindAttachmentsByEmailID(ctx, id)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+	"gorm.io/gorm"
+)
+
+type sdanRepo struct {
+	db *gorm.DB
+}
+
+// NewSDAnalyticsRepository create new SDAnalyticsRepository
+func NewSDAnalyticsRepository(db *gorm.DB) model.SDAnalyticsRepository {
+	return &sdanRepo{db}
+}
+
+func (r *sdanRepo) TotalScoreDistribution(ctx context.Context, filter *model.SDAnalyticsFilter) ([]model.SDScoreDistributionRow, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdanRepo.TotalScoreDistribution",
+		"input": helper.Dump(filter),
+	})
+
+	where, conds := filter.ToWhereQuery()
+
+	var rows []model.SDScoreDistributionRow
+	err := r.db.WithContext(ctx).
+		Raw(fmt.Sprintf(`
+			SELECT
+			tr.user_id IS NOT NULL AS registered,
+			(tr."result" ->> 'total')::int AS point,
+			COUNT(tr.id) AS count,
+			COUNT(tr.id) FILTER (WHERE (tr."result" -> 'interpretation' ->> 'isPositive')::bool) AS positive
+				FROM test_results tr
+						WHERE %s
+						GROUP BY registered, point
+						ORDER BY registered, point;
+		`, strings.Join(where, " AND ")), conds...).Scan(&rows).Error
+	if err != nil {
+		logger.WithError(err).Error("failed to aggregate total score distribution")
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *sdanRepo) SubGroupScoreDistribution(ctx context.Context, filter *model.SDAnalyticsFilter) ([]model.SDScoreDistributionRow, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdanRepo.SubGroupScoreDistribution",
+		"input": helper.Dump(filter),
+	})
+
+	where, conds := filter.ToWhereQuery()
+
+	var rows []model.SDScoreDistributionRow
+	err := r.db.WithContext(ctx).
+		Raw(fmt.Sprintf(`
+			SELECT
+			tr.user_id IS NOT NULL AS registered,
+			sg ->> 'groupName' AS group_name,
+			(sg ->> 'result')::int AS point,
+			COUNT(tr.id) AS count
+				FROM test_results tr
+					CROSS JOIN LATERAL JSONB_ARRAY_ELEMENTS(tr."result" -> 'result') AS sg
+						WHERE %s
+						GROUP BY registered, group_name, point
+						ORDER BY registered, group_name, point;
+		`, strings.Join(where, " AND ")), conds...).Scan(&rows).Error
+	if err != nil {
+		logger.WithError(err).Error("failed to aggregate sub group score distribution")
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// rawFinishedAnswer is used to scan the answer of the finished test
+type rawFinishedAnswer struct {
+	Answer model.SDTestAnswer `gorm:"column:answer"`
+}
+
+func (r *sdanRepo) FindFinishedAnswers(ctx context.Context, filter *model.SDAnalyticsFilter) ([]model.SDTestAnswer, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdanRepo.FindFinishedAnswers",
+		"input": helper.Dump(filter),
+	})
+
+	where, conds := filter.ToWhereQuery()
+
+	var rows []rawFinishedAnswer
+	err := r.db.WithContext(ctx).
+		Raw(fmt.Sprintf(`
+			SELECT tr.answer
+				FROM test_results tr
+						WHERE %s
+						ORDER BY tr.finished_at ASC;
+		`, strings.Join(where, " AND ")), conds...).Scan(&rows).Error
+	if err != nil {
+		logger.WithError(err).Error("failed to find finished test answers")
+		return nil, err
+	}
+
+	answers := []model.SDTestAnswer{}
+	for _, row := range rows {
+		answers = append(answers, row.Answer)
+	}
+
+	return answers, nil
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSDAnalyticsRepository_TotalScoreDistribution(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDAnalyticsRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	now := time.Now().UTC()
+	filter := &model.SDAnalyticsFilter{
+		TemplateID: uuid.New(),
+		PackageID:  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		From:       now.Add(-time.Hour),
+		To:         now,
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectQuery(`SELECT .+ COUNT\(tr.id\) FILTER \(WHERE \(tr."result" -> 'interpretation' ->> 'isPositive'\)::bool\) AS positive FROM test_results tr WHERE tr.finished_at IS NOT NULL AND tr.deleted_at IS NULL AND tr.template_id = \$1 AND tr.package_id = \$2 AND tr.finished_at >= \$3 AND tr.finished_at <= \$4 GROUP BY registered, point`).
+					WithArgs(filter.TemplateID, filter.PackageID.UUID, filter.From, filter.To).
+					WillReturnRows(mock.NewRows([]string{"registered", "point", "count", "positive"}).
+						AddRow(false, 3, 2, 0).
+						AddRow(true, 10, 1, 1))
+			},
+			Run: func() {
+				res, err := repo.TotalScoreDistribution(ctx, filter)
+				assert.NoError(t, err)
+				assert.Equal(t, res, []model.SDScoreDistributionRow{
+					{Registered: false, Point: 3, Count: 2},
+					{Registered: true, Point: 10, Count: 1, Positive: 1},
+				})
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`SELECT .+ FROM test_results tr`).
+					WithArgs(filter.TemplateID, filter.PackageID.UUID, filter.From, filter.To).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.TotalScoreDistribution(ctx, filter)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDAnalyticsRepository_SubGroupScoreDistribution(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDAnalyticsRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	filter := &model.SDAnalyticsFilter{
+		TemplateID: uuid.New(),
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectQuery(`SELECT .+ FROM test_results tr CROSS JOIN LATERAL JSONB_ARRAY_ELEMENTS\(tr."result" -> 'result'\) AS sg WHERE tr.finished_at IS NOT NULL AND tr.deleted_at IS NULL AND tr.template_id = \$1 GROUP BY registered, group_name, point`).
+					WithArgs(filter.TemplateID).
+					WillReturnRows(mock.NewRows([]string{"registered", "group_name", "point", "count"}).
+						AddRow(false, "a", 1, 2).
+						AddRow(true, "b", 4, 1))
+			},
+			Run: func() {
+				res, err := repo.SubGroupScoreDistribution(ctx, filter)
+				assert.NoError(t, err)
+				assert.Equal(t, res, []model.SDScoreDistributionRow{
+					{Registered: false, GroupName: "a", Point: 1, Count: 2},
+					{Registered: true, GroupName: "b", Point: 4, Count: 1},
+				})
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`SELECT .+ FROM test_results tr`).
+					WithArgs(filter.TemplateID).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.SubGroupScoreDistribution(ctx, filter)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDAnalyticsRepository_FindFinishedAnswers(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDAnalyticsRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	filter := &model.SDAnalyticsFilter{
+		TemplateID: uuid.New(),
+		PackageID:  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+	}
+	questionID, answerID := uuid.New(), uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectQuery(`SELECT tr.answer FROM test_results tr WHERE tr.finished_at IS NOT NULL AND tr.deleted_at IS NULL AND tr.template_id = \$1 AND tr.package_id = \$2 ORDER BY tr.finished_at ASC`).
+					WithArgs(filter.TemplateID, filter.PackageID.UUID).
+					WillReturnRows(mock.NewRows([]string{"answer"}).
+						AddRow(`{"testAnswers":[{"groupName":"group","answers":[{"questionID":"` + questionID.String() + `","answerID":"` + answerID.String() + `"}]}]}`))
+			},
+			Run: func() {
+				res, err := repo.FindFinishedAnswers(ctx, filter)
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+				assert.Equal(t, res[0].TestAnswers[0].GroupName, "group")
+				assert.Equal(t, res[0].TestAnswers[0].Answers[0].QuestionID, questionID)
+				assert.Equal(t, res[0].TestAnswers[0].Answers[0].AnswerID, answerID)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`SELECT tr.answer FROM test_results tr`).
+					WithArgs(filter.TemplateID, filter.PackageID.UUID).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.FindFinishedAnswers(ctx, filter)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+	"gorm.io/gorm"
+)
+
+type sdaRepo struct {
+	db *gorm.DB
+}
+
+// NewSDAssignmentRepository create new SDAssignmentRepository
+func NewSDAssignmentRepository(db *gorm.DB) model.SDAssignmentRepository {
+	return &sdaRepo{db}
+}
+
+func (r *sdaRepo) Create(ctx context.Context, assignment *model.SDAssignment, tx *gorm.DB) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdaRepo.Create",
+		"input": helper.Dump(assignment),
+	})
+
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(assignment).Error; err != nil {
+		logger.WithError(err).Error("failed to create test assignment")
+		return err
+	}
+
+	return nil
+}
+
+func (r *sdaRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.SDAssignment, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func": "sdaRepo.FindByID",
+		"id":   id.String(),
+	})
+
+	assignment := &model.SDAssignment{}
+	err := r.db.WithContext(ctx).Take(assignment, "id = ?", id).Error
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find test assignment")
+		return nil, err
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	case nil:
+		return assignment, nil
+	}
+}
+
+func (r *sdaRepo) Update(ctx context.Context, assignment *model.SDAssignment, tx *gorm.DB) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdaRepo.Update",
+		"input": helper.Dump(assignment),
+	})
+
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Save(assignment).Error; err != nil {
+		logger.WithError(err).Error("failed to update test assignment")
+		return err
+	}
+
+	return nil
+}
+
+func (r *sdaRepo) Search(ctx context.Context, input *model.SearchSDAssignmentInput) ([]*model.SDAssignment, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdaRepo.Search",
+		"input": helper.Dump(input),
+	})
+
+	query := r.db.WithContext(ctx)
+	where, conds := input.ToWhereQuery()
+	for i := 0; i < len(where); i++ {
+		query = query.Where(where[i], conds[i])
+	}
+
+	var assignments []*model.SDAssignment
+	err := query.Limit(input.Limit).Offset(input.Offset).Order("due_at ASC").Find(&assignments).Error
+	if err != nil {
+		logger.WithError(err).Error("failed to search test assignment")
+		return nil, err
+	}
+
+	return assignments, nil
+}
@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
+)
+
+func TestSDAssignmentRepository_Create(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDAssignmentRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	now := time.Now().UTC()
+	a := &model.SDAssignment{
+		ID:         uuid.New(),
+		AssigneeID: uuid.New(),
+		PackageID:  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		DueAt:      now.Add(time.Hour),
+		Note:       "note",
+		Status:     model.SDAssignmentStatusAssigned,
+		CreatedBy:  uuid.New(),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^INSERT INTO "test_assignments"`).
+					WithArgs(a.ID, a.AssigneeID, a.PackageID, a.TemplateID, a.DueAt, a.Note, a.Status, a.TestID, a.CreatedBy, a.CompletedAt, a.CancelledAt, a.CreatedAt, a.UpdatedAt, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				err := repo.Create(ctx, a, nil)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^INSERT INTO "test_assignments"`).
+					WithArgs(a.ID, a.AssigneeID, a.PackageID, a.TemplateID, a.DueAt, a.Note, a.Status, a.TestID, a.CreatedBy, a.CompletedAt, a.CancelledAt, a.CreatedAt, a.UpdatedAt, sqlmock.AnyArg()).
+					WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				err := repo.Create(ctx, a, nil)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDAssignmentRepository_FindByID(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDAssignmentRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+	id := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_assignments" WHERE`).
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(id, model.SDAssignmentStatusAssigned))
+			},
+			Run: func() {
+				res, err := repo.FindByID(ctx, id)
+				assert.NoError(t, err)
+				assert.Equal(t, res.ID, id)
+				assert.Equal(t, res.Status, model.SDAssignmentStatusAssigned)
+			},
+		},
+		{
+			Name: "not found",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_assignments" WHERE`).
+					WithArgs(id).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			Run: func() {
+				_, err := repo.FindByID(ctx, id)
+				assert.Equal(t, err, ErrNotFound)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_assignments" WHERE`).
+					WithArgs(id).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.FindByID(ctx, id)
+				assert.Error(t, err)
+				assert.Equal(t, err.Error(), "err db")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDAssignmentRepository_Update(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDAssignmentRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	now := time.Now().UTC()
+	a := &model.SDAssignment{
+		ID:         uuid.New(),
+		AssigneeID: uuid.New(),
+		TemplateID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		DueAt:      now.Add(time.Hour),
+		Status:     model.SDAssignmentStatusInProgress,
+		TestID:     uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		CreatedBy:  uuid.New(),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_assignments" SET`).
+					WithArgs(a.AssigneeID, a.PackageID, a.TemplateID, a.DueAt, a.Note, a.Status, a.TestID, a.CreatedBy, a.CompletedAt, a.CancelledAt, a.CreatedAt, sqlmock.AnyArg(), sqlmock.AnyArg(), a.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				err := repo.Update(ctx, a, nil)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_assignments" SET`).
+					WithArgs(a.AssigneeID, a.PackageID, a.TemplateID, a.DueAt, a.Note, a.Status, a.TestID, a.CreatedBy, a.CompletedAt, a.CancelledAt, a.CreatedAt, sqlmock.AnyArg(), sqlmock.AnyArg(), a.ID).
+					WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				err := repo.Update(ctx, a, nil)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDAssignmentRepository_Search(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDAssignmentRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+	id := uuid.New()
+	assigneeID := uuid.New()
+	dueBefore := time.Now().UTC()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok without filter",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_assignments" WHERE "test_assignments"."deleted_at" IS NULL ORDER BY due_at ASC LIMIT 100`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+			},
+			Run: func() {
+				res, err := repo.Search(ctx, &model.SearchSDAssignmentInput{})
+				assert.NoError(t, err)
+				assert.Equal(t, res[0].ID, id)
+			},
+		},
+		{
+			Name: "ok with all filters",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_assignments" WHERE assignee_id = .+ AND status = .+ AND due_at < .+`).
+					WithArgs(assigneeID, model.SDAssignmentStatusAssigned, dueBefore).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+			},
+			Run: func() {
+				res, err := repo.Search(ctx, &model.SearchSDAssignmentInput{
+					AssigneeID: uuid.NullUUID{UUID: assigneeID, Valid: true},
+					Status:     model.SDAssignmentStatusAssigned,
+					DueBefore:  null.NewTime(dueBefore, true),
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, res[0].ID, id)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_assignments"`).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.Search(ctx, &model.SearchSDAssignmentInput{})
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
@@ -2,13 +2,11 @@ package repository
 
 import (
 	"context"
-	"errors"
 
 	"github.com/google/uuid"
 	"github.com/luckyAkbar/atec-api/internal/model"
 	"github.com/sirupsen/logrus"
 	"github.com/sweet-go/stdlib/helper"
-	"golang.org/x/exp/slices"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -24,12 +22,23 @@ func NewSDPackageRepository(db *gorm.DB) model.SDPackageRepository {
 	}
 }
 
-func (r *sdpRepo) Create(ctx context.Context, input *model.SpeechDelayPackage) error {
+func (r *sdpRepo) Create(ctx context.Context, input *model.SpeechDelayPackage, tx *gorm.DB) error {
 	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":  "sdpRepo.Create",
 		"input": helper.Dump(input),
 	})
-	if err := r.db.WithContext(ctx).Create(input).Error; err != nil {
+	if tx == nil {
+		tx = r.db
+	}
+
+	err := tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(input).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(input.ToVersion()).Error
+	})
+	if err != nil {
 		logger.WithError(err).Error("failed to create sd package")
 		return err
 	}
@@ -139,89 +148,25 @@ func (r *sdpRepo) UndoDelete(ctx context.Context, id uuid.UUID) (*model.SpeechDe
 	return pack, nil
 }
 
-func (r *sdpRepo) FindRandomActivePackage(ctx context.Context) (*model.SpeechDelayPackage, error) {
+func (r *sdpRepo) FindActive(ctx context.Context, templateID uuid.NullUUID) ([]*model.SpeechDelayPackage, error) {
 	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
-		"func": "sdpRepo.FindRandomActivePackage",
+		"func":  "sdpRepo.FindActive",
+		"input": helper.Dump(templateID),
 	})
 
-	pack := &model.SpeechDelayPackage{}
-	err := r.db.WithContext(ctx).
-		Order("RANDOM()").
-		Limit(1).
-		Take(pack, "is_active = ?", true).Error
-	switch err {
-	default:
-		logger.WithError(err).Error("failed to find random active package")
-		return nil, err
-	case gorm.ErrRecordNotFound:
-		return nil, ErrNotFound
-	case nil:
-		return pack, nil
+	query := r.db.WithContext(ctx).Where("is_active = ?", true)
+	if templateID.Valid {
+		query = query.Where("template_id = ?", templateID.UUID)
 	}
-}
-
-type testPackageCount struct {
-	PackageID uuid.UUID `gorm:"column:package_id"`
-	Count     int       `gorm:"column:count"`
-}
 
-func (r *sdpRepo) FindLeastUsedPackageIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
-	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
-		"func":  "sdpRepo.FindLeastUsedPackageIDByUserID",
-		"input": helper.Dump(userID),
-	})
-
-	var activePackageIDs []uuid.UUID
-	err := r.db.WithContext(ctx).Model(&model.SpeechDelayPackage{}).
-		Select("id").
-		Where("is_active = ?", true).
-		Scan(&activePackageIDs).Error
-	switch err {
-	default:
+	var packs []*model.SpeechDelayPackage
+	err := query.Order("created_at ASC, id ASC").Find(&packs).Error
+	if err != nil {
 		logger.WithError(err).Error("failed to find active sd package")
-		return uuid.Nil, err
-	case gorm.ErrRecordNotFound:
-		return uuid.Nil, ErrNotFound
-	case nil:
-		if len(activePackageIDs) == 0 {
-			return uuid.Nil, ErrNotFound
-		}
-	}
-
-	var tpc []testPackageCount
-	err = r.db.WithContext(ctx).
-		Model(&model.SDTest{}).
-		Where("user_id = ?", userID).
-		Select("package_id, count(package_id) as count").
-		Group("package_id").
-		Scan(&tpc).Error
-	switch err {
-	default:
-		logger.WithError(err).Error("failed to find sd test result")
-		return uuid.Nil, err
-	case gorm.ErrRecordNotFound:
-		return uuid.Nil, ErrNotFound
-	case nil:
-		break
-	}
-
-	// here we will find a package id that doesn't exists in
-	// the ids listed in tpc
-	// if not found, it means the user never used that, so return the id immediatly
-	for _, v := range activePackageIDs {
-		found := false
-		for _, x := range tpc {
-			if v == x.PackageID {
-				found = true
-			}
-		}
-
-		if !found {
-			return v, nil
-		}
+		return []*model.SpeechDelayPackage{}, err
 	}
 
-	return findTheLeastUsedPackage(tpc)
+	return packs, nil
 }
 
 func (r *sdpRepo) GetTemplateByPackageID(ctx context.Context, packageID uuid.UUID) (*model.SpeechDelayTemplate, error) {
@@ -246,21 +191,62 @@ func (r *sdpRepo) GetTemplateByPackageID(ctx context.Context, packageID uuid.UUI
 	}
 }
 
-// will be used to find the least used package
-// originally made to reduce the code complexity in order to pass the linter
-func findTheLeastUsedPackage(tpc []testPackageCount) (uuid.UUID, error) {
-	// here we will find the least used package by user
-	arr := []int{}
-	for _, v := range tpc {
-		arr = append(arr, v.Count)
-	}
+func (r *sdpRepo) UpdateWithVersion(ctx context.Context, pack *model.SpeechDelayPackage) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":    "sdpRepo.UpdateWithVersion",
+		"package": helper.Dump(pack),
+	})
 
-	min := slices.Min(arr)
-	for _, v := range tpc {
-		if v.Count == min {
-			return v.PackageID, nil
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(pack).Error; err != nil {
+			return err
 		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "package_id"}, {Name: "version"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "package", "created_at"}),
+		}).Create(pack.ToVersion()).Error
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to update speech delay package with version")
+		return err
+	}
+
+	return nil
+}
+
+func (r *sdpRepo) FindVersions(ctx context.Context, packageID uuid.UUID) ([]*model.SDPackageVersion, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdpRepo.FindVersions",
+		"input": helper.Dump(packageID),
+	})
+
+	var versions []*model.SDPackageVersion
+	err := r.db.WithContext(ctx).Where("package_id = ?", packageID).Order("version DESC").Find(&versions).Error
+	if err != nil {
+		logger.WithError(err).Error("failed to find speech delay package versions")
+		return []*model.SDPackageVersion{}, err
 	}
 
-	return uuid.Nil, errors.New("unexpected error when trying to find least used package")
+	return versions, nil
+}
+
+func (r *sdpRepo) FindVersion(ctx context.Context, packageID uuid.UUID, version int) (*model.SDPackageVersion, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":    "sdpRepo.FindVersion",
+		"input":   helper.Dump(packageID),
+		"version": version,
+	})
+
+	v := &model.SDPackageVersion{}
+	err := r.db.WithContext(ctx).Take(v, "package_id = ? AND version = ?", packageID, version).Error
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find speech delay package version")
+		return nil, err
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	case nil:
+		return v, nil
+	}
 }
@@ -41,26 +41,46 @@ func TestSDPackageRepository_Create(t *testing.T) {
 			MockFn: func() {
 				mock.ExpectBegin()
 				mock.ExpectExec(`^INSERT INTO "test_packages"`).
-					WithArgs(pack.ID, pack.TemplateID, pack.Name, pack.CreatedBy, sqlmock.AnyArg(), pack.IsActive, pack.IsLocked, pack.CreatedAt, sqlmock.AnyArg(), pack.DeletedAt).
+					WithArgs(pack.ID, pack.TemplateID, pack.Name, pack.CreatedBy, sqlmock.AnyArg(), pack.IsActive, pack.IsLocked, pack.CreatedAt, sqlmock.AnyArg(), pack.DeletedAt, pack.Type, pack.CurrentVersion, pack.TemplateVersion).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`^INSERT INTO "test_package_versions"`).
+					WithArgs(pack.ID, pack.CurrentVersion, pack.Name, sqlmock.AnyArg(), pack.CreatedBy, pack.UpdatedAt).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
 			Run: func() {
-				err := repo.Create(ctx, pack)
+				err := repo.Create(ctx, pack, nil)
 				assert.NoError(t, err)
 			},
 		},
+		{
+			Name: "err db when creating the version",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^INSERT INTO "test_packages"`).
+					WithArgs(pack.ID, pack.TemplateID, pack.Name, pack.CreatedBy, sqlmock.AnyArg(), pack.IsActive, pack.IsLocked, pack.CreatedAt, sqlmock.AnyArg(), pack.DeletedAt, pack.Type, pack.CurrentVersion, pack.TemplateVersion).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`^INSERT INTO "test_package_versions"`).
+					WithArgs(pack.ID, pack.CurrentVersion, pack.Name, sqlmock.AnyArg(), pack.CreatedBy, pack.UpdatedAt).
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				err := repo.Create(ctx, pack, nil)
+				assert.Error(t, err)
+			},
+		},
 		{
 			Name: "err db",
 			MockFn: func() {
 				mock.ExpectBegin()
 				mock.ExpectExec(`^INSERT INTO "test_packages"`).
-					WithArgs(pack.ID, pack.TemplateID, pack.Name, pack.CreatedBy, sqlmock.AnyArg(), pack.IsActive, pack.IsLocked, pack.CreatedAt, sqlmock.AnyArg(), pack.DeletedAt).
+					WithArgs(pack.ID, pack.TemplateID, pack.Name, pack.CreatedBy, sqlmock.AnyArg(), pack.IsActive, pack.IsLocked, pack.CreatedAt, sqlmock.AnyArg(), pack.DeletedAt, pack.Type, pack.CurrentVersion, pack.TemplateVersion).
 					WillReturnError(errors.New("db error"))
 				mock.ExpectRollback()
 			},
 			Run: func() {
-				err := repo.Create(ctx, pack)
+				err := repo.Create(ctx, pack, nil)
 				assert.Error(t, err)
 			},
 		},
@@ -306,7 +326,7 @@ func TestSDPackageRepository_Update(t *testing.T) {
 			MockFn: func() {
 				mock.ExpectBegin()
 				mock.ExpectExec(`^UPDATE "test_packages" SET`).
-					WithArgs(p.TemplateID, p.Name, p.CreatedBy, sqlmock.AnyArg(), p.IsActive, p.IsLocked, p.CreatedAt, sqlmock.AnyArg(), p.DeletedAt, p.ID).
+					WithArgs(p.TemplateID, p.Name, p.CreatedBy, sqlmock.AnyArg(), p.IsActive, p.IsLocked, p.CreatedAt, sqlmock.AnyArg(), p.DeletedAt, p.Type, p.CurrentVersion, p.TemplateVersion, p.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
@@ -320,7 +340,7 @@ func TestSDPackageRepository_Update(t *testing.T) {
 			MockFn: func() {
 				mock.ExpectBegin()
 				mock.ExpectExec(`^UPDATE "test_packages" SET`).
-					WithArgs(p.TemplateID, p.Name, p.CreatedBy, sqlmock.AnyArg(), p.IsActive, p.IsLocked, p.CreatedAt, sqlmock.AnyArg(), p.DeletedAt, p.ID).
+					WithArgs(p.TemplateID, p.Name, p.CreatedBy, sqlmock.AnyArg(), p.IsActive, p.IsLocked, p.CreatedAt, sqlmock.AnyArg(), p.DeletedAt, p.Type, p.CurrentVersion, p.TemplateVersion, p.ID).
 					WillReturnError(errors.New("err db"))
 				mock.ExpectRollback()
 			},
@@ -449,7 +469,7 @@ func TestSDPackageRepository_UndoDelete(t *testing.T) {
 	}
 }
 
-func TestSDPackageRepository_FindRandomActivePackage(t *testing.T) {
+func TestSDPackageRepository_FindActive(t *testing.T) {
 	kit, closer := common.InitializeRepoTestKit(t)
 	defer closer()
 
@@ -457,55 +477,46 @@ func TestSDPackageRepository_FindRandomActivePackage(t *testing.T) {
 	ctx := context.Background()
 	mock := kit.DBmock
 
-	now := time.Now().UTC()
-	pack := &model.SpeechDelayPackage{
-		ID:         uuid.NameSpaceDNS,
-		TemplateID: uuid.New(),
-		Name:       "name",
-		CreatedBy:  uuid.New(),
-		Package:    &model.SDPackage{},
-		IsActive:   false,
-		IsLocked:   false,
-		CreatedAt:  now,
-		UpdatedAt:  now,
-	}
+	templateID := uuid.New()
+	packID := uuid.New()
 
 	tests := []common.TestStructure{
 		{
-			Name: "ok",
+			Name: "ok, all templates",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT .+ FROM "test_packages" WHERE`).
+				mock.ExpectQuery(`^SELECT \* FROM "test_packages" WHERE is_active = .+ AND "test_packages"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC`).
 					WithArgs(true).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(pack.ID))
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(packID))
 			},
 			Run: func() {
-				res, err := repo.FindRandomActivePackage(ctx)
+				res, err := repo.FindActive(ctx, uuid.NullUUID{})
 				assert.NoError(t, err)
-				assert.Equal(t, res.ID, pack.ID)
+				assert.Equal(t, len(res), 1)
+				assert.Equal(t, res[0].ID, packID)
 			},
 		},
 		{
-			Name: "not found",
+			Name: "ok, filtered by template",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT .+ FROM "test_packages" WHERE`).
-					WithArgs(true).
-					WillReturnError(gorm.ErrRecordNotFound)
+				mock.ExpectQuery(`^SELECT \* FROM "test_packages" WHERE is_active = .+ AND template_id = .+ AND "test_packages"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC`).
+					WithArgs(true, templateID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
 			},
 			Run: func() {
-				_, err := repo.FindRandomActivePackage(ctx)
-				assert.Error(t, err)
-				assert.Equal(t, err, ErrNotFound)
+				res, err := repo.FindActive(ctx, uuid.NullUUID{UUID: templateID, Valid: true})
+				assert.NoError(t, err)
+				assert.Equal(t, len(res), 0)
 			},
 		},
 		{
-			Name: "not found",
+			Name: "err db",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT .+ FROM "test_packages" WHERE`).
+				mock.ExpectQuery(`^SELECT \* FROM "test_packages" WHERE`).
 					WithArgs(true).
 					WillReturnError(errors.New("err db"))
 			},
 			Run: func() {
-				_, err := repo.FindRandomActivePackage(ctx)
+				_, err := repo.FindActive(ctx, uuid.NullUUID{})
 				assert.Error(t, err)
 			},
 		},
@@ -519,126 +530,166 @@ func TestSDPackageRepository_FindRandomActivePackage(t *testing.T) {
 	}
 }
 
-func TestSDPackage_FindLeastUsedPackageIDByUserID(t *testing.T) {
+func TestSDPackage_GetTemplateByPackageID(t *testing.T) {
 	kit, closer := common.InitializeRepoTestKit(t)
 	defer closer()
 
 	repo := NewSDPackageRepository(kit.DB)
 	ctx := context.Background()
 	mock := kit.DBmock
-
-	userID := uuid.New()
-	unusedPackageID := uuid.New()
-	leastUsedPackageID := uuid.New()
-	usedPackageID1 := uuid.New()
-	usedPackageID2 := uuid.New()
-	usedPackageID3 := uuid.New()
+	pid := uuid.New()
+	tid := uuid.New()
 
 	tests := []common.TestStructure{
 		{
-			Name: "failed when trying to find active package",
+			Name: "err db",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT "id" FROM "test_packages" WHERE is_active`).
-					WillReturnError(errors.New("err"))
+				mock.ExpectQuery(`^SELECT .+ FROM "test_templates"`).
+					WithArgs(pid).WillReturnError(errors.New("err db"))
 			},
 			Run: func() {
-				_, err := repo.FindLeastUsedPackageIDByUserID(ctx, userID)
+				_, err := repo.GetTemplateByPackageID(ctx, pid)
 				assert.Error(t, err)
 			},
 		},
 		{
-			Name: "returning error not found",
+			Name: "not found",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT "id" FROM "test_packages" WHERE is_active`).
-					WithArgs(true).
-					WillReturnError(gorm.ErrRecordNotFound)
+				mock.ExpectQuery(`^SELECT .+ FROM "test_templates"`).
+					WithArgs(pid).WillReturnError(gorm.ErrRecordNotFound)
 			},
 			Run: func() {
-				_, err := repo.FindLeastUsedPackageIDByUserID(ctx, userID)
+				_, err := repo.GetTemplateByPackageID(ctx, pid)
 				assert.Error(t, err)
 				assert.Equal(t, err, ErrNotFound)
 			},
 		},
 		{
-			Name: "if no rows found, must return err not found",
+			Name: "ok",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT "id" FROM "test_packages" WHERE is_active`).
-					WithArgs(true).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery(`^SELECT .+ FROM "test_templates"`).
+					WithArgs(pid).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(tid))
 			},
 			Run: func() {
-				_, err := repo.FindLeastUsedPackageIDByUserID(ctx, userID)
-				assert.Error(t, err)
-				assert.Equal(t, err, ErrNotFound)
+				res, err := repo.GetTemplateByPackageID(ctx, pid)
+				assert.NoError(t, err)
+				assert.Equal(t, res.ID, tid)
 			},
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDPackageRepository_UpdateWithVersion(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDPackageRepository(kit.DB)
+	mock := kit.DBmock
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	p := &model.SpeechDelayPackage{
+		ID:         uuid.New(),
+		TemplateID: uuid.New(),
+		Name:       "name",
+		CreatedBy:  uuid.New(),
+		Package: &model.SDPackage{
+			PackageName: "name",
+		},
+		IsActive:        false,
+		IsLocked:        false,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		CurrentVersion:  2,
+		TemplateVersion: 1,
+	}
+
+	tests := []common.TestStructure{
 		{
-			Name: "failed to find history test result",
+			Name: "ok",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT "id" FROM "test_packages" WHERE is_active`).
-					WithArgs(true).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
-				mock.ExpectQuery(`^SELECT .+ FROM "test_results"`).
-					WithArgs(userID).
-					WillReturnError(errors.New("err db"))
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_packages" SET`).
+					WithArgs(p.TemplateID, p.Name, p.CreatedBy, sqlmock.AnyArg(), p.IsActive, p.IsLocked, p.CreatedAt, sqlmock.AnyArg(), p.DeletedAt, p.Type, p.CurrentVersion, p.TemplateVersion, p.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`^INSERT INTO "test_package_versions" .+ ON CONFLICT \("package_id","version"\) DO UPDATE`).
+					WithArgs(p.ID, p.CurrentVersion, p.Name, sqlmock.AnyArg(), p.CreatedBy, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			},
 			Run: func() {
-				_, err := repo.FindLeastUsedPackageIDByUserID(ctx, userID)
-				assert.Error(t, err)
+				err := repo.UpdateWithVersion(ctx, p)
+				assert.NoError(t, err)
 			},
 		},
 		{
-			Name: "error not found",
+			Name: "err db when saving the version",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT "id" FROM "test_packages" WHERE is_active`).
-					WithArgs(true).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
-				mock.ExpectQuery(`^SELECT .+ FROM "test_results"`).
-					WithArgs(userID).
-					WillReturnError(gorm.ErrRecordNotFound)
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_packages" SET`).
+					WithArgs(p.TemplateID, p.Name, p.CreatedBy, sqlmock.AnyArg(), p.IsActive, p.IsLocked, p.CreatedAt, sqlmock.AnyArg(), p.DeletedAt, p.Type, p.CurrentVersion, p.TemplateVersion, p.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`^INSERT INTO "test_package_versions"`).
+					WithArgs(p.ID, p.CurrentVersion, p.Name, sqlmock.AnyArg(), p.CreatedBy, sqlmock.AnyArg()).
+					WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
 			},
 			Run: func() {
-				_, err := repo.FindLeastUsedPackageIDByUserID(ctx, userID)
+				err := repo.UpdateWithVersion(ctx, p)
 				assert.Error(t, err)
-				assert.Equal(t, err, ErrNotFound)
 			},
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDPackageRepository_FindVersions(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDPackageRepository(kit.DB)
+	mock := kit.DBmock
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []common.TestStructure{
 		{
-			Name: "active package id was found and never used by user",
+			Name: "ok",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT "id" FROM "test_packages" WHERE is_active`).
-					WithArgs(true).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(unusedPackageID))
-				mock.ExpectQuery(`^SELECT .+ FROM "test_results"`).
-					WithArgs(userID).
-					WillReturnRows(sqlmock.NewRows([]string{"package_id", "count"}).AddRow(uuid.New(), 1))
+				mock.ExpectQuery(`^SELECT .+ FROM "test_package_versions" WHERE package_id = .+ ORDER BY version DESC`).
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"package_id", "version"}).AddRow(id, 2).AddRow(id, 1))
 			},
 			Run: func() {
-				res, err := repo.FindLeastUsedPackageIDByUserID(ctx, userID)
+				res, err := repo.FindVersions(ctx, id)
 				assert.NoError(t, err)
-				assert.Equal(t, res, unusedPackageID)
+				assert.Equal(t, len(res), 2)
+				assert.Equal(t, res[0].Version, 2)
 			},
 		},
 		{
-			Name: "returning the least used package count",
+			Name: "err db",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT "id" FROM "test_packages" WHERE is_active`).
-					WithArgs(true).
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(usedPackageID1).AddRow(usedPackageID2).AddRow(usedPackageID3))
-				mock.ExpectQuery(`^SELECT .+ FROM "test_results"`).
-					WithArgs(userID).
-					WillReturnRows(sqlmock.NewRows(
-						[]string{"package_id", "count"}).
-						AddRow(usedPackageID1, 10).
-						AddRow(usedPackageID2, 1029).
-						AddRow(usedPackageID3, 1229).
-						AddRow(leastUsedPackageID, 1),
-					)
-			},
-			Run: func() {
-				res, err := repo.FindLeastUsedPackageIDByUserID(ctx, userID)
-				assert.NoError(t, err)
-				assert.Equal(t, res, leastUsedPackageID)
+				mock.ExpectQuery(`^SELECT .+ FROM "test_package_versions" WHERE package_id = .+`).
+					WithArgs(id).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.FindVersions(ctx, id)
+				assert.Error(t, err)
 			},
 		},
 	}
@@ -651,50 +702,53 @@ func TestSDPackage_FindLeastUsedPackageIDByUserID(t *testing.T) {
 	}
 }
 
-func TestSDPackage_GetTemplateByPackageID(t *testing.T) {
+func TestSDPackageRepository_FindVersion(t *testing.T) {
 	kit, closer := common.InitializeRepoTestKit(t)
 	defer closer()
 
 	repo := NewSDPackageRepository(kit.DB)
-	ctx := context.Background()
 	mock := kit.DBmock
-	pid := uuid.New()
-	tid := uuid.New()
+	ctx := context.Background()
+	id := uuid.New()
 
 	tests := []common.TestStructure{
 		{
-			Name: "err db",
+			Name: "ok",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT .+ FROM "test_templates"`).
-					WithArgs(pid).WillReturnError(errors.New("err db"))
+				mock.ExpectQuery(`^SELECT .+ FROM "test_package_versions" WHERE package_id = .+ AND version = .+`).
+					WithArgs(id, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"package_id", "version"}).AddRow(id, 2))
 			},
 			Run: func() {
-				_, err := repo.GetTemplateByPackageID(ctx, pid)
-				assert.Error(t, err)
+				res, err := repo.FindVersion(ctx, id, 2)
+				assert.NoError(t, err)
+				assert.Equal(t, res.PackageID, id)
+				assert.Equal(t, res.Version, 2)
 			},
 		},
 		{
 			Name: "not found",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT .+ FROM "test_templates"`).
-					WithArgs(pid).WillReturnError(gorm.ErrRecordNotFound)
+				mock.ExpectQuery(`^SELECT .+ FROM "test_package_versions" WHERE package_id = .+ AND version = .+`).
+					WithArgs(id, 2).
+					WillReturnError(gorm.ErrRecordNotFound)
 			},
 			Run: func() {
-				_, err := repo.GetTemplateByPackageID(ctx, pid)
+				_, err := repo.FindVersion(ctx, id, 2)
 				assert.Error(t, err)
 				assert.Equal(t, err, ErrNotFound)
 			},
 		},
 		{
-			Name: "ok",
+			Name: "err db",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT .+ FROM "test_templates"`).
-					WithArgs(pid).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(tid))
+				mock.ExpectQuery(`^SELECT .+ FROM "test_package_versions" WHERE package_id = .+ AND version = .+`).
+					WithArgs(id, 2).
+					WillReturnError(errors.New("err db"))
 			},
 			Run: func() {
-				res, err := repo.GetTemplateByPackageID(ctx, pid)
-				assert.NoError(t, err)
-				assert.Equal(t, res.ID, tid)
+				_, err := repo.FindVersion(ctx, id, 2)
+				assert.Error(t, err)
 			},
 		},
 	}
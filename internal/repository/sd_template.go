@@ -20,13 +20,24 @@ func NewSDTemplateRepository(db *gorm.DB) model.SDTemplateRepository {
 	return &sdRepo{db}
 }
 
-func (r *sdRepo) Create(ctx context.Context, template *model.SpeechDelayTemplate) error {
+func (r *sdRepo) Create(ctx context.Context, template *model.SpeechDelayTemplate, tx *gorm.DB) error {
 	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":  "sdRepo.Create",
 		"input": helper.Dump(template),
 	})
 
-	if err := r.db.WithContext(ctx).Create(template).Error; err != nil {
+	if tx == nil {
+		tx = r.db
+	}
+
+	err := tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(template).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(template.ToVersion()).Error
+	})
+	if err != nil {
 		logger.WithError(err).Error("failed to create test template")
 		return err
 	}
@@ -136,3 +147,63 @@ func (r *sdRepo) UndoDelete(ctx context.Context, id uuid.UUID) (*model.SpeechDel
 
 	return template, nil
 }
+
+func (r *sdRepo) UpdateWithVersion(ctx context.Context, template *model.SpeechDelayTemplate) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":     "sdRepo.UpdateWithVersion",
+		"template": helper.Dump(template),
+	})
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(template).Error; err != nil {
+			return err
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "template_id"}, {Name: "version"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "template", "created_at"}),
+		}).Create(template.ToVersion()).Error
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to update speech delay template with version")
+		return err
+	}
+
+	return nil
+}
+
+func (r *sdRepo) FindVersions(ctx context.Context, templateID uuid.UUID) ([]*model.SDTemplateVersion, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdRepo.FindVersions",
+		"input": helper.Dump(templateID),
+	})
+
+	var versions []*model.SDTemplateVersion
+	err := r.db.WithContext(ctx).Where("template_id = ?", templateID).Order("version DESC").Find(&versions).Error
+	if err != nil {
+		logger.WithError(err).Error("failed to find speech delay template versions")
+		return []*model.SDTemplateVersion{}, err
+	}
+
+	return versions, nil
+}
+
+func (r *sdRepo) FindVersion(ctx context.Context, templateID uuid.UUID, version int) (*model.SDTemplateVersion, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":    "sdRepo.FindVersion",
+		"input":   helper.Dump(templateID),
+		"version": version,
+	})
+
+	v := &model.SDTemplateVersion{}
+	err := r.db.WithContext(ctx).Take(v, "template_id = ? AND version = ?", templateID, version).Error
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find speech delay template version")
+		return nil, err
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	case nil:
+		return v, nil
+	}
+}
@@ -53,13 +53,16 @@ func TestSDTemplateRepository_Create(t *testing.T) {
 			MockFn: func() {
 				mock.ExpectBegin()
 				mock.ExpectExec(`^INSERT INTO "test_templates"`).
-					WithArgs(tem.ID, tem.CreatedBy, tem.Name, tem.IsActive, tem.IsLocked, tem.CreatedAt, sqlmock.AnyArg(), tem.DeletedAt, sqlmock.AnyArg()).
+					WithArgs(tem.ID, tem.CreatedBy, tem.Name, tem.IsActive, tem.IsLocked, tem.CreatedAt, sqlmock.AnyArg(), tem.DeletedAt, sqlmock.AnyArg(), tem.Type, tem.CurrentVersion).
 					//WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(tem.ID))
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`^INSERT INTO "test_template_versions"`).
+					WithArgs(tem.ID, tem.CurrentVersion, tem.Name, sqlmock.AnyArg(), tem.CreatedBy, tem.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
 			Run: func() {
-				err := repo.Create(ctx, tem)
+				err := repo.Create(ctx, tem, nil)
 				assert.NoError(t, err)
 			},
 		},
@@ -68,13 +71,13 @@ func TestSDTemplateRepository_Create(t *testing.T) {
 			MockFn: func() {
 				mock.ExpectBegin()
 				mock.ExpectExec(`^INSERT INTO "test_templates"`).
-					WithArgs(tem.ID, tem.CreatedBy, tem.Name, tem.IsActive, tem.IsLocked, tem.CreatedAt, sqlmock.AnyArg(), tem.DeletedAt, sqlmock.AnyArg()).
+					WithArgs(tem.ID, tem.CreatedBy, tem.Name, tem.IsActive, tem.IsLocked, tem.CreatedAt, sqlmock.AnyArg(), tem.DeletedAt, sqlmock.AnyArg(), tem.Type, tem.CurrentVersion).
 					WillReturnError(errors.New("db error"))
 					//WillReturnError(errors.New("err db"))
 				mock.ExpectRollback()
 			},
 			Run: func() {
-				err := repo.Create(ctx, tem)
+				err := repo.Create(ctx, tem, nil)
 				assert.Error(t, err)
 			},
 		},
@@ -315,7 +318,7 @@ func TestSDTemplateRepository_Update(t *testing.T) {
 			MockFn: func() {
 				mock.ExpectBegin()
 				mock.ExpectExec(`^UPDATE "test_templates" SET`).
-					WithArgs(te.CreatedBy, te.Name, te.IsActive, te.IsLocked, te.CreatedAt, sqlmock.AnyArg(), te.DeletedAt, sqlmock.AnyArg(), te.ID).
+					WithArgs(te.CreatedBy, te.Name, te.IsActive, te.IsLocked, te.CreatedAt, sqlmock.AnyArg(), te.DeletedAt, sqlmock.AnyArg(), te.Type, te.CurrentVersion, te.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
@@ -329,7 +332,7 @@ func TestSDTemplateRepository_Update(t *testing.T) {
 			MockFn: func() {
 				mock.ExpectBegin()
 				mock.ExpectExec(`^UPDATE "test_templates" SET`).
-					WithArgs(te.CreatedBy, te.Name, te.IsActive, te.IsLocked, te.CreatedAt, sqlmock.AnyArg(), te.DeletedAt, sqlmock.AnyArg(), te.ID).
+					WithArgs(te.CreatedBy, te.Name, te.IsActive, te.IsLocked, te.CreatedAt, sqlmock.AnyArg(), te.DeletedAt, sqlmock.AnyArg(), te.Type, te.CurrentVersion, te.ID).
 					WillReturnError(errors.New("err db"))
 				mock.ExpectRollback()
 			},
@@ -455,3 +458,169 @@ func TestSDTemplateRepository_UndoDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestSDTemplateRepository_UpdateWithVersion(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDTemplateRepository(kit.DB)
+	mock := kit.DBmock
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	te := &model.SpeechDelayTemplate{
+		ID:        uuid.New(),
+		CreatedBy: uuid.New(),
+		Name:      "name",
+		CreatedAt: now,
+		UpdatedAt: now,
+		Template: &model.SDTemplate{
+			Name: "name",
+		},
+		CurrentVersion: 3,
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_templates" SET`).
+					WithArgs(te.CreatedBy, te.Name, te.IsActive, te.IsLocked, te.CreatedAt, sqlmock.AnyArg(), te.DeletedAt, sqlmock.AnyArg(), te.Type, te.CurrentVersion, te.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`^INSERT INTO "test_template_versions" .+ ON CONFLICT \("template_id","version"\) DO UPDATE`).
+					WithArgs(te.ID, te.CurrentVersion, te.Name, sqlmock.AnyArg(), te.CreatedBy, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				err := repo.UpdateWithVersion(ctx, te)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_templates" SET`).
+					WithArgs(te.CreatedBy, te.Name, te.IsActive, te.IsLocked, te.CreatedAt, sqlmock.AnyArg(), te.DeletedAt, sqlmock.AnyArg(), te.Type, te.CurrentVersion, te.ID).
+					WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				err := repo.UpdateWithVersion(ctx, te)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTemplateRepository_FindVersions(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDTemplateRepository(kit.DB)
+	mock := kit.DBmock
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_template_versions" WHERE template_id = .+ ORDER BY version DESC`).
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"template_id", "version"}).AddRow(id, 2).AddRow(id, 1))
+			},
+			Run: func() {
+				res, err := repo.FindVersions(ctx, id)
+				assert.NoError(t, err)
+				assert.Equal(t, len(res), 2)
+				assert.Equal(t, res[1].Version, 1)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_template_versions" WHERE template_id = .+`).
+					WithArgs(id).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.FindVersions(ctx, id)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTemplateRepository_FindVersion(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDTemplateRepository(kit.DB)
+	mock := kit.DBmock
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_template_versions" WHERE template_id = .+ AND version = .+`).
+					WithArgs(id, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"template_id", "version"}).AddRow(id, 1))
+			},
+			Run: func() {
+				res, err := repo.FindVersion(ctx, id, 1)
+				assert.NoError(t, err)
+				assert.Equal(t, res.TemplateID, id)
+			},
+		},
+		{
+			Name: "not found",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_template_versions" WHERE template_id = .+ AND version = .+`).
+					WithArgs(id, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			Run: func() {
+				_, err := repo.FindVersion(ctx, id, 1)
+				assert.Equal(t, err, ErrNotFound)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_template_versions" WHERE template_id = .+ AND version = .+`).
+					WithArgs(id, 1).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.FindVersion(ctx, id, 1)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
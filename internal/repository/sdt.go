@@ -2,10 +2,7 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -110,178 +107,313 @@ func (r *sdtrRepo) Search(ctx context.Context, input *model.ViewHistoriesInput)
 	return sdt, nil
 }
 
-// uuidList a helper type to Scan database value from ARRAY_AGG from database.
-// If only needed here, no need to move it to other package
-type uuidList []uuid.UUID
-
-// Scan implements the sql.Scanner interface.
-func (ul *uuidList) Scan(src interface{}) error {
-	switch src := src.(type) {
-	case string:
-		uuidStrings := strings.Split(strings.Trim(src, "{}"), ",")
-		for _, u := range uuidStrings {
-			parsedUUID, err := uuid.Parse(u)
-			if err != nil {
-				return err
-			}
-			*ul = append(*ul, parsedUUID)
-		}
+type rawStatisticRow struct {
+	TemplateID             uuid.UUID          `gorm:"column:template_id"`
+	TemplateName           string             `gorm:"column:template_name"`
+	IndicationThreshold    int                `gorm:"column:indication_threshold"`
+	PositiveIndiationText  string             `gorm:"column:positive_indication_text"`
+	NegativeIndicationText string             `gorm:"column:negative_indication_text"`
+	TestResultID           uuid.UUID          `gorm:"column:test_id"`
+	PackageID              uuid.UUID          `gorm:"column:package_id"`
+	PackageName            string             `gorm:"column:package_name"`
+	TestFinishedAt         time.Time          `gorm:"column:finished_at"`
+	Result                 model.SDTestResult `gorm:"column:result"`
+	PreviousTestID         uuid.NullUUID      `gorm:"column:previous_test_id"`
+	PreviousResult         model.SDTestResult `gorm:"column:previous_result"`
+}
 
-		return nil
-	default:
-		return errors.New("unsupported type to scan uuidList")
+func (r *sdtrRepo) MarkExpired(ctx context.Context, now time.Time) (int64, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func": "sdtrRepo.MarkExpired",
+		"now":  now.String(),
+	})
+
+	res := r.db.WithContext(ctx).Model(&model.SDTest{}).
+		Where("status = ? AND finished_at IS NULL AND open_until < ?", model.SDTestStatusInProgress, now).
+		Updates(map[string]interface{}{
+			"status":     model.SDTestStatusExpired,
+			"updated_at": now,
+		})
+	if res.Error != nil {
+		logger.WithError(res.Error).Error("failed to mark expired sd test")
+		return 0, res.Error
 	}
+
+	return res.RowsAffected, nil
 }
 
-// intList a helper type to Scan database value from ARRAY_AGG from database.
-// If only needed here, no need to move it to other package
-type intList []int
-
-// Scan implements the sql.Scanner interface.
-func (il *intList) Scan(src interface{}) error {
-	switch src := src.(type) {
-	case string:
-		intString := strings.Split(strings.Trim(src, "{}"), ",")
-		for _, u := range intString {
-			val, err := strconv.ParseInt(u, 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid value for int: %s. err: %s", u, err.Error())
-			}
-
-			*il = append(*il, int(val))
-		}
+// Claim set the owner of the test only when the test still has no owner. Returns ErrNotFound when no test
+// is updated, thus the test is already owned, maybe by a concurrent claim, or doesn't exist
+func (r *sdtrRepo) Claim(ctx context.Context, id, userID uuid.UUID, now time.Time) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":   "sdtrRepo.Claim",
+		"id":     id.String(),
+		"userID": userID.String(),
+	})
 
-		return nil
-	default:
-		return errors.New("unsupported type to scan intList")
+	res := r.db.WithContext(ctx).Model(&model.SDTest{}).
+		Where("id = ? AND user_id IS NULL", id).
+		Updates(map[string]interface{}{
+			"user_id":    userID,
+			"updated_at": now,
+		})
+	if res.Error != nil {
+		logger.WithError(res.Error).Error("failed to claim sd test")
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
 	}
+
+	return nil
 }
 
-// stringList a helper type to Scan database value from ARRAY_AGG from database.
-// If only needed here, no need to move it to other package
-type stringList []string
-
-// Scan implements the sql.Scanner interface.
-func (sl *stringList) Scan(src interface{}) error {
-	switch src := src.(type) {
-	case string:
-		strString := strings.Split(strings.Trim(src, "{}"), ",")
-		for _, u := range strString {
-			s := strings.TrimSuffix(u, "\"")
-			s = strings.TrimPrefix(s, "\"")
-			*sl = append(*sl, s)
-		}
+// SaveDraft only write the draft answer of the test, and only when the test is still not finished. Returns ErrNotFound
+// when no test is updated, thus the test is already finished, maybe by a concurrent submit, or doesn't exist
+func (r *sdtrRepo) SaveDraft(ctx context.Context, id uuid.UUID, draft model.SDTestAnswer, now time.Time) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func": "sdtrRepo.SaveDraft",
+		"id":   id.String(),
+	})
 
-		return nil
-	default:
-		return errors.New("unsupported type to scan stringList")
+	res := r.db.WithContext(ctx).Model(&model.SDTest{}).
+		Select("draft_answer", "updated_at").
+		Where("id = ? AND finished_at IS NULL", id).
+		Updates(&model.SDTest{
+			DraftAnswer: draft,
+			UpdatedAt:   now,
+		})
+	if res.Error != nil {
+		logger.WithError(res.Error).Error("failed to save sd test draft")
+		return res.Error
 	}
+
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
 }
 
-// timeList a helper type to Scan database value from ARRAY_AGG from database.
-// If only needed here, no need to move it to other package
-type timeList []time.Time
-
-// Scan implements the sql.Scanner interface.
-func (tl *timeList) Scan(src interface{}) error {
-	switch src := src.(type) {
-	case string:
-		timeString := strings.Split(strings.Trim(src, "{}"), ",")
-		for _, u := range timeString {
-			s := strings.Trim(u, "\"")
-			t, err := time.Parse("2006-01-02 15:04:05.999999-07", s)
-			if err != nil {
-				return fmt.Errorf("invalid value for time: %s. err: %s", u, err.Error())
-			}
-
-			*tl = append(*tl, t)
-		}
+type testPackageCount struct {
+	PackageID uuid.UUID `gorm:"column:package_id"`
+	Count     int       `gorm:"column:count"`
+}
+
+func (r *sdtrRepo) CountPackageUsage(ctx context.Context, userID uuid.UUID, packageIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":       "sdtrRepo.CountPackageUsage",
+		"userID":     userID.String(),
+		"packageIDs": helper.Dump(packageIDs),
+	})
+
+	var tpc []testPackageCount
+	err := r.db.WithContext(ctx).
+		Model(&model.SDTest{}).
+		Where("user_id = ? AND package_id IN (?) AND status <> ?", userID, packageIDs, model.SDTestStatusExpired).
+		Select("package_id, count(package_id) as count").
+		Group("package_id").
+		Scan(&tpc).Error
+	if err != nil {
+		logger.WithError(err).Error("failed to count sd package usage")
+		return nil, err
+	}
+
+	usage := make(map[uuid.UUID]int)
+	for _, v := range tpc {
+		usage[v.PackageID] = v.Count
+	}
+
+	return usage, nil
+}
+
+func (r *sdtrRepo) FindLatest(ctx context.Context, input *model.FindLatestSDTestInput) (*model.SDTest, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdtrRepo.FindLatest",
+		"input": helper.Dump(input),
+	})
 
-		return nil
+	query := r.db.WithContext(ctx)
+	if input.UserID.Valid {
+		query = query.Where("user_id = ?", input.UserID.UUID)
+	}
+
+	if len(input.PackageIDs) > 0 {
+		query = query.Where("package_id IN (?)", input.PackageIDs)
+	}
+
+	sdt := &model.SDTest{}
+	err := query.Order("created_at DESC").Take(sdt).Error
+	switch err {
 	default:
-		return errors.New("unsupported type to scan timeList")
+		logger.WithError(err).Error("failed to find latest sd test")
+		return nil, err
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	case nil:
+		return sdt, nil
 	}
 }
 
-type rawTemplateStatistic struct {
-	TemplateID             uuid.UUID  `gorm:"column:template_id"`
-	TemplateName           string     `gorm:"column:template_name"`
-	IndicationThreshold    int        `gorm:"column:indication_threshold"`
-	PositiveIndiationText  string     `gorm:"column:positive_indication_text"`
-	NegativeIndicationText string     `gorm:"column:negative_indication_text"`
-	TestResultID           uuidList   `gorm:"column:test_id"`
-	PackageID              uuidList   `gorm:"column:package_id"`
-	TotalPoint             intList    `gorm:"column:total_point"`
-	PackageName            stringList `gorm:"column:package_name"`
-	TestFinishedAt         timeList   `gorm:"column:finished_at"`
+// FindRetakeStat when tx is given, the stat is read holding the transaction level lock of the user and the template,
+// thus the concurrent callers are serialized until tx is ended
+func (r *sdtrRepo) FindRetakeStat(ctx context.Context, input *model.SDRetakeStatInput, tx *gorm.DB) (*model.SDRetakeStat, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdtrRepo.FindRetakeStat",
+		"input": helper.Dump(input),
+	})
+
+	if tx == nil {
+		tx = r.db
+	} else {
+		err := tx.WithContext(ctx).
+			Exec("SELECT pg_advisory_xact_lock(hashtext(?), hashtext(?))", input.UserID.String(), input.TemplateID.String()).Error
+		if err != nil {
+			logger.WithError(err).Error("failed to lock the user and template of sd test retake stat")
+			return nil, err
+		}
+	}
+
+	// the tests of the user and each of the child profiles have their own retake limit
+	childFilter := "AND tr.child_id IS NULL"
+	args := []interface{}{
+		model.SDTestStatusInProgress, input.Now,
+		model.SDTestStatusInProgress, input.Now,
+		input.AttemptsSince,
+		input.AttemptsSince,
+		input.UserID, input.TemplateID,
+	}
+	if input.ChildID.Valid {
+		childFilter = "AND tr.child_id = ?"
+		args = append(args, input.ChildID)
+	}
+
+	stat := &model.SDRetakeStat{}
+	err := tx.WithContext(ctx).
+		Raw(fmt.Sprintf(`
+			SELECT
+			COUNT(tr.id) FILTER (WHERE tr.status = ? AND tr.open_until > ?) AS open_tests,
+			MIN(tr.open_until) FILTER (WHERE tr.status = ? AND tr.open_until > ?) AS earliest_open_until,
+			COUNT(tr.id) FILTER (WHERE tr.created_at >= ?) AS attempts,
+			MIN(tr.created_at) FILTER (WHERE tr.created_at >= ?) AS oldest_attempt_at,
+			MAX(tr.finished_at) AS last_finished_at
+				FROM test_results tr
+						WHERE tr.user_id = ?
+						AND tr.template_id = ?
+						AND tr.deleted_at IS NULL
+						%s;
+		`, childFilter), args...).Scan(stat).Error
+	if err != nil {
+		logger.WithError(err).Error("failed to find sd test retake stat")
+		return nil, err
+	}
+
+	return stat, nil
 }
 
-func (r *sdtrRepo) Statistic(ctx context.Context, userID uuid.UUID) ([]model.SDTestStatistic, error) {
+func (r *sdtrRepo) Statistic(ctx context.Context, input *model.SDTestStatisticInput) ([]model.SDTestStatistic, error) {
 	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
-		"func": "sdtrRepo.Statistic",
-		"id":   userID.String(),
+		"func":  "sdtrRepo.Statistic",
+		"input": helper.Dump(input),
 	})
 
-	var res []rawTemplateStatistic
+	filter := ""
+	args := []interface{}{input.UserID}
+	where, conds := input.ToWhereQuery()
+	for i := range where {
+		filter += " AND " + where[i]
+	}
+	args = append(args, conds...)
+
+	// LIMIT NULL is the same as having no limit
+	var limit interface{} = input.Limit
+	if input.Unlimited {
+		limit = nil
+	}
+	args = append(args, limit, input.Offset)
+
+	// the previous result is taken before the pagination, thus the first test on each page still has its change
+	var rows []rawStatisticRow
 	err := r.db.WithContext(ctx).
-		Raw(`
+		Raw(fmt.Sprintf(`
 			SELECT
 			tt.id AS template_id,
-			tt."name"  AS template_name,
+			tt."name" AS template_name,
 			tt."template" -> 'indicationThreshold' AS indication_threshold,
-			tt."template" -> 'negativeIndicationText' AS negative_indication_text,
-			tt."template" -> 'positiveIndicationText' AS positive_indication_text,
-			ARRAY_AGG(tr.id) AS test_id,
-			ARRAY_AGG(tr.package_id) AS package_id,
-			ARRAY_AGG(tr."result"-> 'total') AS total_point,
-			ARRAY_AGG(tp."name") AS package_name,
-			ARRAY_AGG(tr.finished_at ORDER BY tr.finished_at ASC)  AS finished_at
+			tt."template" ->> 'negativeIndicationText' AS negative_indication_text,
+			tt."template" ->> 'positiveIndicationText' AS positive_indication_text,
+			tr.id AS test_id,
+			tr.package_id AS package_id,
+			tp."name" AS package_name,
+			tr.finished_at AS finished_at,
+			tr."result" AS "result",
+			LAG(tr.id) OVER w AS previous_test_id,
+			LAG(tr."result") OVER w AS previous_result
 				FROM test_results tr
 					JOIN test_packages tp ON tr.package_id = tp.id
-					JOIN test_templates tt ON tp.template_id = tt.id 
-						WHERE tr.user_id  = ?
+					JOIN test_templates tt ON tr.template_id = tt.id
+						WHERE tr.user_id = ?
 						AND tr.finished_at IS NOT NULL
-						GROUP BY tt.id;
-		`, userID).Scan(&res).Error
+						AND tr.deleted_at IS NULL
+						%s
+						WINDOW w AS (PARTITION BY tt.id ORDER BY tr.finished_at ASC)
+						ORDER BY tt.id, tr.finished_at ASC
+						LIMIT ? OFFSET ?;
+		`, filter), args...).Scan(&rows).Error
 
 	if err != nil {
 		logger.WithError(err).Error("failed to get test result statistic")
 		return nil, err
 	}
 
-	if len(res) == 0 {
+	if len(rows) == 0 {
 		return nil, ErrNotFound
 	}
 
+	return toSDTestStatistics(rows), nil
+}
+
+// toSDTestStatistics group the rows by the template, keeping the rows order
+func toSDTestStatistics(rows []rawStatisticRow) []model.SDTestStatistic {
 	stats := []model.SDTestStatistic{}
+	index := make(map[uuid.UUID]int)
+	for _, row := range rows {
+		idx, ok := index[row.TemplateID]
+		if !ok {
+			idx = len(stats)
+			index[row.TemplateID] = idx
+			stats = append(stats, model.SDTestStatistic{
+				TemplateID:             row.TemplateID,
+				TemplateName:           row.TemplateName,
+				IndicationThreshold:    row.IndicationThreshold,
+				PositiveIndiationText:  row.PositiveIndiationText,
+				NegativeIndicationText: row.NegativeIndicationText,
+				Stats:                  []model.StatsComponent{},
+			})
+		}
 
-	for _, v := range res {
-		stats = append(stats, toSDTestStatistic(v))
-	}
+		sc := model.StatsComponent{
+			TestResultID:   row.TestResultID,
+			PackageID:      row.PackageID,
+			ResultPoint:    row.Result.Total,
+			PackageName:    row.PackageName,
+			TestFinishedAt: row.TestFinishedAt,
+			Interpretation: row.Result.Interpretation,
+			SubGroups:      row.Result.Result,
+		}
 
-	return stats, nil
-}
+		if row.PreviousTestID.Valid {
+			previous := row.PreviousResult
+			sc.PreviousResult = &previous
+		}
 
-func toSDTestStatistic(res rawTemplateStatistic) model.SDTestStatistic {
-	s := model.SDTestStatistic{}
-	s.TemplateID = res.TemplateID
-	s.TemplateName = res.TemplateName
-	s.IndicationThreshold = res.IndicationThreshold
-	s.NegativeIndicationText = strings.TrimSuffix(strings.TrimPrefix(res.NegativeIndicationText, "\""), "\"")
-	s.PositiveIndiationText = strings.TrimSuffix(strings.TrimPrefix(res.PositiveIndiationText, "\""), "\"")
-
-	stats := []model.StatsComponent{}
-	for i := 0; i < len(res.TotalPoint); i++ {
-		stats = append(stats, model.StatsComponent{
-			TestResultID:   res.TestResultID[i],
-			PackageID:      res.PackageID[i],
-			ResultPoint:    res.TotalPoint[i],
-			PackageName:    res.PackageName[i],
-			TestFinishedAt: res.TestFinishedAt[i],
-		})
+		stats[idx].Stats = append(stats[idx].Stats, sc)
 	}
 
-	s.Stats = stats
+	for i := range stats {
+		stats[i].ComputeTrends()
+	}
 
-	return s
+	return stats
 }
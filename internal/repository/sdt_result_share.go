@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+	"gorm.io/gorm"
+)
+
+type sdrsRepo struct {
+	db *gorm.DB
+}
+
+// NewSDResultShareRepository create new SDResultShareRepository
+func NewSDResultShareRepository(db *gorm.DB) model.SDResultShareRepository {
+	return &sdrsRepo{db}
+}
+
+func (r *sdrsRepo) Create(ctx context.Context, share *model.SDResultShare) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdrsRepo.Create",
+		"input": helper.Dump(share),
+	})
+
+	if err := r.db.WithContext(ctx).Create(share).Error; err != nil {
+		logger.WithError(err).Error("failed to create test result share")
+		return err
+	}
+
+	return nil
+}
+
+func (r *sdrsRepo) FindByID(ctx context.Context, id uuid.UUID) (*model.SDResultShare, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func": "sdrsRepo.FindByID",
+		"id":   id.String(),
+	})
+
+	share := &model.SDResultShare{}
+	err := r.db.WithContext(ctx).Take(share, "id = ?", id).Error
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find test result share")
+		return nil, err
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	case nil:
+		return share, nil
+	}
+}
+
+func (r *sdrsRepo) FindByToken(ctx context.Context, token string) (*model.SDResultShare, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func": "sdrsRepo.FindByToken",
+	})
+
+	share := &model.SDResultShare{}
+	err := r.db.WithContext(ctx).Take(share, "token = ?", token).Error
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find test result share by token")
+		return nil, err
+	case gorm.ErrRecordNotFound:
+		return nil, ErrNotFound
+	case nil:
+		return share, nil
+	}
+}
+
+func (r *sdrsRepo) FindByTestID(ctx context.Context, testID uuid.UUID) ([]*model.SDResultShare, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":   "sdrsRepo.FindByTestID",
+		"testID": testID.String(),
+	})
+
+	var shares []*model.SDResultShare
+	err := r.db.WithContext(ctx).Where("test_id = ?", testID).Order("created_at DESC").Find(&shares).Error
+	if err != nil {
+		logger.WithError(err).Error("failed to find test result shares")
+		return nil, err
+	}
+
+	return shares, nil
+}
+
+func (r *sdrsRepo) Update(ctx context.Context, share *model.SDResultShare) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdrsRepo.Update",
+		"input": helper.Dump(share),
+	})
+
+	if err := r.db.WithContext(ctx).Save(share).Error; err != nil {
+		logger.WithError(err).Error("failed to update test result share")
+		return err
+	}
+
+	return nil
+}
+
+// RecordView will return ErrNotFound when the share is already revoked, expired or reaching the max views.
+// The view count is checked and increased on the same statement, thus concurrent views can't exceed the max views
+func (r *sdrsRepo) RecordView(ctx context.Context, view *model.SDResultShareView) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdrsRepo.RecordView",
+		"input": helper.Dump(view),
+	})
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.SDResultShare{}).
+			Where("id = ? AND revoked_at IS NULL AND expired_at > ? AND (max_views IS NULL OR view_count < max_views)", view.ShareID, view.ViewedAt).
+			Updates(map[string]interface{}{
+				"view_count": gorm.Expr("view_count + 1"),
+				"updated_at": view.ViewedAt,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		return tx.Create(view).Error
+	})
+
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to record test result share view")
+		return err
+	case ErrNotFound:
+		return ErrNotFound
+	case nil:
+		return nil
+	}
+}
@@ -0,0 +1,361 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestSDResultShareRepository_Create(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDResultShareRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	now := time.Now().UTC()
+	s := &model.SDResultShare{
+		ID:        uuid.New(),
+		TestID:    uuid.New(),
+		Token:     "token",
+		Scope:     model.SDResultShareScopeImage,
+		ExpiredAt: now.Add(time.Hour),
+		MaxViews:  null.IntFrom(3),
+		CreatedBy: uuid.New(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^INSERT INTO "test_result_shares"`).
+					WithArgs(s.ID, s.TestID, s.Token, s.Scope, s.ExpiredAt, s.MaxViews, s.ViewCount, s.RevokedAt, s.CreatedBy, s.CreatedAt, s.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				err := repo.Create(ctx, s)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^INSERT INTO "test_result_shares"`).
+					WithArgs(s.ID, s.TestID, s.Token, s.Scope, s.ExpiredAt, s.MaxViews, s.ViewCount, s.RevokedAt, s.CreatedBy, s.CreatedAt, s.UpdatedAt).
+					WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				err := repo.Create(ctx, s)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDResultShareRepository_FindByID(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDResultShareRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+	id := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_result_shares" WHERE`).
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+			},
+			Run: func() {
+				res, err := repo.FindByID(ctx, id)
+				assert.NoError(t, err)
+				assert.Equal(t, res.ID, id)
+			},
+		},
+		{
+			Name: "not found",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_result_shares" WHERE`).
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			Run: func() {
+				_, err := repo.FindByID(ctx, id)
+				assert.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_result_shares" WHERE`).
+					WithArgs(id).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.FindByID(ctx, id)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDResultShareRepository_FindByToken(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDResultShareRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+	id := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_result_shares" WHERE token = `).
+					WithArgs("token").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow(id, "token"))
+			},
+			Run: func() {
+				res, err := repo.FindByToken(ctx, "token")
+				assert.NoError(t, err)
+				assert.Equal(t, res.ID, id)
+			},
+		},
+		{
+			Name: "not found",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_result_shares" WHERE token = `).
+					WithArgs("token").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "token"}))
+			},
+			Run: func() {
+				_, err := repo.FindByToken(ctx, "token")
+				assert.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_result_shares" WHERE token = `).
+					WithArgs("token").
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.FindByToken(ctx, "token")
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDResultShareRepository_FindByTestID(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDResultShareRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+	testID := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_result_shares" WHERE test_id = .+ ORDER BY created_at DESC`).
+					WithArgs(testID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "test_id"}).AddRow(uuid.New(), testID).AddRow(uuid.New(), testID))
+			},
+			Run: func() {
+				res, err := repo.FindByTestID(ctx, testID)
+				assert.NoError(t, err)
+				assert.Len(t, res, 2)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM "test_result_shares" WHERE test_id = .+ ORDER BY created_at DESC`).
+					WithArgs(testID).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.FindByTestID(ctx, testID)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDResultShareRepository_Update(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDResultShareRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	s := &model.SDResultShare{
+		ID:        uuid.New(),
+		RevokedAt: null.TimeFrom(time.Now().UTC()),
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_result_shares" SET`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				err := repo.Update(ctx, s)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_result_shares" SET`).
+					WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				err := repo.Update(ctx, s)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDResultShareRepository_RecordView(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDResultShareRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	v := &model.SDResultShareView{
+		ID:        uuid.New(),
+		ShareID:   uuid.New(),
+		Scope:     model.SDResultShareScopeFull,
+		IPAddress: "127.0.0.1",
+		UserAgent: "agent",
+		ViewedAt:  time.Now().UTC(),
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_result_shares" SET "updated_at"=.+,"view_count"=view_count \+ 1 WHERE`).
+					WithArgs(v.ViewedAt, v.ShareID, v.ViewedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`^INSERT INTO "test_result_share_views"`).
+					WithArgs(v.ID, v.ShareID, v.Scope, v.IPAddress, v.UserAgent, v.ViewedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				err := repo.RecordView(ctx, v)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name: "share is no longer viewable",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_result_shares" SET "updated_at"=.+,"view_count"=view_count \+ 1 WHERE`).
+					WithArgs(v.ViewedAt, v.ShareID, v.ViewedAt).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				err := repo.RecordView(ctx, v)
+				assert.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+		{
+			Name: "failed to write the view",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_result_shares" SET "updated_at"=.+,"view_count"=view_count \+ 1 WHERE`).
+					WithArgs(v.ViewedAt, v.ShareID, v.ViewedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`^INSERT INTO "test_result_share_views"`).
+					WithArgs(v.ID, v.ShareID, v.Scope, v.IPAddress, v.UserAgent, v.ViewedAt).
+					WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				err := repo.RecordView(ctx, v)
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, ErrNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
@@ -39,7 +39,7 @@ func TestSDTestResultRepository_Create(t *testing.T) {
 			MockFn: func() {
 				mock.ExpectBegin()
 				mock.ExpectExec(`^INSERT INTO "test_results"`).
-					WithArgs(tt.ID, tt.PackageID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), tt.OpenUntil, tt.SubmitKey, tt.CreatedAt, tt.UpdatedAt, sqlmock.AnyArg()).
+					WithArgs(tt.ID, tt.PackageID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), tt.OpenUntil, tt.SubmitKey, tt.CreatedAt, tt.UpdatedAt, sqlmock.AnyArg(), tt.TemplateID, tt.PackageVersion, tt.TemplateVersion, sqlmock.AnyArg(), tt.AssignmentID, tt.ChildID, tt.Status).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
@@ -53,7 +53,7 @@ func TestSDTestResultRepository_Create(t *testing.T) {
 			MockFn: func() {
 				mock.ExpectBegin()
 				mock.ExpectExec(`^INSERT INTO "test_results"`).
-					WithArgs(tt.ID, tt.PackageID, tt.UserID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), tt.OpenUntil, tt.SubmitKey, tt.CreatedAt, tt.UpdatedAt, sqlmock.AnyArg()).
+					WithArgs(tt.ID, tt.PackageID, tt.UserID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), tt.OpenUntil, tt.SubmitKey, tt.CreatedAt, tt.UpdatedAt, sqlmock.AnyArg(), tt.TemplateID, tt.PackageVersion, tt.TemplateVersion, sqlmock.AnyArg(), tt.AssignmentID, tt.ChildID, tt.Status).
 					WillReturnError(errors.New("db error"))
 				mock.ExpectRollback()
 			},
@@ -161,7 +161,7 @@ func TestSDTestResultRepository_Update(t *testing.T) {
 			MockFn: func() {
 				mock.ExpectBegin()
 				mock.ExpectExec(`^UPDATE "test_results" SET`).
-					WithArgs(p.PackageID, p.UserID, sqlmock.AnyArg(), sqlmock.AnyArg(), p.FinishedAt, p.OpenUntil, p.SubmitKey, p.CreatedAt, sqlmock.AnyArg(), sqlmock.AnyArg(), p.ID).
+					WithArgs(p.PackageID, p.UserID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), p.FinishedAt, p.OpenUntil, p.SubmitKey, p.CreatedAt, sqlmock.AnyArg(), sqlmock.AnyArg(), p.TemplateID, p.PackageVersion, p.TemplateVersion, sqlmock.AnyArg(), p.AssignmentID, p.ChildID, p.Status, p.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 					//WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(p.ID))
 				mock.ExpectCommit()
@@ -176,7 +176,7 @@ func TestSDTestResultRepository_Update(t *testing.T) {
 			MockFn: func() {
 				mock.ExpectBegin()
 				mock.ExpectExec(`^UPDATE "test_results" SET`).
-					WithArgs(p.PackageID, p.UserID, sqlmock.AnyArg(), sqlmock.AnyArg(), p.FinishedAt, p.OpenUntil, p.SubmitKey, p.CreatedAt, sqlmock.AnyArg(), sqlmock.AnyArg(), p.ID).
+					WithArgs(p.PackageID, p.UserID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), p.FinishedAt, p.OpenUntil, p.SubmitKey, p.CreatedAt, sqlmock.AnyArg(), sqlmock.AnyArg(), p.TemplateID, p.PackageVersion, p.TemplateVersion, sqlmock.AnyArg(), p.AssignmentID, p.ChildID, p.Status, p.ID).
 					WillReturnError(errors.New("err db"))
 					//WillReturnError(errors.New("err db"))
 				mock.ExpectRollback()
@@ -355,6 +355,442 @@ func TestSDTestResultRepository_Search(t *testing.T) {
 	}
 }
 
+func TestSDTestResultRepository_MarkExpired(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDTestResultRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	now := time.Now().UTC()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_results" SET "status"=.+,"updated_at"=.+ WHERE \(status = .+ AND finished_at IS NULL AND open_until < .+\) AND "test_results"."deleted_at" IS NULL`).
+					WithArgs(model.SDTestStatusExpired, now, model.SDTestStatusInProgress, now).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				affected, err := repo.MarkExpired(ctx, now)
+				assert.NoError(t, err)
+				assert.Equal(t, affected, int64(3))
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_results" SET`).
+					WithArgs(model.SDTestStatusExpired, now, model.SDTestStatusInProgress, now).
+					WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				_, err := repo.MarkExpired(ctx, now)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestResultRepository_Claim(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDTestResultRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	id := uuid.New()
+	userID := uuid.New()
+	now := time.Now().UTC()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_results" SET "updated_at"=.+,"user_id"=.+ WHERE \(id = .+ AND user_id IS NULL\) AND "test_results"."deleted_at" IS NULL`).
+					WithArgs(now, userID, id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				err := repo.Claim(ctx, id, userID, now)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name: "already owned",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_results" SET`).
+					WithArgs(now, userID, id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				err := repo.Claim(ctx, id, userID, now)
+				assert.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_results" SET`).
+					WithArgs(now, userID, id).
+					WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				err := repo.Claim(ctx, id, userID, now)
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, ErrNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestResultRepository_SaveDraft(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDTestResultRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	id := uuid.New()
+	now := time.Now().UTC()
+	draft := model.SDTestAnswer{
+		TestAnswers: []*model.TestAnswer{
+			{
+				GroupName: "group",
+				Answers: []model.Answer{
+					{
+						Question: "question",
+						Answer:   "answer",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_results" SET "draft_answer"=.+,"updated_at"=.+ WHERE \(id = .+ AND finished_at IS NULL\) AND "test_results"."deleted_at" IS NULL$`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				err := repo.SaveDraft(ctx, id, draft, now)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name: "test already finished",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_results" SET`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				err := repo.SaveDraft(ctx, id, draft, now)
+				assert.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^UPDATE "test_results" SET`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+					WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				err := repo.SaveDraft(ctx, id, draft, now)
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, ErrNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestResultRepository_CountPackageUsage(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDTestResultRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	userID := uuid.New()
+	usedPackageID := uuid.New()
+	unusedPackageID := uuid.New()
+	packageIDs := []uuid.UUID{usedPackageID, unusedPackageID}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT package_id, count\(package_id\) as count FROM "test_results" WHERE \(user_id = .+ AND package_id IN \(.+,.+\) AND status <> .+\) AND "test_results"."deleted_at" IS NULL GROUP BY "package_id"`).
+					WithArgs(userID, usedPackageID, unusedPackageID, model.SDTestStatusExpired).
+					WillReturnRows(sqlmock.NewRows([]string{"package_id", "count"}).AddRow(usedPackageID, 3))
+			},
+			Run: func() {
+				res, err := repo.CountPackageUsage(ctx, userID, packageIDs)
+				assert.NoError(t, err)
+				assert.Equal(t, res[usedPackageID], 3)
+				assert.Equal(t, res[unusedPackageID], 0)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT package_id, count\(package_id\) as count FROM "test_results"`).
+					WithArgs(userID, usedPackageID, unusedPackageID, model.SDTestStatusExpired).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.CountPackageUsage(ctx, userID, packageIDs)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestResultRepository_FindLatest(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDTestResultRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	userID := uuid.New()
+	packageID := uuid.New()
+	testID := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok, filtered by user",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT \* FROM "test_results" WHERE user_id = .+ AND "test_results"."deleted_at" IS NULL ORDER BY created_at DESC`).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "package_id"}).AddRow(testID, packageID))
+			},
+			Run: func() {
+				res, err := repo.FindLatest(ctx, &model.FindLatestSDTestInput{UserID: uuid.NullUUID{UUID: userID, Valid: true}})
+				assert.NoError(t, err)
+				assert.Equal(t, res.ID, testID)
+				assert.Equal(t, res.PackageID, packageID)
+			},
+		},
+		{
+			Name: "ok, filtered by packages",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT \* FROM "test_results" WHERE package_id IN \(.+\) AND "test_results"."deleted_at" IS NULL ORDER BY created_at DESC`).
+					WithArgs(packageID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "package_id"}).AddRow(testID, packageID))
+			},
+			Run: func() {
+				res, err := repo.FindLatest(ctx, &model.FindLatestSDTestInput{PackageIDs: []uuid.UUID{packageID}})
+				assert.NoError(t, err)
+				assert.Equal(t, res.ID, testID)
+			},
+		},
+		{
+			Name: "not found",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT \* FROM "test_results"`).
+					WithArgs(userID).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			Run: func() {
+				_, err := repo.FindLatest(ctx, &model.FindLatestSDTestInput{UserID: uuid.NullUUID{UUID: userID, Valid: true}})
+				assert.Error(t, err)
+				assert.Equal(t, err, ErrNotFound)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT \* FROM "test_results"`).
+					WithArgs(userID).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.FindLatest(ctx, &model.FindLatestSDTestInput{UserID: uuid.NullUUID{UUID: userID, Valid: true}})
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestResultRepository_FindRetakeStat(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	repo := NewSDTestResultRepository(kit.DB)
+	ctx := context.Background()
+	mock := kit.DBmock
+
+	now := time.Now().UTC()
+	input := &model.SDRetakeStatInput{
+		UserID:        uuid.New(),
+		TemplateID:    uuid.New(),
+		AttemptsSince: now.Add(-time.Hour),
+		Now:           now,
+	}
+	childID := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mock.ExpectQuery(`SELECT .+ FROM test_results tr WHERE tr.user_id = .+ AND tr.template_id = .+ AND tr.deleted_at IS NULL AND tr.child_id IS NULL`).
+					WithArgs(model.SDTestStatusInProgress, now, model.SDTestStatusInProgress, now, input.AttemptsSince, input.AttemptsSince, input.UserID, input.TemplateID).
+					WillReturnRows(sqlmock.NewRows([]string{"open_tests", "earliest_open_until", "attempts", "oldest_attempt_at", "last_finished_at"}).
+						AddRow(1, now.Add(time.Hour), 2, now.Add(-time.Minute), nil))
+			},
+			Run: func() {
+				res, err := repo.FindRetakeStat(ctx, input, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, res.OpenTests, 1)
+				assert.Equal(t, res.Attempts, 2)
+				assert.True(t, res.EarliestOpenUntil.Valid)
+				assert.True(t, res.OldestAttemptAt.Valid)
+				assert.False(t, res.LastFinishedAt.Valid)
+			},
+		},
+		{
+			Name: "ok filtered by child",
+			MockFn: func() {
+				mock.ExpectQuery(`SELECT .+ FROM test_results tr .+ AND tr.child_id = .+`).
+					WithArgs(model.SDTestStatusInProgress, now, model.SDTestStatusInProgress, now, input.AttemptsSince, input.AttemptsSince, input.UserID, input.TemplateID, childID).
+					WillReturnRows(sqlmock.NewRows([]string{"open_tests", "earliest_open_until", "attempts", "oldest_attempt_at", "last_finished_at"}).
+						AddRow(0, nil, 0, nil, now))
+			},
+			Run: func() {
+				res, err := repo.FindRetakeStat(ctx, &model.SDRetakeStatInput{
+					UserID:        input.UserID,
+					ChildID:       uuid.NullUUID{UUID: childID, Valid: true},
+					TemplateID:    input.TemplateID,
+					AttemptsSince: input.AttemptsSince,
+					Now:           now,
+				}, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, res.OpenTests, 0)
+				assert.True(t, res.LastFinishedAt.Valid)
+			},
+		},
+		{
+			Name: "ok locked on the user and template in the transaction",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^SELECT pg_advisory_xact_lock\(hashtext\(.+\), hashtext\(.+\)\)`).
+					WithArgs(input.UserID.String(), input.TemplateID.String()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`SELECT .+ FROM test_results tr WHERE tr.user_id = .+ AND tr.template_id = .+`).
+					WithArgs(model.SDTestStatusInProgress, now, model.SDTestStatusInProgress, now, input.AttemptsSince, input.AttemptsSince, input.UserID, input.TemplateID).
+					WillReturnRows(sqlmock.NewRows([]string{"open_tests", "earliest_open_until", "attempts", "oldest_attempt_at", "last_finished_at"}).
+						AddRow(1, now.Add(time.Hour), 2, now.Add(-time.Minute), nil))
+				mock.ExpectCommit()
+			},
+			Run: func() {
+				tx := kit.DB.Begin()
+				res, err := repo.FindRetakeStat(ctx, input, tx)
+				assert.NoError(t, err)
+				assert.Equal(t, res.OpenTests, 1)
+				assert.NoError(t, tx.Commit().Error)
+				assert.NoError(t, mock.ExpectationsWereMet())
+			},
+		},
+		{
+			Name: "failed to take the lock",
+			MockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`^SELECT pg_advisory_xact_lock`).
+					WithArgs(input.UserID.String(), input.TemplateID.String()).
+					WillReturnError(errors.New("err db"))
+				mock.ExpectRollback()
+			},
+			Run: func() {
+				tx := kit.DB.Begin()
+				_, err := repo.FindRetakeStat(ctx, input, tx)
+				assert.Error(t, err)
+				assert.NoError(t, tx.Rollback().Error)
+				assert.NoError(t, mock.ExpectationsWereMet())
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mock.ExpectQuery(`SELECT .+ FROM test_results tr`).
+					WillReturnError(errors.New("err db"))
+			},
+			Run: func() {
+				_, err := repo.FindRetakeStat(ctx, input, nil)
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
 func TestSDTestResultRepository_Statistic(t *testing.T) {
 	kit, closer := common.InitializeRepoTestKit(t)
 	defer closer()
@@ -362,38 +798,140 @@ func TestSDTestResultRepository_Statistic(t *testing.T) {
 	repo := NewSDTestResultRepository(kit.DB)
 	ctx := context.Background()
 	uid := uuid.New()
+	temID := uuid.New()
+	tid1 := uuid.New()
+	tid2 := uuid.New()
+	pid := uuid.New()
+	cid := uuid.New()
+	otherCID := uuid.New()
 	mock := kit.DBmock
 
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	columns := []string{"template_id", "template_name", "indication_threshold", "negative_indication_text", "positive_indication_text", "test_id", "package_id", "package_name", "finished_at", "result", "previous_test_id", "previous_result"}
+
 	tests := []common.TestStructure{
 		{
 			Name: "db err",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT .+ FROM test_results`).WithArgs(uid).WillReturnError(errors.New("err db"))
+				mock.ExpectQuery(`^SELECT .+ FROM test_results`).WithArgs(uid, 100, 0).WillReturnError(errors.New("err db"))
 			},
 			Run: func() {
-				_, err := repo.Statistic(ctx, uid)
+				_, err := repo.Statistic(ctx, &model.SDTestStatisticInput{UserID: uid})
 				assert.Error(t, err)
 			},
 		},
 		{
 			Name: "0 data returned must return errnotfound",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT .+ FROM test_results`).WithArgs(uid).WillReturnRows(sqlmock.NewRows([]string{"template_id"}))
+				mock.ExpectQuery(`^SELECT .+ FROM test_results`).WithArgs(uid, 100, 0).WillReturnRows(sqlmock.NewRows(columns))
 			},
 			Run: func() {
-				_, err := repo.Statistic(ctx, uid)
+				_, err := repo.Statistic(ctx, &model.SDTestStatisticInput{UserID: uid})
 				assert.Error(t, err)
 				assert.Equal(t, err, ErrNotFound)
 			},
 		},
 		{
-			Name: "ok",
+			Name: "ok, with the changes and the sub group trends",
+			MockFn: func() {
+				rows := sqlmock.NewRows(columns).
+					AddRow(temID, "template", "10", "neg", "pos", tid1, pid, "package", time.Date(2023, 10, 10, 0, 0, 0, 0, time.UTC),
+						`{"result":[{"groupName":"a","result":5},{"groupName":"b","result":3}],"total":8,"interpretation":{"isPositive":true,"severity":"severe"}}`,
+						nil, nil).
+					AddRow(temID, "template", "10", "neg", "pos", tid2, pid, "package", time.Date(2023, 11, 10, 0, 0, 0, 0, time.UTC),
+						`{"result":[{"groupName":"a","result":2},{"groupName":"b","result":3}],"total":5}`,
+						tid1, `{"result":[{"groupName":"a","result":5},{"groupName":"b","result":3}],"total":8}`)
+				mock.ExpectQuery(`^SELECT .+ FROM test_results .+ AND tr.child_id IS NULL WINDOW w AS \(PARTITION BY tt.id ORDER BY tr.finished_at ASC\) ORDER BY tt.id, tr.finished_at ASC LIMIT \$2 OFFSET \$3`).
+					WithArgs(uid, 100, 0).
+					WillReturnRows(rows)
+			},
+			Run: func() {
+				res, err := repo.Statistic(ctx, &model.SDTestStatisticInput{UserID: uid})
+				assert.NoError(t, err)
+				assert.Equal(t, len(res), 1)
+				assert.Equal(t, res[0].TemplateID, temID)
+				assert.Equal(t, res[0].IndicationThreshold, 10)
+				assert.Equal(t, res[0].PositiveIndiationText, "pos")
+				assert.Equal(t, len(res[0].Stats), 2)
+				assert.Equal(t, res[0].Stats[0].TestResultID, tid1)
+				assert.Equal(t, res[0].Stats[0].ResultPoint, 8)
+				assert.Nil(t, res[0].Stats[0].Change)
+				assert.Equal(t, res[0].Stats[0].Interpretation, &model.SDTestInterpretation{IsPositive: true, Severity: "severe"})
+				assert.Equal(t, *res[0].Stats[1].Change, -3)
+				assert.Equal(t, res[0].Trend, model.SDTrendDecreasing)
+				assert.Equal(t, len(res[0].SubGroupTrends), 2)
+				assert.Equal(t, res[0].SubGroupTrends[0].GroupName, "a")
+				assert.Equal(t, res[0].SubGroupTrends[0].Trend, model.SDTrendDecreasing)
+				assert.Equal(t, *res[0].SubGroupTrends[0].Series[1].Change, -3)
+				assert.Equal(t, res[0].SubGroupTrends[1].Trend, model.SDTrendStable)
+			},
+		},
+		{
+			Name: "ok, filtered and paginated",
 			MockFn: func() {
-				mock.ExpectQuery(`^SELECT .+ FROM test_results`).WithArgs(uid).WillReturnRows(sqlmock.NewRows([]string{"template_id"}).AddRow(uuid.New()))
+				mock.ExpectQuery(`^SELECT .+ FROM test_results .+ AND tr.child_id = \$2 AND tr.finished_at >= \$3 AND tr.finished_at <= \$4 .+ LIMIT \$5 OFFSET \$6`).
+					WithArgs(uid, uuid.NullUUID{UUID: cid, Valid: true}, from, to, 10, 20).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(temID, "template", "10", "neg", "pos", tid2, pid, "package", to, `{"total":5}`, tid1, `{"total":8}`))
 			},
 			Run: func() {
-				_, err := repo.Statistic(ctx, uid)
+				res, err := repo.Statistic(ctx, &model.SDTestStatisticInput{
+					UserID:  uid,
+					ChildID: uuid.NullUUID{UUID: cid, Valid: true},
+					From:    from,
+					To:      to,
+					Limit:   10,
+					Offset:  20,
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, *res[0].Stats[0].Change, -3)
+			},
+		},
+		{
+			Name: "ok, the tests of the other child are never compared",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM test_results .+ AND tr.child_id = \$2 WINDOW w AS .+ LIMIT \$3 OFFSET \$4`).
+					WithArgs(uid, uuid.NullUUID{UUID: cid, Valid: true}, 100, 0).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(temID, "template", "10", "neg", "pos", tid1, pid, "package", from, `{"total":5}`, nil, nil))
+				mock.ExpectQuery(`^SELECT .+ FROM test_results .+ AND tr.child_id = \$2 WINDOW w AS .+ LIMIT \$3 OFFSET \$4`).
+					WithArgs(uid, uuid.NullUUID{UUID: otherCID, Valid: true}, 100, 0).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(temID, "template", "10", "neg", "pos", tid2, pid, "package", to, `{"total":8}`, nil, nil))
+			},
+			Run: func() {
+				res, err := repo.Statistic(ctx, &model.SDTestStatisticInput{
+					UserID:  uid,
+					ChildID: uuid.NullUUID{UUID: cid, Valid: true},
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, len(res[0].Stats), 1)
+				assert.Equal(t, res[0].Stats[0].TestResultID, tid1)
+				assert.Nil(t, res[0].Stats[0].Change)
+
+				res, err = repo.Statistic(ctx, &model.SDTestStatisticInput{
+					UserID:  uid,
+					ChildID: uuid.NullUUID{UUID: otherCID, Valid: true},
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, len(res[0].Stats), 1)
+				assert.Equal(t, res[0].Stats[0].TestResultID, tid2)
+				assert.Nil(t, res[0].Stats[0].Change)
+			},
+		},
+		{
+			Name: "ok, every test of the template without limit",
+			MockFn: func() {
+				mock.ExpectQuery(`^SELECT .+ FROM test_results .+ AND tr.child_id IS NULL AND tr.template_id = \$2 .+ LIMIT \$3 OFFSET \$4`).
+					WithArgs(uid, uuid.NullUUID{UUID: temID, Valid: true}, nil, 0).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(temID, "template", "10", "neg", "pos", tid2, pid, "package", to, `{"total":5}`, tid1, `{"total":8}`))
+			},
+			Run: func() {
+				res, err := repo.Statistic(ctx, &model.SDTestStatisticInput{
+					UserID:     uid,
+					TemplateID: uuid.NullUUID{UUID: temID, Valid: true},
+					Unlimited:  true,
+				})
 				assert.NoError(t, err)
+				assert.Equal(t, res[0].TemplateID, temID)
 			},
 		},
 	}
@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+)
+
+type cpUc struct {
+	cpRepo model.ChildProfileRepository
+}
+
+// NewChildProfileUsecase create ChildProfileUsecase
+func NewChildProfileUsecase(cpRepo model.ChildProfileRepository) model.ChildProfileUsecase {
+	return &cpUc{
+		cpRepo: cpRepo,
+	}
+}
+
+func (uc *cpUc) Create(ctx context.Context, input *model.ChildProfileInput) (*model.GeneratedChildProfile, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "cpUc.Create",
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to create child profile: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrChildProfileInputInvalid,
+		}
+	}
+
+	now := time.Now().UTC()
+	child := &model.ChildProfile{
+		ID:        uuid.New(),
+		UserID:    model.GetUserFromCtx(ctx).UserID,
+		Name:      input.Name,
+		BirthDate: input.BirthDate.UTC(),
+		Sex:       input.Sex,
+		Notes:     input.Notes,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := uc.cpRepo.Create(ctx, child); err != nil {
+		logger.WithError(err).Error("failed to create child profile")
+		return nil, &common.Error{
+			Message: "failed to create child profile",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	return child.ToRESTResponse(), nilErr
+}
+
+func (uc *cpUc) FindByID(ctx context.Context, id uuid.UUID) (*model.GeneratedChildProfile, *common.Error) {
+	child, cerr := uc.findAccessibleChild(ctx, id)
+	if cerr.Type != nil {
+		return nil, cerr
+	}
+
+	return child.ToRESTResponse(), nilErr
+}
+
+func (uc *cpUc) Search(ctx context.Context, input *model.SearchChildProfileInput) ([]*model.GeneratedChildProfile, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "cpUc.Search",
+		"input": helper.Dump(input),
+	})
+
+	requester := model.GetUserFromCtx(ctx)
+	if !requester.IsAdmin() {
+		input.UserID = uuid.NullUUID{UUID: requester.UserID, Valid: true}
+	}
+
+	res, err := uc.cpRepo.Search(ctx, input)
+	if err != nil {
+		logger.WithError(err).Error("failed to search child profile")
+		return nil, &common.Error{
+			Message: "failed to search child profile",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	resp := []*model.GeneratedChildProfile{}
+	for _, v := range res {
+		resp = append(resp, v.ToRESTResponse())
+	}
+
+	return resp, nilErr
+}
+
+func (uc *cpUc) Update(ctx context.Context, id uuid.UUID, input *model.ChildProfileInput) (*model.GeneratedChildProfile, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "cpUc.Update",
+		"id":    id.String(),
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to update child profile: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrChildProfileInputInvalid,
+		}
+	}
+
+	child, cerr := uc.findAccessibleChild(ctx, id)
+	if cerr.Type != nil {
+		return nil, cerr
+	}
+
+	child.Name = input.Name
+	child.BirthDate = input.BirthDate.UTC()
+	child.Sex = input.Sex
+	child.Notes = input.Notes
+	child.UpdatedAt = time.Now().UTC()
+
+	if err := uc.cpRepo.Update(ctx, child); err != nil {
+		logger.WithError(err).Error("failed to update child profile")
+		return nil, &common.Error{
+			Message: "failed to update child profile",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	return child.ToRESTResponse(), nilErr
+}
+
+func (uc *cpUc) Delete(ctx context.Context, id uuid.UUID) (*model.GeneratedChildProfile, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func": "cpUc.Delete",
+		"id":   id.String(),
+	})
+
+	if _, cerr := uc.findAccessibleChild(ctx, id); cerr.Type != nil {
+		return nil, cerr
+	}
+
+	deleted, err := uc.cpRepo.Delete(ctx, id)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete child profile")
+		return nil, &common.Error{
+			Message: "failed to delete child profile",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	return deleted.ToRESTResponse(), nilErr
+}
+
+// findAccessibleChild will find the child profile and ensure the requester is the owner or an admin
+func (uc *cpUc) findAccessibleChild(ctx context.Context, id uuid.UUID) (*model.ChildProfile, *common.Error) {
+	child, err := uc.cpRepo.FindByID(ctx, id)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).Error("failed to find child profile")
+		return nil, &common.Error{
+			Message: "failed to find child profile",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "child profile not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	requester := model.GetUserFromCtx(ctx)
+	if !requester.IsAdmin() && !child.IsOwnedBy(requester.UserID) {
+		return nil, &common.Error{
+			Message: "forbidden to access other people child profile",
+			Cause:   errors.New("forbidden to access other people child profile"),
+			Code:    http.StatusForbidden,
+			Type:    ErrForbiddenToAccessChildProfile,
+		}
+	}
+
+	return child, nilErr
+}
@@ -0,0 +1,392 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/model/mock"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChildProfileUsecase_Create(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	mockChildProfileRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
+	uc := NewChildProfileUsecase(mockChildProfileRepo)
+
+	user := model.AuthUser{
+		UserID:      uuid.New(),
+		AccessToken: "token",
+		Role:        model.RoleUser,
+	}
+	ctx := model.SetUserToCtx(context.Background(), user)
+
+	validInput := &model.ChildProfileInput{
+		Name:      "child",
+		BirthDate: time.Now().AddDate(-3, 0, 0),
+		Sex:       model.ChildSexMale,
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid input",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Create(ctx, &model.ChildProfileInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrChildProfileInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name:   "birth date in the future",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Create(ctx, &model.ChildProfileInput{
+					Name:      "child",
+					BirthDate: time.Now().Add(time.Hour * 24),
+					Sex:       model.ChildSexFemale,
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrChildProfileInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Create(ctx, validInput)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(nil)
+			},
+			Run: func() {
+				res, cerr := uc.Create(ctx, validInput)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.UserID, user.UserID)
+				assert.Equal(t, res.Name, validInput.Name)
+				assert.Equal(t, res.Sex, validInput.Sex)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestChildProfileUsecase_FindByID(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	mockChildProfileRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
+	uc := NewChildProfileUsecase(mockChildProfileRepo)
+
+	user := model.AuthUser{
+		UserID: uuid.New(),
+		Role:   model.RoleUser,
+	}
+	ctx := model.SetUserToCtx(context.Background(), user)
+	adminCtx := model.SetUserToCtx(context.Background(), model.AuthUser{
+		UserID: uuid.New(),
+		Role:   model.RoleAdmin,
+	})
+	id := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "err db",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().FindByID(ctx, id).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.FindByID(ctx, id)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "not found",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().FindByID(ctx, id).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.FindByID(ctx, id)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "owned by other user",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().FindByID(ctx, id).Times(1).Return(&model.ChildProfile{ID: id, UserID: uuid.New()}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.FindByID(ctx, id)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenToAccessChildProfile)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "ok by owner",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().FindByID(ctx, id).Times(1).Return(&model.ChildProfile{ID: id, UserID: user.UserID}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.FindByID(ctx, id)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.ID, id)
+			},
+		},
+		{
+			Name: "ok by admin",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().FindByID(adminCtx, id).Times(1).Return(&model.ChildProfile{ID: id, UserID: user.UserID}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.FindByID(adminCtx, id)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.ID, id)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestChildProfileUsecase_Search(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	mockChildProfileRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
+	uc := NewChildProfileUsecase(mockChildProfileRepo)
+
+	user := model.AuthUser{
+		UserID: uuid.New(),
+		Role:   model.RoleUser,
+	}
+	ctx := model.SetUserToCtx(context.Background(), user)
+	adminCtx := model.SetUserToCtx(context.Background(), model.AuthUser{
+		UserID: uuid.New(),
+		Role:   model.RoleAdmin,
+	})
+	otherUserID := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+
+	tests := []common.TestStructure{
+		{
+			Name: "non admin only see their own children",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().Search(ctx, &model.SearchChildProfileInput{
+					UserID: uuid.NullUUID{UUID: user.UserID, Valid: true},
+				}).Times(1).Return([]*model.ChildProfile{{ID: uuid.New(), UserID: user.UserID}}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Search(ctx, &model.SearchChildProfileInput{UserID: otherUserID})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, len(res), 1)
+			},
+		},
+		{
+			Name: "admin can search any user children",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().Search(adminCtx, &model.SearchChildProfileInput{UserID: otherUserID}).Times(1).Return([]*model.ChildProfile{}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Search(adminCtx, &model.SearchChildProfileInput{UserID: otherUserID})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, len(res), 0)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().Search(adminCtx, gomock.Any()).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Search(adminCtx, &model.SearchChildProfileInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestChildProfileUsecase_Update(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	mockChildProfileRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
+	uc := NewChildProfileUsecase(mockChildProfileRepo)
+
+	user := model.AuthUser{
+		UserID: uuid.New(),
+		Role:   model.RoleUser,
+	}
+	ctx := model.SetUserToCtx(context.Background(), user)
+	id := uuid.New()
+
+	input := &model.ChildProfileInput{
+		Name:      "new name",
+		BirthDate: time.Now().AddDate(-2, 0, 0),
+		Sex:       model.ChildSexFemale,
+		Notes:     "new notes",
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid input",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Update(ctx, id, &model.ChildProfileInput{Name: "name", BirthDate: time.Now(), Sex: "unknown"})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrChildProfileInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "owned by other user",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().FindByID(ctx, id).Times(1).Return(&model.ChildProfile{ID: id, UserID: uuid.New()}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.Update(ctx, id, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenToAccessChildProfile)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "err db on update",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().FindByID(ctx, id).Times(1).Return(&model.ChildProfile{ID: id, UserID: user.UserID}, nil)
+				mockChildProfileRepo.EXPECT().Update(ctx, gomock.Any()).Times(1).Return(errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Update(ctx, id, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().FindByID(ctx, id).Times(1).Return(&model.ChildProfile{ID: id, UserID: user.UserID, Name: "old name"}, nil)
+				mockChildProfileRepo.EXPECT().Update(ctx, gomock.Any()).Times(1).Return(nil)
+			},
+			Run: func() {
+				res, cerr := uc.Update(ctx, id, input)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.Name, input.Name)
+				assert.Equal(t, res.Notes, input.Notes)
+				assert.Equal(t, res.Sex, input.Sex)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestChildProfileUsecase_Delete(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	mockChildProfileRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
+	uc := NewChildProfileUsecase(mockChildProfileRepo)
+
+	user := model.AuthUser{
+		UserID: uuid.New(),
+		Role:   model.RoleUser,
+	}
+	ctx := model.SetUserToCtx(context.Background(), user)
+	id := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "not found",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().FindByID(ctx, id).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.Delete(ctx, id)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "err db on delete",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().FindByID(ctx, id).Times(1).Return(&model.ChildProfile{ID: id, UserID: user.UserID}, nil)
+				mockChildProfileRepo.EXPECT().Delete(ctx, id).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Delete(ctx, id)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockChildProfileRepo.EXPECT().FindByID(ctx, id).Times(1).Return(&model.ChildProfile{ID: id, UserID: user.UserID}, nil)
+				mockChildProfileRepo.EXPECT().Delete(ctx, id).Times(1).Return(&model.ChildProfile{ID: id, UserID: user.UserID}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Delete(ctx, id)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.ID, id)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
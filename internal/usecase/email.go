@@ -59,6 +59,17 @@ func (uc *emailUc) Register(ctx context.Context, input *model.RegisterEmailInput
 		UpdatedAt: time.Now().UTC(),
 	}
 
+	for _, a := range input.Attachments {
+		email.Attachments = append(email.Attachments, model.EmailAttachment{
+			ID:          uuid.New(),
+			EmailID:     email.ID,
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Content:     a.Content,
+			CreatedAt:   email.CreatedAt,
+		})
+	}
+
 	if err := uc.emailRepo.Create(ctx, email); err != nil {
 		logger.WithError(err).Error("repository layer return error when create emails")
 		return nil, custerr.ErrChain{
@@ -85,6 +85,22 @@ func TestEmailUsecase_Register(t *testing.T) {
 				assert.Equal(t, custErr.Type, ErrEmailInputInvalid)
 			},
 		},
+		{
+			Name:   "attachment content is empty",
+			MockFn: func() {},
+			Run: func() {
+				input := *validInput
+				input.Attachments = []model.RegisterEmailAttachmentInput{{Filename: "result.png", ContentType: "image/png"}}
+				_, err := uc.Register(ctx, &input)
+				assert.Error(t, err)
+
+				custErr, ok := err.(custerr.ErrChain)
+				assert.True(t, ok)
+
+				assert.Equal(t, custErr.Code, http.StatusBadRequest)
+				assert.Equal(t, custErr.Type, ErrEmailInputInvalid)
+			},
+		},
 		{
 			Name: "failed to write data to database",
 			MockFn: func() {
@@ -131,6 +147,27 @@ func TestEmailUsecase_Register(t *testing.T) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			Name: "ok with attachments",
+			MockFn: func() {
+				mockEmailRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, email *model.Email) error {
+					assert.Len(t, email.Attachments, 1)
+					assert.Equal(t, email.Attachments[0].EmailID, email.ID)
+					assert.Equal(t, email.Attachments[0].Filename, "result.png")
+					assert.Equal(t, email.Attachments[0].Content, []byte("content"))
+					return nil
+				})
+				mockWorkerClient.EXPECT().EnqueueSendEmailTask(ctx, gomock.Any()).Times(1).Return(&asynq.TaskInfo{
+					ID: "id",
+				}, nil)
+			},
+			Run: func() {
+				input := *validInput
+				input.Attachments = []model.RegisterEmailAttachmentInput{{Filename: "result.png", ContentType: "image/png", Content: []byte("content")}}
+				_, err := uc.Register(ctx, &input)
+				assert.NoError(t, err)
+			},
+		},
 	}
 
 	for _, tt := range tests {
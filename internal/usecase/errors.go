@@ -105,6 +105,63 @@ var (
 
 	// ErrForbiddenDownloadSDTestResult will be returned when access blocked for sd test result is
 	ErrForbiddenDownloadSDTestResult = errors.New("005005")
+
+	// ErrInvalidClaimSDTestInput will be returned if the input to claim sd test is invalid
+	ErrInvalidClaimSDTestInput = errors.New("005006")
+
+	// ErrSDTestAlreadyOwned will be returned when trying to claim sd test which already has an owner
+	ErrSDTestAlreadyOwned = errors.New("005007")
+
+	// ErrSDPackageTemplateMismatch will be returned when the sd package to initiate the test does not belong to the requested template
+	ErrSDPackageTemplateMismatch = errors.New("005008")
+
+	// ErrSDTestRetakeNotAllowed will be returned when the sd test can't be initiated because of the template retake policy
+	ErrSDTestRetakeNotAllowed = errors.New("005009")
+
+	// ErrInvalidSDTestStatisticInput will be returned when the input to get sd test statistic is invalid
+	ErrInvalidSDTestStatisticInput = errors.New("005010")
+
+	// ErrInvalidDownloadSDTestResultInput will be returned when the input to download sd test result is invalid
+	ErrInvalidDownloadSDTestResultInput = errors.New("005011")
+
+	// ErrInvalidSDTestProgressChartInput will be returned when the input to draw sd test progress chart is invalid
+	ErrInvalidSDTestProgressChartInput = errors.New("005012")
+
+	// ErrInvalidVerifySDTestResultInput will be returned when the input to verify sd test result is invalid
+	ErrInvalidVerifySDTestResultInput = errors.New("005013")
+
+	// ErrInvalidSDResultShareInput will be returned when the input to create, revoke or view sd test result share is invalid
+	ErrInvalidSDResultShareInput = errors.New("005014")
+
+	// ErrForbiddenToShareSDTestResult will be returned when the requester is not allowed to manage the sd test result share
+	ErrForbiddenToShareSDTestResult = errors.New("005015")
+
+	// ErrSDResultShareUnavailable will be returned when the sd test result share is already revoked, expired or reaching the max views
+	ErrSDResultShareUnavailable = errors.New("005016")
+
+	// ErrSDResultShareScopeNotAllowed will be returned when the sd test result share scope doesn't allow the requested view
+	ErrSDResultShareScopeNotAllowed = errors.New("005017")
+
+	// ErrSDBundleInputInvalid will be returned when the bundle to export or import is invalid
+	ErrSDBundleInputInvalid = errors.New("006001")
+
+	// ErrSDAssignmentInputInvalid will be returned when the input to create sd test assignment is invalid
+	ErrSDAssignmentInputInvalid = errors.New("007001")
+
+	// ErrSDAssignmentNotOpen will be returned when the sd test assignment is already completed or cancelled
+	ErrSDAssignmentNotOpen = errors.New("007002")
+
+	// ErrForbiddenToAccessSDAssignment will be returned when the requester is not the assignee of the sd test assignment
+	ErrForbiddenToAccessSDAssignment = errors.New("007003")
+
+	// ErrChildProfileInputInvalid will be returned when the input to create or update child profile is invalid
+	ErrChildProfileInputInvalid = errors.New("008001")
+
+	// ErrForbiddenToAccessChildProfile will be returned when the requester is not the owner of the child profile
+	ErrForbiddenToAccessChildProfile = errors.New("008002")
+
+	// ErrSDAnalyticsInputInvalid will be returned when the input to get sd analytics is invalid
+	ErrSDAnalyticsInputInvalid = errors.New("009001")
 )
 
 var nilErr = &common.Error{
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+)
+
+// newPackageSelectors create all the known package selectors keyed by their strategy.
+// intn is used as the source of randomness, usually rand.Intn
+func newPackageSelectors(sdtrRepo model.SDTestRepository, intn func(n int) int) map[model.PackageSelectionStrategy]model.PackageSelector {
+	random := &randomSelector{intn: intn}
+	leastUsed := &leastUsedSelector{sdtrRepo: sdtrRepo, fallback: random}
+
+	selectors := make(map[model.PackageSelectionStrategy]model.PackageSelector)
+	for _, s := range []model.PackageSelector{
+		random,
+		leastUsed,
+		&roundRobinSelector{sdtrRepo: sdtrRepo},
+		&weightedRandomSelector{intn: intn},
+		&sameTemplateSelector{sdtrRepo: sdtrRepo, leastUsed: leastUsed},
+	} {
+		selectors[s.Strategy()] = s
+	}
+
+	return selectors
+}
+
+type randomSelector struct {
+	intn func(n int) int
+}
+
+func (s *randomSelector) Strategy() model.PackageSelectionStrategy {
+	return model.PackageSelectionRandom
+}
+
+func (s *randomSelector) Select(_ context.Context, input *model.PackageSelectionInput) (*model.SpeechDelayPackage, error) {
+	return input.Candidates[s.intn(len(input.Candidates))], nil
+}
+
+type leastUsedSelector struct {
+	sdtrRepo model.SDTestRepository
+	fallback model.PackageSelector
+}
+
+func (s *leastUsedSelector) Strategy() model.PackageSelectionStrategy {
+	return model.PackageSelectionLeastUsed
+}
+
+// Select will choose the package least used by the user. When some packages have the same usage,
+// the oldest one is chosen. Anonymous user has no usage, thus will use the fallback selector
+func (s *leastUsedSelector) Select(ctx context.Context, input *model.PackageSelectionInput) (*model.SpeechDelayPackage, error) {
+	if !input.UserID.Valid {
+		return s.fallback.Select(ctx, input)
+	}
+
+	usage, err := s.sdtrRepo.CountPackageUsage(ctx, input.UserID.UUID, packageIDsOf(input.Candidates))
+	if err != nil {
+		return nil, err
+	}
+
+	chosen := input.Candidates[0]
+	for _, v := range input.Candidates[1:] {
+		if usage[v.ID] < usage[chosen.ID] {
+			chosen = v
+		}
+	}
+
+	return chosen, nil
+}
+
+type roundRobinSelector struct {
+	sdtrRepo model.SDTestRepository
+}
+
+func (s *roundRobinSelector) Strategy() model.PackageSelectionStrategy {
+	return model.PackageSelectionRoundRobin
+}
+
+// Select will choose the package next to the one used by the latest test of any user.
+// When none of the candidates was used, the first candidate is chosen
+func (s *roundRobinSelector) Select(ctx context.Context, input *model.PackageSelectionInput) (*model.SpeechDelayPackage, error) {
+	latest, err := s.sdtrRepo.FindLatest(ctx, &model.FindLatestSDTestInput{
+		PackageIDs: packageIDsOf(input.Candidates),
+	})
+	switch err {
+	default:
+		return nil, err
+	case repository.ErrNotFound:
+		return input.Candidates[0], nil
+	case nil:
+		break
+	}
+
+	for i, v := range input.Candidates {
+		if v.ID == latest.PackageID {
+			return input.Candidates[(i+1)%len(input.Candidates)], nil
+		}
+	}
+
+	return input.Candidates[0], nil
+}
+
+type weightedRandomSelector struct {
+	intn func(n int) int
+}
+
+func (s *weightedRandomSelector) Strategy() model.PackageSelectionStrategy {
+	return model.PackageSelectionWeightedRandom
+}
+
+// Select will choose a random package, where the chance of each package is proportional to its selection weight
+func (s *weightedRandomSelector) Select(_ context.Context, input *model.PackageSelectionInput) (*model.SpeechDelayPackage, error) {
+	total := 0
+	for _, v := range input.Candidates {
+		total += selectionWeightOf(v)
+	}
+
+	point := s.intn(total)
+	for _, v := range input.Candidates {
+		point -= selectionWeightOf(v)
+		if point < 0 {
+			return v, nil
+		}
+	}
+
+	return input.Candidates[len(input.Candidates)-1], nil
+}
+
+type sameTemplateSelector struct {
+	sdtrRepo  model.SDTestRepository
+	leastUsed model.PackageSelector
+}
+
+func (s *sameTemplateSelector) Strategy() model.PackageSelectionStrategy {
+	return model.PackageSelectionSameTemplate
+}
+
+// Select will narrow the candidates to the template used by the user latest test, then choose the least used package.
+// When the user never took any test, or the template has no candidate left, all the candidates are used.
+// When the test is initiated for a specific template, the candidates are already narrowed, thus behave as least used
+func (s *sameTemplateSelector) Select(ctx context.Context, input *model.PackageSelectionInput) (*model.SpeechDelayPackage, error) {
+	if !input.UserID.Valid {
+		return s.leastUsed.Select(ctx, input)
+	}
+
+	latest, err := s.sdtrRepo.FindLatest(ctx, &model.FindLatestSDTestInput{UserID: input.UserID})
+	switch err {
+	default:
+		return nil, err
+	case repository.ErrNotFound:
+		return s.leastUsed.Select(ctx, input)
+	case nil:
+		break
+	}
+
+	var sameTemplate []*model.SpeechDelayPackage
+	for _, v := range input.Candidates {
+		if v.TemplateID == latest.TemplateID {
+			sameTemplate = append(sameTemplate, v)
+		}
+	}
+
+	if len(sameTemplate) == 0 {
+		return s.leastUsed.Select(ctx, input)
+	}
+
+	return s.leastUsed.Select(ctx, &model.PackageSelectionInput{
+		UserID:     input.UserID,
+		Candidates: sameTemplate,
+	})
+}
+
+func packageIDsOf(packs []*model.SpeechDelayPackage) []uuid.UUID {
+	ids := []uuid.UUID{}
+	for _, v := range packs {
+		ids = append(ids, v.ID)
+	}
+
+	return ids
+}
+
+func selectionWeightOf(pack *model.SpeechDelayPackage) int {
+	if pack.Package == nil || pack.Package.SelectionWeight <= 0 {
+		return 1
+	}
+
+	return pack.Package.SelectionWeight
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/model/mock"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageSelectors(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
+	ctx := context.Background()
+
+	// deterministic randomness, counting backward from the last index
+	lastIntn := 0
+	intn := func(n int) int {
+		return n - 1 - lastIntn
+	}
+	selectors := newPackageSelectors(sdtrRepo, intn)
+
+	userID := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	templateA := uuid.New()
+	templateB := uuid.New()
+	pack1 := &model.SpeechDelayPackage{ID: uuid.New(), TemplateID: templateA, Package: &model.SDPackage{SelectionWeight: 3}}
+	pack2 := &model.SpeechDelayPackage{ID: uuid.New(), TemplateID: templateA, Package: &model.SDPackage{}}
+	pack3 := &model.SpeechDelayPackage{ID: uuid.New(), TemplateID: templateB, Package: &model.SDPackage{}}
+	candidates := []*model.SpeechDelayPackage{pack1, pack2, pack3}
+	candidateIDs := []uuid.UUID{pack1.ID, pack2.ID, pack3.ID}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "all strategies are registered",
+			MockFn: func() {},
+			Run: func() {
+				for _, s := range []model.PackageSelectionStrategy{
+					model.PackageSelectionLeastUsed,
+					model.PackageSelectionRoundRobin,
+					model.PackageSelectionWeightedRandom,
+					model.PackageSelectionSameTemplate,
+					model.PackageSelectionRandom,
+				} {
+					assert.Equal(t, selectors[s].Strategy(), s)
+				}
+			},
+		},
+		{
+			Name:   "random",
+			MockFn: func() {},
+			Run: func() {
+				res, err := selectors[model.PackageSelectionRandom].Select(ctx, &model.PackageSelectionInput{Candidates: candidates})
+				assert.NoError(t, err)
+				assert.Equal(t, res, pack3)
+			},
+		},
+		{
+			Name:   "least used for anonymous user fallback to random",
+			MockFn: func() {},
+			Run: func() {
+				res, err := selectors[model.PackageSelectionLeastUsed].Select(ctx, &model.PackageSelectionInput{Candidates: candidates})
+				assert.NoError(t, err)
+				assert.Equal(t, res, pack3)
+			},
+		},
+		{
+			Name: "least used must pick the oldest package with the least usage",
+			MockFn: func() {
+				sdtrRepo.EXPECT().CountPackageUsage(ctx, userID.UUID, candidateIDs).Times(1).Return(map[uuid.UUID]int{
+					pack1.ID: 2,
+				}, nil)
+			},
+			Run: func() {
+				res, err := selectors[model.PackageSelectionLeastUsed].Select(ctx, &model.PackageSelectionInput{UserID: userID, Candidates: candidates})
+				assert.NoError(t, err)
+				assert.Equal(t, res, pack2)
+			},
+		},
+		{
+			Name: "least used got err db",
+			MockFn: func() {
+				sdtrRepo.EXPECT().CountPackageUsage(ctx, userID.UUID, candidateIDs).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, err := selectors[model.PackageSelectionLeastUsed].Select(ctx, &model.PackageSelectionInput{UserID: userID, Candidates: candidates})
+				assert.Error(t, err)
+			},
+		},
+		{
+			Name: "round robin start from the first package",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{PackageIDs: candidateIDs}).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				res, err := selectors[model.PackageSelectionRoundRobin].Select(ctx, &model.PackageSelectionInput{UserID: userID, Candidates: candidates})
+				assert.NoError(t, err)
+				assert.Equal(t, res, pack1)
+			},
+		},
+		{
+			Name: "round robin wrap around to the first package",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{PackageIDs: candidateIDs}).Times(1).Return(&model.SDTest{PackageID: pack3.ID}, nil)
+			},
+			Run: func() {
+				res, err := selectors[model.PackageSelectionRoundRobin].Select(ctx, &model.PackageSelectionInput{Candidates: candidates})
+				assert.NoError(t, err)
+				assert.Equal(t, res, pack1)
+			},
+		},
+		{
+			Name: "round robin pick the next package",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{PackageIDs: candidateIDs}).Times(1).Return(&model.SDTest{PackageID: pack1.ID}, nil)
+			},
+			Run: func() {
+				res, err := selectors[model.PackageSelectionRoundRobin].Select(ctx, &model.PackageSelectionInput{Candidates: candidates})
+				assert.NoError(t, err)
+				assert.Equal(t, res, pack2)
+			},
+		},
+		{
+			Name: "round robin got err db",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{PackageIDs: candidateIDs}).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, err := selectors[model.PackageSelectionRoundRobin].Select(ctx, &model.PackageSelectionInput{Candidates: candidates})
+				assert.Error(t, err)
+			},
+		},
+		{
+			Name:   "weighted random respect the package weight",
+			MockFn: func() {},
+			Run: func() {
+				// total weight is 5, pack1 own the point 0 until 2
+				for i, expected := range []*model.SpeechDelayPackage{pack3, pack2, pack1, pack1, pack1} {
+					lastIntn = i
+					res, err := selectors[model.PackageSelectionWeightedRandom].Select(ctx, &model.PackageSelectionInput{Candidates: candidates})
+					assert.NoError(t, err)
+					assert.Equal(t, res, expected)
+				}
+				lastIntn = 0
+			},
+		},
+		{
+			Name: "same template narrow the candidates to the template of the latest test",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{UserID: userID}).Times(1).Return(&model.SDTest{PackageID: pack1.ID, TemplateID: templateA}, nil)
+				sdtrRepo.EXPECT().CountPackageUsage(ctx, userID.UUID, []uuid.UUID{pack1.ID, pack2.ID}).Times(1).Return(map[uuid.UUID]int{
+					pack1.ID: 1,
+					pack2.ID: 1,
+				}, nil)
+			},
+			Run: func() {
+				res, err := selectors[model.PackageSelectionSameTemplate].Select(ctx, &model.PackageSelectionInput{UserID: userID, Candidates: candidates})
+				assert.NoError(t, err)
+				assert.Equal(t, res, pack1)
+			},
+		},
+		{
+			Name: "same template use all the candidates when the user never took any test",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{UserID: userID}).Times(1).Return(nil, repository.ErrNotFound)
+				sdtrRepo.EXPECT().CountPackageUsage(ctx, userID.UUID, candidateIDs).Times(1).Return(map[uuid.UUID]int{}, nil)
+			},
+			Run: func() {
+				res, err := selectors[model.PackageSelectionSameTemplate].Select(ctx, &model.PackageSelectionInput{UserID: userID, Candidates: candidates})
+				assert.NoError(t, err)
+				assert.Equal(t, res, pack1)
+			},
+		},
+		{
+			Name: "same template still narrow the candidates when the latest package is no longer a candidate",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{UserID: userID}).Times(1).Return(&model.SDTest{PackageID: uuid.New(), TemplateID: templateB}, nil)
+				sdtrRepo.EXPECT().CountPackageUsage(ctx, userID.UUID, []uuid.UUID{pack3.ID}).Times(1).Return(map[uuid.UUID]int{}, nil)
+			},
+			Run: func() {
+				res, err := selectors[model.PackageSelectionSameTemplate].Select(ctx, &model.PackageSelectionInput{UserID: userID, Candidates: candidates})
+				assert.NoError(t, err)
+				assert.Equal(t, res, pack3)
+			},
+		},
+		{
+			Name: "same template use all the candidates when the template of the latest test has no candidate left",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{UserID: userID}).Times(1).Return(&model.SDTest{PackageID: uuid.New(), TemplateID: uuid.New()}, nil)
+				sdtrRepo.EXPECT().CountPackageUsage(ctx, userID.UUID, candidateIDs).Times(1).Return(map[uuid.UUID]int{
+					pack1.ID: 1,
+					pack2.ID: 1,
+				}, nil)
+			},
+			Run: func() {
+				res, err := selectors[model.PackageSelectionSameTemplate].Select(ctx, &model.PackageSelectionInput{UserID: userID, Candidates: candidates})
+				assert.NoError(t, err)
+				assert.Equal(t, res, pack3)
+			},
+		},
+		{
+			Name: "same template got err db",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{UserID: userID}).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, err := selectors[model.PackageSelectionSameTemplate].Select(ctx, &model.PackageSelectionInput{UserID: userID, Candidates: candidates})
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+)
+
+type sdanUc struct {
+	sdanRepo model.SDAnalyticsRepository
+	sdtRepo  model.SDTemplateRepository
+	sdpRepo  model.SDPackageRepository
+}
+
+// NewSDAnalyticsUsecase create SDAnalyticsUsecase
+func NewSDAnalyticsUsecase(sdanRepo model.SDAnalyticsRepository, sdtRepo model.SDTemplateRepository, sdpRepo model.SDPackageRepository) model.SDAnalyticsUsecase {
+	return &sdanUc{
+		sdanRepo: sdanRepo,
+		sdtRepo:  sdtRepo,
+		sdpRepo:  sdpRepo,
+	}
+}
+
+func (uc *sdanUc) TemplateAnalytics(ctx context.Context, input *model.SDAnalyticsInput) (*model.SDAnalytics, *common.Error) {
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to get sd template analytics: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrSDAnalyticsInputInvalid,
+		}
+	}
+
+	analytics, cerr := uc.newTemplateAnalytics(ctx, input.ID)
+	if cerr.Type != nil {
+		return nil, cerr
+	}
+
+	return uc.aggregate(ctx, analytics, input)
+}
+
+func (uc *sdanUc) PackageAnalytics(ctx context.Context, input *model.SDAnalyticsInput) (*model.SDAnalytics, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdanUc.PackageAnalytics",
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to get sd package analytics: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrSDAnalyticsInputInvalid,
+		}
+	}
+
+	pack, err := uc.sdpRepo.FindByID(ctx, input.ID, true)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find sd package")
+		return nil, &common.Error{
+			Message: "failed to find sd package",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd package not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	analytics, cerr := uc.newTemplateAnalytics(ctx, pack.TemplateID)
+	if cerr.Type != nil {
+		return nil, cerr
+	}
+
+	analytics.PackageID = uuid.NullUUID{UUID: pack.ID, Valid: true}
+	analytics.PackageName = pack.Name
+
+	return uc.aggregate(ctx, analytics, input)
+}
+
+// newTemplateAnalytics create the analytics of the template, without the segments. Deleted template is allowed
+func (uc *sdanUc) newTemplateAnalytics(ctx context.Context, templateID uuid.UUID) (*model.SDAnalytics, *common.Error) {
+	tem, err := uc.sdtRepo.FindByID(ctx, templateID, true)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).Error("failed to find sd template")
+		return nil, &common.Error{
+			Message: "failed to find sd template",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd template not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	analytics := &model.SDAnalytics{
+		TemplateID:   tem.ID,
+		TemplateName: tem.Name,
+	}
+
+	if tem.Template != nil {
+		analytics.IndicationThreshold = tem.Template.IndicationThreshold
+	}
+
+	return analytics, nilErr
+}
+
+// aggregate will fill the analytics segments from the score distributions aggregated by the database
+func (uc *sdanUc) aggregate(ctx context.Context, analytics *model.SDAnalytics, input *model.SDAnalyticsInput) (*model.SDAnalytics, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdanUc.aggregate",
+		"input": helper.Dump(input),
+	})
+
+	analytics.From = input.From
+	analytics.To = input.To
+	filter := &model.SDAnalyticsFilter{
+		TemplateID: analytics.TemplateID,
+		PackageID:  analytics.PackageID,
+		From:       input.From,
+		To:         input.To,
+	}
+
+	total, err := uc.sdanRepo.TotalScoreDistribution(ctx, filter)
+	if err != nil {
+		logger.WithError(err).Error("failed to aggregate total score distribution")
+		return nil, &common.Error{
+			Message: "failed to aggregate total score distribution",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	subGroups, err := uc.sdanRepo.SubGroupScoreDistribution(ctx, filter)
+	if err != nil {
+		logger.WithError(err).Error("failed to aggregate sub group score distribution")
+		return nil, &common.Error{
+			Message: "failed to aggregate sub group score distribution",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	analytics.Aggregate(total, subGroups)
+
+	return analytics, nilErr
+}
+
+func (uc *sdanUc) PackageItemAnalysis(ctx context.Context, input *model.SDItemAnalysisInput) (*model.SDItemAnalysisReport, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdanUc.PackageItemAnalysis",
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to get sd package item analysis: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrSDAnalyticsInputInvalid,
+		}
+	}
+
+	pack, err := uc.sdpRepo.FindByID(ctx, input.ID, true)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find sd package")
+		return nil, &common.Error{
+			Message: "failed to find sd package",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd package not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	answers, err := uc.sdanRepo.FindFinishedAnswers(ctx, &model.SDAnalyticsFilter{
+		TemplateID: pack.TemplateID,
+		PackageID:  uuid.NullUUID{UUID: pack.ID, Valid: true},
+		From:       input.From,
+		To:         input.To,
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to find finished test answers")
+		return nil, &common.Error{
+			Message: "failed to find finished test answers",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	report := model.NewSDItemAnalysisReport(pack, answers, input.DominanceThreshold)
+	report.From = input.From
+	report.To = input.To
+
+	return report, nilErr
+}
@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/model/mock"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSDAnalyticsUsecase_TemplateAnalytics(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	mockSDAnalyticsRepo := mock.NewMockSDAnalyticsRepository(kit.Ctrl)
+	mockSDTemplateRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	mockSDPackageRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	uc := NewSDAnalyticsUsecase(mockSDAnalyticsRepo, mockSDTemplateRepo, mockSDPackageRepo)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	input := &model.SDAnalyticsInput{
+		ID:   uuid.New(),
+		From: now.Add(-time.Hour),
+		To:   now,
+	}
+	template := &model.SpeechDelayTemplate{
+		ID:   input.ID,
+		Name: "template",
+		Template: &model.SDTemplate{
+			IndicationThreshold: 10,
+		},
+	}
+	filter := &model.SDAnalyticsFilter{
+		TemplateID: input.ID,
+		From:       input.From,
+		To:         input.To,
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid date range",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.TemplateAnalytics(ctx, &model.SDAnalyticsInput{ID: input.ID, From: now, To: now.Add(-time.Hour)})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDAnalyticsInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "template not found",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, input.ID, true).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.TemplateAnalytics(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "err db when finding template",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, input.ID, true).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.TemplateAnalytics(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "err db when aggregating total score",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, input.ID, true).Times(1).Return(template, nil)
+				mockSDAnalyticsRepo.EXPECT().TotalScoreDistribution(ctx, filter).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.TemplateAnalytics(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "err db when aggregating sub group score",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, input.ID, true).Times(1).Return(template, nil)
+				mockSDAnalyticsRepo.EXPECT().TotalScoreDistribution(ctx, filter).Times(1).Return([]model.SDScoreDistributionRow{}, nil)
+				mockSDAnalyticsRepo.EXPECT().SubGroupScoreDistribution(ctx, filter).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.TemplateAnalytics(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, input.ID, true).Times(1).Return(template, nil)
+				mockSDAnalyticsRepo.EXPECT().TotalScoreDistribution(ctx, filter).Times(1).Return([]model.SDScoreDistributionRow{
+					{Registered: false, Point: 3, Count: 1},
+					{Registered: true, Point: 12, Count: 1, Positive: 1},
+				}, nil)
+				mockSDAnalyticsRepo.EXPECT().SubGroupScoreDistribution(ctx, filter).Times(1).Return([]model.SDScoreDistributionRow{
+					{Registered: false, GroupName: "a", Point: 3, Count: 1},
+					{Registered: true, GroupName: "a", Point: 12, Count: 1},
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.TemplateAnalytics(ctx, input)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.TemplateID, template.ID)
+				assert.Equal(t, res.TemplateName, template.Name)
+				assert.False(t, res.PackageID.Valid)
+				assert.Equal(t, res.IndicationThreshold, 10)
+				assert.Equal(t, res.From, input.From)
+				assert.Equal(t, res.To, input.To)
+				assert.Equal(t, res.Overall.FinishedTests, 2)
+				assert.Equal(t, res.Overall.PositiveIndications, 1)
+				assert.Equal(t, res.Anonymous.FinishedTests, 1)
+				assert.Equal(t, res.Anonymous.PositiveIndications, 0)
+				assert.Equal(t, res.Registered.FinishedTests, 1)
+				assert.Equal(t, res.Registered.PositiveIndications, 1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDAnalyticsUsecase_PackageAnalytics(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	mockSDAnalyticsRepo := mock.NewMockSDAnalyticsRepository(kit.Ctrl)
+	mockSDTemplateRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	mockSDPackageRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	uc := NewSDAnalyticsUsecase(mockSDAnalyticsRepo, mockSDTemplateRepo, mockSDPackageRepo)
+	ctx := context.Background()
+
+	input := &model.SDAnalyticsInput{
+		ID: uuid.New(),
+	}
+	pack := &model.SpeechDelayPackage{
+		ID:         input.ID,
+		TemplateID: uuid.New(),
+		Name:       "package",
+	}
+	template := &model.SpeechDelayTemplate{
+		ID:   pack.TemplateID,
+		Name: "template",
+		Template: &model.SDTemplate{
+			IndicationThreshold: 10,
+		},
+	}
+	filter := &model.SDAnalyticsFilter{
+		TemplateID: template.ID,
+		PackageID:  uuid.NullUUID{UUID: pack.ID, Valid: true},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "package not found",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, input.ID, true).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.PackageAnalytics(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "err db when finding package",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, input.ID, true).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.PackageAnalytics(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "template not found",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, input.ID, true).Times(1).Return(pack, nil)
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, pack.TemplateID, true).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.PackageAnalytics(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, input.ID, true).Times(1).Return(pack, nil)
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, pack.TemplateID, true).Times(1).Return(template, nil)
+				mockSDAnalyticsRepo.EXPECT().TotalScoreDistribution(ctx, filter).Times(1).Return([]model.SDScoreDistributionRow{
+					{Registered: true, Point: 10, Count: 2, Positive: 2},
+				}, nil)
+				mockSDAnalyticsRepo.EXPECT().SubGroupScoreDistribution(ctx, filter).Times(1).Return([]model.SDScoreDistributionRow{}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.PackageAnalytics(ctx, input)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.TemplateID, template.ID)
+				assert.Equal(t, res.PackageID, filter.PackageID)
+				assert.Equal(t, res.PackageName, pack.Name)
+				assert.Equal(t, res.Overall.FinishedTests, 2)
+				assert.Equal(t, res.Overall.PositiveIndicationRate, float64(1))
+				assert.Equal(t, res.Anonymous.FinishedTests, 0)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDAnalyticsUsecase_PackageItemAnalysis(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	mockSDAnalyticsRepo := mock.NewMockSDAnalyticsRepository(kit.Ctrl)
+	mockSDTemplateRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	mockSDPackageRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	uc := NewSDAnalyticsUsecase(mockSDAnalyticsRepo, mockSDTemplateRepo, mockSDPackageRepo)
+	ctx := context.Background()
+
+	groupID, questionID, answerID := uuid.New(), uuid.New(), uuid.New()
+	pack := &model.SpeechDelayPackage{
+		ID:         uuid.New(),
+		TemplateID: uuid.New(),
+		Name:       "package",
+		Package: &model.SDPackage{
+			SubGroupDetails: []model.SDSubGroupDetail{
+				{
+					ID:   groupID,
+					Name: "group",
+					QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+						{
+							ID:              questionID,
+							Question:        "question",
+							AnswersAndValue: []model.SDAnswerAndValue{{ID: answerID, Text: "answer", Value: 1}},
+						},
+					},
+				},
+			},
+		},
+	}
+	filter := &model.SDAnalyticsFilter{
+		TemplateID: pack.TemplateID,
+		PackageID:  uuid.NullUUID{UUID: pack.ID, Valid: true},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid dominance threshold",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.PackageItemAnalysis(ctx, &model.SDItemAnalysisInput{ID: pack.ID, DominanceThreshold: 2})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDAnalyticsInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "package not found",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, pack.ID, true).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.PackageItemAnalysis(ctx, &model.SDItemAnalysisInput{ID: pack.ID})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "err db when finding package",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, pack.ID, true).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.PackageItemAnalysis(ctx, &model.SDItemAnalysisInput{ID: pack.ID})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "err db when finding answers",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, pack.ID, true).Times(1).Return(pack, nil)
+				mockSDAnalyticsRepo.EXPECT().FindFinishedAnswers(ctx, filter).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.PackageItemAnalysis(ctx, &model.SDItemAnalysisInput{ID: pack.ID})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, pack.ID, true).Times(1).Return(pack, nil)
+				mockSDAnalyticsRepo.EXPECT().FindFinishedAnswers(ctx, filter).Times(1).Return([]model.SDTestAnswer{
+					{
+						TestAnswers: []*model.TestAnswer{
+							{GroupID: groupID, Answers: []model.Answer{{QuestionID: questionID, AnswerID: answerID}}},
+						},
+					},
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.PackageItemAnalysis(ctx, &model.SDItemAnalysisInput{ID: pack.ID})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.PackageID, pack.ID)
+				assert.Equal(t, res.DominanceThreshold, model.DefaultSDItemDominanceThreshold)
+				assert.Equal(t, res.FinishedTests, 1)
+				assert.Equal(t, res.SubGroups[0].Items[0].Responses, 1)
+				assert.True(t, res.SubGroups[0].Items[0].Flagged)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/config"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+	"gorm.io/gorm"
+)
+
+type sdaUc struct {
+	sdaRepo       model.SDAssignmentRepository
+	userRepo      model.UserRepository
+	sdpRepo       model.SDPackageRepository
+	sdtRepo       model.SDTemplateRepository
+	sharedCryptor common.SharedCryptor
+	emailUsecase  model.EmailUsecase
+	tx            *gorm.DB
+}
+
+// NewSDAssignmentUsecase create SDAssignmentUsecase
+func NewSDAssignmentUsecase(sdaRepo model.SDAssignmentRepository, userRepo model.UserRepository, sdpRepo model.SDPackageRepository, sdtRepo model.SDTemplateRepository, sharedCryptor common.SharedCryptor, emailUsecase model.EmailUsecase, tx *gorm.DB) model.SDAssignmentUsecase {
+	return &sdaUc{
+		sdaRepo:       sdaRepo,
+		userRepo:      userRepo,
+		sdpRepo:       sdpRepo,
+		sdtRepo:       sdtRepo,
+		sharedCryptor: sharedCryptor,
+		emailUsecase:  emailUsecase,
+		tx:            tx,
+	}
+}
+
+func (uc *sdaUc) Create(ctx context.Context, input *model.CreateSDAssignmentInput) (*model.GeneratedSDAssignment, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdaUc.Create",
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to create sd test assignment: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrSDAssignmentInputInvalid,
+		}
+	}
+
+	assignee, err := uc.userRepo.FindByID(ctx, input.AssigneeID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find assignee")
+		return nil, &common.Error{
+			Message: "failed to find assignee",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "assignee not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	if assignee.IsBlocked() {
+		return nil, &common.Error{
+			Message: "assignee is blocked or inactive",
+			Cause:   errors.New("assignee is blocked or inactive"),
+			Code:    http.StatusPreconditionFailed,
+			Type:    ErrUserIsBlocked,
+		}
+	}
+
+	testName, cerr := uc.findAssignedTestName(ctx, input)
+	if cerr.Type != nil {
+		logger.WithError(cerr.Cause).Error("failed to find the assigned test: ", cerr.Message)
+		return nil, cerr
+	}
+
+	email, err := uc.sharedCryptor.Decrypt(assignee.Email)
+	if err != nil {
+		logger.WithError(err).Error("failed to decrypt assignee email")
+		return nil, &common.Error{
+			Message: "failed to decrypt assignee email",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	now := time.Now().UTC()
+	assignment := &model.SDAssignment{
+		ID:         uuid.New(),
+		AssigneeID: assignee.ID,
+		PackageID:  input.PackageID,
+		TemplateID: input.TemplateID,
+		DueAt:      input.DueAt.UTC(),
+		Note:       input.Note,
+		Status:     model.SDAssignmentStatusAssigned,
+		CreatedBy:  model.GetUserFromCtx(ctx).UserID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	tx := uc.tx.Begin()
+	if err := uc.sdaRepo.Create(ctx, assignment, tx); err != nil {
+		tx.Rollback()
+		return nil, &common.Error{
+			Message: "failed to create sd test assignment",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		logger.WithError(err).Error("failed to commit sd test assignment creation")
+		return nil, &common.Error{
+			Message: "failed to create sd test assignment",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	// the invitation is only registered for the saved assignment. The assignment is still usable from the
+	// assignee list when failing to register the invitation, thus not reported as failure
+	link := fmt.Sprintf("%sid=%s", config.SDAssignmentBaseURL(), assignment.ID)
+	if _, err := uc.emailUsecase.Register(ctx, generateEmailTemplateForSDAssignment(assignee.Username, email, testName, link, assignment)); err != nil {
+		logger.WithError(err).Error("failed to register sd test assignment invitation email")
+	}
+
+	return assignment.ToRESTResponse(), nilErr
+}
+
+func (uc *sdaUc) Search(ctx context.Context, input *model.SearchSDAssignmentInput) ([]*model.GeneratedSDAssignment, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdaUc.Search",
+		"input": helper.Dump(input),
+	})
+
+	requester := model.GetUserFromCtx(ctx)
+	if !requester.IsAdmin() {
+		input.AssigneeID = uuid.NullUUID{UUID: requester.UserID, Valid: true}
+	}
+
+	res, err := uc.sdaRepo.Search(ctx, input)
+	if err != nil {
+		logger.WithError(err).Error("failed to search sd test assignment")
+		return nil, &common.Error{
+			Message: "failed to search sd test assignment",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	resp := []*model.GeneratedSDAssignment{}
+	for _, v := range res {
+		resp = append(resp, v.ToRESTResponse())
+	}
+
+	return resp, nilErr
+}
+
+func (uc *sdaUc) Cancel(ctx context.Context, id uuid.UUID) (*model.GeneratedSDAssignment, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdaUc.Cancel",
+		"input": id.String(),
+	})
+
+	assignment, err := uc.sdaRepo.FindByID(ctx, id)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find sd test assignment")
+		return nil, &common.Error{
+			Message: "failed to find sd test assignment",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd test assignment not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	if !assignment.IsOpen() {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("sd test assignment is already %s", assignment.Status),
+			Cause:   errors.New("sd test assignment is not open"),
+			Code:    http.StatusForbidden,
+			Type:    ErrSDAssignmentNotOpen,
+		}
+	}
+
+	now := time.Now().UTC()
+	assignment.Status = model.SDAssignmentStatusCancelled
+	assignment.CancelledAt.SetValid(now)
+	assignment.UpdatedAt = now
+	if err := uc.sdaRepo.Update(ctx, assignment, nil); err != nil {
+		return nil, &common.Error{
+			Message: "failed to cancel sd test assignment",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	return assignment.ToRESTResponse(), nilErr
+}
+
+// findAssignedTestName ensure the assigned package or template is active, and return its name
+func (uc *sdaUc) findAssignedTestName(ctx context.Context, input *model.CreateSDAssignmentInput) (string, *common.Error) {
+	if input.PackageID.Valid {
+		pack, err := uc.sdpRepo.FindByID(ctx, input.PackageID.UUID, false)
+		switch err {
+		default:
+			return "", &common.Error{
+				Message: "failed to find sd package",
+				Cause:   err,
+				Code:    http.StatusInternalServerError,
+				Type:    ErrInternal,
+			}
+		case repository.ErrNotFound:
+			return "", &common.Error{
+				Message: "sd package not found",
+				Cause:   err,
+				Code:    http.StatusNotFound,
+				Type:    ErrResourceNotFound,
+			}
+		case nil:
+			break
+		}
+
+		if !pack.IsActive {
+			return "", &common.Error{
+				Message: "sd package is not active",
+				Cause:   errors.New("sd package is not active"),
+				Code:    http.StatusBadRequest,
+				Type:    ErrSDPackageAlreadyDeactivated,
+			}
+		}
+
+		return pack.Name, nilErr
+	}
+
+	template, err := uc.sdtRepo.FindByID(ctx, input.TemplateID.UUID, false)
+	switch err {
+	default:
+		return "", &common.Error{
+			Message: "failed to find sd template",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return "", &common.Error{
+			Message: "sd template not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	if !template.IsActive {
+		return "", &common.Error{
+			Message: "sd template is not active",
+			Cause:   errors.New("sd template is not active"),
+			Code:    http.StatusBadRequest,
+			Type:    ErrSDTemplateIsDeactivated,
+		}
+	}
+
+	return template.Name, nilErr
+}
+
+func generateEmailTemplateForSDAssignment(username, email, testName, link string, assignment *model.SDAssignment) *model.RegisterEmailInput {
+	note := ""
+	if assignment.Note != "" {
+		note = fmt.Sprintf("<p>Catatan: %s</p>", html.EscapeString(assignment.Note))
+	}
+
+	return &model.RegisterEmailInput{
+		Subject: "Undangan Tes ATEC",
+		Body: fmt.Sprintf(`
+			<h2>Halo %s!</h2>
+			<p>Anda mendapatkan tugas untuk mengerjakan tes <strong>%s</strong> pada layanan Autism Treatment Evaluation Checklist (ATEC).</p>
+			<p>Silahkan kerjakan tes sebelum %s.</p>
+			%s
+			</p>Untuk memulai tes, silahkan klik: <a href="%s">mulai tes</a>.</p> <br>
+		`, username, html.EscapeString(testName), assignment.DueAt.Format(time.RFC1123), note, link),
+		To: []string{email},
+	}
+}
@@ -0,0 +1,428 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	commonMock "github.com/luckyAkbar/atec-api/internal/common/mock"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/model/mock"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestSDAssignmentUsecase_Create(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	dbmock := kit.DBmock
+	mockSDAssignmentRepo := mock.NewMockSDAssignmentRepository(kit.Ctrl)
+	mockUserRepo := mock.NewMockUserRepository(kit.Ctrl)
+	mockSDPackageRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	mockSDTemplateRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	mockSharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+	mockEmailUsecase := mock.NewMockEmailUsecase(kit.Ctrl)
+
+	uc := NewSDAssignmentUsecase(mockSDAssignmentRepo, mockUserRepo, mockSDPackageRepo, mockSDTemplateRepo, mockSharedCryptor, mockEmailUsecase, kit.DB)
+
+	admin := model.AuthUser{
+		UserID:      uuid.New(),
+		AccessToken: "token",
+		Role:        model.RoleAdmin,
+	}
+	ctx := model.SetUserToCtx(context.Background(), admin)
+
+	assignee := &model.User{
+		ID:       uuid.New(),
+		Email:    "encrypted-email",
+		Username: "assignee",
+		IsActive: true,
+		Role:     model.RoleUser,
+	}
+	pack := &model.SpeechDelayPackage{
+		ID:       uuid.New(),
+		Name:     "package",
+		IsActive: true,
+	}
+	tem := &model.SpeechDelayTemplate{
+		ID:       uuid.New(),
+		Name:     "template",
+		IsActive: true,
+	}
+
+	input := &model.CreateSDAssignmentInput{
+		AssigneeID: assignee.ID,
+		PackageID:  uuid.NullUUID{UUID: pack.ID, Valid: true},
+		DueAt:      time.Now().Add(time.Hour * 24),
+		Note:       "note",
+	}
+	templateInput := &model.CreateSDAssignmentInput{
+		AssigneeID: assignee.ID,
+		TemplateID: uuid.NullUUID{UUID: tem.ID, Valid: true},
+		DueAt:      time.Now().Add(time.Hour * 24),
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid input: both package and template are set",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Create(ctx, &model.CreateSDAssignmentInput{
+					AssigneeID: assignee.ID,
+					PackageID:  uuid.NullUUID{UUID: pack.ID, Valid: true},
+					TemplateID: uuid.NullUUID{UUID: tem.ID, Valid: true},
+					DueAt:      time.Now().Add(time.Hour),
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDAssignmentInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name:   "invalid input: due date already passed",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Create(ctx, &model.CreateSDAssignmentInput{
+					AssigneeID: assignee.ID,
+					PackageID:  uuid.NullUUID{UUID: pack.ID, Valid: true},
+					DueAt:      time.Now().Add(-time.Hour),
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDAssignmentInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "assignee not found",
+			MockFn: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, assignee.ID).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.Create(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "assignee is blocked",
+			MockFn: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, assignee.ID).Times(1).Return(&model.User{
+					ID:        assignee.ID,
+					IsActive:  true,
+					DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true},
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.Create(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrUserIsBlocked)
+				assert.Equal(t, cerr.Code, http.StatusPreconditionFailed)
+			},
+		},
+		{
+			Name: "assigned package is not active",
+			MockFn: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, assignee.ID).Times(1).Return(assignee, nil)
+				mockSDPackageRepo.EXPECT().FindByID(ctx, pack.ID, false).Times(1).Return(&model.SpeechDelayPackage{
+					ID:       pack.ID,
+					IsActive: false,
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.Create(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDPackageAlreadyDeactivated)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "assigned template not found",
+			MockFn: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, assignee.ID).Times(1).Return(assignee, nil)
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.Create(ctx, templateInput)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "failed to decrypt assignee email",
+			MockFn: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, assignee.ID).Times(1).Return(assignee, nil)
+				mockSDPackageRepo.EXPECT().FindByID(ctx, pack.ID, false).Times(1).Return(pack, nil)
+				mockSharedCryptor.EXPECT().Decrypt(assignee.Email).Times(1).Return("", errors.New("err decrypt"))
+			},
+			Run: func() {
+				_, cerr := uc.Create(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "failed to save the assignment",
+			MockFn: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, assignee.ID).Times(1).Return(assignee, nil)
+				mockSDPackageRepo.EXPECT().FindByID(ctx, pack.ID, false).Times(1).Return(pack, nil)
+				mockSharedCryptor.EXPECT().Decrypt(assignee.Email).Times(1).Return("assignee@email.test", nil)
+				dbmock.ExpectBegin()
+				mockSDAssignmentRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
+				dbmock.ExpectRollback()
+			},
+			Run: func() {
+				_, cerr := uc.Create(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "failed to register the invitation email is not failing the creation",
+			MockFn: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, assignee.ID).Times(1).Return(assignee, nil)
+				mockSDPackageRepo.EXPECT().FindByID(ctx, pack.ID, false).Times(1).Return(pack, nil)
+				mockSharedCryptor.EXPECT().Decrypt(assignee.Email).Times(1).Return("assignee@email.test", nil)
+				dbmock.ExpectBegin()
+				mockSDAssignmentRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				dbmock.ExpectCommit()
+				mockEmailUsecase.EXPECT().Register(ctx, gomock.Any()).Times(1).Return(nil, errors.New("err email"))
+			},
+			Run: func() {
+				res, cerr := uc.Create(ctx, input)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.AssigneeID, assignee.ID)
+			},
+		},
+		{
+			Name: "failed to commit the assignment",
+			MockFn: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, assignee.ID).Times(1).Return(assignee, nil)
+				mockSDPackageRepo.EXPECT().FindByID(ctx, pack.ID, false).Times(1).Return(pack, nil)
+				mockSharedCryptor.EXPECT().Decrypt(assignee.Email).Times(1).Return("assignee@email.test", nil)
+				dbmock.ExpectBegin()
+				mockSDAssignmentRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				dbmock.ExpectCommit().WillReturnError(errors.New("err commit"))
+			},
+			Run: func() {
+				_, cerr := uc.Create(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok: package assigned",
+			MockFn: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, assignee.ID).Times(1).Return(assignee, nil)
+				mockSDPackageRepo.EXPECT().FindByID(ctx, pack.ID, false).Times(1).Return(pack, nil)
+				mockSharedCryptor.EXPECT().Decrypt(assignee.Email).Times(1).Return("assignee@email.test", nil)
+				dbmock.ExpectBegin()
+				mockSDAssignmentRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				dbmock.ExpectCommit()
+				mockEmailUsecase.EXPECT().Register(ctx, gomock.Any()).Times(1).Return(&model.Email{}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Create(ctx, input)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.AssigneeID, assignee.ID)
+				assert.Equal(t, res.PackageID, input.PackageID)
+				assert.Equal(t, res.Status, model.SDAssignmentStatusAssigned)
+				assert.Equal(t, res.CreatedBy, admin.UserID)
+				assert.False(t, res.IsOverdue)
+			},
+		},
+		{
+			Name: "ok: template assigned",
+			MockFn: func() {
+				mockUserRepo.EXPECT().FindByID(ctx, assignee.ID).Times(1).Return(assignee, nil)
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(tem, nil)
+				mockSharedCryptor.EXPECT().Decrypt(assignee.Email).Times(1).Return("assignee@email.test", nil)
+				dbmock.ExpectBegin()
+				mockSDAssignmentRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				dbmock.ExpectCommit()
+				mockEmailUsecase.EXPECT().Register(ctx, gomock.Any()).Times(1).Return(&model.Email{}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Create(ctx, templateInput)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.TemplateID, templateInput.TemplateID)
+				assert.False(t, res.PackageID.Valid)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDAssignmentUsecase_Search(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	mockSDAssignmentRepo := mock.NewMockSDAssignmentRepository(kit.Ctrl)
+	uc := NewSDAssignmentUsecase(mockSDAssignmentRepo, nil, nil, nil, nil, nil, kit.DB)
+
+	admin := model.AuthUser{
+		UserID:      uuid.New(),
+		AccessToken: "token",
+		Role:        model.RoleAdmin,
+	}
+	user := model.AuthUser{
+		UserID:      uuid.New(),
+		AccessToken: "token",
+		Role:        model.RoleUser,
+	}
+	adminCtx := model.SetUserToCtx(context.Background(), admin)
+	userCtx := model.SetUserToCtx(context.Background(), user)
+
+	assignment := &model.SDAssignment{
+		ID:         uuid.New(),
+		AssigneeID: user.UserID,
+		Status:     model.SDAssignmentStatusAssigned,
+		DueAt:      time.Now().Add(-time.Hour),
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "db error",
+			MockFn: func() {
+				mockSDAssignmentRepo.EXPECT().Search(adminCtx, gomock.Any()).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Search(adminCtx, &model.SearchSDAssignmentInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "non admin only able to see their own assignments",
+			MockFn: func() {
+				mockSDAssignmentRepo.EXPECT().Search(userCtx, &model.SearchSDAssignmentInput{
+					AssigneeID: uuid.NullUUID{UUID: user.UserID, Valid: true},
+				}).Times(1).Return([]*model.SDAssignment{assignment}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Search(userCtx, &model.SearchSDAssignmentInput{
+					AssigneeID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, len(res), 1)
+				assert.True(t, res[0].IsOverdue)
+			},
+		},
+		{
+			Name: "ok: empty result",
+			MockFn: func() {
+				mockSDAssignmentRepo.EXPECT().Search(adminCtx, gomock.Any()).Times(1).Return(nil, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Search(adminCtx, &model.SearchSDAssignmentInput{})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, len(res), 0)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDAssignmentUsecase_Cancel(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	mockSDAssignmentRepo := mock.NewMockSDAssignmentRepository(kit.Ctrl)
+	uc := NewSDAssignmentUsecase(mockSDAssignmentRepo, nil, nil, nil, nil, nil, kit.DB)
+
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []common.TestStructure{
+		{
+			Name: "not found",
+			MockFn: func() {
+				mockSDAssignmentRepo.EXPECT().FindByID(ctx, id).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.Cancel(ctx, id)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "already completed",
+			MockFn: func() {
+				mockSDAssignmentRepo.EXPECT().FindByID(ctx, id).Times(1).Return(&model.SDAssignment{
+					ID:     id,
+					Status: model.SDAssignmentStatusCompleted,
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.Cancel(ctx, id)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDAssignmentNotOpen)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "db error when updating",
+			MockFn: func() {
+				mockSDAssignmentRepo.EXPECT().FindByID(ctx, id).Times(1).Return(&model.SDAssignment{
+					ID:     id,
+					Status: model.SDAssignmentStatusAssigned,
+				}, nil)
+				mockSDAssignmentRepo.EXPECT().Update(ctx, gomock.Any(), nil).Times(1).Return(errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Cancel(ctx, id)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockSDAssignmentRepo.EXPECT().FindByID(ctx, id).Times(1).Return(&model.SDAssignment{
+					ID:     id,
+					Status: model.SDAssignmentStatusInProgress,
+				}, nil)
+				mockSDAssignmentRepo.EXPECT().Update(ctx, gomock.Any(), nil).Times(1).Return(nil)
+			},
+			Run: func() {
+				res, cerr := uc.Cancel(ctx, id)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.Status, model.SDAssignmentStatusCancelled)
+				assert.True(t, res.CancelledAt.Valid)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+	"gorm.io/gorm"
+)
+
+type sdbUc struct {
+	sdtRepo model.SDTemplateRepository
+	sdpRepo model.SDPackageRepository
+	tx      *gorm.DB
+}
+
+// NewSDBundleUsecase create SDBundleUsecase
+func NewSDBundleUsecase(sdtRepo model.SDTemplateRepository, sdpRepo model.SDPackageRepository, tx *gorm.DB) model.SDBundleUsecase {
+	return &sdbUc{
+		sdtRepo: sdtRepo,
+		sdpRepo: sdpRepo,
+		tx:      tx,
+	}
+}
+
+func (uc *sdbUc) Export(ctx context.Context, input *model.ExportSDBundleInput) (*model.SDBundle, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdbUc.Export",
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to export sd bundle: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrSDBundleInputInvalid,
+		}
+	}
+
+	templates := []*model.SpeechDelayTemplate{}
+	packages := make(map[uuid.UUID][]*model.SpeechDelayPackage)
+	for _, id := range input.TemplateIDs {
+		template, err := uc.sdtRepo.FindByID(ctx, id, false)
+		switch err {
+		default:
+			logger.WithError(err).Error("failed to find sd template")
+			return nil, &common.Error{
+				Message: "failed to find sd template",
+				Cause:   err,
+				Code:    http.StatusInternalServerError,
+				Type:    ErrInternal,
+			}
+		case repository.ErrNotFound:
+			return nil, &common.Error{
+				Message: fmt.Sprintf("sd template %s not found", id),
+				Cause:   err,
+				Code:    http.StatusNotFound,
+				Type:    ErrResourceNotFound,
+			}
+		case nil:
+			break
+		}
+
+		packs, err := uc.findAllPackages(ctx, id)
+		if err != nil {
+			logger.WithError(err).Error("failed to find sd packages of the template")
+			return nil, &common.Error{
+				Message: "failed to find sd packages of the template",
+				Cause:   err,
+				Code:    http.StatusInternalServerError,
+				Type:    ErrInternal,
+			}
+		}
+
+		templates = append(templates, template)
+		packages[id] = packs
+	}
+
+	return model.NewSDBundle(templates, packages), nilErr
+}
+
+func (uc *sdbUc) Import(ctx context.Context, input *model.ImportSDBundleInput) (*model.ImportSDBundleOutput, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":   "sdbUc.Import",
+		"dryRun": input.DryRun,
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to import sd bundle: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrSDBundleInputInvalid,
+		}
+	}
+
+	requester := model.GetUserFromCtx(ctx)
+	templates, packages, mapping := input.Bundle.Instantiate(requester.UserID, time.Now().UTC())
+	if err := validateSDBundle(templates, packages); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid sd bundle: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrSDBundleInputInvalid,
+		}
+	}
+
+	output := &model.ImportSDBundleOutput{
+		DryRun:    input.DryRun,
+		Templates: []*model.GeneratedSDTemplate{},
+		Packages:  []*model.GeneratedSDPackage{},
+		IDMapping: mapping,
+	}
+
+	for _, t := range templates {
+		output.Templates = append(output.Templates, t.ToRESTResponse())
+	}
+
+	for _, p := range packages {
+		output.Packages = append(output.Packages, p.ToRESTResponse())
+	}
+
+	if input.DryRun {
+		return output, nilErr
+	}
+
+	tx := uc.tx.Begin()
+	for _, t := range templates {
+		if err := uc.sdtRepo.Create(ctx, t, tx); err != nil {
+			tx.Rollback()
+			logger.WithError(err).Error("failed to create sd template from the bundle")
+			return nil, &common.Error{
+				Message: "failed to create sd template from the bundle",
+				Cause:   err,
+				Code:    http.StatusInternalServerError,
+				Type:    ErrInternal,
+			}
+		}
+	}
+
+	for _, p := range packages {
+		if err := uc.sdpRepo.Create(ctx, p, tx); err != nil {
+			tx.Rollback()
+			logger.WithError(err).Error("failed to create sd package from the bundle")
+			return nil, &common.Error{
+				Message: "failed to create sd package from the bundle",
+				Cause:   err,
+				Code:    http.StatusInternalServerError,
+				Type:    ErrInternal,
+			}
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		logger.WithError(err).Error("failed to commit the sd bundle import")
+		return nil, &common.Error{
+			Message: "failed to import sd bundle",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	return output, nilErr
+}
+
+// findAllPackages will find all the undeleted packages of the template, page by page
+func (uc *sdbUc) findAllPackages(ctx context.Context, templateID uuid.UUID) ([]*model.SpeechDelayPackage, error) {
+	const pageSize = 100
+
+	packages := []*model.SpeechDelayPackage{}
+	for offset := 0; ; offset += pageSize {
+		res, err := uc.sdpRepo.Search(ctx, &model.SearchSDPackageInput{
+			TemplateID: templateID,
+			Limit:      pageSize,
+			Offset:     offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		packages = append(packages, res...)
+		if len(res) < pageSize {
+			return packages, nil
+		}
+	}
+}
+
+// validateSDBundle will validate the templates and packages created from the bundle using the same rules
+// as creating and activating them one by one. Every template and package must pass the partial validation,
+// while the active ones must also pass the test type validation
+func validateSDBundle(templates []*model.SpeechDelayTemplate, packages []*model.SpeechDelayPackage) error {
+	byID := make(map[uuid.UUID]*model.SpeechDelayTemplate)
+	for i, t := range templates {
+		if t.Template == nil {
+			return fmt.Errorf("template #%d has no content", i+1)
+		}
+
+		if err := t.Template.PartialValidation(); err != nil {
+			return fmt.Errorf("template %s: %w", t.Name, err)
+		}
+
+		testType, err := model.GetTestTypeDefinition(t.Type)
+		if err != nil {
+			return fmt.Errorf("template %s: %w", t.Name, err)
+		}
+
+		if t.IsActive {
+			if err := testType.ValidateTemplate(t.Template); err != nil {
+				return fmt.Errorf("template %s can't be activated: %w", t.Name, err)
+			}
+		}
+
+		byID[t.ID] = t
+	}
+
+	for i, p := range packages {
+		if p.Package == nil {
+			return fmt.Errorf("package #%d has no content", i+1)
+		}
+
+		if err := p.Package.PartialValidation(); err != nil {
+			return fmt.Errorf("package %s: %w", p.Name, err)
+		}
+
+		if !p.IsActive {
+			continue
+		}
+
+		template := byID[p.TemplateID]
+		if !template.IsActive {
+			return fmt.Errorf("package %s can't be activated: %w", p.Name, errors.New("the template is not active"))
+		}
+
+		testType, err := model.GetTestTypeDefinition(template.Type)
+		if err != nil {
+			return fmt.Errorf("package %s: %w", p.Name, err)
+		}
+
+		if err := testType.ValidatePackage(p.Package, template); err != nil {
+			return fmt.Errorf("package %s can't be activated: %w", p.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/model/mock"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSDBundleUsecase_Export(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	ctx := context.Background()
+
+	mockSDTemplateRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	mockSDPackageRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	uc := NewSDBundleUsecase(mockSDTemplateRepo, mockSDPackageRepo, kit.DB)
+
+	templateID := uuid.New()
+	template := &model.SpeechDelayTemplate{
+		ID:       templateID,
+		Name:     "template",
+		IsActive: true,
+		Template: &model.SDTemplate{
+			Name: "template",
+		},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid input",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Export(ctx, &model.ExportSDBundleInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDBundleInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "template not found",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, templateID, false).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.Export(ctx, &model.ExportSDBundleInput{TemplateIDs: []uuid.UUID{templateID}})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "db error when finding the template",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, templateID, false).Times(1).Return(nil, errors.New("db err"))
+			},
+			Run: func() {
+				_, cerr := uc.Export(ctx, &model.ExportSDBundleInput{TemplateIDs: []uuid.UUID{templateID}})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "db error when searching the packages",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, templateID, false).Times(1).Return(template, nil)
+				mockSDPackageRepo.EXPECT().Search(ctx, gomock.Any()).Times(1).Return(nil, errors.New("db err"))
+			},
+			Run: func() {
+				_, cerr := uc.Export(ctx, &model.ExportSDBundleInput{TemplateIDs: []uuid.UUID{templateID}})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok with packages spread on multiple pages",
+			MockFn: func() {
+				firstPage := []*model.SpeechDelayPackage{}
+				for i := 0; i < 100; i++ {
+					firstPage = append(firstPage, &model.SpeechDelayPackage{ID: uuid.New(), TemplateID: templateID, Package: &model.SDPackage{}})
+				}
+
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, templateID, false).Times(1).Return(template, nil)
+				mockSDPackageRepo.EXPECT().Search(ctx, &model.SearchSDPackageInput{TemplateID: templateID, Limit: 100, Offset: 0}).Times(1).Return(firstPage, nil)
+				mockSDPackageRepo.EXPECT().Search(ctx, &model.SearchSDPackageInput{TemplateID: templateID, Limit: 100, Offset: 100}).Times(1).Return([]*model.SpeechDelayPackage{
+					{ID: uuid.New(), TemplateID: templateID, Package: &model.SDPackage{}},
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Export(ctx, &model.ExportSDBundleInput{TemplateIDs: []uuid.UUID{templateID}})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.Version, model.SDBundleVersion)
+				assert.Equal(t, len(res.Templates), 1)
+				assert.Equal(t, res.Templates[0].ID, templateID)
+				assert.True(t, res.Templates[0].IsActive)
+				assert.Equal(t, res.Templates[0].Template.Type, model.TestTypeSpeechDelay)
+				assert.Equal(t, len(res.Templates[0].Packages), 101)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDBundleUsecase_Import(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	mockDB := kit.DBmock
+	ctx := model.SetUserToCtx(context.Background(), model.AuthUser{
+		UserID: uuid.New(),
+		Role:   model.RoleAdmin,
+	})
+
+	mockSDTemplateRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	mockSDPackageRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	uc := NewSDBundleUsecase(mockSDTemplateRepo, mockSDPackageRepo, kit.DB)
+
+	newBundle := func(templateActive, packageActive bool) *model.SDBundle {
+		return &model.SDBundle{
+			Version: model.SDBundleVersion,
+			Templates: []model.SDBundleTemplate{
+				{
+					ID:       uuid.New(),
+					IsActive: templateActive,
+					Template: &model.SDTemplate{
+						Name:                   "template",
+						IndicationThreshold:    1,
+						PositiveIndiationText:  "pos",
+						NegativeIndicationText: "neg",
+						SubGroupDetails: []model.SDTemplateSubGroupDetail{
+							{
+								Name:              "group",
+								QuestionCount:     1,
+								AnswerOptionCount: 2,
+							},
+						},
+					},
+					Packages: []model.SDBundlePackage{
+						{
+							ID:       uuid.New(),
+							IsActive: packageActive,
+							Package: &model.SDPackage{
+								PackageName: "package",
+								TemplateID:  uuid.New(),
+								SubGroupDetails: []model.SDSubGroupDetail{
+									{
+										Name: "group",
+										QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+											{
+												Question: "question",
+												AnswersAndValue: []model.SDAnswerAndValue{
+													{Text: "no", Value: 1},
+													{Text: "yes", Value: 2},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid input",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Import(ctx, &model.ImportSDBundleInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDBundleInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name:   "invalid template content",
+			MockFn: func() {},
+			Run: func() {
+				bundle := newBundle(false, false)
+				bundle.Templates[0].Template.Name = ""
+
+				_, cerr := uc.Import(ctx, &model.ImportSDBundleInput{Bundle: bundle})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDBundleInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name:   "unknown test type",
+			MockFn: func() {},
+			Run: func() {
+				bundle := newBundle(false, false)
+				bundle.Templates[0].Template.Type = model.TestType("unknown")
+
+				_, cerr := uc.Import(ctx, &model.ImportSDBundleInput{Bundle: bundle})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDBundleInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name:   "active template failed the full validation",
+			MockFn: func() {},
+			Run: func() {
+				bundle := newBundle(true, false)
+				bundle.Templates[0].Template.IndicationThreshold = 100
+
+				_, cerr := uc.Import(ctx, &model.ImportSDBundleInput{Bundle: bundle})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDBundleInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name:   "missing package content",
+			MockFn: func() {},
+			Run: func() {
+				bundle := newBundle(false, false)
+				bundle.Templates[0].Packages[0].Package = nil
+
+				_, cerr := uc.Import(ctx, &model.ImportSDBundleInput{Bundle: bundle})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDBundleInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name:   "active package on inactive template",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Import(ctx, &model.ImportSDBundleInput{Bundle: newBundle(false, true)})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDBundleInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name:   "active package failed the full validation",
+			MockFn: func() {},
+			Run: func() {
+				bundle := newBundle(true, true)
+				bundle.Templates[0].Packages[0].Package.SubGroupDetails[0].Name = "other group"
+
+				_, cerr := uc.Import(ctx, &model.ImportSDBundleInput{Bundle: bundle})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDBundleInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name:   "dry run will not save anything",
+			MockFn: func() {},
+			Run: func() {
+				bundle := newBundle(true, true)
+				res, cerr := uc.Import(ctx, &model.ImportSDBundleInput{Bundle: bundle, DryRun: true})
+				assert.NoError(t, cerr.Type)
+				assert.True(t, res.DryRun)
+				assert.Equal(t, len(res.Templates), 1)
+				assert.Equal(t, len(res.Packages), 1)
+				assert.Equal(t, res.IDMapping[bundle.Templates[0].ID], res.Templates[0].ID)
+				assert.Equal(t, res.IDMapping[bundle.Templates[0].Packages[0].ID], res.Packages[0].ID)
+				assert.Equal(t, res.Packages[0].TemplateID, res.Templates[0].ID)
+				assert.True(t, res.Templates[0].IsLocked)
+			},
+		},
+		{
+			Name: "failed to create the template",
+			MockFn: func() {
+				mockDB.ExpectBegin()
+				mockSDTemplateRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(errors.New("db err"))
+				mockDB.ExpectRollback()
+			},
+			Run: func() {
+				_, cerr := uc.Import(ctx, &model.ImportSDBundleInput{Bundle: newBundle(true, true)})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "failed to create the package",
+			MockFn: func() {
+				mockDB.ExpectBegin()
+				mockSDTemplateRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				mockSDPackageRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(errors.New("db err"))
+				mockDB.ExpectRollback()
+			},
+			Run: func() {
+				_, cerr := uc.Import(ctx, &model.ImportSDBundleInput{Bundle: newBundle(true, true)})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockDB.ExpectBegin()
+				mockSDTemplateRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				mockSDPackageRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				mockDB.ExpectCommit()
+			},
+			Run: func() {
+				res, cerr := uc.Import(ctx, &model.ImportSDBundleInput{Bundle: newBundle(true, true)})
+				assert.NoError(t, cerr.Type)
+				assert.False(t, res.DryRun)
+				assert.Equal(t, len(res.Templates), 1)
+				assert.Equal(t, len(res.Packages), 1)
+				assert.True(t, res.Packages[0].IsActive)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
@@ -79,15 +79,19 @@ func (uc *sdpUc) Create(ctx context.Context, input *model.SDPackage) (*model.Gen
 		ID:         uuid.New(),
 		TemplateID: input.TemplateID,
 		Name:       input.PackageName,
+		Type:       template.Type.OrDefault(),
 		CreatedBy:  requester.UserID,
 		Package:    input,
 		IsActive:   false,
 		IsLocked:   false,
 		CreatedAt:  now,
 		UpdatedAt:  now,
+
+		CurrentVersion:  1,
+		TemplateVersion: template.CurrentVersion,
 	}
 
-	if err := uc.sdpRepo.Create(ctx, sdpackage); err != nil {
+	if err := uc.sdpRepo.Create(ctx, sdpackage, nil); err != nil {
 		logger.WithError(err).Error("failed to create sd package")
 		return nil, &common.Error{
 			Message: "failed to create sd package",
@@ -224,15 +228,6 @@ func (uc *sdpUc) Update(ctx context.Context, id uuid.UUID, input *model.SDPackag
 		break
 	}
 
-	if pack.IsLocked {
-		return nil, &common.Error{
-			Message: "speech delay package is already locked",
-			Cause:   errors.New("speech delay package is already locked"),
-			Code:    http.StatusForbidden,
-			Type:    ErrSDPackageAlreadyLocked,
-		}
-	}
-
 	if pack.IsActive {
 		return nil, &common.Error{
 			Message: "to ensure consistency, unable to update active template. Please deactivate it first",
@@ -242,12 +237,21 @@ func (uc *sdpUc) Update(ctx context.Context, id uuid.UUID, input *model.SDPackag
 		}
 	}
 
+	// locked version is already used by tests, thus must be kept as is.
+	// The changes will be saved as a new unlocked version instead
+	if pack.IsLocked {
+		pack.CurrentVersion++
+		pack.IsLocked = false
+	}
+
 	pack.UpdatedAt = time.Now().UTC()
 	pack.Name = input.PackageName
 	pack.TemplateID = input.TemplateID
+	pack.TemplateVersion = template.CurrentVersion
+	pack.Type = template.Type.OrDefault()
 	pack.Package = input
 
-	if err := uc.sdpRepo.Update(ctx, pack, nil); err != nil {
+	if err := uc.sdpRepo.UpdateWithVersion(ctx, pack); err != nil {
 		logger.WithError(err).Error("failed to update speech delay package")
 		return nil, &common.Error{
 			Message: "failed to update speech delay package",
@@ -445,7 +449,26 @@ func (uc *sdpUc) ChangeSDPackageActiveStatus(ctx context.Context, id uuid.UUID,
 		}
 	}
 
-	if err := pack.Package.FullValidation(template); err != nil {
+	testType, err := model.GetTestTypeDefinition(template.Type)
+	if err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("speech delay package can't be activated because: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusForbidden,
+			Type:    ErrSDPackageCantBeActivated,
+		}
+	}
+
+	if pack.Type.OrDefault() != testType.Type() {
+		return nil, &common.Error{
+			Message: "speech delay package can't be activated because the test type is not match with the template",
+			Cause:   errors.New("test type of the package is not match with the template"),
+			Code:    http.StatusForbidden,
+			Type:    ErrSDPackageCantBeActivated,
+		}
+	}
+
+	if err := testType.ValidatePackage(pack.Package, template); err != nil {
 		return nil, &common.Error{
 			Message: fmt.Sprintf("speech delay package can't be activated because: %s", err.Error()),
 			Cause:   err,
@@ -454,7 +477,24 @@ func (uc *sdpUc) ChangeSDPackageActiveStatus(ctx context.Context, id uuid.UUID,
 		}
 	}
 
+	// once a package is validated against the template version, that template version
+	// must be kept as is. Any changes on the template will be saved as a new version
+	if !template.IsLocked {
+		template.IsLocked = true
+		template.UpdatedAt = time.Now().UTC()
+		if err := uc.sdtRepo.Update(ctx, template, nil); err != nil {
+			logger.WithError(err).Error("failed to lock speech delay template")
+			return nil, &common.Error{
+				Message: "failed to lock speech delay template",
+				Cause:   err,
+				Code:    http.StatusInternalServerError,
+				Type:    ErrInternal,
+			}
+		}
+	}
+
 	pack.IsActive = true
+	pack.TemplateVersion = template.CurrentVersion
 	pack.UpdatedAt = time.Now().UTC()
 	if err != uc.sdpRepo.Update(ctx, pack, nil) {
 		logger.WithError(err).Error("failed to update speech delay package")
@@ -525,3 +565,198 @@ func (uc *sdpUc) FindReadyToUse(ctx context.Context, limit, offset int) (*model.
 	resp.Count = len(packages)
 	return resp, nilErr
 }
+
+func (uc *sdpUc) FindVersions(ctx context.Context, id uuid.UUID) (*model.SDPackageVersionsOutput, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func": "sdpUc.FindVersions",
+		"id":   id.String(),
+	})
+
+	pack, err := uc.sdpRepo.FindByID(ctx, id, true)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find speech delay package")
+		return nil, &common.Error{
+			Message: "failed to find speech delay package",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "speech delay package not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	versions, err := uc.sdpRepo.FindVersions(ctx, pack.ID)
+	if err != nil {
+		logger.WithError(err).Error("failed to find speech delay package versions")
+		return nil, &common.Error{
+			Message: "failed to find speech delay package versions",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	resp := &model.SDPackageVersionsOutput{
+		PackageID:      pack.ID,
+		CurrentVersion: pack.CurrentVersion,
+		Versions:       []*model.GeneratedSDPackageVersion{},
+	}
+	for _, v := range versions {
+		resp.Versions = append(resp.Versions, v.ToRESTResponse())
+	}
+
+	return resp, nilErr
+}
+
+func (uc *sdpUc) FindVersion(ctx context.Context, id uuid.UUID, version int) (*model.GeneratedSDPackageVersion, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":    "sdpUc.FindVersion",
+		"id":      id.String(),
+		"version": version,
+	})
+
+	res, err := uc.sdpRepo.FindVersion(ctx, id, version)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find speech delay package version")
+		return nil, &common.Error{
+			Message: "failed to find speech delay package version",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "speech delay package version not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		return res.ToRESTResponse(), nilErr
+	}
+}
+
+func (uc *sdpUc) Clone(ctx context.Context, id uuid.UUID, input *model.CloneSDPackageInput) (*model.CloneSDPackageOutput, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdpUc.Clone",
+		"id":    id.String(),
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: err.Error(),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrSDPackageInputInvalid,
+		}
+	}
+
+	source, err := uc.sdpRepo.FindByID(ctx, id, true)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find sd package")
+		return nil, &common.Error{
+			Message: "failed to find sd package",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd package not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	// only a re-targeted template is required to be active, just like creating a new package.
+	// the original template is allowed to be deactivated or deleted, because the clone is only a draft
+	templateID := source.TemplateID
+	retarget := input.TemplateID.Valid && input.TemplateID.UUID != source.TemplateID
+	if retarget {
+		templateID = input.TemplateID.UUID
+	}
+
+	template, err := uc.sdtRepo.FindByID(ctx, templateID, !retarget)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find sd template by id")
+		return nil, &common.Error{
+			Message: "failed to find sd template by id",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd template not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	if retarget && !template.IsActive {
+		return nil, &common.Error{
+			Message: "sd template is not active",
+			Cause:   errors.New("sd template is not active"),
+			Code:    http.StatusForbidden,
+			Type:    ErrSDTemplateIsDeactivated,
+		}
+	}
+
+	content := *source.Package
+	if input.PackageName != "" {
+		content.PackageName = input.PackageName
+	}
+
+	mismatched := content.RetargetTemplate(template)
+
+	requester := model.GetUserFromCtx(ctx)
+	now := time.Now().UTC()
+	sdpackage := &model.SpeechDelayPackage{
+		ID:         uuid.New(),
+		TemplateID: template.ID,
+		Name:       content.PackageName,
+		Type:       template.Type.OrDefault(),
+		CreatedBy:  requester.UserID,
+		Package:    &content,
+		IsActive:   false,
+		IsLocked:   false,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+
+		CurrentVersion:  1,
+		TemplateVersion: template.CurrentVersion,
+	}
+
+	if err := uc.sdpRepo.Create(ctx, sdpackage, nil); err != nil {
+		logger.WithError(err).Error("failed to create the cloned sd package")
+		return nil, &common.Error{
+			Message: "failed to create the cloned sd package",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	return &model.CloneSDPackageOutput{
+		Package:             sdpackage.ToRESTResponse(),
+		MismatchedSubGroups: mismatched,
+	}, nilErr
+}
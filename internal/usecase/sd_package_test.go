@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -158,7 +159,7 @@ func TestSDPackageUsecase_Create(t *testing.T) {
 			Name: "failed to create sd package",
 			MockFn: func() {
 				sdtRepo.EXPECT().FindByID(ctx, validInput.TemplateID, false).Times(1).Return(activetem, nil)
-				sdpRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(errors.New("err db"))
+				sdpRepo.EXPECT().Create(ctx, gomock.Any(), nil).Times(1).Return(errors.New("err db"))
 			},
 			Run: func() {
 				_, cerr := uc.Create(ctx, validInput)
@@ -170,7 +171,7 @@ func TestSDPackageUsecase_Create(t *testing.T) {
 			Name: "ok",
 			MockFn: func() {
 				sdtRepo.EXPECT().FindByID(ctx, validInput.TemplateID, false).Times(1).Return(activetem, nil)
-				sdpRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(nil)
+				sdpRepo.EXPECT().Create(ctx, gomock.Any(), nil).Times(1).Return(nil)
 			},
 			Run: func() {
 				res, cerr := uc.Create(ctx, validInput)
@@ -405,7 +406,7 @@ func TestSDPackageUsecase_Update(t *testing.T) {
 			MockFn: func() {
 				mockSDTemplateRepo.EXPECT().FindByID(ctx, templateID, false).Times(1).Return(activeTem, nil)
 				mockSDPackageRepo.EXPECT().FindByID(ctx, packageID, false).Times(1).Return(pack, nil)
-				mockSDPackageRepo.EXPECT().Update(ctx, gomock.Any(), nil).Times(1).Return(nil)
+				mockSDPackageRepo.EXPECT().UpdateWithVersion(ctx, gomock.Any()).Times(1).Return(nil)
 			},
 			Run: func() {
 				res, cerr := uc.Update(ctx, packageID, validInput)
@@ -490,16 +491,17 @@ func TestSDPackageUsecase_Update(t *testing.T) {
 			},
 		},
 		{
-			Name: "package already locked",
+			Name: "package already locked, must create a new unlocked version",
 			MockFn: func() {
 				mockSDTemplateRepo.EXPECT().FindByID(ctx, templateID, false).Times(1).Return(activeTem, nil)
-				mockSDPackageRepo.EXPECT().FindByID(ctx, packageID, false).Times(1).Return(&model.SpeechDelayPackage{IsLocked: true}, nil)
+				mockSDPackageRepo.EXPECT().FindByID(ctx, packageID, false).Times(1).Return(&model.SpeechDelayPackage{IsLocked: true, CurrentVersion: 1}, nil)
+				mockSDPackageRepo.EXPECT().UpdateWithVersion(ctx, gomock.Any()).Times(1).Return(nil)
 			},
 			Run: func() {
-				_, cerr := uc.Update(ctx, packageID, validInput)
-				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Type, ErrSDPackageAlreadyLocked)
-				assert.Equal(t, cerr.Code, http.StatusForbidden)
+				res, cerr := uc.Update(ctx, packageID, validInput)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.CurrentVersion, 2)
+				assert.False(t, res.IsLocked)
 			},
 		},
 		{
@@ -520,7 +522,7 @@ func TestSDPackageUsecase_Update(t *testing.T) {
 			MockFn: func() {
 				mockSDTemplateRepo.EXPECT().FindByID(ctx, templateID, false).Times(1).Return(activeTem, nil)
 				mockSDPackageRepo.EXPECT().FindByID(ctx, packageID, false).Times(1).Return(pack, nil)
-				mockSDPackageRepo.EXPECT().Update(ctx, gomock.Any(), nil).Times(1).Return(errors.New("err db"))
+				mockSDPackageRepo.EXPECT().UpdateWithVersion(ctx, gomock.Any()).Times(1).Return(errors.New("err db"))
 			},
 			Run: func() {
 				_, cerr := uc.Update(ctx, packageID, validInput)
@@ -945,6 +947,28 @@ func TestSDPackageUsecase_ChangeSDPackageActiveStatus(t *testing.T) {
 				assert.Equal(t, cerr.Code, http.StatusForbidden)
 			},
 		},
+		{
+			Name: "package test type is not match with the template",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, id, false).Times(1).Return(&model.SpeechDelayPackage{
+					ID:         id,
+					TemplateID: templateID,
+					IsActive:   false,
+					Type:       model.TestTypeSpeechDelay,
+					Package:    &model.SDPackage{},
+				}, nil)
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, templateID, false).Times(1).Return(&model.SpeechDelayTemplate{
+					IsActive: true,
+					Type:     model.TestTypeSociability,
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.ChangeSDPackageActiveStatus(ctx, id, true)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDPackageCantBeActivated)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
 		{
 			// no need to cover all the edge cases here. The testing on FullValidation are on the model package
 			Name: "fail on package full validation",
@@ -1009,7 +1033,8 @@ func TestSDPackageUsecase_ChangeSDPackageActiveStatus(t *testing.T) {
 				}
 
 				validTemplate := &model.SpeechDelayTemplate{
-					IsActive: true,
+					IsActive:       true,
+					CurrentVersion: 2,
 					Template: &model.SDTemplate{
 						Name:                   "ok",
 						IndicationThreshold:    2,
@@ -1027,6 +1052,7 @@ func TestSDPackageUsecase_ChangeSDPackageActiveStatus(t *testing.T) {
 
 				mockSDPackageRepo.EXPECT().FindByID(ctx, id, false).Times(1).Return(validPackage, nil)
 				mockSDTemplateRepo.EXPECT().FindByID(ctx, templateID, false).Times(1).Return(validTemplate, nil)
+				mockSDTemplateRepo.EXPECT().Update(ctx, gomock.Any(), nil).Times(1).Return(nil)
 				mockSDPackageRepo.EXPECT().Update(ctx, gomock.Any(), nil).Times(1).Return(errors.New("err db"))
 				_, cerr := uc.ChangeSDPackageActiveStatus(ctx, id, true)
 				assert.Error(t, cerr.Type)
@@ -1077,7 +1103,8 @@ func TestSDPackageUsecase_ChangeSDPackageActiveStatus(t *testing.T) {
 				}
 
 				validTemplate := &model.SpeechDelayTemplate{
-					IsActive: true,
+					IsActive:       true,
+					CurrentVersion: 2,
 					Template: &model.SDTemplate{
 						Name:                   "ok",
 						IndicationThreshold:    2,
@@ -1095,11 +1122,14 @@ func TestSDPackageUsecase_ChangeSDPackageActiveStatus(t *testing.T) {
 
 				mockSDPackageRepo.EXPECT().FindByID(ctx, id, false).Times(1).Return(validPackage, nil)
 				mockSDTemplateRepo.EXPECT().FindByID(ctx, templateID, false).Times(1).Return(validTemplate, nil)
+				mockSDTemplateRepo.EXPECT().Update(ctx, gomock.Any(), nil).Times(1).Return(nil)
 				mockSDPackageRepo.EXPECT().Update(ctx, gomock.Any(), nil).Times(1).Return(nil)
 
 				res, cerr := uc.ChangeSDPackageActiveStatus(ctx, id, true)
 				assert.NoError(t, cerr.Type)
 				assert.Equal(t, res.ID, validPackage.ID)
+				assert.Equal(t, res.TemplateVersion, validTemplate.CurrentVersion)
+				assert.True(t, validTemplate.IsLocked)
 			},
 		},
 	}
@@ -1215,3 +1245,354 @@ func TestSDPackageUsecase_FindReadyToUse(t *testing.T) {
 		})
 	}
 }
+
+func TestSDPackageUsecase_FindVersions(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	ctx := context.Background()
+
+	mockSDPackageRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	mockSDTemplateRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	uc := NewSDPackageUsecase(mockSDPackageRepo, mockSDTemplateRepo)
+
+	id := uuid.New()
+	pack := &model.SpeechDelayPackage{
+		ID:             id,
+		CurrentVersion: 2,
+		Package:        &model.SDPackage{},
+	}
+	versions := []*model.SDPackageVersion{
+		{PackageID: id, Version: 2, Package: &model.SDPackage{}},
+		{PackageID: id, Version: 1, Package: &model.SDPackage{}},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(pack, nil)
+				mockSDPackageRepo.EXPECT().FindVersions(ctx, id).Times(1).Return(versions, nil)
+			},
+			Run: func() {
+				res, cerr := uc.FindVersions(ctx, id)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.CurrentVersion, 2)
+				assert.Equal(t, len(res.Versions), 2)
+				assert.Equal(t, res.Versions[0].Version, 2)
+			},
+		},
+		{
+			Name: "package not found",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.FindVersions(ctx, id)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "db err when finding versions",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(pack, nil)
+				mockSDPackageRepo.EXPECT().FindVersions(ctx, id).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.FindVersions(ctx, id)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDPackageUsecase_FindVersion(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	ctx := context.Background()
+
+	mockSDPackageRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	mockSDTemplateRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	uc := NewSDPackageUsecase(mockSDPackageRepo, mockSDTemplateRepo)
+
+	id := uuid.New()
+	version := &model.SDPackageVersion{PackageID: id, Version: 1, Package: &model.SDPackage{}}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindVersion(ctx, id, 1).Times(1).Return(version, nil)
+			},
+			Run: func() {
+				res, cerr := uc.FindVersion(ctx, id, 1)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.PackageID, id)
+				assert.Equal(t, res.Version, 1)
+			},
+		},
+		{
+			Name: "not found",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindVersion(ctx, id, 1).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.FindVersion(ctx, id, 1)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "db err",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindVersion(ctx, id, 1).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.FindVersion(ctx, id, 1)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDPackageUsecase_Clone(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	requester := model.AuthUser{UserID: uuid.New()}
+	ctx := model.SetUserToCtx(context.Background(), requester)
+
+	mockSDPackageRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	mockSDTemplateRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	uc := NewSDPackageUsecase(mockSDPackageRepo, mockSDTemplateRepo)
+
+	id := uuid.New()
+	templateID := uuid.New()
+	otherTemplateID := uuid.New()
+
+	source := &model.SpeechDelayPackage{
+		ID:              id,
+		TemplateID:      templateID,
+		Name:            "source",
+		IsActive:        true,
+		IsLocked:        true,
+		CurrentVersion:  4,
+		TemplateVersion: 2,
+		DeletedAt:       gorm.DeletedAt{Time: time.Now(), Valid: true},
+		Package: &model.SDPackage{
+			PackageName: "source",
+			TemplateID:  templateID,
+			SubGroupDetails: []model.SDSubGroupDetail{
+				{Name: "group 1"},
+				{Name: "group 2"},
+			},
+		},
+	}
+
+	template := &model.SpeechDelayTemplate{
+		ID:             templateID,
+		IsActive:       false,
+		CurrentVersion: 2,
+		Template: &model.SDTemplate{
+			SubGroupDetails: []model.SDTemplateSubGroupDetail{
+				{Name: "group 1"},
+				{Name: "group 2"},
+			},
+		},
+	}
+
+	otherTemplate := &model.SpeechDelayTemplate{
+		ID:             otherTemplateID,
+		Type:           model.TestTypeSociability,
+		IsActive:       true,
+		CurrentVersion: 5,
+		Template: &model.SDTemplate{
+			SubGroupDetails: []model.SDTemplateSubGroupDetail{
+				{Name: "group 1"},
+				{Name: "group 3"},
+			},
+		},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "blank package name",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Clone(ctx, id, &model.CloneSDPackageInput{PackageName: "   "})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDPackageInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name:   "package name too long",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Clone(ctx, id, &model.CloneSDPackageInput{PackageName: strings.Repeat("a", 256)})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDPackageInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "source package not found",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.Clone(ctx, id, &model.CloneSDPackageInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "db err when finding source package",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Clone(ctx, id, &model.CloneSDPackageInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "re-targeted template not found",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(source, nil)
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, otherTemplateID, false).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.Clone(ctx, id, &model.CloneSDPackageInput{
+					TemplateID: uuid.NullUUID{UUID: otherTemplateID, Valid: true},
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "db err when finding the template",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(source, nil)
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, templateID, true).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Clone(ctx, id, &model.CloneSDPackageInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "re-targeted template is not active",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(source, nil)
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, otherTemplateID, false).Times(1).Return(&model.SpeechDelayTemplate{
+					ID:       otherTemplateID,
+					IsActive: false,
+					Template: &model.SDTemplate{},
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.Clone(ctx, id, &model.CloneSDPackageInput{
+					TemplateID: uuid.NullUUID{UUID: otherTemplateID, Valid: true},
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDTemplateIsDeactivated)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "db err when creating the clone",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(source, nil)
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, templateID, true).Times(1).Return(template, nil)
+				mockSDPackageRepo.EXPECT().Create(ctx, gomock.Any(), nil).Times(1).Return(errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Clone(ctx, id, &model.CloneSDPackageInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok, cloned on the original template",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(source, nil)
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, templateID, true).Times(1).Return(template, nil)
+				mockSDPackageRepo.EXPECT().Create(ctx, gomock.Any(), nil).Times(1).Return(nil)
+			},
+			Run: func() {
+				res, cerr := uc.Clone(ctx, id, &model.CloneSDPackageInput{})
+				assert.NoError(t, cerr.Type)
+				assert.NotEqual(t, res.Package.ID, id)
+				assert.Equal(t, res.Package.TemplateID, templateID)
+				assert.Equal(t, res.Package.Name, "source")
+				assert.Equal(t, res.Package.CreatedBy, requester.UserID)
+				assert.Equal(t, res.Package.CurrentVersion, 1)
+				assert.Equal(t, res.Package.TemplateVersion, 2)
+				assert.False(t, res.Package.IsActive)
+				assert.False(t, res.Package.IsLocked)
+				assert.False(t, res.Package.DeletedAt.Valid)
+				assert.Empty(t, res.MismatchedSubGroups)
+			},
+		},
+		{
+			Name: "ok, re-targeted to another template and report the mismatched sub groups",
+			MockFn: func() {
+				mockSDPackageRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(source, nil)
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, otherTemplateID, false).Times(1).Return(otherTemplate, nil)
+				mockSDPackageRepo.EXPECT().Create(ctx, gomock.Any(), nil).Times(1).Return(nil)
+			},
+			Run: func() {
+				res, cerr := uc.Clone(ctx, id, &model.CloneSDPackageInput{
+					TemplateID:  uuid.NullUUID{UUID: otherTemplateID, Valid: true},
+					PackageName: "cloned",
+				})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.Package.TemplateID, otherTemplateID)
+				assert.Equal(t, res.Package.Package.TemplateID, otherTemplateID)
+				assert.Equal(t, res.Package.Name, "cloned")
+				assert.Equal(t, res.Package.Type, model.TestTypeSociability)
+				assert.Equal(t, res.Package.TemplateVersion, 5)
+				assert.Equal(t, res.MismatchedSubGroups, []string{"group 2"})
+				assert.Equal(t, source.Package.TemplateID, templateID)
+				assert.Equal(t, source.Package.PackageName, "source")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
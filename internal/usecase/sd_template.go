@@ -39,20 +39,34 @@ func (uc *sdtUc) Create(ctx context.Context, input *model.SDTemplate) (*model.Ge
 		}
 	}
 
+	testType, err := model.GetTestTypeDefinition(input.Type)
+	if err != nil {
+		return nil, &common.Error{
+			Message: err.Error(),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrSDTemplateInputInvalid,
+		}
+	}
+
+	input.Type = testType.Type()
 	requester := model.GetUserFromCtx(ctx)
 	now := time.Now().UTC()
 	template := &model.SpeechDelayTemplate{
 		ID:        uuid.New(),
 		CreatedBy: requester.UserID,
 		Name:      input.Name,
+		Type:      testType.Type(),
 		IsActive:  false,
 		IsLocked:  false,
 		CreatedAt: now,
 		UpdatedAt: now,
 		Template:  input,
+
+		CurrentVersion: 1,
 	}
 
-	if err := uc.sdtRepo.Create(ctx, template); err != nil {
+	if err := uc.sdtRepo.Create(ctx, template, nil); err != nil {
 		logger.WithError(err).Error("failed to create template")
 		return nil, &common.Error{
 			Message: "failed to create template",
@@ -157,12 +171,16 @@ func (uc *sdtUc) Update(ctx context.Context, id uuid.UUID, input *model.SDTempla
 		break
 	}
 
-	if template.IsLocked {
+	if input.Type == "" {
+		input.Type = template.Type.OrDefault()
+	}
+
+	if input.Type != template.Type.OrDefault() {
 		return nil, &common.Error{
-			Message: "speech delay template is locked",
-			Cause:   nil,
-			Code:    http.StatusForbidden,
-			Type:    ErrSDTemplateAlreadyLocked,
+			Message: "test type of a template can't be changed",
+			Cause:   errors.New("test type of a template can't be changed"),
+			Code:    http.StatusBadRequest,
+			Type:    ErrSDTemplateInputInvalid,
 		}
 	}
 
@@ -175,11 +193,18 @@ func (uc *sdtUc) Update(ctx context.Context, id uuid.UUID, input *model.SDTempla
 		}
 	}
 
+	// locked version may already be used by packages and tests, thus must be kept as is.
+	// The changes will be saved as a new unlocked version instead
+	if template.IsLocked {
+		template.CurrentVersion++
+		template.IsLocked = false
+	}
+
 	template.UpdatedAt = time.Now().UTC()
 	template.Name = input.Name
 	template.Template = input
 
-	if err := uc.sdtRepo.Update(ctx, template, nil); err != nil {
+	if err := uc.sdtRepo.UpdateWithVersion(ctx, template); err != nil {
 		logger.WithError(err).Error("failed to update speech delay template")
 		return nil, &common.Error{
 			Message: "failed to update speech delay template",
@@ -346,7 +371,17 @@ func (uc *sdtUc) ChangeSDTemplateActiveStatus(ctx context.Context, id uuid.UUID,
 		return template.ToRESTResponse(), nilErr
 	}
 
-	if err := template.Template.FullValidation(); err != nil {
+	testType, err := model.GetTestTypeDefinition(template.Type)
+	if err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("speech delay template can't be activated because: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusForbidden,
+			Type:    ErrSDTemplateCantBeActivated,
+		}
+	}
+
+	if err := testType.ValidateTemplate(template.Template); err != nil {
 		return nil, &common.Error{
 			Message: fmt.Sprintf("speech delay template can't be activated because: %s", err.Error()),
 			Cause:   err,
@@ -369,3 +404,153 @@ func (uc *sdtUc) ChangeSDTemplateActiveStatus(ctx context.Context, id uuid.UUID,
 
 	return template.ToRESTResponse(), nilErr
 }
+
+func (uc *sdtUc) FindVersions(ctx context.Context, id uuid.UUID) (*model.SDTemplateVersionsOutput, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func": "sdtUc.FindVersions",
+		"id":   id.String(),
+	})
+
+	template, err := uc.sdtRepo.FindByID(ctx, id, true)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find speech delay template")
+		return nil, &common.Error{
+			Message: "failed to find speech delay template",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "speech delay template not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	versions, err := uc.sdtRepo.FindVersions(ctx, template.ID)
+	if err != nil {
+		logger.WithError(err).Error("failed to find speech delay template versions")
+		return nil, &common.Error{
+			Message: "failed to find speech delay template versions",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	resp := &model.SDTemplateVersionsOutput{
+		TemplateID:     template.ID,
+		CurrentVersion: template.CurrentVersion,
+		Versions:       []*model.GeneratedSDTemplateVersion{},
+	}
+	for _, v := range versions {
+		resp.Versions = append(resp.Versions, v.ToRESTResponse())
+	}
+
+	return resp, nilErr
+}
+
+func (uc *sdtUc) FindVersion(ctx context.Context, id uuid.UUID, version int) (*model.GeneratedSDTemplateVersion, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":    "sdtUc.FindVersion",
+		"id":      id.String(),
+		"version": version,
+	})
+
+	res, err := uc.sdtRepo.FindVersion(ctx, id, version)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find speech delay template version")
+		return nil, &common.Error{
+			Message: "failed to find speech delay template version",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "speech delay template version not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		return res.ToRESTResponse(), nilErr
+	}
+}
+
+func (uc *sdtUc) Clone(ctx context.Context, id uuid.UUID, input *model.CloneSDTemplateInput) (*model.GeneratedSDTemplate, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdtUc.Clone",
+		"id":    id.String(),
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: err.Error(),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrSDTemplateInputInvalid,
+		}
+	}
+
+	source, err := uc.sdtRepo.FindByID(ctx, id, true)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find speech delay template")
+		return nil, &common.Error{
+			Message: "failed to find speech delay template",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "speech delay template not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	content := *source.Template
+	if input.Name != "" {
+		content.Name = input.Name
+	}
+
+	requester := model.GetUserFromCtx(ctx)
+	now := time.Now().UTC()
+	template := &model.SpeechDelayTemplate{
+		ID:        uuid.New(),
+		CreatedBy: requester.UserID,
+		Name:      content.Name,
+		Type:      source.Type.OrDefault(),
+		IsActive:  false,
+		IsLocked:  false,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Template:  &content,
+
+		CurrentVersion: 1,
+	}
+
+	if err := uc.sdtRepo.Create(ctx, template, nil); err != nil {
+		logger.WithError(err).Error("failed to create the cloned template")
+		return nil, &common.Error{
+			Message: "failed to create the cloned template",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	return template.ToRESTResponse(), nilErr
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -59,10 +60,33 @@ func TestSDTemplateUsecase_Create(t *testing.T) {
 				assert.Equal(t, cerr.Code, http.StatusBadRequest)
 			},
 		},
+		{
+			Name:   "unknown test type",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Create(ctx, &model.SDTemplate{
+					Type:                   model.TestType("unknown"),
+					Name:                   "name",
+					IndicationThreshold:    10,
+					PositiveIndiationText:  "pos",
+					NegativeIndicationText: "neg",
+					SubGroupDetails: []model.SDTemplateSubGroupDetail{
+						{
+							Name:              "ok",
+							QuestionCount:     99,
+							AnswerOptionCount: 12,
+						},
+					},
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDTemplateInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
 		{
 			Name: "db err when insert data",
 			MockFn: func() {
-				mockSDTemplateRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(errors.New("err db"))
+				mockSDTemplateRepo.EXPECT().Create(ctx, gomock.Any(), nil).Times(1).Return(errors.New("err db"))
 			},
 			Run: func() {
 				_, cerr := uc.Create(ctx, input)
@@ -74,12 +98,13 @@ func TestSDTemplateUsecase_Create(t *testing.T) {
 		{
 			Name: "ok",
 			MockFn: func() {
-				mockSDTemplateRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(nil)
+				mockSDTemplateRepo.EXPECT().Create(ctx, gomock.Any(), nil).Times(1).Return(nil)
 			},
 			Run: func() {
 				res, cerr := uc.Create(ctx, input)
 				assert.NoError(t, cerr.Type)
 				assert.Equal(t, res.Template.SubGroupDetails, input.SubGroupDetails)
+				assert.Equal(t, res.Type, model.TestTypeSpeechDelay)
 			},
 		},
 	}
@@ -292,7 +317,7 @@ func TestSDTemplateUsecase_Update(t *testing.T) {
 			Name: "ok",
 			MockFn: func() {
 				mockSDTemplateRepo.EXPECT().FindByID(ctx, id, false).Times(1).Return(tem, nil)
-				mockSDTemplateRepo.EXPECT().Update(ctx, tem, nil).Times(1).Return(nil)
+				mockSDTemplateRepo.EXPECT().UpdateWithVersion(ctx, tem).Times(1).Return(nil)
 			},
 			Run: func() {
 				res, cerr := uc.Update(ctx, id, input)
@@ -325,24 +350,61 @@ func TestSDTemplateUsecase_Update(t *testing.T) {
 			},
 		},
 		{
-			Name: "template is locked",
+			Name: "changing the test type is not allowed",
 			MockFn: func() {
 				mockSDTemplateRepo.EXPECT().FindByID(ctx, id, false).Times(1).Return(&model.SpeechDelayTemplate{
-					ID:        uuid.New(),
-					CreatedBy: uuid.New(),
-					Name:      "name",
-					IsActive:  false,
-					IsLocked:  true,
-					CreatedAt: now,
-					UpdatedAt: now,
-					Template:  input,
+					ID:       id,
+					Name:     "name",
+					Type:     model.TestTypeSpeechDelay,
+					Template: input,
 				}, nil)
 			},
 			Run: func() {
-				_, cerr := uc.Update(ctx, id, input)
+				_, cerr := uc.Update(ctx, id, &model.SDTemplate{
+					Type:                   model.TestTypeSociability,
+					Name:                   "name",
+					IndicationThreshold:    10,
+					PositiveIndiationText:  "pos",
+					NegativeIndicationText: "neg",
+					SubGroupDetails: []model.SDTemplateSubGroupDetail{
+						{
+							Name:              "ok",
+							QuestionCount:     99,
+							AnswerOptionCount: 12,
+						},
+					},
+				})
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Type, ErrSDTemplateAlreadyLocked)
-				assert.Equal(t, cerr.Code, http.StatusForbidden)
+				assert.Equal(t, cerr.Type, ErrSDTemplateInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "template is locked, must create a new unlocked version",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, id, false).Times(1).Return(&model.SpeechDelayTemplate{
+					ID:             uuid.New(),
+					CreatedBy:      uuid.New(),
+					Name:           "name",
+					IsActive:       false,
+					IsLocked:       true,
+					CreatedAt:      now,
+					UpdatedAt:      now,
+					Template:       input,
+					CurrentVersion: 2,
+				}, nil)
+				mockSDTemplateRepo.EXPECT().UpdateWithVersion(ctx, gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, template *model.SpeechDelayTemplate) error {
+						assert.Equal(t, template.CurrentVersion, 3)
+						assert.False(t, template.IsLocked)
+						return nil
+					})
+			},
+			Run: func() {
+				res, cerr := uc.Update(ctx, id, input)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.CurrentVersion, 3)
+				assert.False(t, res.IsLocked)
 			},
 		},
 		{
@@ -370,7 +432,7 @@ func TestSDTemplateUsecase_Update(t *testing.T) {
 			Name: "failed to update",
 			MockFn: func() {
 				mockSDTemplateRepo.EXPECT().FindByID(ctx, id, false).Times(1).Return(tem, nil)
-				mockSDTemplateRepo.EXPECT().Update(ctx, tem, nil).Times(1).Return(errors.New("err db"))
+				mockSDTemplateRepo.EXPECT().UpdateWithVersion(ctx, tem).Times(1).Return(errors.New("err db"))
 			},
 			Run: func() {
 				_, cerr := uc.Update(ctx, id, input)
@@ -824,3 +886,270 @@ func TestSDTemplateUsecase_ChangeSDTemplateActiveStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestSDTemplateUsecase_FindVersions(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	ctx := context.Background()
+
+	mockSDTemplateRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	uc := NewSDTemplateUsecase(mockSDTemplateRepo)
+
+	id := uuid.New()
+	tem := &model.SpeechDelayTemplate{
+		ID:             id,
+		CurrentVersion: 2,
+		Template:       &model.SDTemplate{},
+	}
+	versions := []*model.SDTemplateVersion{
+		{TemplateID: id, Version: 2, Template: &model.SDTemplate{}},
+		{TemplateID: id, Version: 1, Template: &model.SDTemplate{}},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(tem, nil)
+				mockSDTemplateRepo.EXPECT().FindVersions(ctx, id).Times(1).Return(versions, nil)
+			},
+			Run: func() {
+				res, cerr := uc.FindVersions(ctx, id)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.CurrentVersion, 2)
+				assert.Equal(t, len(res.Versions), 2)
+				assert.Equal(t, res.Versions[0].Version, 2)
+			},
+		},
+		{
+			Name: "template not found",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.FindVersions(ctx, id)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "db err when finding versions",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(tem, nil)
+				mockSDTemplateRepo.EXPECT().FindVersions(ctx, id).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.FindVersions(ctx, id)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTemplateUsecase_FindVersion(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	ctx := context.Background()
+
+	mockSDTemplateRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	uc := NewSDTemplateUsecase(mockSDTemplateRepo)
+
+	id := uuid.New()
+	version := &model.SDTemplateVersion{TemplateID: id, Version: 1, Template: &model.SDTemplate{}}
+
+	tests := []common.TestStructure{
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindVersion(ctx, id, 1).Times(1).Return(version, nil)
+			},
+			Run: func() {
+				res, cerr := uc.FindVersion(ctx, id, 1)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.TemplateID, id)
+				assert.Equal(t, res.Version, 1)
+			},
+		},
+		{
+			Name: "not found",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindVersion(ctx, id, 1).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.FindVersion(ctx, id, 1)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "db err",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindVersion(ctx, id, 1).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.FindVersion(ctx, id, 1)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTemplateUsecase_Clone(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	requester := model.AuthUser{UserID: uuid.New()}
+	ctx := model.SetUserToCtx(context.Background(), requester)
+
+	mockSDTemplateRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	uc := NewSDTemplateUsecase(mockSDTemplateRepo)
+
+	id := uuid.New()
+	source := &model.SpeechDelayTemplate{
+		ID:             id,
+		CreatedBy:      uuid.New(),
+		Name:           "source",
+		Type:           model.TestTypeSociability,
+		IsActive:       true,
+		IsLocked:       true,
+		CurrentVersion: 3,
+		DeletedAt:      gorm.DeletedAt{Time: time.Now(), Valid: true},
+		Template: &model.SDTemplate{
+			Name:                   "source",
+			Type:                   model.TestTypeSociability,
+			IndicationThreshold:    1,
+			PositiveIndiationText:  "positive",
+			NegativeIndicationText: "negative",
+			SubGroupDetails: []model.SDTemplateSubGroupDetail{
+				{
+					Name:              "name",
+					QuestionCount:     1,
+					AnswerOptionCount: 1,
+				},
+			},
+		},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "blank name",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Clone(ctx, id, &model.CloneSDTemplateInput{Name: "   "})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDTemplateInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name:   "name too long",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Clone(ctx, id, &model.CloneSDTemplateInput{Name: strings.Repeat("a", 256)})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDTemplateInputInvalid)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "source template not found",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.Clone(ctx, id, &model.CloneSDTemplateInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "db err when finding source template",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Clone(ctx, id, &model.CloneSDTemplateInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "db err when creating the clone",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(source, nil)
+				mockSDTemplateRepo.EXPECT().Create(ctx, gomock.Any(), nil).Times(1).Return(errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Clone(ctx, id, &model.CloneSDTemplateInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok, deleted and locked template cloned as inactive and unlocked draft",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(source, nil)
+				mockSDTemplateRepo.EXPECT().Create(ctx, gomock.Any(), nil).Times(1).Return(nil)
+			},
+			Run: func() {
+				res, cerr := uc.Clone(ctx, id, &model.CloneSDTemplateInput{})
+				assert.NoError(t, cerr.Type)
+				assert.NotEqual(t, res.ID, id)
+				assert.Equal(t, res.Name, "source")
+				assert.Equal(t, res.Type, model.TestTypeSociability)
+				assert.Equal(t, res.CreatedBy, requester.UserID)
+				assert.Equal(t, res.CurrentVersion, 1)
+				assert.False(t, res.IsActive)
+				assert.False(t, res.IsLocked)
+				assert.False(t, res.DeletedAt.Valid)
+				assert.Equal(t, res.Template.SubGroupDetails, source.Template.SubGroupDetails)
+			},
+		},
+		{
+			Name: "ok, using the new name",
+			MockFn: func() {
+				mockSDTemplateRepo.EXPECT().FindByID(ctx, id, true).Times(1).Return(source, nil)
+				mockSDTemplateRepo.EXPECT().Create(ctx, gomock.Any(), nil).Times(1).Return(nil)
+			},
+			Run: func() {
+				res, cerr := uc.Clone(ctx, id, &model.CloneSDTemplateInput{Name: "cloned"})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.Name, "cloned")
+				assert.Equal(t, res.Template.Name, "cloned")
+				assert.Equal(t, source.Template.Name, "source")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
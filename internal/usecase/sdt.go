@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"net/http"
 	"time"
 
 	"github.com/golang/freetype/truetype"
 	"github.com/google/uuid"
 	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/config"
 	"github.com/luckyAkbar/atec-api/internal/model"
 	"github.com/luckyAkbar/atec-api/internal/repository"
 	"github.com/sirupsen/logrus"
@@ -21,23 +23,43 @@ import (
 type sdtrUc struct {
 	sdtrRepo      model.SDTestRepository
 	sdpRepo       model.SDPackageRepository
+	sdtRepo       model.SDTemplateRepository
+	sdaRepo       model.SDAssignmentRepository
+	cpRepo        model.ChildProfileRepository
+	sdrsRepo      model.SDResultShareRepository
+	userRepo      model.UserRepository
 	sharedCryptor common.SharedCryptor
+	signer        common.Signer
+	emailUsecase  model.EmailUsecase
+	workerClient  model.WorkerClient
 	tx            *gorm.DB
 	font          *truetype.Font
+
+	packageSelectors map[model.PackageSelectionStrategy]model.PackageSelector
 }
 
 // NewSDTestResultUsecase create new sd test usecase. satisfy model.SDTestUsecase
-func NewSDTestResultUsecase(sdtrRepo model.SDTestRepository, sdpRepo model.SDPackageRepository, sharedCryptor common.SharedCryptor, tx *gorm.DB, f *truetype.Font) model.SDTestUsecase {
+func NewSDTestResultUsecase(sdtrRepo model.SDTestRepository, sdpRepo model.SDPackageRepository, sdtRepo model.SDTemplateRepository, sdaRepo model.SDAssignmentRepository, cpRepo model.ChildProfileRepository, sdrsRepo model.SDResultShareRepository, userRepo model.UserRepository, sharedCryptor common.SharedCryptor, signer common.Signer, emailUsecase model.EmailUsecase, workerClient model.WorkerClient, tx *gorm.DB, f *truetype.Font) model.SDTestUsecase {
 	return &sdtrUc{
 		sdtrRepo:      sdtrRepo,
 		sdpRepo:       sdpRepo,
+		sdtRepo:       sdtRepo,
+		sdaRepo:       sdaRepo,
+		cpRepo:        cpRepo,
+		sdrsRepo:      sdrsRepo,
+		userRepo:      userRepo,
 		sharedCryptor: sharedCryptor,
+		signer:        signer,
+		emailUsecase:  emailUsecase,
+		workerClient:  workerClient,
 		tx:            tx,
 		font:          f,
+
+		packageSelectors: newPackageSelectors(sdtrRepo, rand.Intn),
 	}
 }
 
-func (uc *sdtrUc) Initiate(ctx context.Context, input *model.InitiateSDTestInput) (*model.InitiateSDTestOutput, *common.Error) {
+func (uc *sdtrUc) Initiate(ctx context.Context, input *model.InitiateSDTestInput) (*model.InitiateSDTestOutput, *model.SDRetakeNotAllowedOutput, *common.Error) {
 	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":  "sdtrUc.Initiate",
 		"input": helper.Dump(input),
@@ -47,15 +69,42 @@ func (uc *sdtrUc) Initiate(ctx context.Context, input *model.InitiateSDTestInput
 		input.DurationMinutes = time.Minute * 60
 	}
 
-	pack, cerr := uc.validateAndFetchPackageID(ctx, input.PackageID, input.UserID)
+	// the owner of the child profile is taken from the auth context, never from the input
+	if input.ChildID.Valid {
+		var requesterID uuid.NullUUID
+		if requester := model.GetUserFromCtx(ctx); requester != nil {
+			requesterID = uuid.NullUUID{UUID: requester.UserID, Valid: true}
+		}
+
+		if _, cerr := uc.findChildOfUser(ctx, input.ChildID.UUID, requesterID); cerr.Type != nil {
+			logger.WithError(cerr.Cause).Error("failed to find child profile to initiate sd test: ", cerr.Message)
+			return nil, nil, cerr
+		}
+	}
+
+	var assignment *model.SDAssignment
+	if input.AssignmentID.Valid {
+		var cerr *common.Error
+		assignment, cerr = uc.findStartableAssignment(ctx, input.AssignmentID.UUID)
+		if cerr.Type != nil {
+			logger.WithError(cerr.Cause).Error("failed to find sd test assignment: ", cerr.Message)
+			return nil, nil, cerr
+		}
+
+		// the assignment decide the package or the template to be used
+		input.PackageID = assignment.PackageID
+		input.TemplateID = assignment.TemplateID
+	}
+
+	pack, cerr := uc.validateAndFetchPackage(ctx, input)
 	if cerr.Type != nil {
 		logger.WithError(cerr.Cause).Error("failed to fetch sd package to initiate sd test: ", cerr.Message)
-		return nil, cerr
+		return nil, nil, cerr
 	}
 
 	// safety check
 	if !pack.IsActive {
-		return nil, &common.Error{
+		return nil, nil, &common.Error{
 			Message: "sd package is not active",
 			Cause:   errors.New("sd package is not active"),
 			Code:    http.StatusBadRequest,
@@ -63,14 +112,39 @@ func (uc *sdtrUc) Initiate(ctx context.Context, input *model.InitiateSDTestInput
 		}
 	}
 
+	// the retake policy is always enforced using the current template, while the test itself uses the template version of the package
+	tem, cerr := uc.findTestTemplate(ctx, &model.SDTest{TemplateID: pack.TemplateID})
+	if cerr.Type != nil {
+		logger.WithError(cerr.Cause).Error("failed to find sd template of the package: ", cerr.Message)
+		return nil, nil, cerr
+	}
+
 	dbTrx := uc.tx.Begin()
 
+	// the retake stat is read in the same transaction creating the test, holding the lock of the user and the template,
+	// so concurrent initiations can't go past the retake limits
+	if input.UserID.Valid && tem.Template.RetakePolicy != nil {
+		notAllowed, cerr := uc.checkRetakePolicy(ctx, input, pack.TemplateID, tem.Template.RetakePolicy, dbTrx)
+		if cerr.Type != nil {
+			dbTrx.Rollback()
+			logger.WithError(cerr.Cause).Error("failed to check the retake policy: ", cerr.Message)
+			return nil, notAllowed, cerr
+		}
+	}
+
+	tem, cerr = uc.useTemplateVersion(ctx, tem, pack.TemplateVersion)
+	if cerr.Type != nil {
+		dbTrx.Rollback()
+		logger.WithError(cerr.Cause).Error("failed to find sd template version of the package: ", cerr.Message)
+		return nil, nil, cerr
+	}
+
 	if !pack.IsLocked {
 		pack.IsLocked = true
 		pack.UpdatedAt = time.Now().UTC()
 		if err := uc.sdpRepo.Update(ctx, pack, dbTrx); err != nil {
 			dbTrx.Rollback()
-			return nil, &common.Error{
+			return nil, nil, &common.Error{
 				Message: "failed to lock sd package",
 				Cause:   err,
 				Code:    http.StatusInternalServerError,
@@ -82,7 +156,7 @@ func (uc *sdtrUc) Initiate(ctx context.Context, input *model.InitiateSDTestInput
 	submitKeyPlain, submitKeyEnc, err := uc.sharedCryptor.CreateSecureToken()
 	if err != nil {
 		dbTrx.Rollback()
-		return nil, &common.Error{
+		return nil, nil, &common.Error{
 			Message: "failed to create submit key",
 			Cause:   err,
 			Code:    http.StatusInternalServerError,
@@ -98,11 +172,19 @@ func (uc *sdtrUc) Initiate(ctx context.Context, input *model.InitiateSDTestInput
 		SubmitKey: submitKeyEnc,
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
+
+		TemplateID:      pack.TemplateID,
+		PackageVersion:  pack.CurrentVersion,
+		TemplateVersion: pack.TemplateVersion,
+		QuestionOrder:   pack.Package.GenerateTestOrder(tem.Template.Randomization, rand.Shuffle),
+		AssignmentID:    input.AssignmentID,
+		ChildID:         input.ChildID,
+		Status:          model.SDTestStatusInProgress,
 	}
 
 	if err := uc.sdtrRepo.Create(ctx, sdtest, dbTrx); err != nil {
 		dbTrx.Rollback()
-		return nil, &common.Error{
+		return nil, nil, &common.Error{
 			Message: "failed to create sd test",
 			Cause:   err,
 			Code:    http.StatusInternalServerError,
@@ -110,13 +192,28 @@ func (uc *sdtrUc) Initiate(ctx context.Context, input *model.InitiateSDTestInput
 		}
 	}
 
+	if assignment != nil {
+		assignment.Start(sdtest)
+		if err := uc.sdaRepo.Update(ctx, assignment, dbTrx); err != nil {
+			dbTrx.Rollback()
+			return nil, nil, &common.Error{
+				Message: "failed to update sd test assignment",
+				Cause:   err,
+				Code:    http.StatusInternalServerError,
+				Type:    ErrInternal,
+			}
+		}
+	}
+
 	dbTrx.Commit()
 
-	return sdtest.ToInitiateSDTestOutput(submitKeyPlain, pack.Name, pack.Package.RenderTestQuestions()), nilErr
+	locale := model.GetLocaleFromCtx(ctx)
+	return sdtest.ToInitiateSDTestOutput(submitKeyPlain, pack.Name, pack.Package.RenderTestQuestions(locale), pack.Package.RenderOrderedTestQuestions(locale, sdtest.QuestionOrder)), nil, nilErr
 }
 
 func (uc *sdtrUc) Submit(ctx context.Context, input *model.SubmitSDTestInput) (*model.SubmitSDTestOutput, *common.Error) {
 	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdtrUc.Submit",
 		"input": helper.Dump(input),
 	})
 
@@ -129,110 +226,175 @@ func (uc *sdtrUc) Submit(ctx context.Context, input *model.SubmitSDTestInput) (*
 		}
 	}
 
-	testData, err := uc.sdtrRepo.FindByID(ctx, input.TestID)
-	switch err {
-	default:
-		logger.WithError(err).Error("failed to find sd test by id")
+	testData, cerr := uc.findSubmittableTest(ctx, input.TestID, input.SubmitKey)
+	if cerr.Type != nil {
+		logger.WithError(cerr.Cause).Error("failed to find submittable sd test: ", cerr.Message)
+		return nil, cerr
+	}
+
+	pack, cerr := uc.findTestPackage(ctx, testData)
+	if cerr.Type != nil {
+		logger.WithError(cerr.Cause).Error("failed to find sd package of the test: ", cerr.Message)
+		return nil, cerr
+	}
+
+	testType, err := model.GetTestTypeDefinition(pack.Type)
+	if err != nil {
+		logger.WithError(err).Error("failed to find test type definition of the sd package")
 		return nil, &common.Error{
-			Message: "failed to find sd test data",
+			Message: "failed to find test type definition",
 			Cause:   err,
 			Code:    http.StatusInternalServerError,
 			Type:    ErrInternal,
 		}
-	case repository.ErrNotFound:
+	}
+
+	// the answer may be written in any locale, thus must be canonicalized to be correctly merged with the draft
+	input.Answers.Canonicalize(pack.Package)
+	answers := testData.DraftAnswer.Merge(input.Answers)
+	result, err := testType.Grade(answers, pack.Package)
+	if err != nil {
 		return nil, &common.Error{
-			Message: "sd test not found",
+			Message: fmt.Sprintf("test answer are invalid. details: %s", err.Error()),
 			Cause:   err,
-			Code:    http.StatusNotFound,
-			Type:    ErrResourceNotFound,
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidSDTestAnswer,
 		}
-	case nil:
-		break
 	}
 
-	if testData.UserID.Valid {
-		requester := model.GetUserFromCtx(ctx)
-		if requester == nil || testData.UserID.UUID != requester.UserID {
-			return nil, &common.Error{
-				Message: "forbidden to submit other people sd test answer",
-				Cause:   errors.New("forbidden to submit other people sd test answer"),
-				Code:    http.StatusForbidden,
-				Type:    ErrForbiddenToSubmitSDTestAnswer,
-			}
+	tem, cerr := uc.findTestTemplate(ctx, testData)
+	if cerr.Type != nil {
+		logger.WithError(cerr.Cause).Error("failed to find sd template of the test: ", cerr.Message)
+		return nil, cerr
+	}
+
+	tem.Template.Interpret(&result)
+
+	testData.Result = result
+	testData.Answer = *answers
+	testData.DraftAnswer = model.SDTestAnswer{}
+	now := time.Now().UTC()
+	testData.UpdatedAt = now
+	testData.FinishedAt = null.NewTime(now, true)
+	testData.Status = model.SDTestStatusFinished
+	if err := uc.saveSubmission(ctx, testData); err != nil {
+		logger.WithError(err).Error("failed to save test result")
+		return nil, &common.Error{
+			Message: "failed to save test result",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
 		}
 	}
 
-	if uc.sharedCryptor.ReverseSecureToken(input.SubmitKey) != testData.SubmitKey {
+	// the result is already saved, thus failing to enqueue the delivery must not fail the submission
+	if input.SendResultEmail {
+		if !testData.UserID.Valid {
+			logger.Info("skipping result email delivery for sd test without owner")
+		} else if _, err := uc.workerClient.EnqueueDeliverSDTestResultTask(ctx, testData.ID); err != nil {
+			logger.WithError(err).Error("failed to enqueue deliver sd test result task")
+		}
+	}
+
+	locale := model.GetLocaleFromCtx(ctx)
+	return testData.ToSubmitTestOutput(pack.Name, input.SubmitKey, pack.Package.RenderTestQuestions(locale), pack.Package.RenderOrderedTestQuestions(locale, testData.QuestionOrder)), nilErr
+}
+
+func (uc *sdtrUc) SaveDraft(ctx context.Context, input *model.SaveSDTestDraftInput) (*model.SDTestDraftOutput, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdtrUc.SaveDraft",
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
 		return nil, &common.Error{
-			Message: "invalid submit key",
-			Cause:   errors.New("invalid submit key"),
+			Message: fmt.Sprintf("invalid input to save test answer draft: %s", err.Error()),
+			Cause:   err,
 			Code:    http.StatusBadRequest,
-			Type:    ErrInvalidSubmitKey,
+			Type:    ErrInvalidSDTestAnswer,
 		}
 	}
 
-	if err := testData.IsStillAcceptingAnswer(); err != nil {
+	testData, cerr := uc.findSubmittableTest(ctx, input.TestID, input.SubmitKey)
+	if cerr.Type != nil {
+		logger.WithError(cerr.Cause).Error("failed to find submittable sd test: ", cerr.Message)
+		return nil, cerr
+	}
+
+	pack, cerr := uc.findTestPackage(ctx, testData)
+	if cerr.Type != nil {
+		logger.WithError(cerr.Cause).Error("failed to find sd package of the test: ", cerr.Message)
+		return nil, cerr
+	}
+
+	if err := input.Answers.ValidateDraft(pack.Package); err != nil {
 		return nil, &common.Error{
-			Message: err.Error(),
+			Message: fmt.Sprintf("test answer draft are invalid. details: %s", err.Error()),
 			Cause:   err,
-			Code:    http.StatusForbidden,
-			Type:    ErrForbiddenToSubmitSDTestAnswer,
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidSDTestAnswer,
 		}
 	}
 
-	pack, err := uc.sdpRepo.FindByID(ctx, testData.PackageID, false)
+	// drafts saved before the ids exist are only written using the text
+	testData.DraftAnswer = *testData.DraftAnswer.Merge(input.Answers)
+	testData.DraftAnswer.Canonicalize(pack.Package)
+	testData.UpdatedAt = time.Now().UTC()
+
+	// only the draft is written, and only when the test is still not finished, so an autosave
+	// racing with the submission can't write the stale unfinished test back
+	err := uc.sdtrRepo.SaveDraft(ctx, testData.ID, testData.DraftAnswer, testData.UpdatedAt)
 	switch err {
 	default:
+		logger.WithError(err).Error("failed to save test answer draft")
 		return nil, &common.Error{
-			Message: "failed to find sd package data",
+			Message: "failed to save test answer draft",
 			Cause:   err,
 			Code:    http.StatusInternalServerError,
 			Type:    ErrInternal,
 		}
 	case repository.ErrNotFound:
 		return nil, &common.Error{
-			Message: "sd package not found",
-			Cause:   err,
-			Code:    http.StatusNotFound,
-			Type:    ErrResourceNotFound,
+			Message: "the test is already answered",
+			Cause:   errors.New("the test is already answered"),
+			Code:    http.StatusForbidden,
+			Type:    ErrForbiddenToSubmitSDTestAnswer,
 		}
 	case nil:
 		break
 	}
 
-	grade, err := input.Answers.DoGradingProcess(pack.Package)
-	if err != nil {
+	return testData.ToSDTestDraftOutput(pack.Package.RenderOrderedTestQuestions(model.GetLocaleFromCtx(ctx), testData.QuestionOrder)), nilErr
+}
+
+func (uc *sdtrUc) ViewDraft(ctx context.Context, input *model.ViewSDTestDraftInput) (*model.SDTestDraftOutput, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdtrUc.ViewDraft",
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
 		return nil, &common.Error{
-			Message: fmt.Sprintf("test answer are invalid. details: %s", err.Error()),
+			Message: fmt.Sprintf("invalid input to view test answer draft: %s", err.Error()),
 			Cause:   err,
 			Code:    http.StatusBadRequest,
 			Type:    ErrInvalidSDTestAnswer,
 		}
 	}
 
-	total := 0
-	for _, v := range grade {
-		total += v.Result
+	testData, cerr := uc.findSubmittableTest(ctx, input.TestID, input.SubmitKey)
+	if cerr.Type != nil {
+		logger.WithError(cerr.Cause).Error("failed to find submittable sd test: ", cerr.Message)
+		return nil, cerr
 	}
 
-	testData.Result = model.SDTestResult{
-		Result: grade,
-		Total:  total,
-	}
-	testData.Answer = *input.Answers
-	now := time.Now().UTC()
-	testData.UpdatedAt = now
-	testData.FinishedAt = null.NewTime(now, true)
-	if err := uc.sdtrRepo.Update(ctx, testData, nil); err != nil {
-		return nil, &common.Error{
-			Message: "failed to save test result",
-			Cause:   err,
-			Code:    http.StatusInternalServerError,
-			Type:    ErrInternal,
-		}
+	pack, cerr := uc.findTestPackage(ctx, testData)
+	if cerr.Type != nil {
+		logger.WithError(cerr.Cause).Error("failed to find sd package of the test: ", cerr.Message)
+		return nil, cerr
 	}
 
-	return testData.ToSubmitTestOutput(pack.Name, input.SubmitKey, pack.Package.RenderTestQuestions()), nilErr
+	return testData.ToSDTestDraftOutput(pack.Package.RenderOrderedTestQuestions(model.GetLocaleFromCtx(ctx), testData.QuestionOrder)), nilErr
 }
 
 func (uc *sdtrUc) Histories(ctx context.Context, input *model.ViewHistoriesInput) ([]model.ViewHistoriesOutput, *common.Error) {
@@ -245,6 +407,12 @@ func (uc *sdtrUc) Histories(ctx context.Context, input *model.ViewHistoriesInput
 	requester := model.GetUserFromCtx(ctx)
 	if !requester.IsAdmin() {
 		searchInput.UserID = uuid.NullUUID{UUID: requester.UserID, Valid: true}
+
+		if searchInput.ChildID.Valid {
+			if _, cerr := uc.findChildOfUser(ctx, searchInput.ChildID.UUID, searchInput.UserID); cerr.Type != nil {
+				return nil, cerr
+			}
+		}
 	}
 
 	res, err := uc.sdtrRepo.Search(ctx, searchInput)
@@ -266,18 +434,33 @@ func (uc *sdtrUc) Histories(ctx context.Context, input *model.ViewHistoriesInput
 	return resp, nilErr
 }
 
-func (uc *sdtrUc) Statistic(ctx context.Context, userID uuid.UUID) ([]model.SDTestStatistic, *common.Error) {
+func (uc *sdtrUc) Statistic(ctx context.Context, input *model.SDTestStatisticInput) ([]model.SDTestStatistic, *common.Error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"func":  "sdtrUc.Statistic",
-		"input": userID,
+		"input": helper.Dump(input),
 	})
 
+	if !input.From.IsZero() && !input.To.IsZero() && input.To.Before(input.From) {
+		return nil, &common.Error{
+			Message: "invalid statistic date range, to must not be before from",
+			Cause:   errors.New("invalid statistic date range"),
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidSDTestStatisticInput,
+		}
+	}
+
 	requester := model.GetUserFromCtx(ctx)
 	if !requester.IsAdmin() {
-		userID = requester.UserID
+		input.UserID = requester.UserID
+
+		if input.ChildID.Valid {
+			if _, cerr := uc.findChildOfUser(ctx, input.ChildID.UUID, uuid.NullUUID{UUID: input.UserID, Valid: true}); cerr.Type != nil {
+				return nil, cerr
+			}
+		}
 	}
 
-	res, err := uc.sdtrRepo.Statistic(ctx, userID)
+	res, err := uc.sdtrRepo.Statistic(ctx, input)
 	switch err {
 	default:
 		logger.WithError(err).Error("failed to get sd test statistic")
@@ -299,13 +482,22 @@ func (uc *sdtrUc) Statistic(ctx context.Context, userID uuid.UUID) ([]model.SDTe
 	}
 }
 
-func (uc *sdtrUc) DownloadResult(ctx context.Context, tid uuid.UUID) (*model.ImageResult, *common.Error) {
+func (uc *sdtrUc) DownloadResult(ctx context.Context, input *model.DownloadSDTestResultInput) (*model.ImageResult, *common.Error) {
 	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":  "sdtrUc.DownloadResult",
-		"input": tid.String(),
+		"input": helper.Dump(input),
 	})
 
-	testRes, err := uc.sdtrRepo.FindByID(ctx, tid)
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to download sd test result: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidDownloadSDTestResultInput,
+		}
+	}
+
+	testRes, err := uc.sdtrRepo.FindByID(ctx, input.ID)
 	switch err {
 	default:
 		logger.WithError(err).Error("failed to find sd test result by id")
@@ -347,19 +539,28 @@ func (uc *sdtrUc) DownloadResult(ctx context.Context, tid uuid.UUID) (*model.Ima
 		}
 	}
 
-	tem, err := uc.sdpRepo.GetTemplateByPackageID(ctx, testRes.PackageID)
+	return uc.generateResult(ctx, testRes, input.Format, input.Width, input.DPI)
+}
+
+func (uc *sdtrUc) DeliverResult(ctx context.Context, testID uuid.UUID) *common.Error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":   "sdtrUc.DeliverResult",
+		"testID": testID,
+	})
+
+	testRes, err := uc.sdtrRepo.FindByID(ctx, testID)
 	switch err {
 	default:
-		logger.WithError(err).Error("failed to find sd test template by id")
-		return nil, &common.Error{
-			Message: "failed to find sd test template",
+		logger.WithError(err).Error("failed to find sd test result by id")
+		return &common.Error{
+			Message: "failed to find sd test result",
 			Cause:   err,
 			Code:    http.StatusInternalServerError,
 			Type:    ErrInternal,
 		}
 	case repository.ErrNotFound:
-		return nil, &common.Error{
-			Message: "sd test template not found",
+		return &common.Error{
+			Message: "sd test result not found",
 			Cause:   err,
 			Code:    http.StatusNotFound,
 			Type:    ErrResourceNotFound,
@@ -368,89 +569,857 @@ func (uc *sdtrUc) DownloadResult(ctx context.Context, tid uuid.UUID) (*model.Ima
 		break
 	}
 
-	var indicationText string
-	if testRes.Result.Total >= tem.Template.IndicationThreshold {
-		indicationText = tem.Template.PositiveIndiationText
-	} else {
-		indicationText = tem.Template.NegativeIndicationText
+	if !testRes.FinishedAt.Valid || !testRes.UserID.Valid {
+		return &common.Error{
+			Message: "only finished sd test having owner can be delivered",
+			Cause:   errors.New("sd test is still open or has no owner"),
+			Code:    http.StatusForbidden,
+			Type:    ErrForbiddenDownloadSDTestResult,
+		}
 	}
 
-	resGen := model.NewResultGenerator(uc.font, &model.SDResultImageGenerationOpts{
-		Title:          "Hasil Score ATEC",
-		Result:         testRes.Result,
-		TestID:         testRes.ID,
-		IndicationText: indicationText,
-	})
+	owner, err := uc.userRepo.FindByID(ctx, testRes.UserID.UUID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find sd test owner")
+		return &common.Error{
+			Message: "failed to find sd test owner",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return &common.Error{
+			Message: "sd test owner not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	email, err := uc.sharedCryptor.Decrypt(owner.Email)
+	if err != nil {
+		logger.WithError(err).Error("failed to decrypt sd test owner email")
+		return &common.Error{
+			Message: "failed to decrypt sd test owner email",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	img, cerr := uc.generateResult(ctx, testRes, model.SDResultFormatPNG, 0, 0)
+	if cerr.Type != nil {
+		return cerr
+	}
+
+	if _, err := uc.emailUsecase.Register(ctx, generateEmailTemplateForSDTestResult(owner.Username, email, testRes.ID, img)); err != nil {
+		logger.WithError(err).Error("failed to register sd test result email")
+		return &common.Error{
+			Message: "failed to register sd test result email",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
 
-	return resGen.GenerateJPEG(), nilErr
+	return nilErr
 }
 
-func (uc *sdtrUc) validateAndFetchPackageID(ctx context.Context, packageID, userID uuid.NullUUID) (*model.SpeechDelayPackage, *common.Error) {
-	if packageID.Valid {
-		pack, err := uc.sdpRepo.FindByID(ctx, packageID.UUID, false)
+// generateResult generate the signed result document of a finished test on the requested format
+func (uc *sdtrUc) generateResult(ctx context.Context, testRes *model.SDTest, format model.SDResultFormat, width, dpi int) (*model.ImageResult, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":   "sdtrUc.generateResult",
+		"testID": testRes.ID,
+		"format": format,
+	})
+
+	tem, cerr := uc.findTestTemplate(ctx, testRes)
+	if cerr.Type != nil {
+		logger.WithError(cerr.Cause).Error("failed to find sd template of the test: ", cerr.Message)
+		return nil, cerr
+	}
+
+	testType, err := model.GetTestTypeDefinition(tem.Type)
+	if err != nil {
+		logger.WithError(err).Error("failed to find test type definition of the sd template")
+		return nil, &common.Error{
+			Message: "failed to find test type definition",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	// tests submitted before the interpretation is stored on the result are interpreted using the current template
+	if testRes.Result.Interpretation == nil {
+		tem.Template.Interpret(&testRes.Result)
+	}
+
+	locale := model.GetLocaleFromCtx(ctx)
+	result := tem.Template.TranslateResult(testRes.Result, locale)
+	opts := &model.SDResultImageGenerationOpts{
+		Title:          testType.ResultTitle(locale),
+		Result:         result,
+		TestID:         testRes.ID,
+		IndicationText: result.Interpretation.IndicationText,
+		Labels:         model.GetSDResultLabels(locale),
+		Width:          width,
+		DPI:            dpi,
+	}
+
+	// the package name and the test date are only written on the pdf document
+	if format == model.SDResultFormatPDF {
+		pack, err := uc.sdpRepo.FindByID(ctx, testRes.PackageID, true)
 		switch err {
 		default:
+			logger.WithError(err).Error("failed to find sd package of the test")
 			return nil, &common.Error{
-				Message: "failed to fetch sd package",
+				Message: "failed to find sd package data",
 				Cause:   err,
 				Code:    http.StatusInternalServerError,
 				Type:    ErrInternal,
 			}
 		case repository.ErrNotFound:
 			return nil, &common.Error{
-				Message: "no package found",
+				Message: "sd package not found",
 				Cause:   err,
 				Code:    http.StatusNotFound,
 				Type:    ErrResourceNotFound,
 			}
 		case nil:
-			return pack, nilErr
+			break
 		}
-	}
 
-	if !userID.Valid {
-		pack, err := uc.sdpRepo.FindRandomActivePackage(ctx)
-		if err != nil {
-			return nil, &common.Error{
-				Message: "failed to fetch sd package",
-				Cause:   err,
-				Code:    http.StatusInternalServerError,
-				Type:    ErrInternal,
-			}
+		// the package name is written as it was when the test was taken
+		pack, cerr := uc.usePackageVersion(ctx, pack, testRes.PackageVersion)
+		if cerr.Type != nil {
+			logger.WithError(cerr.Cause).Error("failed to find sd package version of the test: ", cerr.Message)
+			return nil, cerr
 		}
 
-		return pack, nilErr
+		opts.PackageName = pack.Name
+		opts.TestDate = testRes.FinishedAt.Time
 	}
 
-	packID, err := uc.sdpRepo.FindLeastUsedPackageIDByUserID(ctx, userID.UUID)
-	switch err {
-	default:
+	signature, err := uc.signer.Sign(model.SDResultSignatureMessage(testRes.ID, testRes.Result.Total))
+	if err != nil {
+		logger.WithError(err).Error("failed to sign sd test result")
 		return nil, &common.Error{
-			Message: "failed to fetch sd package",
+			Message: "failed to sign sd test result",
 			Cause:   err,
 			Code:    http.StatusInternalServerError,
 			Type:    ErrInternal,
 		}
-	case repository.ErrNotFound:
-		return nil, &common.Error{
-			Message: "sd package not found",
-			Cause:   err,
-			Code:    http.StatusNotFound,
-			Type:    ErrResourceNotFound,
-		}
-	case nil:
-		break
 	}
 
-	pack, err := uc.sdpRepo.FindByID(ctx, packID, false)
+	opts.VerificationContent, err = model.SDResultVerificationContent(config.SDResultVerificationBaseURL(), testRes.ID, testRes.Result.Total, signature)
 	if err != nil {
+		logger.WithError(err).Error("failed to build sd test result verification content")
 		return nil, &common.Error{
-			Message: "failed to fetch sd package",
+			Message: "failed to build sd test result verification content",
 			Cause:   err,
 			Code:    http.StatusInternalServerError,
 			Type:    ErrInternal,
 		}
 	}
 
-	return pack, nilErr
+	return format.Generate(model.NewResultGenerator(uc.font, opts)), nilErr
+}
+
+func (uc *sdtrUc) VerifyResult(ctx context.Context, input *model.VerifySDTestResultInput) (*model.VerifySDTestResultOutput, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdtrUc.VerifyResult",
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to verify sd test result: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidVerifySDTestResultInput,
+		}
+	}
+
+	signature, err := model.DecodeSDResultSignature(input.Signature)
+	if err != nil {
+		return nil, &common.Error{
+			Message: "invalid sd test result signature encoding",
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidVerifySDTestResultInput,
+		}
+	}
+
+	// the signature is checked first, thus the forged signature won't reach the database
+	output := &model.VerifySDTestResultOutput{TestID: input.ID}
+	if err := uc.signer.Verify(model.SDResultSignatureMessage(input.ID, input.Total), signature); err != nil {
+		logger.WithError(err).Info("sd test result signature is not valid")
+		return output, nilErr
+	}
+
+	testRes, err := uc.sdtrRepo.FindByID(ctx, input.ID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find sd test result by id")
+		return nil, &common.Error{
+			Message: "failed to find sd test result",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd test result not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	if !testRes.FinishedAt.Valid || testRes.Result.Total != input.Total {
+		logger.Info("signed sd test result doesn't match the stored result")
+		return output, nilErr
+	}
+
+	output.Valid = true
+	output.Result = &testRes.Result
+	output.FinishedAt = testRes.FinishedAt
+
+	return output, nilErr
+}
+
+func (uc *sdtrUc) ResultPublicKey(ctx context.Context) (*model.SDResultPublicKeyOutput, *common.Error) {
+	publicKey, err := uc.signer.PublicKeyPEM()
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).Error("failed to encode the sd test result public key")
+		return nil, &common.Error{
+			Message: "failed to encode the public key",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	return &model.SDResultPublicKeyOutput{
+		Algorithm:     common.SignatureAlgorithm,
+		PublicKey:     publicKey,
+		MessageFormat: model.SDResultSignatureMessageFormat,
+	}, nilErr
+}
+
+func (uc *sdtrUc) ProgressChart(ctx context.Context, input *model.SDTestProgressChartInput) (*model.ImageResult, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdtrUc.ProgressChart",
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to draw sd test progress chart: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidSDTestProgressChartInput,
+		}
+	}
+
+	stats, cerr := uc.Statistic(ctx, input.ToStatisticInput())
+	if cerr.Type != nil {
+		logger.WithError(cerr.Cause).Error("failed to get sd test statistic: ", cerr.Message)
+		return nil, cerr
+	}
+
+	// the statistic is filtered by the template, thus only has the statistic of the template
+	chart := model.NewProgressChartGenerator(uc.font, &model.SDProgressChartOpts{
+		Statistic: stats[0],
+		Labels:    model.GetSDResultLabels(model.GetLocaleFromCtx(ctx)),
+		Width:     input.Width,
+		DPI:       input.DPI,
+	})
+
+	if input.Format == model.SDResultFormatJPEG {
+		return chart.GenerateJPEG(), nilErr
+	}
+
+	return chart.GeneratePNG(), nilErr
+}
+
+func (uc *sdtrUc) validateAndFetchPackage(ctx context.Context, input *model.InitiateSDTestInput) (*model.SpeechDelayPackage, *common.Error) {
+	if !input.PackageID.Valid {
+		return uc.selectPackage(ctx, input.TemplateID, input.UserID)
+	}
+
+	pack, err := uc.sdpRepo.FindByID(ctx, input.PackageID.UUID, false)
+	switch err {
+	default:
+		return nil, &common.Error{
+			Message: "failed to fetch sd package",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "no package found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	if input.TemplateID.Valid && pack.TemplateID != input.TemplateID.UUID {
+		return nil, &common.Error{
+			Message: "sd package does not belong to the requested template",
+			Cause:   errors.New("sd package does not belong to the requested template"),
+			Code:    http.StatusBadRequest,
+			Type:    ErrSDPackageTemplateMismatch,
+		}
+	}
+
+	return pack, nilErr
+}
+
+// checkRetakePolicy will ensure the user, or the child when specified, is allowed to take another test of the template.
+// When not allowed, return the violated rule and when the next test is allowed
+func (uc *sdtrUc) checkRetakePolicy(ctx context.Context, input *model.InitiateSDTestInput, templateID uuid.UUID, policy *model.SDRetakePolicy, tx *gorm.DB) (*model.SDRetakeNotAllowedOutput, *common.Error) {
+	now := time.Now().UTC()
+	stat, err := uc.sdtrRepo.FindRetakeStat(ctx, &model.SDRetakeStatInput{
+		UserID:        input.UserID.UUID,
+		ChildID:       input.ChildID,
+		TemplateID:    templateID,
+		AttemptsSince: now.Add(-policy.AttemptPeriod()),
+		Now:           now,
+	}, tx)
+	if err != nil {
+		return nil, &common.Error{
+			Message: "failed to find the previous sd tests",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	notAllowed := policy.Check(stat, now)
+	if notAllowed == nil {
+		return nil, nilErr
+	}
+
+	return notAllowed, &common.Error{
+		Message: fmt.Sprintf("retake is not allowed by %s rule until %s", notAllowed.Rule, notAllowed.NextAllowedAt.Format(time.RFC3339)),
+		Cause:   errors.New("retake is not allowed by the template retake policy"),
+		Code:    http.StatusTooManyRequests,
+		Type:    ErrSDTestRetakeNotAllowed,
+	}
+}
+
+// selectPackage will choose one of the active packages using the package selection strategy.
+// When the template is specified, only its packages are used and the strategy is configured by the template.
+// Otherwise, the strategy configured by the template of the user latest test is used
+func (uc *sdtrUc) selectPackage(ctx context.Context, templateID, userID uuid.NullUUID) (*model.SpeechDelayPackage, *common.Error) {
+	strategy, cerr := uc.findSelectionStrategy(ctx, templateID, userID)
+	if cerr.Type != nil {
+		return nil, cerr
+	}
+
+	packs, err := uc.sdpRepo.FindActive(ctx, templateID)
+	if err != nil {
+		return nil, &common.Error{
+			Message: "failed to fetch sd package",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	if len(packs) == 0 {
+		return nil, &common.Error{
+			Message: "no active sd package found",
+			Cause:   repository.ErrNotFound,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	}
+
+	selector, ok := uc.packageSelectors[strategy.OrDefault(userID)]
+	if !ok {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("unknown package selection strategy: %s", strategy),
+			Cause:   errors.New("unknown package selection strategy"),
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	pack, err := selector.Select(ctx, &model.PackageSelectionInput{
+		UserID:     userID,
+		Candidates: packs,
+	})
+	if err != nil {
+		return nil, &common.Error{
+			Message: "failed to select sd package",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	return pack, nilErr
+}
+
+// findSelectionStrategy will find the package selection strategy configured by the requested template. When no template
+// is requested, the template of the user latest test is used. Empty strategy is returned when none is configured
+func (uc *sdtrUc) findSelectionStrategy(ctx context.Context, templateID, userID uuid.NullUUID) (model.PackageSelectionStrategy, *common.Error) {
+	if templateID.Valid {
+		tem, err := uc.sdtRepo.FindByID(ctx, templateID.UUID, false)
+		switch err {
+		default:
+			return "", &common.Error{
+				Message: "failed to fetch sd template",
+				Cause:   err,
+				Code:    http.StatusInternalServerError,
+				Type:    ErrInternal,
+			}
+		case repository.ErrNotFound:
+			return "", &common.Error{
+				Message: "sd template not found",
+				Cause:   err,
+				Code:    http.StatusNotFound,
+				Type:    ErrResourceNotFound,
+			}
+		case nil:
+			return tem.PackageSelectionStrategy(), nilErr
+		}
+	}
+
+	if !userID.Valid {
+		return "", nilErr
+	}
+
+	latest, err := uc.sdtrRepo.FindLatest(ctx, &model.FindLatestSDTestInput{UserID: userID})
+	switch err {
+	default:
+		return "", &common.Error{
+			Message: "failed to find the latest sd test",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return "", nilErr
+	case nil:
+		break
+	}
+
+	// the template of the latest test may already be deleted, thus the default strategy is used
+	tem, err := uc.sdtRepo.FindByID(ctx, latest.TemplateID, false)
+	switch err {
+	default:
+		return "", &common.Error{
+			Message: "failed to fetch sd template",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return "", nilErr
+	case nil:
+		return tem.PackageSelectionStrategy(), nilErr
+	}
+}
+
+func (uc *sdtrUc) Claim(ctx context.Context, input *model.ClaimSDTestInput) (*model.ViewHistoriesOutput, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdtrUc.Claim",
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to claim sd test: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidClaimSDTestInput,
+		}
+	}
+
+	testData, err := uc.sdtrRepo.FindByID(ctx, input.TestID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find sd test to claim")
+		return nil, &common.Error{
+			Message: "failed to find sd test data",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd test not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	if testData.UserID.Valid {
+		return nil, &common.Error{
+			Message: "sd test is already owned by an account",
+			Cause:   errors.New("sd test is already owned by an account"),
+			Code:    http.StatusForbidden,
+			Type:    ErrSDTestAlreadyOwned,
+		}
+	}
+
+	if uc.sharedCryptor.ReverseSecureToken(input.SubmitKey) != testData.SubmitKey {
+		return nil, &common.Error{
+			Message: "invalid submit key",
+			Cause:   errors.New("invalid submit key"),
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidSubmitKey,
+		}
+	}
+
+	// the owner is only set when the test is still not owned, thus only one of the concurrent claims succeed
+	testData.UserID = uuid.NullUUID{UUID: model.GetUserFromCtx(ctx).UserID, Valid: true}
+	testData.UpdatedAt = time.Now().UTC()
+	err = uc.sdtrRepo.Claim(ctx, testData.ID, testData.UserID.UUID, testData.UpdatedAt)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to claim sd test")
+		return nil, &common.Error{
+			Message: "failed to claim sd test",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd test is already owned by an account",
+			Cause:   errors.New("sd test is already owned by an account"),
+			Code:    http.StatusForbidden,
+			Type:    ErrSDTestAlreadyOwned,
+		}
+	case nil:
+		break
+	}
+
+	res := testData.ToViewHistoriesOutput()
+	return &res, nilErr
+}
+
+// findChildOfUser will find the child profile and ensure it is owned by the user
+func (uc *sdtrUc) findChildOfUser(ctx context.Context, childID uuid.UUID, userID uuid.NullUUID) (*model.ChildProfile, *common.Error) {
+	child, err := uc.cpRepo.FindByID(ctx, childID)
+	switch err {
+	default:
+		return nil, &common.Error{
+			Message: "failed to find child profile",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "child profile not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	if !userID.Valid || !child.IsOwnedBy(userID.UUID) {
+		return nil, &common.Error{
+			Message: "forbidden to access other people child profile",
+			Cause:   errors.New("forbidden to access other people child profile"),
+			Code:    http.StatusForbidden,
+			Type:    ErrForbiddenToAccessChildProfile,
+		}
+	}
+
+	return child, nilErr
+}
+
+// findStartableAssignment will find the assignment and ensure the requester is the assignee and the assignment is still open.
+// The requester is taken from the auth context, never from the input, thus anonymous request can't start any assignment
+func (uc *sdtrUc) findStartableAssignment(ctx context.Context, id uuid.UUID) (*model.SDAssignment, *common.Error) {
+	requester := model.GetUserFromCtx(ctx)
+	if requester == nil {
+		return nil, &common.Error{
+			Message: "login is required to start sd test assignment",
+			Cause:   errors.New("anonymous request to start sd test assignment"),
+			Code:    http.StatusForbidden,
+			Type:    ErrForbiddenToAccessSDAssignment,
+		}
+	}
+
+	assignment, err := uc.sdaRepo.FindByID(ctx, id)
+	switch err {
+	default:
+		return nil, &common.Error{
+			Message: "failed to find sd test assignment",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd test assignment not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	if assignment.AssigneeID != requester.UserID {
+		return nil, &common.Error{
+			Message: "forbidden to start other people sd test assignment",
+			Cause:   errors.New("forbidden to start other people sd test assignment"),
+			Code:    http.StatusForbidden,
+			Type:    ErrForbiddenToAccessSDAssignment,
+		}
+	}
+
+	if !assignment.IsOpen() {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("sd test assignment is already %s", assignment.Status),
+			Cause:   errors.New("sd test assignment is not open"),
+			Code:    http.StatusForbidden,
+			Type:    ErrSDAssignmentNotOpen,
+		}
+	}
+
+	return assignment, nilErr
+}
+
+// saveSubmission will save the submitted test. When the test was started from a still open assignment,
+// the assignment is marked as completed on the same transaction
+func (uc *sdtrUc) saveSubmission(ctx context.Context, test *model.SDTest) error {
+	if !test.AssignmentID.Valid {
+		return uc.sdtrRepo.Update(ctx, test, nil)
+	}
+
+	assignment, err := uc.sdaRepo.FindByID(ctx, test.AssignmentID.UUID)
+	switch err {
+	default:
+		return err
+	case repository.ErrNotFound:
+		return uc.sdtrRepo.Update(ctx, test, nil)
+	case nil:
+		break
+	}
+
+	// cancelled assignment must stay cancelled, the test is saved as a regular test
+	if !assignment.IsOpen() {
+		return uc.sdtrRepo.Update(ctx, test, nil)
+	}
+
+	tx := uc.tx.Begin()
+	if err := uc.sdtrRepo.Update(ctx, test, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	assignment.Complete(test)
+	if err := uc.sdaRepo.Update(ctx, assignment, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// findTestPackage will find the package of the test, with the content of the package version the test was taken on
+func (uc *sdtrUc) findTestPackage(ctx context.Context, test *model.SDTest) (*model.SpeechDelayPackage, *common.Error) {
+	pack, err := uc.sdpRepo.FindByID(ctx, test.PackageID, false)
+	switch err {
+	default:
+		return nil, &common.Error{
+			Message: "failed to find sd package data",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd package not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	return uc.usePackageVersion(ctx, pack, test.PackageVersion)
+}
+
+// usePackageVersion will replace the package content with the content of the given version.
+// Zero value or the current version will use the package as is
+func (uc *sdtrUc) usePackageVersion(ctx context.Context, pack *model.SpeechDelayPackage, packageVersion int) (*model.SpeechDelayPackage, *common.Error) {
+	if packageVersion == 0 || packageVersion == pack.CurrentVersion {
+		return pack, nilErr
+	}
+
+	version, err := uc.sdpRepo.FindVersion(ctx, pack.ID, packageVersion)
+	switch err {
+	default:
+		return nil, &common.Error{
+			Message: "failed to find sd package version",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd package version not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		pack.UseVersion(version)
+		return pack, nilErr
+	}
+}
+
+// findTestTemplate will find the template of the test, with the content of the template version the test was taken on
+func (uc *sdtrUc) findTestTemplate(ctx context.Context, test *model.SDTest) (*model.SpeechDelayTemplate, *common.Error) {
+	tem, err := uc.sdtRepo.FindByID(ctx, test.TemplateID, false)
+	switch err {
+	default:
+		return nil, &common.Error{
+			Message: "failed to find sd template data",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd template not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	return uc.useTemplateVersion(ctx, tem, test.TemplateVersion)
+}
+
+// useTemplateVersion will replace the template content with the content of the given version.
+// Zero value or the current version will use the template as is
+func (uc *sdtrUc) useTemplateVersion(ctx context.Context, tem *model.SpeechDelayTemplate, templateVersion int) (*model.SpeechDelayTemplate, *common.Error) {
+	if templateVersion == 0 || templateVersion == tem.CurrentVersion {
+		return tem, nilErr
+	}
+
+	version, err := uc.sdtRepo.FindVersion(ctx, tem.ID, templateVersion)
+	switch err {
+	default:
+		return nil, &common.Error{
+			Message: "failed to find sd template version",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd template version not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		tem.UseVersion(version)
+		return tem, nilErr
+	}
+}
+
+// findSubmittableTest will find the sd test by id and ensure the requester is allowed to submit the answer
+// using the given plain submit key, and the test is still accepting answer
+func (uc *sdtrUc) findSubmittableTest(ctx context.Context, testID uuid.UUID, submitKey string) (*model.SDTest, *common.Error) {
+	testData, err := uc.sdtrRepo.FindByID(ctx, testID)
+	switch err {
+	default:
+		return nil, &common.Error{
+			Message: "failed to find sd test data",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd test not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	if testData.UserID.Valid {
+		requester := model.GetUserFromCtx(ctx)
+		if requester == nil || testData.UserID.UUID != requester.UserID {
+			return nil, &common.Error{
+				Message: "forbidden to submit other people sd test answer",
+				Cause:   errors.New("forbidden to submit other people sd test answer"),
+				Code:    http.StatusForbidden,
+				Type:    ErrForbiddenToSubmitSDTestAnswer,
+			}
+		}
+	}
+
+	if uc.sharedCryptor.ReverseSecureToken(submitKey) != testData.SubmitKey {
+		return nil, &common.Error{
+			Message: "invalid submit key",
+			Cause:   errors.New("invalid submit key"),
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidSubmitKey,
+		}
+	}
+
+	if err := testData.IsStillAcceptingAnswer(); err != nil {
+		return nil, &common.Error{
+			Message: err.Error(),
+			Cause:   err,
+			Code:    http.StatusForbidden,
+			Type:    ErrForbiddenToSubmitSDTestAnswer,
+		}
+	}
+
+	return testData, nilErr
+}
+
+func generateEmailTemplateForSDTestResult(username, email string, testID uuid.UUID, result *model.ImageResult) *model.RegisterEmailInput {
+	return &model.RegisterEmailInput{
+		Subject: "Hasil Tes ATEC",
+		Body: fmt.Sprintf(`
+			<h2>Halo %s!</h2>
+			<p>Terimakasih telah mengerjakan tes pada layanan Autism Treatment Evaluation Checklist (ATEC).</p>
+			<p>Hasil tes Anda terlampir pada email ini.</p> <br>
+		`, username),
+		To: []string{email},
+		Attachments: []model.RegisterEmailAttachmentInput{
+			{
+				Filename:    fmt.Sprintf("hasil-tes-%s.png", testID),
+				ContentType: result.ContentType,
+				Content:     result.Buffer.Bytes(),
+			},
+		},
+	}
 }
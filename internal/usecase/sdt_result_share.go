@@ -0,0 +1,400 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/sirupsen/logrus"
+	"github.com/sweet-go/stdlib/helper"
+	"gopkg.in/guregu/null.v4"
+)
+
+func (uc *sdtrUc) CreateShare(ctx context.Context, input *model.CreateSDResultShareInput) (*model.GeneratedSDResultShare, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdtrUc.CreateShare",
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to share sd test result: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidSDResultShareInput,
+		}
+	}
+
+	testRes, cerr := uc.findShareableTest(ctx, input.TestID)
+	if cerr.Type != nil {
+		return nil, cerr
+	}
+
+	if !testRes.FinishedAt.Valid {
+		return nil, &common.Error{
+			Message: "sd test is still not answered yet",
+			Cause:   errors.New("sd test is still open"),
+			Code:    http.StatusForbidden,
+			Type:    ErrForbiddenToShareSDTestResult,
+		}
+	}
+
+	plainToken, encToken, err := uc.sharedCryptor.CreateSecureToken()
+	if err != nil {
+		logger.WithError(err).Error("failed to create share token")
+		return nil, &common.Error{
+			Message: "failed to create share token",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	now := time.Now().UTC()
+	share := &model.SDResultShare{
+		ID:        uuid.New(),
+		TestID:    testRes.ID,
+		Token:     encToken,
+		Scope:     input.Scope,
+		ExpiredAt: input.ExpiredAt.UTC(),
+		MaxViews:  input.MaxViews,
+		CreatedBy: model.GetUserFromCtx(ctx).UserID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := uc.sdrsRepo.Create(ctx, share); err != nil {
+		logger.WithError(err).Error("failed to create sd test result share")
+		return nil, &common.Error{
+			Message: "failed to create sd test result share",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	return share.ToRESTResponse(plainToken), nilErr
+}
+
+func (uc *sdtrUc) FindShares(ctx context.Context, testID uuid.UUID) ([]*model.GeneratedSDResultShare, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":   "sdtrUc.FindShares",
+		"testID": testID,
+	})
+
+	if _, cerr := uc.findShareableTest(ctx, testID); cerr.Type != nil {
+		return nil, cerr
+	}
+
+	shares, err := uc.sdrsRepo.FindByTestID(ctx, testID)
+	if err != nil {
+		logger.WithError(err).Error("failed to find sd test result shares")
+		return nil, &common.Error{
+			Message: "failed to find sd test result shares",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	res := []*model.GeneratedSDResultShare{}
+	for _, share := range shares {
+		res = append(res, share.ToRESTResponse(""))
+	}
+
+	return res, nilErr
+}
+
+func (uc *sdtrUc) RevokeShare(ctx context.Context, input *model.RevokeSDResultShareInput) (*model.GeneratedSDResultShare, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdtrUc.RevokeShare",
+		"input": helper.Dump(input),
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to revoke sd test result share: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidSDResultShareInput,
+		}
+	}
+
+	if _, cerr := uc.findShareableTest(ctx, input.TestID); cerr.Type != nil {
+		return nil, cerr
+	}
+
+	share, err := uc.sdrsRepo.FindByID(ctx, input.ShareID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find sd test result share")
+		return nil, &common.Error{
+			Message: "failed to find sd test result share",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd test result share not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	// the share of other test is treated as not found, thus the owner of a test can't guess the share of other tests
+	if share.TestID != input.TestID {
+		return nil, &common.Error{
+			Message: "sd test result share not found",
+			Cause:   repository.ErrNotFound,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	}
+
+	if share.RevokedAt.Valid {
+		return share.ToRESTResponse(""), nilErr
+	}
+
+	now := time.Now().UTC()
+	share.RevokedAt = null.TimeFrom(now)
+	share.UpdatedAt = now
+	if err := uc.sdrsRepo.Update(ctx, share); err != nil {
+		logger.WithError(err).Error("failed to revoke sd test result share")
+		return nil, &common.Error{
+			Message: "failed to revoke sd test result share",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	}
+
+	return share.ToRESTResponse(""), nilErr
+}
+
+func (uc *sdtrUc) ViewSharedResultImage(ctx context.Context, input *model.ViewSharedSDTestResultInput) (*model.ImageResult, *common.Error) {
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to view shared sd test result: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidSDResultShareInput,
+		}
+	}
+
+	share, testRes, cerr := uc.resolveShare(ctx, input.Token, model.SDResultShareScopeImage)
+	if cerr.Type != nil {
+		return nil, cerr
+	}
+
+	res, cerr := uc.generateResult(ctx, testRes, input.Format, input.Width, input.DPI)
+	if cerr.Type != nil {
+		return nil, cerr
+	}
+
+	if cerr := uc.recordShareView(ctx, share, model.SDResultShareScopeImage, input); cerr.Type != nil {
+		return nil, cerr
+	}
+
+	return res, nilErr
+}
+
+func (uc *sdtrUc) ViewSharedResult(ctx context.Context, input *model.ViewSharedSDTestResultInput) (*model.SharedSDTestResultOutput, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func": "sdtrUc.ViewSharedResult",
+	})
+
+	if err := input.Validate(); err != nil {
+		return nil, &common.Error{
+			Message: fmt.Sprintf("invalid input to view shared sd test result: %s", err.Error()),
+			Cause:   err,
+			Code:    http.StatusBadRequest,
+			Type:    ErrInvalidSDResultShareInput,
+		}
+	}
+
+	share, testRes, cerr := uc.resolveShare(ctx, input.Token, model.SDResultShareScopeFull)
+	if cerr.Type != nil {
+		return nil, cerr
+	}
+
+	pack, cerr := uc.findTestPackage(ctx, testRes)
+	if cerr.Type != nil {
+		logger.WithError(cerr.Cause).Error("failed to find sd package of the test: ", cerr.Message)
+		return nil, cerr
+	}
+
+	tem, cerr := uc.findTestTemplate(ctx, testRes)
+	if cerr.Type != nil {
+		logger.WithError(cerr.Cause).Error("failed to find sd template of the test: ", cerr.Message)
+		return nil, cerr
+	}
+
+	// tests submitted before the interpretation is stored on the result are interpreted using the current template
+	if testRes.Result.Interpretation == nil {
+		tem.Template.Interpret(&testRes.Result)
+	}
+
+	if cerr := uc.recordShareView(ctx, share, model.SDResultShareScopeFull, input); cerr.Type != nil {
+		return nil, cerr
+	}
+
+	locale := model.GetLocaleFromCtx(ctx)
+	return &model.SharedSDTestResultOutput{
+		TestID:         testRes.ID,
+		PackageName:    pack.Name,
+		PackageVersion: testRes.PackageVersion,
+		Answer:         testRes.Answer,
+		Result:         tem.Template.TranslateResult(testRes.Result, locale),
+		TestQuestions:  pack.Package.RenderOrderedTestQuestions(locale, testRes.QuestionOrder),
+		FinishedAt:     testRes.FinishedAt.Time.UTC(),
+		ShareExpiredAt: share.ExpiredAt,
+	}, nilErr
+}
+
+// findShareableTest find the test to manage its shares. Only the owner of the test and admin are allowed,
+// thus the test without owner can't be shared, but it's result is already public anyway
+func (uc *sdtrUc) findShareableTest(ctx context.Context, testID uuid.UUID) (*model.SDTest, *common.Error) {
+	testRes, err := uc.sdtrRepo.FindByID(ctx, testID)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).Error("failed to find sd test result by id")
+		return nil, &common.Error{
+			Message: "failed to find sd test result",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, &common.Error{
+			Message: "sd test result not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	requester := model.GetUserFromCtx(ctx)
+	if !testRes.UserID.Valid || requester == nil || (!requester.IsAdmin() && testRes.UserID.UUID != requester.UserID) {
+		return nil, &common.Error{
+			Message: "forbidden to manage the share of other people sd test result",
+			Cause:   errors.New("forbidden to manage the share of other people sd test result"),
+			Code:    http.StatusForbidden,
+			Type:    ErrForbiddenToShareSDTestResult,
+		}
+	}
+
+	return testRes, nilErr
+}
+
+// resolveShare find the share and its test using the plain token. The share must still be viewable and allowing the scope
+func (uc *sdtrUc) resolveShare(ctx context.Context, token string, scope model.SDResultShareScope) (*model.SDResultShare, *model.SDTest, *common.Error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "sdtrUc.resolveShare",
+		"scope": scope,
+	})
+
+	share, err := uc.sdrsRepo.FindByToken(ctx, uc.sharedCryptor.ReverseSecureToken(token))
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find sd test result share by token")
+		return nil, nil, &common.Error{
+			Message: "failed to find sd test result share",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, nil, &common.Error{
+			Message: "sd test result share not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		break
+	}
+
+	if !share.IsViewable(time.Now()) {
+		return nil, nil, errSDResultShareUnavailable()
+	}
+
+	if !share.Scope.Allow(scope) {
+		return nil, nil, &common.Error{
+			Message: "sd test result share doesn't allow to view the full answers",
+			Cause:   errors.New("sd test result share scope not allowed"),
+			Code:    http.StatusForbidden,
+			Type:    ErrSDResultShareScopeNotAllowed,
+		}
+	}
+
+	testRes, err := uc.sdtrRepo.FindByID(ctx, share.TestID)
+	switch err {
+	default:
+		logger.WithError(err).Error("failed to find sd test result by id")
+		return nil, nil, &common.Error{
+			Message: "failed to find sd test result",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return nil, nil, &common.Error{
+			Message: "sd test result not found",
+			Cause:   err,
+			Code:    http.StatusNotFound,
+			Type:    ErrResourceNotFound,
+		}
+	case nil:
+		return share, testRes, nilErr
+	}
+}
+
+// recordShareView is called after the result is ready to be returned, thus only the served view is counted.
+// The share may become unavailable meanwhile by a concurrent view reaching the max views
+func (uc *sdtrUc) recordShareView(ctx context.Context, share *model.SDResultShare, scope model.SDResultShareScope, input *model.ViewSharedSDTestResultInput) *common.Error {
+	view := &model.SDResultShareView{
+		ID:        uuid.New(),
+		ShareID:   share.ID,
+		Scope:     scope,
+		IPAddress: input.IPAddress,
+		UserAgent: input.UserAgent,
+		ViewedAt:  time.Now().UTC(),
+	}
+
+	err := uc.sdrsRepo.RecordView(ctx, view)
+	switch err {
+	default:
+		logrus.WithContext(ctx).WithError(err).Error("failed to record sd test result share view")
+		return &common.Error{
+			Message: "failed to record sd test result share view",
+			Cause:   err,
+			Code:    http.StatusInternalServerError,
+			Type:    ErrInternal,
+		}
+	case repository.ErrNotFound:
+		return errSDResultShareUnavailable()
+	case nil:
+		return nilErr
+	}
+}
+
+func errSDResultShareUnavailable() *common.Error {
+	return &common.Error{
+		Message: "sd test result share is already revoked, expired or reaching the max views",
+		Cause:   errors.New("sd test result share unavailable"),
+		Code:    http.StatusGone,
+		Type:    ErrSDResultShareUnavailable,
+	}
+}
@@ -0,0 +1,718 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image/png"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	commonMock "github.com/luckyAkbar/atec-api/internal/common/mock"
+	"github.com/luckyAkbar/atec-api/internal/model"
+	"github.com/luckyAkbar/atec-api/internal/model/mock"
+	"github.com/luckyAkbar/atec-api/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestSDTestUsecase_CreateShare(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
+	sdrsRepo := mock.NewMockSDResultShareRepository(kit.Ctrl)
+	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+
+	uc := NewSDTestResultUsecase(sdtrRepo, nil, nil, nil, nil, sdrsRepo, nil, sharedCryptor, nil, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	tid := uuid.New()
+	owner := model.AuthUser{UserID: uuid.New(), Role: model.RoleUser}
+	ownerCtx := model.SetUserToCtx(ctx, owner)
+	otherCtx := model.SetUserToCtx(ctx, model.AuthUser{UserID: uuid.New(), Role: model.RoleUser})
+	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{UserID: uuid.New(), Role: model.RoleAdmin})
+
+	finishedTest := &model.SDTest{
+		ID:         tid,
+		UserID:     uuid.NullUUID{UUID: owner.UserID, Valid: true},
+		FinishedAt: null.NewTime(time.Now().UTC(), true),
+	}
+	input := &model.CreateSDResultShareInput{
+		TestID:    tid,
+		Scope:     model.SDResultShareScopeImage,
+		ExpiredAt: time.Now().Add(24 * time.Hour),
+		MaxViews:  null.IntFrom(5),
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid input",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.CreateShare(ownerCtx, &model.CreateSDResultShareInput{
+					TestID:    tid,
+					Scope:     model.SDResultShareScopeImage,
+					ExpiredAt: time.Now().Add(model.MaxSDResultShareDuration + time.Hour),
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInvalidSDResultShareInput)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "test not found",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.CreateShare(ownerCtx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "failed to find test",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.CreateShare(ownerCtx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "not the owner of the test",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(otherCtx, tid).Times(1).Return(finishedTest, nil)
+			},
+			Run: func() {
+				_, cerr := uc.CreateShare(otherCtx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenToShareSDTestResult)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "test has no owner",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(adminCtx, tid).Times(1).Return(&model.SDTest{
+					ID:         tid,
+					FinishedAt: null.NewTime(time.Now().UTC(), true),
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.CreateShare(adminCtx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenToShareSDTestResult)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "test is still not answered",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(&model.SDTest{
+					ID:     tid,
+					UserID: uuid.NullUUID{UUID: owner.UserID, Valid: true},
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.CreateShare(ownerCtx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenToShareSDTestResult)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "failed to create token",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(finishedTest, nil)
+				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("", "", errors.New("err token"))
+			},
+			Run: func() {
+				_, cerr := uc.CreateShare(ownerCtx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "failed to save the share",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(finishedTest, nil)
+				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "enc", nil)
+				sdrsRepo.EXPECT().Create(ownerCtx, gomock.Any()).Times(1).Return(errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.CreateShare(ownerCtx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(finishedTest, nil)
+				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "enc", nil)
+				sdrsRepo.EXPECT().Create(ownerCtx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, share *model.SDResultShare) error {
+					assert.Equal(t, share.TestID, tid)
+					assert.Equal(t, share.Token, "enc")
+					assert.Equal(t, share.Scope, input.Scope)
+					assert.Equal(t, share.MaxViews, input.MaxViews)
+					assert.Equal(t, share.CreatedBy, owner.UserID)
+					return nil
+				})
+			},
+			Run: func() {
+				res, cerr := uc.CreateShare(ownerCtx, input)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.Token, "plain")
+				assert.True(t, res.IsViewable)
+			},
+		},
+		{
+			Name: "ok by admin",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(adminCtx, tid).Times(1).Return(finishedTest, nil)
+				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "enc", nil)
+				sdrsRepo.EXPECT().Create(adminCtx, gomock.Any()).Times(1).Return(nil)
+			},
+			Run: func() {
+				res, cerr := uc.CreateShare(adminCtx, input)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.Token, "plain")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestUsecase_FindShares(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
+	sdrsRepo := mock.NewMockSDResultShareRepository(kit.Ctrl)
+
+	uc := NewSDTestResultUsecase(sdtrRepo, nil, nil, nil, nil, sdrsRepo, nil, nil, nil, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	tid := uuid.New()
+	owner := model.AuthUser{UserID: uuid.New(), Role: model.RoleUser}
+	ownerCtx := model.SetUserToCtx(ctx, owner)
+	test := &model.SDTest{
+		ID:     tid,
+		UserID: uuid.NullUUID{UUID: owner.UserID, Valid: true},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "not the owner of the test",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(test, nil)
+			},
+			Run: func() {
+				_, cerr := uc.FindShares(ctx, tid)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenToShareSDTestResult)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "failed to find shares",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(test, nil)
+				sdrsRepo.EXPECT().FindByTestID(ownerCtx, tid).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.FindShares(ownerCtx, tid)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok without token",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(test, nil)
+				sdrsRepo.EXPECT().FindByTestID(ownerCtx, tid).Times(1).Return([]*model.SDResultShare{
+					{ID: uuid.New(), TestID: tid, Token: "enc", ExpiredAt: time.Now().Add(time.Hour)},
+					{ID: uuid.New(), TestID: tid, Token: "enc", ExpiredAt: time.Now().Add(-time.Hour)},
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.FindShares(ownerCtx, tid)
+				assert.NoError(t, cerr.Type)
+				assert.Len(t, res, 2)
+				assert.Equal(t, res[0].Token, "")
+				assert.True(t, res[0].IsViewable)
+				assert.False(t, res[1].IsViewable)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestUsecase_RevokeShare(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
+	sdrsRepo := mock.NewMockSDResultShareRepository(kit.Ctrl)
+
+	uc := NewSDTestResultUsecase(sdtrRepo, nil, nil, nil, nil, sdrsRepo, nil, nil, nil, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	tid := uuid.New()
+	sid := uuid.New()
+	owner := model.AuthUser{UserID: uuid.New(), Role: model.RoleUser}
+	ownerCtx := model.SetUserToCtx(ctx, owner)
+	test := &model.SDTest{
+		ID:     tid,
+		UserID: uuid.NullUUID{UUID: owner.UserID, Valid: true},
+	}
+	input := &model.RevokeSDResultShareInput{TestID: tid, ShareID: sid}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid input",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.RevokeShare(ownerCtx, &model.RevokeSDResultShareInput{TestID: tid})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInvalidSDResultShareInput)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "share not found",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(test, nil)
+				sdrsRepo.EXPECT().FindByID(ownerCtx, sid).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.RevokeShare(ownerCtx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "share belongs to other test",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(test, nil)
+				sdrsRepo.EXPECT().FindByID(ownerCtx, sid).Times(1).Return(&model.SDResultShare{ID: sid, TestID: uuid.New()}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.RevokeShare(ownerCtx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "already revoked",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(test, nil)
+				sdrsRepo.EXPECT().FindByID(ownerCtx, sid).Times(1).Return(&model.SDResultShare{
+					ID:        sid,
+					TestID:    tid,
+					RevokedAt: null.TimeFrom(time.Now().UTC()),
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.RevokeShare(ownerCtx, input)
+				assert.NoError(t, cerr.Type)
+				assert.True(t, res.RevokedAt.Valid)
+			},
+		},
+		{
+			Name: "failed to update",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(test, nil)
+				sdrsRepo.EXPECT().FindByID(ownerCtx, sid).Times(1).Return(&model.SDResultShare{ID: sid, TestID: tid}, nil)
+				sdrsRepo.EXPECT().Update(ownerCtx, gomock.Any()).Times(1).Return(errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.RevokeShare(ownerCtx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(test, nil)
+				sdrsRepo.EXPECT().FindByID(ownerCtx, sid).Times(1).Return(&model.SDResultShare{
+					ID:        sid,
+					TestID:    tid,
+					ExpiredAt: time.Now().Add(time.Hour),
+				}, nil)
+				sdrsRepo.EXPECT().Update(ownerCtx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, share *model.SDResultShare) error {
+					assert.True(t, share.RevokedAt.Valid)
+					return nil
+				})
+			},
+			Run: func() {
+				res, cerr := uc.RevokeShare(ownerCtx, input)
+				assert.NoError(t, cerr.Type)
+				assert.True(t, res.RevokedAt.Valid)
+				assert.False(t, res.IsViewable)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestUsecase_ViewSharedResultImage(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
+	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	sdtRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	sdrsRepo := mock.NewMockSDResultShareRepository(kit.Ctrl)
+	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+	signer := commonMock.NewMockSigner(kit.Ctrl)
+
+	fontBytes, err := os.ReadFile("../../assets/font.ttf")
+	assert.NoError(t, err)
+	f, err := truetype.Parse(fontBytes)
+	assert.NoError(t, err)
+
+	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, nil, nil, sdrsRepo, nil, sharedCryptor, signer, nil, nil, nil, f)
+
+	ctx := context.Background()
+	tid := uuid.New()
+	pid := uuid.New()
+	share := &model.SDResultShare{
+		ID:        uuid.New(),
+		TestID:    tid,
+		Scope:     model.SDResultShareScopeImage,
+		ExpiredAt: time.Now().Add(time.Hour),
+	}
+	test := &model.SDTest{
+		ID:         tid,
+		PackageID:  pid,
+		FinishedAt: null.NewTime(time.Now().UTC(), true),
+		Result: model.SDTestResult{
+			Result:         []model.SDTestGroupResult{{GroupName: "group", Result: 5}},
+			Total:          5,
+			Interpretation: &model.SDTestInterpretation{IsPositive: true, IndicationText: "indicated"},
+		},
+	}
+	template := &model.SpeechDelayTemplate{
+		Type:     model.TestTypeSpeechDelay,
+		Template: &model.SDTemplate{IndicationThreshold: 5},
+	}
+	input := &model.ViewSharedSDTestResultInput{
+		Token:     "plain",
+		Format:    model.SDResultFormatPNG,
+		IPAddress: "127.0.0.1",
+		UserAgent: "agent",
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "missing token",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.ViewSharedResultImage(ctx, &model.ViewSharedSDTestResultInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInvalidSDResultShareInput)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "share not found",
+			MockFn: func() {
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdrsRepo.EXPECT().FindByToken(ctx, "enc").Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.ViewSharedResultImage(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "share is expired",
+			MockFn: func() {
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdrsRepo.EXPECT().FindByToken(ctx, "enc").Times(1).Return(&model.SDResultShare{
+					ID:        share.ID,
+					TestID:    tid,
+					Scope:     model.SDResultShareScopeImage,
+					ExpiredAt: time.Now().Add(-time.Minute),
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.ViewSharedResultImage(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDResultShareUnavailable)
+				assert.Equal(t, cerr.Code, http.StatusGone)
+			},
+		},
+		{
+			Name: "share is reaching max views",
+			MockFn: func() {
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdrsRepo.EXPECT().FindByToken(ctx, "enc").Times(1).Return(&model.SDResultShare{
+					ID:        share.ID,
+					TestID:    tid,
+					Scope:     model.SDResultShareScopeImage,
+					ExpiredAt: time.Now().Add(time.Hour),
+					MaxViews:  null.IntFrom(1),
+					ViewCount: 1,
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.ViewSharedResultImage(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDResultShareUnavailable)
+				assert.Equal(t, cerr.Code, http.StatusGone)
+			},
+		},
+		{
+			Name: "view is not recorded because the share became unavailable",
+			MockFn: func() {
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdrsRepo.EXPECT().FindByToken(ctx, "enc").Times(1).Return(share, nil)
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(test, nil)
+				sdtRepo.EXPECT().FindByID(ctx, gomock.Any(), false).Times(1).Return(template, nil)
+				signer.EXPECT().Sign(model.SDResultSignatureMessage(tid, 5)).Times(1).Return([]byte("signature"), nil)
+				sdrsRepo.EXPECT().RecordView(ctx, gomock.Any()).Times(1).Return(repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.ViewSharedResultImage(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDResultShareUnavailable)
+				assert.Equal(t, cerr.Code, http.StatusGone)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdrsRepo.EXPECT().FindByToken(ctx, "enc").Times(1).Return(share, nil)
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(test, nil)
+				sdtRepo.EXPECT().FindByID(ctx, gomock.Any(), false).Times(1).Return(template, nil)
+				signer.EXPECT().Sign(model.SDResultSignatureMessage(tid, 5)).Times(1).Return([]byte("signature"), nil)
+				sdrsRepo.EXPECT().RecordView(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, view *model.SDResultShareView) error {
+					assert.Equal(t, view.ShareID, share.ID)
+					assert.Equal(t, view.Scope, model.SDResultShareScopeImage)
+					assert.Equal(t, view.IPAddress, "127.0.0.1")
+					assert.Equal(t, view.UserAgent, "agent")
+					return nil
+				})
+			},
+			Run: func() {
+				res, cerr := uc.ViewSharedResultImage(ctx, input)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.ContentType, model.SDResultFormatPNG.ContentType())
+
+				_, err := png.Decode(bytes.NewReader(res.Buffer.Bytes()))
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestUsecase_ViewSharedResult(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
+	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	sdtRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	sdrsRepo := mock.NewMockSDResultShareRepository(kit.Ctrl)
+	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+
+	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, nil, nil, sdrsRepo, nil, sharedCryptor, nil, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	tid := uuid.New()
+	pid := uuid.New()
+	share := &model.SDResultShare{
+		ID:        uuid.New(),
+		TestID:    tid,
+		Scope:     model.SDResultShareScopeFull,
+		ExpiredAt: time.Now().Add(time.Hour),
+	}
+	test := &model.SDTest{
+		ID:         tid,
+		PackageID:  pid,
+		FinishedAt: null.NewTime(time.Now().UTC(), true),
+		Result: model.SDTestResult{
+			Result: []model.SDTestGroupResult{{GroupName: "group", Result: 5}},
+			Total:  5,
+		},
+	}
+	pack := &model.SpeechDelayPackage{
+		ID:      pid,
+		Name:    "package",
+		Package: &model.SDPackage{},
+	}
+	template := &model.SpeechDelayTemplate{
+		Type: model.TestTypeSpeechDelay,
+		Template: &model.SDTemplate{
+			IndicationThreshold:    5,
+			PositiveIndiationText:  "positive",
+			NegativeIndicationText: "negative",
+		},
+	}
+	input := &model.ViewSharedSDTestResultInput{Token: "plain"}
+
+	tests := []common.TestStructure{
+		{
+			Name: "share scope is only image",
+			MockFn: func() {
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdrsRepo.EXPECT().FindByToken(ctx, "enc").Times(1).Return(&model.SDResultShare{
+					ID:        share.ID,
+					TestID:    tid,
+					Scope:     model.SDResultShareScopeImage,
+					ExpiredAt: time.Now().Add(time.Hour),
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.ViewSharedResult(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDResultShareScopeNotAllowed)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "share is revoked",
+			MockFn: func() {
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdrsRepo.EXPECT().FindByToken(ctx, "enc").Times(1).Return(&model.SDResultShare{
+					ID:        share.ID,
+					TestID:    tid,
+					Scope:     model.SDResultShareScopeFull,
+					ExpiredAt: time.Now().Add(time.Hour),
+					RevokedAt: null.TimeFrom(time.Now().UTC()),
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.ViewSharedResult(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDResultShareUnavailable)
+				assert.Equal(t, cerr.Code, http.StatusGone)
+			},
+		},
+		{
+			Name: "failed to find share",
+			MockFn: func() {
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdrsRepo.EXPECT().FindByToken(ctx, "enc").Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.ViewSharedResult(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "failed to find package",
+			MockFn: func() {
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdrsRepo.EXPECT().FindByToken(ctx, "enc").Times(1).Return(share, nil)
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(test, nil)
+				sdpRepo.EXPECT().FindByID(ctx, pid, false).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.ViewSharedResult(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "failed to record view",
+			MockFn: func() {
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdrsRepo.EXPECT().FindByToken(ctx, "enc").Times(1).Return(share, nil)
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(test, nil)
+				sdpRepo.EXPECT().FindByID(ctx, pid, false).Times(1).Return(pack, nil)
+				sdtRepo.EXPECT().FindByID(ctx, gomock.Any(), false).Times(1).Return(template, nil)
+				sdrsRepo.EXPECT().RecordView(ctx, gomock.Any()).Times(1).Return(errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.ViewSharedResult(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdrsRepo.EXPECT().FindByToken(ctx, "enc").Times(1).Return(share, nil)
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(test, nil)
+				sdpRepo.EXPECT().FindByID(ctx, pid, false).Times(1).Return(pack, nil)
+				sdtRepo.EXPECT().FindByID(ctx, gomock.Any(), false).Times(1).Return(template, nil)
+				sdrsRepo.EXPECT().RecordView(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, view *model.SDResultShareView) error {
+					assert.Equal(t, view.ShareID, share.ID)
+					assert.Equal(t, view.Scope, model.SDResultShareScopeFull)
+					return nil
+				})
+			},
+			Run: func() {
+				res, cerr := uc.ViewSharedResult(ctx, input)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.TestID, tid)
+				assert.Equal(t, res.PackageName, "package")
+				assert.Equal(t, res.ShareExpiredAt, share.ExpiredAt)
+				assert.NotNil(t, res.Result.Interpretation)
+				assert.True(t, res.Result.Interpretation.IsPositive)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
@@ -1,14 +1,20 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"image/png"
 	"net/http"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/golang/freetype/truetype"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
 	"github.com/luckyAkbar/atec-api/internal/common"
 	commonMock "github.com/luckyAkbar/atec-api/internal/common/mock"
 	"github.com/luckyAkbar/atec-api/internal/model"
@@ -16,6 +22,7 @@ import (
 	"github.com/luckyAkbar/atec-api/internal/repository"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/guregu/null.v4"
+	"gorm.io/gorm"
 )
 
 func TestSDTestUsecase_Initiate(t *testing.T) {
@@ -24,6 +31,9 @@ func TestSDTestUsecase_Initiate(t *testing.T) {
 
 	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
 	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	sdtRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	sdaRepo := mock.NewMockSDAssignmentRepository(kit.Ctrl)
+	cpRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
 	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
 
 	ctx := context.Background()
@@ -32,23 +42,98 @@ func TestSDTestUsecase_Initiate(t *testing.T) {
 
 	inputPackageID := uuid.New()
 	userID := uuid.New()
+	assignmentID := uuid.New()
+	childID := uuid.New()
+	userCtx := model.SetUserToCtx(ctx, model.AuthUser{UserID: userID, Role: model.RoleUser})
+	tem := &model.SpeechDelayTemplate{
+		ID:       uuid.New(),
+		Template: &model.SDTemplate{},
+	}
 	pack := &model.SpeechDelayPackage{
-		ID:       inputPackageID,
-		IsActive: true,
-		IsLocked: true,
-		Package:  &model.SDPackage{},
+		ID:         inputPackageID,
+		TemplateID: tem.ID,
+		IsActive:   true,
+		IsLocked:   true,
+		Package:    &model.SDPackage{},
 	}
 
-	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sharedCryptor, db, nil)
+	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, sdaRepo, cpRepo, nil, nil, sharedCryptor, nil, nil, nil, db, nil)
 
 	tests := []common.TestStructure{
+		{
+			Name: "initiating test for a child without logging in",
+			MockFn: func() {
+				cpRepo.EXPECT().FindByID(ctx, childID).Times(1).Return(&model.ChildProfile{ID: childID, UserID: userID}, nil)
+			},
+			Run: func() {
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
+					ChildID:   uuid.NullUUID{UUID: childID, Valid: true},
+				})
+
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenToAccessChildProfile)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "initiating test for a child using the owner id without logging in",
+			MockFn: func() {
+				cpRepo.EXPECT().FindByID(ctx, childID).Times(1).Return(&model.ChildProfile{ID: childID, UserID: userID}, nil)
+			},
+			Run: func() {
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
+					UserID:    uuid.NullUUID{UUID: userID, Valid: true},
+					ChildID:   uuid.NullUUID{UUID: childID, Valid: true},
+				})
+
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenToAccessChildProfile)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "initiating test for other people child",
+			MockFn: func() {
+				cpRepo.EXPECT().FindByID(userCtx, childID).Times(1).Return(&model.ChildProfile{ID: childID, UserID: uuid.New()}, nil)
+			},
+			Run: func() {
+				_, _, cerr := uc.Initiate(userCtx, &model.InitiateSDTestInput{
+					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
+					UserID:    uuid.NullUUID{UUID: userID, Valid: true},
+					ChildID:   uuid.NullUUID{UUID: childID, Valid: true},
+				})
+
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenToAccessChildProfile)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "initiating test for unknown child",
+			MockFn: func() {
+				cpRepo.EXPECT().FindByID(userCtx, childID).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, _, cerr := uc.Initiate(userCtx, &model.InitiateSDTestInput{
+					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
+					UserID:    uuid.NullUUID{UUID: userID, Valid: true},
+					ChildID:   uuid.NullUUID{UUID: childID, Valid: true},
+				})
+
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
 		{
 			Name: "using defined package id, but repository return not found",
 			MockFn: func() {
 				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(nil, repository.ErrNotFound)
 			},
 			Run: func() {
-				_, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
 					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
 				})
 
@@ -63,7 +148,7 @@ func TestSDTestUsecase_Initiate(t *testing.T) {
 				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(nil, errors.New("err db"))
 			},
 			Run: func() {
-				_, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
 					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
 				})
 
@@ -76,12 +161,13 @@ func TestSDTestUsecase_Initiate(t *testing.T) {
 			Name: "when using defined package id, somehow got inactive package",
 			MockFn: func() {
 				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(&model.SpeechDelayPackage{
-					ID:       inputPackageID,
-					IsActive: false,
+					ID:         inputPackageID,
+					TemplateID: tem.ID,
+					IsActive:   false,
 				}, nil)
 			},
 			Run: func() {
-				_, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
 					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
 				})
 
@@ -94,16 +180,19 @@ func TestSDTestUsecase_Initiate(t *testing.T) {
 			Name: "when using defined package id, failure on locking the package must return internal error",
 			MockFn: func() {
 				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(&model.SpeechDelayPackage{
-					ID:       inputPackageID,
-					IsActive: true,
-					IsLocked: false,
+					ID:         inputPackageID,
+					TemplateID: tem.ID,
+					Package:    &model.SDPackage{},
+					IsActive:   true,
+					IsLocked:   false,
 				}, nil)
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(tem, nil)
 				mockDB.ExpectBegin()
 				sdpRepo.EXPECT().Update(ctx, gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
 				mockDB.ExpectRollback()
 			},
 			Run: func() {
-				_, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
 					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
 				})
 
@@ -116,17 +205,20 @@ func TestSDTestUsecase_Initiate(t *testing.T) {
 			Name: "when using defined package id, success locking but failed when creating submit key must result in internal error",
 			MockFn: func() {
 				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(&model.SpeechDelayPackage{
-					ID:       inputPackageID,
-					IsActive: true,
-					IsLocked: false,
+					ID:         inputPackageID,
+					TemplateID: tem.ID,
+					Package:    &model.SDPackage{},
+					IsActive:   true,
+					IsLocked:   false,
 				}, nil)
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(tem, nil)
 				mockDB.ExpectBegin()
 				sdpRepo.EXPECT().Update(ctx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
 				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("", "", errors.New("err db"))
 				mockDB.ExpectRollback()
 			},
 			Run: func() {
-				_, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
 					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
 				})
 
@@ -139,17 +231,20 @@ func TestSDTestUsecase_Initiate(t *testing.T) {
 			Name: "when using defined package id, no need to lock the package but fails when creating sd test record",
 			MockFn: func() {
 				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(&model.SpeechDelayPackage{
-					ID:       inputPackageID,
-					IsActive: true,
-					IsLocked: true,
+					ID:         inputPackageID,
+					TemplateID: tem.ID,
+					Package:    &model.SDPackage{},
+					IsActive:   true,
+					IsLocked:   true,
 				}, nil)
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(tem, nil)
 				mockDB.ExpectBegin()
 				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "crypted", nil)
 				sdtrRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err"))
 				mockDB.ExpectRollback()
 			},
 			Run: func() {
-				_, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
 					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
 				})
 
@@ -162,18 +257,20 @@ func TestSDTestUsecase_Initiate(t *testing.T) {
 			Name: "when using defined package id: ok",
 			MockFn: func() {
 				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(&model.SpeechDelayPackage{
-					ID:       inputPackageID,
-					Package:  &model.SDPackage{},
-					IsActive: true,
-					IsLocked: true,
+					ID:         inputPackageID,
+					TemplateID: tem.ID,
+					Package:    &model.SDPackage{},
+					IsActive:   true,
+					IsLocked:   true,
 				}, nil)
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(tem, nil)
 				mockDB.ExpectBegin()
 				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "crypted", nil)
 				sdtrRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
 				mockDB.ExpectCommit()
 			},
 			Run: func() {
-				res, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+				res, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
 					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
 				})
 
@@ -182,30 +279,172 @@ func TestSDTestUsecase_Initiate(t *testing.T) {
 				assert.Equal(t, res.SubmitKey, "plain")
 			},
 		},
+		{
+			Name: "when using defined package id: ok, rendered in the requested locale",
+			MockFn: func() {
+				enCtx := model.SetLocaleToCtx(ctx, model.LocaleEnglish)
+				sdpRepo.EXPECT().FindByID(enCtx, inputPackageID, false).Times(1).Return(&model.SpeechDelayPackage{
+					ID:         inputPackageID,
+					TemplateID: tem.ID,
+					Package: &model.SDPackage{
+						SubGroupDetails: []model.SDSubGroupDetail{
+							{
+								Name:             "bicara",
+								NameTranslations: model.Translations{model.LocaleEnglish: "speech"},
+								QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+									{
+										Question:             "bisa bicara?",
+										QuestionTranslations: model.Translations{model.LocaleEnglish: "can speak?"},
+										AnswersAndValue: []model.SDAnswerAndValue{
+											{Text: "ya", Value: 1, TextTranslations: model.Translations{model.LocaleEnglish: "yes"}},
+											{Text: "tidak", Value: 2},
+										},
+									},
+								},
+							},
+						},
+					},
+					IsActive: true,
+					IsLocked: true,
+				}, nil)
+				sdtRepo.EXPECT().FindByID(enCtx, tem.ID, false).Times(1).Return(tem, nil)
+				mockDB.ExpectBegin()
+				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "crypted", nil)
+				sdtrRepo.EXPECT().Create(enCtx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				mockDB.ExpectCommit()
+			},
+			Run: func() {
+				res, _, cerr := uc.Initiate(model.SetLocaleToCtx(ctx, model.LocaleEnglish), &model.InitiateSDTestInput{
+					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.TestQuestion, map[string][]model.SDTestQuestion{
+					"speech": {
+						{
+							Question:      "can speak?",
+							Answers:       []string{"yes", "tidak"},
+							AnswerOptions: []model.SDTestAnswerOption{{Text: "yes"}, {Text: "tidak"}},
+						},
+					},
+				})
+			},
+		},
+		{
+			Name: "when using defined package id, failed to find the template of the package",
+			MockFn: func() {
+				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(pack, nil)
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
+				})
+
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "when using defined package id: ok, rendered in the order stored on the test",
+			MockFn: func() {
+				shuffled := &model.SpeechDelayPackage{
+					ID:         inputPackageID,
+					TemplateID: tem.ID,
+					Package: &model.SDPackage{
+						SubGroupDetails: []model.SDSubGroupDetail{
+							{
+								Name: "satu",
+								QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+									{Question: "a?", AnswersAndValue: []model.SDAnswerAndValue{{Text: "ya", Value: 1}, {Text: "tidak", Value: 2}}},
+									{Question: "b?", AnswersAndValue: []model.SDAnswerAndValue{{Text: "ya", Value: 1}, {Text: "tidak", Value: 2}}},
+								},
+							},
+							{
+								Name: "dua",
+								QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+									{Question: "c?", AnswersAndValue: []model.SDAnswerAndValue{{Text: "ya", Value: 1}, {Text: "tidak", Value: 2}}},
+								},
+							},
+						},
+					},
+					IsActive: true,
+					IsLocked: true,
+				}
+				shuffled.Package.EnsureIDs()
+
+				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(shuffled, nil)
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(&model.SpeechDelayTemplate{
+					Template: &model.SDTemplate{
+						Randomization: &model.SDRandomization{ShuffleGroups: true, ShuffleQuestions: true, ShuffleAnswers: true},
+					},
+				}, nil)
+				mockDB.ExpectBegin()
+				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "crypted", nil)
+				sdtrRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, sdt *model.SDTest, _ *gorm.DB) error {
+					assert.Equal(t, len(sdt.QuestionOrder.Groups), 2)
+					return nil
+				})
+				mockDB.ExpectCommit()
+			},
+			Run: func() {
+				res, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, len(res.TestQuestions), 2)
+
+				questions := map[string]int{}
+				for _, g := range res.TestQuestions {
+					for _, q := range g.Questions {
+						questions[q.Question]++
+						assert.Equal(t, q.GroupID, g.GroupID)
+						assert.ElementsMatch(t, q.Answers, []string{"ya", "tidak"})
+					}
+				}
+				assert.Equal(t, questions, map[string]int{"a?": 1, "b?": 1, "c?": 1})
+			},
+		},
 		{
 			Name: "used by unregistered user must using random active package, when got any error must returning err internal",
 			MockFn: func() {
-				sdpRepo.EXPECT().FindRandomActivePackage(ctx).Times(1).Return(nil, errors.New("err"))
+				sdpRepo.EXPECT().FindActive(ctx, uuid.NullUUID{}).Times(1).Return(nil, errors.New("err"))
 			},
 			Run: func() {
-				_, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{})
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{})
 
 				assert.Error(t, cerr.Type)
 				assert.Equal(t, cerr.Type, ErrInternal)
 				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
 			},
 		},
+		{
+			Name: "no active package at all",
+			MockFn: func() {
+				sdpRepo.EXPECT().FindActive(ctx, uuid.NullUUID{}).Times(1).Return([]*model.SpeechDelayPackage{}, nil)
+			},
+			Run: func() {
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{})
+
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
 		{
 			Name: "used by unregistered user must using random active package: ok",
 			MockFn: func() {
-				sdpRepo.EXPECT().FindRandomActivePackage(ctx).Times(1).Return(pack, nil)
+				sdpRepo.EXPECT().FindActive(ctx, uuid.NullUUID{}).Times(1).Return([]*model.SpeechDelayPackage{pack}, nil)
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(tem, nil)
 				mockDB.ExpectBegin()
 				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "crypted", nil)
 				sdtrRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
 				mockDB.ExpectCommit()
 			},
 			Run: func() {
-				res, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{})
+				res, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{})
 
 				assert.NoError(t, cerr.Type)
 				assert.Equal(t, res.PackageID, inputPackageID)
@@ -213,12 +452,12 @@ func TestSDTestUsecase_Initiate(t *testing.T) {
 			},
 		},
 		{
-			Name: "used by registered user and not specifying any package id: got error from db",
+			Name: "used by registered user and not specifying any package id: failed to find the latest test",
 			MockFn: func() {
-				sdpRepo.EXPECT().FindLeastUsedPackageIDByUserID(ctx, userID).Times(1).Return(uuid.Nil, errors.New("err db"))
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{UserID: uuid.NullUUID{UUID: userID, Valid: true}}).Times(1).Return(nil, errors.New("err db"))
 			},
 			Run: func() {
-				_, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
 					UserID: uuid.NullUUID{UUID: userID, Valid: true},
 				})
 
@@ -228,452 +467,663 @@ func TestSDTestUsecase_Initiate(t *testing.T) {
 			},
 		},
 		{
-			Name: "used by registered user and not specifying any package id: somehow got not found hmz",
+			Name: "used by registered user and not specifying any package id: got error from db",
 			MockFn: func() {
-				sdpRepo.EXPECT().FindLeastUsedPackageIDByUserID(ctx, userID).Times(1).Return(uuid.Nil, repository.ErrNotFound)
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{UserID: uuid.NullUUID{UUID: userID, Valid: true}}).Times(1).Return(nil, repository.ErrNotFound)
+				sdpRepo.EXPECT().FindActive(ctx, uuid.NullUUID{}).Times(1).Return([]*model.SpeechDelayPackage{pack}, nil)
+				sdtrRepo.EXPECT().CountPackageUsage(ctx, userID, []uuid.UUID{inputPackageID}).Times(1).Return(nil, errors.New("err db"))
 			},
 			Run: func() {
-				_, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
 					UserID: uuid.NullUUID{UUID: userID, Valid: true},
 				})
 
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Type, ErrResourceNotFound)
-				assert.Equal(t, cerr.Code, http.StatusNotFound)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
 			},
 		},
 		{
-			Name: "used by registered user and not specifying any package id: but got error when finding by id??",
+			Name: "used by registered user and not specifying any package id: ok using the least used package",
 			MockFn: func() {
-				sdpRepo.EXPECT().FindLeastUsedPackageIDByUserID(ctx, userID).Times(1).Return(inputPackageID, nil)
-				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(nil, errors.New("err db"))
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{UserID: uuid.NullUUID{UUID: userID, Valid: true}}).Times(1).Return(nil, repository.ErrNotFound)
+				sdpRepo.EXPECT().FindActive(ctx, uuid.NullUUID{}).Times(1).Return([]*model.SpeechDelayPackage{{ID: uuid.New()}, pack}, nil)
+				sdtrRepo.EXPECT().CountPackageUsage(ctx, userID, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, _ uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]int, error) {
+					return map[uuid.UUID]int{ids[0]: 2, inputPackageID: 1}, nil
+				})
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(tem, nil)
+				mockDB.ExpectBegin()
+				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "crypted", nil)
+				sdtrRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				mockDB.ExpectCommit()
 			},
 			Run: func() {
-				_, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+				res, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
 					UserID: uuid.NullUUID{UUID: userID, Valid: true},
 				})
 
-				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Type, ErrInternal)
-				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.PackageID, inputPackageID)
+				assert.Equal(t, res.SubmitKey, "plain")
 			},
 		},
 		{
-			Name: "used by registered user and not specifying any package id: ok",
+			Name: "used by registered user and not specifying any package id: ok using the strategy configured by the template of the latest test",
 			MockFn: func() {
-				sdpRepo.EXPECT().FindLeastUsedPackageIDByUserID(ctx, userID).Times(1).Return(inputPackageID, nil)
-				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(pack, nil)
+				latestTemplateID := uuid.New()
+				other := &model.SpeechDelayPackage{ID: uuid.New(), TemplateID: latestTemplateID}
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{UserID: uuid.NullUUID{UUID: userID, Valid: true}}).Times(1).Return(&model.SDTest{
+					PackageID:  other.ID,
+					TemplateID: latestTemplateID,
+				}, nil)
+				sdtRepo.EXPECT().FindByID(ctx, latestTemplateID, false).Times(1).Return(&model.SpeechDelayTemplate{
+					ID:       latestTemplateID,
+					Template: &model.SDTemplate{PackageSelection: model.PackageSelectionRoundRobin},
+				}, nil)
+				sdpRepo.EXPECT().FindActive(ctx, uuid.NullUUID{}).Times(1).Return([]*model.SpeechDelayPackage{other, pack}, nil)
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{PackageIDs: []uuid.UUID{other.ID, inputPackageID}}).Times(1).Return(&model.SDTest{PackageID: other.ID}, nil)
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(tem, nil)
 				mockDB.ExpectBegin()
 				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "crypted", nil)
 				sdtrRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
 				mockDB.ExpectCommit()
 			},
 			Run: func() {
-				res, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+				res, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
 					UserID: uuid.NullUUID{UUID: userID, Valid: true},
 				})
 
 				assert.NoError(t, cerr.Type)
 				assert.Equal(t, res.PackageID, inputPackageID)
-				assert.Equal(t, res.SubmitKey, "plain")
 			},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.Name, func(t *testing.T) {
-			tt.MockFn()
-			tt.Run()
-		})
-	}
-}
-
-func TestSDTestUsecase_Submit(t *testing.T) {
-	kit, closer := common.InitializeRepoTestKit(t)
-	defer closer()
-
-	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
-	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
-	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
-
-	ctx := context.Background()
-	db := kit.DB
-	tid := uuid.New()
-	packID := uuid.New()
-
-	user := model.AuthUser{
-		UserID:      uuid.New(),
-		AccessToken: "token",
-		Role:        model.RoleAdmin,
-	}
-
-	authCtx := model.SetUserToCtx(ctx, user)
-
-	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sharedCryptor, db, nil)
-
-	tests := []common.TestStructure{
 		{
-			Name:   "input invalid",
-			MockFn: func() {},
+			Name: "requested template not found",
+			MockFn: func() {
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(nil, repository.ErrNotFound)
+			},
 			Run: func() {
-				_, cerr := uc.Submit(ctx, &model.SubmitSDTestInput{})
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+					UserID:     uuid.NullUUID{UUID: userID, Valid: true},
+					TemplateID: uuid.NullUUID{UUID: tem.ID, Valid: true},
+				})
+
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Code, http.StatusBadRequest)
-				assert.Equal(t, cerr.Type, ErrInvalidSDTestAnswer)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
 			},
 		},
 		{
-			Name:   "input invalid",
-			MockFn: func() {},
+			Name: "requested template failed to be fetched",
+			MockFn: func() {
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(nil, errors.New("err db"))
+			},
 			Run: func() {
-				_, cerr := uc.Submit(ctx, &model.SubmitSDTestInput{
-					TestID:    tid,
-					SubmitKey: "",
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+					UserID:     uuid.NullUUID{UUID: userID, Valid: true},
+					TemplateID: uuid.NullUUID{UUID: tem.ID, Valid: true},
 				})
+
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Code, http.StatusBadRequest)
-				assert.Equal(t, cerr.Type, ErrInvalidSDTestAnswer)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
 			},
 		},
 		{
-			Name:   "input invalid",
-			MockFn: func() {},
+			Name: "requested package does not belong to the requested template",
+			MockFn: func() {
+				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(&model.SpeechDelayPackage{
+					ID:         inputPackageID,
+					TemplateID: uuid.New(),
+					IsActive:   true,
+				}, nil)
+			},
 			Run: func() {
-				_, cerr := uc.Submit(ctx, &model.SubmitSDTestInput{
-					TestID:    tid,
-					SubmitKey: "valid",
-					Answers:   &model.SDTestAnswer{},
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+					PackageID:  uuid.NullUUID{UUID: inputPackageID, Valid: true},
+					TemplateID: uuid.NullUUID{UUID: tem.ID, Valid: true},
 				})
+
 				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrSDPackageTemplateMismatch)
 				assert.Equal(t, cerr.Code, http.StatusBadRequest)
-				assert.Equal(t, cerr.Type, ErrInvalidSDTestAnswer)
 			},
 		},
 		{
-			Name: "db failed when trying to find sd test result by id",
+			Name: "ok using the strategy configured by the requested template",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, errors.New("err db"))
+				other := &model.SpeechDelayPackage{ID: uuid.New(), TemplateID: tem.ID}
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(&model.SpeechDelayTemplate{
+					ID:       tem.ID,
+					Template: &model.SDTemplate{PackageSelection: model.PackageSelectionRoundRobin},
+				}, nil)
+				sdpRepo.EXPECT().FindActive(ctx, uuid.NullUUID{UUID: tem.ID, Valid: true}).Times(1).Return([]*model.SpeechDelayPackage{other, pack}, nil)
+				sdtrRepo.EXPECT().FindLatest(ctx, &model.FindLatestSDTestInput{PackageIDs: []uuid.UUID{other.ID, inputPackageID}}).Times(1).Return(&model.SDTest{PackageID: other.ID}, nil)
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(tem, nil)
+				mockDB.ExpectBegin()
+				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "crypted", nil)
+				sdtrRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				mockDB.ExpectCommit()
 			},
 			Run: func() {
-				_, cerr := uc.Submit(ctx, &model.SubmitSDTestInput{
-					TestID:    tid,
-					SubmitKey: "valid",
-					Answers: &model.SDTestAnswer{
-						TestAnswers: []*model.TestAnswer{
-							{
-								GroupName: "test",
-								Answers: []model.Answer{
-									{
-										Question: "test",
-										Answer:   "test",
-									},
-								},
-							},
-						},
-					},
+				res, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+					UserID:     uuid.NullUUID{UUID: userID, Valid: true},
+					TemplateID: uuid.NullUUID{UUID: tem.ID, Valid: true},
 				})
-				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
-				assert.Equal(t, cerr.Type, ErrInternal)
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.PackageID, inputPackageID)
 			},
 		},
 		{
-			Name: "test data not found",
+			Name: "failed to find the previous tests to check the retake policy",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, repository.ErrNotFound)
+				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(pack, nil)
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(&model.SpeechDelayTemplate{
+					ID:       tem.ID,
+					Template: &model.SDTemplate{RetakePolicy: &model.SDRetakePolicy{MaxOpenTests: 1}},
+				}, nil)
+				mockDB.ExpectBegin()
+				sdtrRepo.EXPECT().FindRetakeStat(ctx, gomock.Any(), gomock.Not(gomock.Nil())).Times(1).Return(nil, errors.New("err db"))
+				mockDB.ExpectRollback()
 			},
 			Run: func() {
-				_, cerr := uc.Submit(ctx, &model.SubmitSDTestInput{
-					TestID:    tid,
-					SubmitKey: "valid",
-					Answers: &model.SDTestAnswer{
-						TestAnswers: []*model.TestAnswer{
-							{
-								GroupName: "test",
-								Answers: []model.Answer{
-									{
-										Question: "test",
-										Answer:   "test",
-									},
-								},
-							},
-						},
-					},
+				_, notAllowed, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+					UserID:    uuid.NullUUID{UUID: userID, Valid: true},
+					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
 				})
+
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Code, http.StatusNotFound)
-				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Nil(t, notAllowed)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
 			},
 		},
 		{
-			Name: "the submitter id set, but submitted by a non registered user",
+			Name: "retake is not allowed by the template retake policy",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
-					UserID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				sdpRepo.EXPECT().FindByID(userCtx, inputPackageID, false).Times(1).Return(pack, nil)
+				sdtRepo.EXPECT().FindByID(userCtx, tem.ID, false).Times(1).Return(&model.SpeechDelayTemplate{
+					ID:       tem.ID,
+					Template: &model.SDTemplate{RetakePolicy: &model.SDRetakePolicy{MinIntervalMinutes: 60}},
 				}, nil)
+				mockDB.ExpectBegin()
+				sdtrRepo.EXPECT().FindRetakeStat(userCtx, gomock.Any(), gomock.Not(gomock.Nil())).Times(1).DoAndReturn(func(_ context.Context, input *model.SDRetakeStatInput, _ *gorm.DB) (*model.SDRetakeStat, error) {
+					assert.Equal(t, input.UserID, userID)
+					assert.Equal(t, input.TemplateID, pack.TemplateID)
+					assert.Equal(t, input.ChildID.UUID, childID)
+					return &model.SDRetakeStat{
+						LastFinishedAt: null.TimeFrom(time.Now().UTC().Add(-time.Minute * 10)),
+					}, nil
+				})
+				mockDB.ExpectRollback()
+				cpRepo.EXPECT().FindByID(userCtx, childID).Times(1).Return(&model.ChildProfile{ID: childID, UserID: userID}, nil)
 			},
 			Run: func() {
-				_, cerr := uc.Submit(ctx, &model.SubmitSDTestInput{
-					TestID:    tid,
-					SubmitKey: "valid",
-					Answers: &model.SDTestAnswer{
-						TestAnswers: []*model.TestAnswer{
-							{
-								GroupName: "test",
-								Answers: []model.Answer{
-									{
-										Question: "test",
-										Answer:   "test",
-									},
-								},
-							},
-						},
-					},
+				_, notAllowed, cerr := uc.Initiate(userCtx, &model.InitiateSDTestInput{
+					UserID:    uuid.NullUUID{UUID: userID, Valid: true},
+					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
+					ChildID:   uuid.NullUUID{UUID: childID, Valid: true},
 				})
+
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Code, http.StatusForbidden)
-				assert.Equal(t, cerr.Type, ErrForbiddenToSubmitSDTestAnswer)
+				assert.Equal(t, cerr.Type, ErrSDTestRetakeNotAllowed)
+				assert.Equal(t, cerr.Code, http.StatusTooManyRequests)
+				assert.Equal(t, notAllowed.Rule, model.SDRetakeRuleMinInterval)
+				assert.WithinDuration(t, notAllowed.NextAllowedAt, time.Now().UTC().Add(time.Minute*50), time.Minute)
 			},
 		},
 		{
-			Name: "the submitter id set, but submitted by different user",
+			Name: "retake policy of the current template is enforced even though the package uses the older template version",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
-					UserID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				pinned := *pack
+				pinned.TemplateVersion = 1
+				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(&pinned, nil)
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(&model.SpeechDelayTemplate{
+					ID:             tem.ID,
+					CurrentVersion: 2,
+					Template:       &model.SDTemplate{RetakePolicy: &model.SDRetakePolicy{MaxOpenTests: 1}},
+				}, nil)
+				mockDB.ExpectBegin()
+				sdtrRepo.EXPECT().FindRetakeStat(ctx, gomock.Any(), gomock.Not(gomock.Nil())).Times(1).Return(&model.SDRetakeStat{
+					OpenTests:         1,
+					EarliestOpenUntil: null.TimeFrom(time.Now().UTC().Add(time.Minute * 30)),
 				}, nil)
+				mockDB.ExpectRollback()
 			},
 			Run: func() {
-				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
-					TestID:    tid,
-					SubmitKey: "valid",
-					Answers: &model.SDTestAnswer{
-						TestAnswers: []*model.TestAnswer{
-							{
-								GroupName: "test",
-								Answers: []model.Answer{
-									{
-										Question: "test",
-										Answer:   "test",
-									},
-								},
-							},
-						},
-					},
+				_, notAllowed, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+					UserID:    uuid.NullUUID{UUID: userID, Valid: true},
+					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
 				})
+
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Code, http.StatusForbidden)
-				assert.Equal(t, cerr.Type, ErrForbiddenToSubmitSDTestAnswer)
+				assert.Equal(t, cerr.Type, ErrSDTestRetakeNotAllowed)
+				assert.Equal(t, notAllowed.Rule, model.SDRetakeRuleMaxOpenTests)
 			},
 		},
 		{
-			Name: "submit key invalid",
+			Name: "ok when the retake policy is satisfied",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
-					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
-					SubmitKey: "submitkeyenc",
+				sdpRepo.EXPECT().FindByID(ctx, inputPackageID, false).Times(1).Return(pack, nil)
+				sdtRepo.EXPECT().FindByID(ctx, tem.ID, false).Times(1).Return(&model.SpeechDelayTemplate{
+					ID:       tem.ID,
+					Template: &model.SDTemplate{RetakePolicy: &model.SDRetakePolicy{MaxAttempts: 2, AttemptPeriodHours: 24}},
 				}, nil)
-				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("different")
+				mockDB.ExpectBegin()
+				sdtrRepo.EXPECT().FindRetakeStat(ctx, gomock.Any(), gomock.Not(gomock.Nil())).Times(1).Return(&model.SDRetakeStat{Attempts: 1}, nil)
+				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "crypted", nil)
+				sdtrRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, test *model.SDTest, _ *gorm.DB) error {
+					assert.Equal(t, test.TemplateID, tem.ID)
+					return nil
+				})
+				mockDB.ExpectCommit()
 			},
 			Run: func() {
-				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
-					TestID:    tid,
-					SubmitKey: "valid",
-					Answers: &model.SDTestAnswer{
-						TestAnswers: []*model.TestAnswer{
-							{
-								GroupName: "test",
-								Answers: []model.Answer{
-									{
-										Question: "test",
-										Answer:   "test",
-									},
-								},
-							},
-						},
-					},
+				res, notAllowed, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+					UserID:    uuid.NullUUID{UUID: userID, Valid: true},
+					PackageID: uuid.NullUUID{UUID: inputPackageID, Valid: true},
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Nil(t, notAllowed)
+				assert.Equal(t, res.PackageID, inputPackageID)
+			},
+		},
+		{
+			Name: "assignment not found",
+			MockFn: func() {
+				sdaRepo.EXPECT().FindByID(userCtx, assignmentID).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, _, cerr := uc.Initiate(userCtx, &model.InitiateSDTestInput{
+					UserID:       uuid.NullUUID{UUID: userID, Valid: true},
+					AssignmentID: uuid.NullUUID{UUID: assignmentID, Valid: true},
 				})
+
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Code, http.StatusBadRequest)
-				assert.Equal(t, cerr.Type, ErrInvalidSubmitKey)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
 			},
 		},
 		{
-			Name: "test data is already pass the OpenUntil",
+			Name: "db error when finding the assignment",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
-					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
-					SubmitKey: "submitkeyenc",
-					OpenUntil: time.Now().Add(time.Hour * -1).UTC(),
-				}, nil)
-				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdaRepo.EXPECT().FindByID(userCtx, assignmentID).Times(1).Return(nil, errors.New("err db"))
 			},
 			Run: func() {
-				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
-					TestID:    tid,
-					SubmitKey: "valid",
-					Answers: &model.SDTestAnswer{
-						TestAnswers: []*model.TestAnswer{
-							{
-								GroupName: "test",
-								Answers: []model.Answer{
-									{
-										Question: "test",
-										Answer:   "test",
-									},
-								},
-							},
-						},
-					},
+				_, _, cerr := uc.Initiate(userCtx, &model.InitiateSDTestInput{
+					UserID:       uuid.NullUUID{UUID: userID, Valid: true},
+					AssignmentID: uuid.NullUUID{UUID: assignmentID, Valid: true},
 				})
+
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Code, http.StatusForbidden)
-				assert.Equal(t, cerr.Type, ErrForbiddenToSubmitSDTestAnswer)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
 			},
 		},
 		{
-			Name: "test data's FinishedAt is already set",
+			Name: "assignment belongs to other user",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
-					UserID:     uuid.NullUUID{UUID: user.UserID, Valid: true},
-					SubmitKey:  "submitkeyenc",
-					OpenUntil:  time.Now().Add(time.Hour * 1).UTC(),
-					FinishedAt: null.NewTime(time.Now().UTC(), true),
+				sdaRepo.EXPECT().FindByID(userCtx, assignmentID).Times(1).Return(&model.SDAssignment{
+					ID:         assignmentID,
+					AssigneeID: uuid.New(),
+					Status:     model.SDAssignmentStatusAssigned,
 				}, nil)
-				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
 			},
 			Run: func() {
-				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
-					TestID:    tid,
-					SubmitKey: "valid",
-					Answers: &model.SDTestAnswer{
-						TestAnswers: []*model.TestAnswer{
-							{
-								GroupName: "test",
-								Answers: []model.Answer{
-									{
-										Question: "test",
-										Answer:   "test",
-									},
-								},
-							},
-						},
-					},
+				_, _, cerr := uc.Initiate(userCtx, &model.InitiateSDTestInput{
+					UserID:       uuid.NullUUID{UUID: userID, Valid: true},
+					AssignmentID: uuid.NullUUID{UUID: assignmentID, Valid: true},
 				})
+
 				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenToAccessSDAssignment)
 				assert.Equal(t, cerr.Code, http.StatusForbidden)
-				assert.Equal(t, cerr.Type, ErrForbiddenToSubmitSDTestAnswer)
 			},
 		},
 		{
-			Name: "failure on finding package id from db",
+			Name:   "unregistered user can't start from assignment",
+			MockFn: func() {},
+			Run: func() {
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+					AssignmentID: uuid.NullUUID{UUID: assignmentID, Valid: true},
+				})
+
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenToAccessSDAssignment)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name:   "unregistered user using the assignee id can't start from assignment",
+			MockFn: func() {},
+			Run: func() {
+				_, _, cerr := uc.Initiate(ctx, &model.InitiateSDTestInput{
+					UserID:       uuid.NullUUID{UUID: userID, Valid: true},
+					AssignmentID: uuid.NullUUID{UUID: assignmentID, Valid: true},
+				})
+
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenToAccessSDAssignment)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "assignment already cancelled",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
-					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
-					SubmitKey: "submitkeyenc",
-					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
-					PackageID: packID,
+				sdaRepo.EXPECT().FindByID(userCtx, assignmentID).Times(1).Return(&model.SDAssignment{
+					ID:         assignmentID,
+					AssigneeID: userID,
+					Status:     model.SDAssignmentStatusCancelled,
 				}, nil)
-				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
-				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(nil, errors.New("err db"))
 			},
 			Run: func() {
-				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
-					TestID:    tid,
-					SubmitKey: "valid",
-					Answers: &model.SDTestAnswer{
-						TestAnswers: []*model.TestAnswer{
-							{
-								GroupName: "test",
-								Answers: []model.Answer{
-									{
-										Question: "test",
-										Answer:   "test",
-									},
-								},
-							},
-						},
-					},
+				_, _, cerr := uc.Initiate(userCtx, &model.InitiateSDTestInput{
+					UserID:       uuid.NullUUID{UUID: userID, Valid: true},
+					AssignmentID: uuid.NullUUID{UUID: assignmentID, Valid: true},
 				})
+
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
-				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Type, ErrSDAssignmentNotOpen)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
 			},
 		},
 		{
-			Name: "somehow the package was not found",
+			Name: "assigned template has no active package",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
-					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
-					SubmitKey: "submitkeyenc",
-					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
-					PackageID: packID,
+				sdaRepo.EXPECT().FindByID(userCtx, assignmentID).Times(1).Return(&model.SDAssignment{
+					ID:         assignmentID,
+					AssigneeID: userID,
+					TemplateID: uuid.NullUUID{UUID: tem.ID, Valid: true},
+					Status:     model.SDAssignmentStatusAssigned,
 				}, nil)
-				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
-				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(nil, repository.ErrNotFound)
+				sdtRepo.EXPECT().FindByID(userCtx, tem.ID, false).Times(1).Return(tem, nil)
+				sdpRepo.EXPECT().FindActive(userCtx, uuid.NullUUID{UUID: tem.ID, Valid: true}).Times(1).Return([]*model.SpeechDelayPackage{}, nil)
 			},
 			Run: func() {
-				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
-					TestID:    tid,
-					SubmitKey: "valid",
-					Answers: &model.SDTestAnswer{
-						TestAnswers: []*model.TestAnswer{
-							{
-								GroupName: "test",
-								Answers: []model.Answer{
-									{
-										Question: "test",
-										Answer:   "test",
-									},
-								},
-							},
-						},
-					},
+				_, _, cerr := uc.Initiate(userCtx, &model.InitiateSDTestInput{
+					UserID:       uuid.NullUUID{UUID: userID, Valid: true},
+					AssignmentID: uuid.NullUUID{UUID: assignmentID, Valid: true},
 				})
+
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Code, http.StatusNotFound)
 				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
 			},
 		},
-		// error on grading process may not be detailed here. but must be on the model package
 		{
-			Name: "any failure on grading process",
+			Name: "failed to update the assignment",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
-					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
-					SubmitKey: "submitkeyenc",
-					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
-					PackageID: packID,
+				sdaRepo.EXPECT().FindByID(userCtx, assignmentID).Times(1).Return(&model.SDAssignment{
+					ID:         assignmentID,
+					AssigneeID: userID,
+					PackageID:  uuid.NullUUID{UUID: inputPackageID, Valid: true},
+					Status:     model.SDAssignmentStatusAssigned,
 				}, nil)
-				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
-				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(&model.SpeechDelayPackage{
-					Package: &model.SDPackage{
-						PackageName: "testing",
-						TemplateID:  uuid.New(),
-						SubGroupDetails: []model.SDSubGroupDetail{
+				sdpRepo.EXPECT().FindByID(userCtx, inputPackageID, false).Times(1).Return(pack, nil)
+				sdtRepo.EXPECT().FindByID(userCtx, tem.ID, false).Times(1).Return(tem, nil)
+				mockDB.ExpectBegin()
+				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "crypted", nil)
+				sdtrRepo.EXPECT().Create(userCtx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				sdaRepo.EXPECT().Update(userCtx, gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
+				mockDB.ExpectRollback()
+			},
+			Run: func() {
+				_, _, cerr := uc.Initiate(userCtx, &model.InitiateSDTestInput{
+					UserID:       uuid.NullUUID{UUID: userID, Valid: true},
+					AssignmentID: uuid.NullUUID{UUID: assignmentID, Valid: true},
+				})
+
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok starting from the assigned package",
+			MockFn: func() {
+				sdaRepo.EXPECT().FindByID(userCtx, assignmentID).Times(1).Return(&model.SDAssignment{
+					ID:         assignmentID,
+					AssigneeID: userID,
+					PackageID:  uuid.NullUUID{UUID: inputPackageID, Valid: true},
+					Status:     model.SDAssignmentStatusAssigned,
+				}, nil)
+				sdpRepo.EXPECT().FindByID(userCtx, inputPackageID, false).Times(1).Return(pack, nil)
+				sdtRepo.EXPECT().FindByID(userCtx, tem.ID, false).Times(1).Return(tem, nil)
+				mockDB.ExpectBegin()
+				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "crypted", nil)
+				sdtrRepo.EXPECT().Create(userCtx, gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, test *model.SDTest, _ *gorm.DB) error {
+					assert.Equal(t, test.AssignmentID.UUID, assignmentID)
+					return nil
+				})
+				sdaRepo.EXPECT().Update(userCtx, gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, a *model.SDAssignment, _ *gorm.DB) error {
+					assert.Equal(t, a.Status, model.SDAssignmentStatusInProgress)
+					assert.True(t, a.TestID.Valid)
+					return nil
+				})
+				mockDB.ExpectCommit()
+			},
+			Run: func() {
+				res, _, cerr := uc.Initiate(userCtx, &model.InitiateSDTestInput{
+					UserID:       uuid.NullUUID{UUID: userID, Valid: true},
+					AssignmentID: uuid.NullUUID{UUID: assignmentID, Valid: true},
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.PackageID, inputPackageID)
+				assert.Equal(t, res.AssignmentID.UUID, assignmentID)
+			},
+		},
+		{
+			Name: "ok starting from the assigned template",
+			MockFn: func() {
+				sdaRepo.EXPECT().FindByID(userCtx, assignmentID).Times(1).Return(&model.SDAssignment{
+					ID:         assignmentID,
+					AssigneeID: userID,
+					TemplateID: uuid.NullUUID{UUID: tem.ID, Valid: true},
+					Status:     model.SDAssignmentStatusInProgress,
+				}, nil)
+				sdtRepo.EXPECT().FindByID(userCtx, tem.ID, false).Times(1).Return(tem, nil)
+				sdpRepo.EXPECT().FindActive(userCtx, uuid.NullUUID{UUID: tem.ID, Valid: true}).Times(1).Return([]*model.SpeechDelayPackage{pack}, nil)
+				sdtrRepo.EXPECT().CountPackageUsage(userCtx, userID, []uuid.UUID{inputPackageID}).Times(1).Return(map[uuid.UUID]int{}, nil)
+				sdtRepo.EXPECT().FindByID(userCtx, tem.ID, false).Times(1).Return(tem, nil)
+				mockDB.ExpectBegin()
+				sharedCryptor.EXPECT().CreateSecureToken().Times(1).Return("plain", "crypted", nil)
+				sdtrRepo.EXPECT().Create(userCtx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				sdaRepo.EXPECT().Update(userCtx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				mockDB.ExpectCommit()
+			},
+			Run: func() {
+				res, _, cerr := uc.Initiate(userCtx, &model.InitiateSDTestInput{
+					UserID:       uuid.NullUUID{UUID: userID, Valid: true},
+					AssignmentID: uuid.NullUUID{UUID: assignmentID, Valid: true},
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.PackageID, inputPackageID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestUsecase_Submit(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
+	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	sdtRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	sdaRepo := mock.NewMockSDAssignmentRepository(kit.Ctrl)
+	cpRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
+	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+	workerClient := mock.NewMockWorkerClient(kit.Ctrl)
+
+	ctx := context.Background()
+	db := kit.DB
+	mockDB := kit.DBmock
+	tid := uuid.New()
+	packID := uuid.New()
+	assignmentID := uuid.New()
+
+	user := model.AuthUser{
+		UserID:      uuid.New(),
+		AccessToken: "token",
+		Role:        model.RoleAdmin,
+	}
+
+	authCtx := model.SetUserToCtx(ctx, user)
+
+	tem := &model.SpeechDelayTemplate{
+		Template: &model.SDTemplate{
+			IndicationThreshold:    2,
+			PositiveIndiationText:  "positive",
+			NegativeIndicationText: "negative",
+			SubGroupDetails: []model.SDTemplateSubGroupDetail{
+				{
+					Name:                   "test1",
+					QuestionCount:          1,
+					AnswerOptionCount:      2,
+					IndicationThreshold:    2,
+					PositiveIndicationText: "test1 positive",
+					NegativeIndicationText: "test1 negative",
+					SeverityBands: []model.SDSeverityBand{
+						{Name: "mild", MinPoint: 1, MaxPoint: 1, Text: "mild text"},
+						{Name: "severe", MinPoint: 2, MaxPoint: 2, Text: "severe text"},
+					},
+				},
+			},
+			SeverityBands: []model.SDSeverityBand{
+				{Name: "low", MinPoint: 0, MaxPoint: 1, Text: "low text"},
+				{Name: "high", MinPoint: 2, MaxPoint: 4, Text: "high text"},
+			},
+		},
+	}
+
+	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, sdaRepo, cpRepo, nil, nil, sharedCryptor, nil, nil, workerClient, db, nil)
+
+	assignedTest := func() *model.SDTest {
+		return &model.SDTest{
+			ID:           tid,
+			UserID:       uuid.NullUUID{UUID: user.UserID, Valid: true},
+			SubmitKey:    "submitkeyenc",
+			OpenUntil:    time.Now().Add(time.Hour * 1).UTC(),
+			PackageID:    packID,
+			AssignmentID: uuid.NullUUID{UUID: assignmentID, Valid: true},
+		}
+	}
+	assignedPack := &model.SpeechDelayPackage{
+		ID: packID,
+		Package: &model.SDPackage{
+			PackageName: "testing",
+			TemplateID:  uuid.New(),
+			SubGroupDetails: []model.SDSubGroupDetail{
+				{
+					Name: "test1",
+					QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+						{
+							Question: "testing?",
+							AnswersAndValue: []model.SDAnswerAndValue{
+								{Text: "iya", Value: 1},
+								{Text: "nope", Value: 2},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	assignedAnswer := &model.SDTestAnswer{
+		TestAnswers: []*model.TestAnswer{
+			{
+				GroupName: "test1",
+				Answers: []model.Answer{
+					{Question: "testing?", Answer: "iya"},
+				},
+			},
+		},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "input invalid",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Submit(ctx, &model.SubmitSDTestInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+				assert.Equal(t, cerr.Type, ErrInvalidSDTestAnswer)
+			},
+		},
+		{
+			Name:   "input invalid",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Submit(ctx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "",
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+				assert.Equal(t, cerr.Type, ErrInvalidSDTestAnswer)
+			},
+		},
+		{
+			Name:   "input invalid",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Submit(ctx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers:   &model.SDTestAnswer{},
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+				assert.Equal(t, cerr.Type, ErrInvalidSDTestAnswer)
+			},
+		},
+		{
+			Name: "db failed when trying to find sd test result by id",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Submit(ctx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers: &model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
 							{
-								Name: "test1",
-								QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+								GroupName: "test",
+								Answers: []model.Answer{
 									{
-										Question: "testing?",
-										AnswersAndValue: []model.SDAnswerAndValue{
-											{
-												Text:  "iya",
-												Value: 1,
-											},
-											{
-												Text:  "nope",
-												Value: 2,
-											},
-										},
+										Question: "test",
+										Answer:   "test",
 									},
 								},
 							},
 						},
 					},
-				}, nil)
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+				assert.Equal(t, cerr.Type, ErrInternal)
+			},
+		},
+		{
+			Name: "test data not found",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, repository.ErrNotFound)
 			},
 			Run: func() {
-				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+				_, cerr := uc.Submit(ctx, &model.SubmitSDTestInput{
 					TestID:    tid,
 					SubmitKey: "valid",
 					Answers: &model.SDTestAnswer{
@@ -691,48 +1141,46 @@ func TestSDTestUsecase_Submit(t *testing.T) {
 					},
 				})
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Code, http.StatusBadRequest)
-				assert.Equal(t, cerr.Type, ErrInvalidSDTestAnswer)
-				assert.Equal(t, cerr.Message, "test answer are invalid. details: group test1 is not found on answers list")
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
 			},
 		},
 		{
-			Name: "failed updating the sd test data",
+			Name: "the submitter id set, but submitted by a non registered user",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
-					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
-					SubmitKey: "submitkeyenc",
-					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
-					PackageID: packID,
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					UserID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
 				}, nil)
-				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
-				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(&model.SpeechDelayPackage{
-					Package: &model.SDPackage{
-						PackageName: "testing",
-						TemplateID:  uuid.New(),
-						SubGroupDetails: []model.SDSubGroupDetail{
+			},
+			Run: func() {
+				_, cerr := uc.Submit(ctx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers: &model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
 							{
-								Name: "test1",
-								QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+								GroupName: "test",
+								Answers: []model.Answer{
 									{
-										Question: "testing?",
-										AnswersAndValue: []model.SDAnswerAndValue{
-											{
-												Text:  "iya",
-												Value: 1,
-											},
-											{
-												Text:  "nope",
-												Value: 2,
-											},
-										},
+										Question: "test",
+										Answer:   "test",
 									},
 								},
 							},
 						},
 					},
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+				assert.Equal(t, cerr.Type, ErrForbiddenToSubmitSDTestAnswer)
+			},
+		},
+		{
+			Name: "the submitter id set, but submitted by different user",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
+					UserID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
 				}, nil)
-				sdtrRepo.EXPECT().Update(authCtx, gomock.Any(), nil).Times(1).Return(errors.New("err db"))
 			},
 			Run: func() {
 				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
@@ -741,11 +1189,11 @@ func TestSDTestUsecase_Submit(t *testing.T) {
 					Answers: &model.SDTestAnswer{
 						TestAnswers: []*model.TestAnswer{
 							{
-								GroupName: "test1",
+								GroupName: "test",
 								Answers: []model.Answer{
 									{
-										Question: "testing?",
-										Answer:   "iya",
+										Question: "test",
+										Answer:   "test",
 									},
 								},
 							},
@@ -753,30 +1201,200 @@ func TestSDTestUsecase_Submit(t *testing.T) {
 					},
 				})
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
-				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+				assert.Equal(t, cerr.Type, ErrForbiddenToSubmitSDTestAnswer)
 			},
 		},
 		{
-			Name: "ok",
+			Name: "submit key invalid",
 			MockFn: func() {
 				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
 					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
 					SubmitKey: "submitkeyenc",
-					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
-					PackageID: packID,
 				}, nil)
-				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
-				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(&model.SpeechDelayPackage{
-					Package: &model.SDPackage{
-						PackageName: "testing",
-						TemplateID:  uuid.New(),
-						SubGroupDetails: []model.SDSubGroupDetail{
-							{
-								Name: "test1",
-								QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
-									{
-										Question: "testing?",
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("different")
+			},
+			Run: func() {
+				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers: &model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test",
+								Answers: []model.Answer{
+									{
+										Question: "test",
+										Answer:   "test",
+									},
+								},
+							},
+						},
+					},
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+				assert.Equal(t, cerr.Type, ErrInvalidSubmitKey)
+			},
+		},
+		{
+			Name: "test data is already pass the OpenUntil",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
+					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * -1).UTC(),
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+			},
+			Run: func() {
+				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers: &model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test",
+								Answers: []model.Answer{
+									{
+										Question: "test",
+										Answer:   "test",
+									},
+								},
+							},
+						},
+					},
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+				assert.Equal(t, cerr.Type, ErrForbiddenToSubmitSDTestAnswer)
+			},
+		},
+		{
+			Name: "test data's FinishedAt is already set",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
+					UserID:     uuid.NullUUID{UUID: user.UserID, Valid: true},
+					SubmitKey:  "submitkeyenc",
+					OpenUntil:  time.Now().Add(time.Hour * 1).UTC(),
+					FinishedAt: null.NewTime(time.Now().UTC(), true),
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+			},
+			Run: func() {
+				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers: &model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test",
+								Answers: []model.Answer{
+									{
+										Question: "test",
+										Answer:   "test",
+									},
+								},
+							},
+						},
+					},
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+				assert.Equal(t, cerr.Type, ErrForbiddenToSubmitSDTestAnswer)
+			},
+		},
+		{
+			Name: "failure on finding package id from db",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
+					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					PackageID: packID,
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers: &model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test",
+								Answers: []model.Answer{
+									{
+										Question: "test",
+										Answer:   "test",
+									},
+								},
+							},
+						},
+					},
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+				assert.Equal(t, cerr.Type, ErrInternal)
+			},
+		},
+		{
+			Name: "somehow the package was not found",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
+					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					PackageID: packID,
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers: &model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test",
+								Answers: []model.Answer{
+									{
+										Question: "test",
+										Answer:   "test",
+									},
+								},
+							},
+						},
+					},
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+			},
+		},
+		// error on grading process may not be detailed here. but must be on the model package
+		{
+			Name: "any failure on grading process",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
+					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					PackageID: packID,
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(&model.SpeechDelayPackage{
+					Package: &model.SDPackage{
+						PackageName: "testing",
+						TemplateID:  uuid.New(),
+						SubGroupDetails: []model.SDSubGroupDetail{
+							{
+								Name: "test1",
+								QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+									{
+										Question: "testing?",
 										AnswersAndValue: []model.SDAnswerAndValue{
 											{
 												Text:  "iya",
@@ -793,10 +1411,71 @@ func TestSDTestUsecase_Submit(t *testing.T) {
 						},
 					},
 				}, nil)
-				sdtrRepo.EXPECT().Update(authCtx, gomock.Any(), nil).Times(1).Return(nil)
 			},
 			Run: func() {
-				res, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers: &model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test",
+								Answers: []model.Answer{
+									{
+										Question: "test",
+										Answer:   "test",
+									},
+								},
+							},
+						},
+					},
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+				assert.Equal(t, cerr.Type, ErrInvalidSDTestAnswer)
+				assert.Equal(t, cerr.Message, "test answer are invalid. details: group test1 is not found on answers list")
+			},
+		},
+		{
+			Name: "failed finding the sd template",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
+					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					PackageID: packID,
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(&model.SpeechDelayPackage{
+					Package: &model.SDPackage{
+						PackageName: "testing",
+						TemplateID:  uuid.New(),
+						SubGroupDetails: []model.SDSubGroupDetail{
+							{
+								Name: "test1",
+								QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+									{
+										Question: "testing?",
+										AnswersAndValue: []model.SDAnswerAndValue{
+											{
+												Text:  "iya",
+												Value: 1,
+											},
+											{
+												Text:  "nope",
+												Value: 2,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+				sdtRepo.EXPECT().FindByID(authCtx, gomock.Any(), false).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
 					TestID:    tid,
 					SubmitKey: "valid",
 					Answers: &model.SDTestAnswer{
@@ -813,349 +1492,2015 @@ func TestSDTestUsecase_Submit(t *testing.T) {
 						},
 					},
 				})
-				assert.NoError(t, cerr.Type)
-				assert.Equal(t, res.Result.Result[0].GroupName, "test1")
-				assert.Equal(t, res.Result.Result[0].Result, 1)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+				assert.Equal(t, cerr.Type, ErrInternal)
 			},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.Name, func(t *testing.T) {
+		{
+			Name: "failed updating the sd test data",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
+					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					PackageID: packID,
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(&model.SpeechDelayPackage{
+					Package: &model.SDPackage{
+						PackageName: "testing",
+						TemplateID:  uuid.New(),
+						SubGroupDetails: []model.SDSubGroupDetail{
+							{
+								Name: "test1",
+								QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+									{
+										Question: "testing?",
+										AnswersAndValue: []model.SDAnswerAndValue{
+											{
+												Text:  "iya",
+												Value: 1,
+											},
+											{
+												Text:  "nope",
+												Value: 2,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+				sdtRepo.EXPECT().FindByID(authCtx, gomock.Any(), false).Return(tem, nil)
+				sdtrRepo.EXPECT().Update(authCtx, gomock.Any(), nil).Times(1).Return(errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers: &model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test1",
+								Answers: []model.Answer{
+									{
+										Question: "testing?",
+										Answer:   "iya",
+									},
+								},
+							},
+						},
+					},
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+				assert.Equal(t, cerr.Type, ErrInternal)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
+					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					PackageID: packID,
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(&model.SpeechDelayPackage{
+					Package: &model.SDPackage{
+						PackageName: "testing",
+						TemplateID:  uuid.New(),
+						SubGroupDetails: []model.SDSubGroupDetail{
+							{
+								Name: "test1",
+								QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+									{
+										Question: "testing?",
+										AnswersAndValue: []model.SDAnswerAndValue{
+											{
+												Text:  "iya",
+												Value: 1,
+											},
+											{
+												Text:  "nope",
+												Value: 2,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+				sdtRepo.EXPECT().FindByID(authCtx, gomock.Any(), false).Return(tem, nil)
+				sdtrRepo.EXPECT().Update(authCtx, gomock.Any(), nil).Times(1).Return(nil)
+			},
+			Run: func() {
+				res, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers: &model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test1",
+								Answers: []model.Answer{
+									{
+										Question: "testing?",
+										Answer:   "iya",
+									},
+								},
+							},
+						},
+					},
+				})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.Result.Result[0].GroupName, "test1")
+				assert.Equal(t, res.Result.Result[0].Result, 1)
+				assert.Equal(t, res.Result.Result[0].Interpretation, &model.SDTestInterpretation{
+					IsPositive:     false,
+					IndicationText: "test1 negative",
+					Severity:       "mild",
+					SeverityText:   "mild text",
+				})
+				assert.Equal(t, res.Result.Interpretation, &model.SDTestInterpretation{
+					IsPositive:     false,
+					IndicationText: "negative",
+					Severity:       "low",
+					SeverityText:   "low text",
+				})
+			},
+		},
+		{
+			Name: "ok, graded using the package and template version the test was taken on",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
+					UserID:          uuid.NullUUID{UUID: user.UserID, Valid: true},
+					SubmitKey:       "submitkeyenc",
+					OpenUntil:       time.Now().Add(time.Hour * 1).UTC(),
+					PackageID:       packID,
+					PackageVersion:  1,
+					TemplateVersion: 1,
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(&model.SpeechDelayPackage{
+					ID:             packID,
+					CurrentVersion: 2,
+					Package: &model.SDPackage{
+						PackageName: "testing v2",
+						SubGroupDetails: []model.SDSubGroupDetail{
+							{
+								Name: "renamed",
+							},
+						},
+					},
+				}, nil)
+				sdpRepo.EXPECT().FindVersion(authCtx, packID, 1).Times(1).Return(&model.SDPackageVersion{
+					PackageID: packID,
+					Version:   1,
+					Name:      "testing",
+					Package: &model.SDPackage{
+						PackageName: "testing",
+						SubGroupDetails: []model.SDSubGroupDetail{
+							{
+								Name: "test1",
+								QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+									{
+										Question: "testing?",
+										AnswersAndValue: []model.SDAnswerAndValue{
+											{
+												Text:  "iya",
+												Value: 1,
+											},
+											{
+												Text:  "nope",
+												Value: 2,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+				sdtRepo.EXPECT().FindByID(authCtx, gomock.Any(), false).Return(&model.SpeechDelayTemplate{
+					ID:             tem.ID,
+					CurrentVersion: 2,
+					Template:       &model.SDTemplate{},
+				}, nil)
+				sdtRepo.EXPECT().FindVersion(authCtx, tem.ID, 1).Times(1).Return(&model.SDTemplateVersion{
+					TemplateID: tem.ID,
+					Version:    1,
+					Template:   tem.Template,
+				}, nil)
+				sdtrRepo.EXPECT().Update(authCtx, gomock.Any(), nil).Times(1).Return(nil)
+			},
+			Run: func() {
+				res, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers: &model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test1",
+								Answers: []model.Answer{
+									{
+										Question: "testing?",
+										Answer:   "nope",
+									},
+								},
+							},
+						},
+					},
+				})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.PackageName, "testing")
+				assert.Equal(t, res.PackageVersion, 1)
+				assert.Equal(t, res.Result.Result[0].Result, 2)
+				assert.Equal(t, res.Result.Interpretation.Severity, "high")
+			},
+		},
+		{
+			Name: "ok, the saved draft is merged with the submitted answer",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
+					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					PackageID: packID,
+					DraftAnswer: model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test1",
+								Answers: []model.Answer{
+									{
+										Question: "testing?",
+										Answer:   "nope",
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(&model.SpeechDelayPackage{
+					Package: &model.SDPackage{
+						PackageName: "testing",
+						TemplateID:  uuid.New(),
+						SubGroupDetails: []model.SDSubGroupDetail{
+							{
+								Name: "test1",
+								QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+									{
+										Question: "testing?",
+										AnswersAndValue: []model.SDAnswerAndValue{
+											{
+												Text:  "iya",
+												Value: 1,
+											},
+											{
+												Text:  "nope",
+												Value: 2,
+											},
+										},
+									},
+								},
+							},
+							{
+								Name: "test2",
+								QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+									{
+										Question: "testing?",
+										AnswersAndValue: []model.SDAnswerAndValue{
+											{
+												Text:  "iya",
+												Value: 1,
+											},
+											{
+												Text:  "nope",
+												Value: 2,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+				sdtRepo.EXPECT().FindByID(authCtx, gomock.Any(), false).Return(tem, nil)
+				sdtrRepo.EXPECT().Update(authCtx, gomock.Any(), nil).Times(1).Return(nil)
+			},
+			Run: func() {
+				res, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers: &model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test2",
+								Answers: []model.Answer{
+									{
+										Question: "testing?",
+										Answer:   "iya",
+									},
+								},
+							},
+						},
+					},
+				})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.Result.Result[0].GroupName, "test1")
+				assert.Equal(t, res.Result.Result[0].Result, 2)
+				assert.Equal(t, res.Result.Result[1].GroupName, "test2")
+				assert.Equal(t, res.Result.Result[1].Result, 1)
+				assert.Equal(t, res.Result.Total, 3)
+				assert.Equal(t, res.Result.Result[0].Interpretation.Severity, "severe")
+				assert.Nil(t, res.Result.Result[1].Interpretation)
+				assert.Equal(t, res.Result.Interpretation.IsPositive, true)
+				assert.Equal(t, res.Result.Interpretation.IndicationText, "positive")
+				assert.Equal(t, res.Result.Interpretation.Severity, "high")
+			},
+		},
+
+		{
+			Name: "ok, the assignment of the test is completed on the same transaction",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(assignedTest(), nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(assignedPack, nil)
+				sdtRepo.EXPECT().FindByID(authCtx, gomock.Any(), false).Return(tem, nil)
+				sdaRepo.EXPECT().FindByID(authCtx, assignmentID).Times(1).Return(&model.SDAssignment{
+					ID:     assignmentID,
+					Status: model.SDAssignmentStatusInProgress,
+				}, nil)
+				mockDB.ExpectBegin()
+				sdtrRepo.EXPECT().Update(authCtx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				sdaRepo.EXPECT().Update(authCtx, gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, a *model.SDAssignment, _ *gorm.DB) error {
+					assert.Equal(t, a.Status, model.SDAssignmentStatusCompleted)
+					assert.Equal(t, a.TestID.UUID, tid)
+					assert.True(t, a.CompletedAt.Valid)
+					return nil
+				})
+				mockDB.ExpectCommit()
+			},
+			Run: func() {
+				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers:   assignedAnswer,
+				})
+				assert.NoError(t, cerr.Type)
+			},
+		},
+		{
+			Name: "failed to complete the assignment must rollback the submission",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(assignedTest(), nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(assignedPack, nil)
+				sdtRepo.EXPECT().FindByID(authCtx, gomock.Any(), false).Return(tem, nil)
+				sdaRepo.EXPECT().FindByID(authCtx, assignmentID).Times(1).Return(&model.SDAssignment{
+					ID:     assignmentID,
+					Status: model.SDAssignmentStatusInProgress,
+				}, nil)
+				mockDB.ExpectBegin()
+				sdtrRepo.EXPECT().Update(authCtx, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				sdaRepo.EXPECT().Update(authCtx, gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
+				mockDB.ExpectRollback()
+			},
+			Run: func() {
+				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers:   assignedAnswer,
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+				assert.Equal(t, cerr.Type, ErrInternal)
+			},
+		},
+		{
+			Name: "ok, cancelled assignment is left as is",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(assignedTest(), nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(assignedPack, nil)
+				sdtRepo.EXPECT().FindByID(authCtx, gomock.Any(), false).Return(tem, nil)
+				sdaRepo.EXPECT().FindByID(authCtx, assignmentID).Times(1).Return(&model.SDAssignment{
+					ID:     assignmentID,
+					Status: model.SDAssignmentStatusCancelled,
+				}, nil)
+				sdtrRepo.EXPECT().Update(authCtx, gomock.Any(), nil).Times(1).Return(nil)
+			},
+			Run: func() {
+				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers:   assignedAnswer,
+				})
+				assert.NoError(t, cerr.Type)
+			},
+		},
+		{
+			Name: "ok, the result email delivery is enqueued",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(assignedTest(), nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(assignedPack, nil)
+				sdtRepo.EXPECT().FindByID(authCtx, gomock.Any(), false).Return(tem, nil)
+				sdaRepo.EXPECT().FindByID(authCtx, assignmentID).Times(1).Return(&model.SDAssignment{
+					ID:     assignmentID,
+					Status: model.SDAssignmentStatusCancelled,
+				}, nil)
+				sdtrRepo.EXPECT().Update(authCtx, gomock.Any(), nil).Times(1).Return(nil)
+				workerClient.EXPECT().EnqueueDeliverSDTestResultTask(authCtx, tid).Times(1).Return(&asynq.TaskInfo{}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:          tid,
+					SubmitKey:       "valid",
+					Answers:         assignedAnswer,
+					SendResultEmail: true,
+				})
+				assert.NoError(t, cerr.Type)
+			},
+		},
+		{
+			Name: "ok, failed to enqueue the result email delivery is ignored",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(assignedTest(), nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(assignedPack, nil)
+				sdtRepo.EXPECT().FindByID(authCtx, gomock.Any(), false).Return(tem, nil)
+				sdaRepo.EXPECT().FindByID(authCtx, assignmentID).Times(1).Return(&model.SDAssignment{
+					ID:     assignmentID,
+					Status: model.SDAssignmentStatusCancelled,
+				}, nil)
+				sdtrRepo.EXPECT().Update(authCtx, gomock.Any(), nil).Times(1).Return(nil)
+				workerClient.EXPECT().EnqueueDeliverSDTestResultTask(authCtx, tid).Times(1).Return(nil, errors.New("err redis"))
+			},
+			Run: func() {
+				_, cerr := uc.Submit(authCtx, &model.SubmitSDTestInput{
+					TestID:          tid,
+					SubmitKey:       "valid",
+					Answers:         assignedAnswer,
+					SendResultEmail: true,
+				})
+				assert.NoError(t, cerr.Type)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestUsecase_SaveDraft(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
+	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	sdtRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	sdaRepo := mock.NewMockSDAssignmentRepository(kit.Ctrl)
+	cpRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
+	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+
+	ctx := context.Background()
+	db := kit.DB
+	tid := uuid.New()
+	packID := uuid.New()
+
+	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, sdaRepo, cpRepo, nil, nil, sharedCryptor, nil, nil, nil, db, nil)
+
+	pack := &model.SpeechDelayPackage{
+		ID: packID,
+		Package: &model.SDPackage{
+			PackageName: "testing",
+			TemplateID:  uuid.New(),
+			SubGroupDetails: []model.SDSubGroupDetail{
+				{
+					Name: "test1",
+					QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+						{
+							Question: "testing?",
+							AnswersAndValue: []model.SDAnswerAndValue{
+								{
+									Text:  "iya",
+									Value: 1,
+								},
+								{
+									Text:  "nope",
+									Value: 2,
+								},
+							},
+						},
+						{
+							Question: "testing lagi?",
+							AnswersAndValue: []model.SDAnswerAndValue{
+								{
+									Text:  "iya",
+									Value: 1,
+								},
+								{
+									Text:  "nope",
+									Value: 2,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	validInput := &model.SaveSDTestDraftInput{
+		TestID:    tid,
+		SubmitKey: "valid",
+		Answers: &model.SDTestAnswer{
+			TestAnswers: []*model.TestAnswer{
+				{
+					GroupName: "test1",
+					Answers: []model.Answer{
+						{
+							Question: "testing lagi?",
+							Answer:   "nope",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "input invalid",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.SaveDraft(ctx, &model.SaveSDTestDraftInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+				assert.Equal(t, cerr.Type, ErrInvalidSDTestAnswer)
+			},
+		},
+		{
+			Name: "test data not found",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.SaveDraft(ctx, validInput)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+			},
+		},
+		{
+			Name: "submit key invalid",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					PackageID: packID,
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("invalid")
+			},
+			Run: func() {
+				_, cerr := uc.SaveDraft(ctx, validInput)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+				assert.Equal(t, cerr.Type, ErrInvalidSubmitKey)
+			},
+		},
+		{
+			Name: "test data is already finished",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					SubmitKey:  "submitkeyenc",
+					OpenUntil:  time.Now().Add(time.Hour * 1).UTC(),
+					FinishedAt: null.NewTime(time.Now(), true),
+					PackageID:  packID,
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+			},
+			Run: func() {
+				_, cerr := uc.SaveDraft(ctx, validInput)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+				assert.Equal(t, cerr.Type, ErrForbiddenToSubmitSDTestAnswer)
+			},
+		},
+		{
+			Name: "failure on finding package from db",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					PackageID: packID,
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(ctx, packID, false).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.SaveDraft(ctx, validInput)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+				assert.Equal(t, cerr.Type, ErrInternal)
+			},
+		},
+		{
+			Name: "draft answer is not valid against the package",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					PackageID: packID,
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(ctx, packID, false).Times(1).Return(pack, nil)
+			},
+			Run: func() {
+				_, cerr := uc.SaveDraft(ctx, &model.SaveSDTestDraftInput{
+					TestID:    tid,
+					SubmitKey: "valid",
+					Answers: &model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test1",
+								Answers: []model.Answer{
+									{
+										Question: "testing lagi?",
+										Answer:   "mungkin",
+									},
+								},
+							},
+						},
+					},
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+				assert.Equal(t, cerr.Type, ErrInvalidSDTestAnswer)
+			},
+		},
+		{
+			Name: "failed updating the sd test data",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					PackageID: packID,
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(ctx, packID, false).Times(1).Return(pack, nil)
+				sdtrRepo.EXPECT().SaveDraft(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.SaveDraft(ctx, validInput)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+				assert.Equal(t, cerr.Type, ErrInternal)
+			},
+		},
+		{
+			Name: "test is submitted while the draft is being saved",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					ID:        tid,
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					PackageID: packID,
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(ctx, packID, false).Times(1).Return(pack, nil)
+				sdtrRepo.EXPECT().SaveDraft(ctx, tid, gomock.Any(), gomock.Any()).Times(1).Return(repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.SaveDraft(ctx, validInput)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+				assert.Equal(t, cerr.Type, ErrForbiddenToSubmitSDTestAnswer)
+			},
+		},
+		{
+			Name: "ok, merged with the previous draft",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					ID:        tid,
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					PackageID: packID,
+					DraftAnswer: model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test1",
+								Answers: []model.Answer{
+									{
+										Question: "testing?",
+										Answer:   "iya",
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(ctx, packID, false).Times(1).Return(pack, nil)
+				sdtrRepo.EXPECT().SaveDraft(ctx, tid, gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			Run: func() {
+				res, cerr := uc.SaveDraft(ctx, validInput)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.TestID, tid)
+				assert.Equal(t, len(res.Answers.TestAnswers), 1)
+				qnas := pack.Package.SubGroupDetails[0].QuestionAndAnswerLists
+				assert.Equal(t, res.Answers.TestAnswers[0].GroupID, pack.Package.SubGroupDetails[0].ID)
+				assert.Equal(t, res.Answers.TestAnswers[0].Answers, []model.Answer{
+					{
+						Question:   "testing?",
+						Answer:     "iya",
+						QuestionID: qnas[0].ID,
+						AnswerID:   qnas[0].AnswersAndValue[0].ID,
+					},
+					{
+						Question:   "testing lagi?",
+						Answer:     "nope",
+						QuestionID: qnas[1].ID,
+						AnswerID:   qnas[1].AnswersAndValue[1].ID,
+					},
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestUsecase_ViewDraft(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
+	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	sdtRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	sdaRepo := mock.NewMockSDAssignmentRepository(kit.Ctrl)
+	cpRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
+	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+
+	ctx := context.Background()
+	db := kit.DB
+	tid := uuid.New()
+	packID := uuid.New()
+
+	user := model.AuthUser{
+		UserID:      uuid.New(),
+		AccessToken: "token",
+		Role:        model.RoleUser,
+	}
+	authCtx := model.SetUserToCtx(ctx, user)
+
+	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, sdaRepo, cpRepo, nil, nil, sharedCryptor, nil, nil, nil, db, nil)
+
+	input := &model.ViewSDTestDraftInput{
+		TestID:    tid,
+		SubmitKey: "valid",
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name:   "input invalid",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.ViewDraft(ctx, &model.ViewSDTestDraftInput{})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+				assert.Equal(t, cerr.Type, ErrInvalidSDTestAnswer)
+			},
+		},
+		{
+			Name: "db failed when trying to find sd test by id",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.ViewDraft(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+				assert.Equal(t, cerr.Type, ErrInternal)
+			},
+		},
+		{
+			Name: "the owner id set, but requested by a non registered user",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.ViewDraft(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+				assert.Equal(t, cerr.Type, ErrForbiddenToSubmitSDTestAnswer)
+			},
+		},
+		{
+			Name: "failed to find the package of the test",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
+					ID:        tid,
+					PackageID: packID,
+					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.ViewDraft(authCtx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+				assert.Equal(t, cerr.Type, ErrInternal)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				pack := &model.SDPackage{
+					SubGroupDetails: []model.SDSubGroupDetail{
+						{
+							Name: "test1",
+							QuestionAndAnswerLists: []model.SDQuestionAndAnswers{
+								{Question: "a?", AnswersAndValue: []model.SDAnswerAndValue{{Text: "ya", Value: 1}, {Text: "tidak", Value: 2}}},
+								{Question: "b?", AnswersAndValue: []model.SDAnswerAndValue{{Text: "ya", Value: 1}, {Text: "tidak", Value: 2}}},
+							},
+						},
+					},
+				}
+				pack.EnsureIDs()
+
+				g := pack.SubGroupDetails[0]
+				sdtrRepo.EXPECT().FindByID(authCtx, tid).Times(1).Return(&model.SDTest{
+					ID:        tid,
+					PackageID: packID,
+					UserID:    uuid.NullUUID{UUID: user.UserID, Valid: true},
+					SubmitKey: "submitkeyenc",
+					OpenUntil: time.Now().Add(time.Hour * 1).UTC(),
+					DraftAnswer: model.SDTestAnswer{
+						TestAnswers: []*model.TestAnswer{
+							{
+								GroupName: "test1",
+							},
+						},
+					},
+					QuestionOrder: model.SDTestOrder{
+						Groups: []model.SDTestGroupOrder{
+							{
+								GroupID: g.ID,
+								Questions: []model.SDTestQuestionOrder{
+									{
+										QuestionID: g.QuestionAndAnswerLists[1].ID,
+										AnswerIDs:  []uuid.UUID{g.QuestionAndAnswerLists[1].AnswersAndValue[1].ID, g.QuestionAndAnswerLists[1].AnswersAndValue[0].ID},
+									},
+									{
+										QuestionID: g.QuestionAndAnswerLists[0].ID,
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("valid").Times(1).Return("submitkeyenc")
+				sdpRepo.EXPECT().FindByID(authCtx, packID, false).Times(1).Return(&model.SpeechDelayPackage{
+					ID:      packID,
+					Package: pack,
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.ViewDraft(authCtx, input)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.TestID, tid)
+				assert.Equal(t, res.Answers.TestAnswers[0].GroupName, "test1")
+				assert.Equal(t, len(res.TestQuestions), 1)
+				assert.Equal(t, res.TestQuestions[0].Questions[0].Question, "b?")
+				assert.Equal(t, res.TestQuestions[0].Questions[0].Answers, []string{"tidak", "ya"})
+				assert.Equal(t, res.TestQuestions[0].Questions[1].Question, "a?")
+				assert.Equal(t, res.TestQuestions[0].Questions[1].Answers, []string{"ya", "tidak"})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestUsecase_Histories(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
+	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	sdtRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	sdaRepo := mock.NewMockSDAssignmentRepository(kit.Ctrl)
+	cpRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
+	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+
+	ctx := context.Background()
+	uid := uuid.New()
+	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{
+		UserID:      uuid.New(),
+		AccessToken: "token",
+		Role:        model.RoleAdmin,
+	})
+	userCtx := model.SetUserToCtx(ctx, model.AuthUser{
+		UserID:      uid,
+		AccessToken: "token",
+		Role:        model.RoleUser,
+	})
+	db := kit.DB
+	randUserID := uuid.New()
+	tid := uuid.New()
+	pid := uuid.New()
+	now := time.Now().UTC()
+
+	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, sdaRepo, cpRepo, nil, nil, sharedCryptor, nil, nil, nil, db, nil)
+
+	tests := []common.TestStructure{
+		{
+			Name: "admin trying to search",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Search(adminCtx, &model.ViewHistoriesInput{
+					UserID: uuid.NullUUID{UUID: randUserID, Valid: true},
+				}).Times(1).Return([]*model.SDTest{
+					{
+						ID: tid,
+					},
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Histories(adminCtx, &model.ViewHistoriesInput{
+					UserID: uuid.NullUUID{UUID: randUserID, Valid: true},
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res[0].ID, tid)
+			},
+		},
+		{
+			Name: "admin trying to search",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Search(adminCtx, &model.ViewHistoriesInput{
+					UserID:    uuid.NullUUID{UUID: randUserID, Valid: true},
+					PackageID: uuid.NullUUID{UUID: pid, Valid: true},
+				}).Times(1).Return([]*model.SDTest{
+					{
+						ID: tid,
+					},
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Histories(adminCtx, &model.ViewHistoriesInput{
+					UserID:    uuid.NullUUID{UUID: randUserID, Valid: true},
+					PackageID: uuid.NullUUID{UUID: pid, Valid: true},
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res[0].ID, tid)
+			},
+		},
+		{
+			Name: "admin trying to search",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Search(adminCtx, &model.ViewHistoriesInput{
+					UserID:       uuid.NullUUID{UUID: randUserID, Valid: true},
+					PackageID:    uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter: null.NewTime(now, true),
+				}).Times(1).Return([]*model.SDTest{
+					{
+						ID: tid,
+					},
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Histories(adminCtx, &model.ViewHistoriesInput{
+					UserID:       uuid.NullUUID{UUID: randUserID, Valid: true},
+					PackageID:    uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter: null.NewTime(now, true),
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res[0].ID, tid)
+			},
+		},
+		{
+			Name: "admin trying to search",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Search(adminCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: randUserID, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+				}).Times(1).Return([]*model.SDTest{
+					{
+						ID: tid,
+					},
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Histories(adminCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: randUserID, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res[0].ID, tid)
+			},
+		},
+		{
+			Name: "admin trying to search",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Search(adminCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: randUserID, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+				}).Times(1).Return([]*model.SDTest{
+					{
+						ID: tid,
+					},
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Histories(adminCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: randUserID, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res[0].ID, tid)
+			},
+		},
+		{
+			Name: "admin trying to search",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Search(adminCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: randUserID, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+					Limit:             10,
+					Offset:            11,
+				}).Times(1).Return([]*model.SDTest{
+					{
+						ID: tid,
+					},
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Histories(adminCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: randUserID, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+					Limit:             10,
+					Offset:            11,
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res[0].ID, tid)
+			},
+		},
+		{
+			Name: "non admin search param user id must be overriden",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Search(userCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+					Limit:             10,
+					Offset:            11,
+				}).Times(1).Return([]*model.SDTest{
+					{
+						ID: tid,
+					},
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Histories(userCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: uuid.New(), Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+					Limit:             10,
+					Offset:            11,
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res[0].ID, tid)
+			},
+		},
+		{
+			Name: "non admin search param user id must be overriden",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Search(userCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+					Limit:             10,
+					Offset:            11,
+				}).Times(1).Return([]*model.SDTest{
+					{
+						ID: tid,
+					},
+				}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Histories(userCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+					Limit:             10,
+					Offset:            11,
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res[0].ID, tid)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Search(userCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+					Limit:             10,
+					Offset:            11,
+				}).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Histories(userCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+					Limit:             10,
+					Offset:            11,
+				})
+
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "empty array?",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Search(userCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+					Limit:             10,
+					Offset:            11,
+				}).Times(1).Return(nil, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Histories(userCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+					Limit:             10,
+					Offset:            11,
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, len(res), 0)
+			},
+		},
+		{
+			Name: "empty array?",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Search(userCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+					Limit:             10,
+					Offset:            11,
+				}).Times(1).Return([]*model.SDTest{}, nil)
+			},
+			Run: func() {
+				res, cerr := uc.Histories(userCtx, &model.ViewHistoriesInput{
+					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
+					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
+					CreatedAfter:      null.NewTime(now, true),
+					IncludeUnfinished: true,
+					IncludeDeleted:    true,
+					Limit:             10,
+					Offset:            11,
+				})
+
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, len(res), 0)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestUsecase_Statistic(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
+	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	sdtRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	sdaRepo := mock.NewMockSDAssignmentRepository(kit.Ctrl)
+	cpRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
+	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+
+	ctx := context.Background()
+	uid := uuid.New()
+	randUID := uuid.New()
+	childID := uuid.New()
+	userCtx := model.SetUserToCtx(ctx, model.AuthUser{
+		UserID: uid,
+		Role:   model.RoleUser,
+	})
+	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{
+		Role: model.RoleAdmin,
+	})
+
+	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, sdaRepo, cpRepo, nil, nil, sharedCryptor, nil, nil, nil, nil, nil)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid date range",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.Statistic(userCtx, &model.SDTestStatisticInput{
+					From: time.Now().UTC(),
+					To:   time.Now().UTC().Add(-time.Hour),
+				})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInvalidSDTestStatisticInput)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "non admin should only be able to view his own statistic",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Statistic(userCtx, &model.SDTestStatisticInput{UserID: uid}).Times(1).Return([]model.SDTestStatistic{}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.Statistic(userCtx, &model.SDTestStatisticInput{UserID: uuid.New()})
+				assert.NoError(t, cerr.Type)
+			},
+		},
+		{
+			Name: "admin can use any user id",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Statistic(adminCtx, &model.SDTestStatisticInput{UserID: randUID}).Times(1).Return([]model.SDTestStatistic{}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.Statistic(adminCtx, &model.SDTestStatisticInput{UserID: randUID})
+				assert.NoError(t, cerr.Type)
+			},
+		},
+		{
+			Name: "non admin filtering by child not found",
+			MockFn: func() {
+				cpRepo.EXPECT().FindByID(userCtx, childID).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.Statistic(userCtx, &model.SDTestStatisticInput{ChildID: uuid.NullUUID{UUID: childID, Valid: true}})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "non admin filtering by other people child",
+			MockFn: func() {
+				cpRepo.EXPECT().FindByID(userCtx, childID).Times(1).Return(&model.ChildProfile{ID: childID, UserID: randUID}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.Statistic(userCtx, &model.SDTestStatisticInput{ChildID: uuid.NullUUID{UUID: childID, Valid: true}})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenToAccessChildProfile)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "non admin filtering by own child",
+			MockFn: func() {
+				cpRepo.EXPECT().FindByID(userCtx, childID).Times(1).Return(&model.ChildProfile{ID: childID, UserID: uid}, nil)
+				sdtrRepo.EXPECT().Statistic(userCtx, &model.SDTestStatisticInput{UserID: uid, ChildID: uuid.NullUUID{UUID: childID, Valid: true}}).Times(1).Return([]model.SDTestStatistic{}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.Statistic(userCtx, &model.SDTestStatisticInput{ChildID: uuid.NullUUID{UUID: childID, Valid: true}})
+				assert.NoError(t, cerr.Type)
+			},
+		},
+		{
+			Name: "err db",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Statistic(adminCtx, &model.SDTestStatisticInput{UserID: randUID}).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.Statistic(adminCtx, &model.SDTestStatisticInput{UserID: randUID})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "not found on db",
+			MockFn: func() {
+				sdtrRepo.EXPECT().Statistic(adminCtx, &model.SDTestStatisticInput{UserID: randUID}).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.Statistic(adminCtx, &model.SDTestStatisticInput{UserID: randUID})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
 			tt.MockFn()
 			tt.Run()
 		})
 	}
 }
 
-func TestSDTestUsecase_Histories(t *testing.T) {
+func TestSDTestUsecase_DownloadResult(t *testing.T) {
 	kit, closer := common.InitializeRepoTestKit(t)
 	defer closer()
 
 	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
 	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	sdtRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	sdaRepo := mock.NewMockSDAssignmentRepository(kit.Ctrl)
+	cpRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
 	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+	signer := commonMock.NewMockSigner(kit.Ctrl)
 
 	ctx := context.Background()
-	uid := uuid.New()
-	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{
+	tid := uuid.New()
+	pid := uuid.New()
+	childID := uuid.New()
+
+	randCtx := model.SetUserToCtx(ctx, model.AuthUser{
 		UserID:      uuid.New(),
 		AccessToken: "token",
-		Role:        model.RoleAdmin,
+		Role:        model.RoleUser,
 	})
-	userCtx := model.SetUserToCtx(ctx, model.AuthUser{
-		UserID:      uid,
+
+	testOwnser := model.AuthUser{
+		UserID:      uuid.New(),
 		AccessToken: "token",
 		Role:        model.RoleUser,
-	})
-	db := kit.DB
-	randUserID := uuid.New()
-	tid := uuid.New()
-	pid := uuid.New()
-	now := time.Now().UTC()
+	}
+	ownerCtx := model.SetUserToCtx(ctx, testOwnser)
+
+	admin := model.AuthUser{
+		UserID:      uuid.New(),
+		AccessToken: "token",
+		Role:        model.RoleAdmin,
+	}
+	adminCtx := model.SetUserToCtx(ctx, admin)
+
+	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, sdaRepo, cpRepo, nil, nil, sharedCryptor, nil, nil, nil, nil, nil)
+
+	fontBytes, err := os.ReadFile("../../assets/font.ttf")
+	assert.NoError(t, err)
+	f, err := truetype.Parse(fontBytes)
+	assert.NoError(t, err)
+	ucWithFont := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, sdaRepo, cpRepo, nil, nil, sharedCryptor, signer, nil, nil, nil, f)
+
+	templateID := uuid.New()
+	finishedTest := &model.SDTest{
+		ID:         tid,
+		UserID:     uuid.NullUUID{UUID: testOwnser.UserID, Valid: true},
+		FinishedAt: null.NewTime(time.Date(2023, time.January, 2, 3, 4, 0, 0, time.UTC), true),
+		PackageID:  pid,
+		TemplateID: templateID,
+		Result: model.SDTestResult{
+			Result:         []model.SDTestGroupResult{{GroupName: "group", Result: 5}},
+			Total:          5,
+			Interpretation: &model.SDTestInterpretation{IsPositive: true, IndicationText: "indicated"},
+		},
+	}
+	template := &model.SpeechDelayTemplate{
+		ID:       templateID,
+		Type:     model.TestTypeSpeechDelay,
+		Template: &model.SDTemplate{IndicationThreshold: 5},
+	}
+
+	tests := []common.TestStructure{
+		{
+			Name: "failed to find sd test result",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.DownloadResult(ctx, &model.DownloadSDTestResultInput{ID: tid})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+
+			},
+		},
+		{
+			Name: "not found",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.DownloadResult(ctx, &model.DownloadSDTestResultInput{ID: tid})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+
+			},
+		},
+		{
+			Name: "test has owner, but accessed by unregistered user",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					ID:     tid,
+					UserID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.DownloadResult(ctx, &model.DownloadSDTestResultInput{ID: tid})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenDownloadSDTestResult)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+
+			},
+		},
+		{
+			Name: "test has owner, but was accessed by different user",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(randCtx, tid).Times(1).Return(&model.SDTest{
+					ID:     tid,
+					UserID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.DownloadResult(randCtx, &model.DownloadSDTestResultInput{ID: tid})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenDownloadSDTestResult)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+
+			},
+		},
+		{
+			Name: "test owner still able to download the result of a deleted child profile",
+			MockFn: func() {
+				childTest := *finishedTest
+				childTest.ChildID = uuid.NullUUID{UUID: childID, Valid: true}
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(&childTest, nil)
+				sdtRepo.EXPECT().FindByID(ownerCtx, templateID, false).Return(template, nil)
+				signer.EXPECT().Sign(model.SDResultSignatureMessage(tid, 5)).Times(1).Return([]byte("signature"), nil)
+			},
+			Run: func() {
+				res, cerr := ucWithFont.DownloadResult(ownerCtx, &model.DownloadSDTestResultInput{ID: tid})
+				assert.NoError(t, cerr.Type)
+				assert.NotZero(t, res.Buffer.Len())
+			},
+		},
+		{
+			Name: "test is still not answered",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(&model.SDTest{
+					ID:     tid,
+					UserID: uuid.NullUUID{UUID: testOwnser.UserID, Valid: true},
+				}, nil)
+			},
+			Run: func() {
+				_, cerr := uc.DownloadResult(ownerCtx, &model.DownloadSDTestResultInput{ID: tid})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenDownloadSDTestResult)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+
+			},
+		},
+		{
+			Name: "failed to find template",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(adminCtx, tid).Times(1).Return(&model.SDTest{
+					ID:         tid,
+					UserID:     uuid.NullUUID{UUID: testOwnser.UserID, Valid: true},
+					FinishedAt: null.NewTime(time.Now().Add(time.Hour*-1).UTC(), true),
+					PackageID:  pid,
+					TemplateID: templateID,
+				}, nil)
+				sdtRepo.EXPECT().FindByID(adminCtx, templateID, false).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := uc.DownloadResult(adminCtx, &model.DownloadSDTestResultInput{ID: tid})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+
+			},
+		},
+		{
+			Name: "somehow template not found",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(adminCtx, tid).Times(1).Return(&model.SDTest{
+					ID:         tid,
+					UserID:     uuid.NullUUID{UUID: testOwnser.UserID, Valid: true},
+					FinishedAt: null.NewTime(time.Now().Add(time.Hour*-1).UTC(), true),
+					PackageID:  pid,
+					TemplateID: templateID,
+				}, nil)
+				sdtRepo.EXPECT().FindByID(adminCtx, templateID, false).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.DownloadResult(adminCtx, &model.DownloadSDTestResultInput{ID: tid})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+
+			},
+		},
+		{
+			Name:   "image width is too large",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.DownloadResult(ownerCtx, &model.DownloadSDTestResultInput{ID: tid, Width: model.MaxSDResultImageWidth + 1})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInvalidDownloadSDTestResultInput)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name:   "image dpi is too small",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.DownloadResult(ownerCtx, &model.DownloadSDTestResultInput{ID: tid, DPI: model.MinSDResultImageDPI - 1})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInvalidDownloadSDTestResultInput)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
+			},
+		},
+		{
+			Name: "failed to sign the result",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(finishedTest, nil)
+				sdtRepo.EXPECT().FindByID(ownerCtx, templateID, false).Return(template, nil)
+				signer.EXPECT().Sign(model.SDResultSignatureMessage(tid, 5)).Times(1).Return(nil, errors.New("err sign"))
+			},
+			Run: func() {
+				_, cerr := ucWithFont.DownloadResult(ownerCtx, &model.DownloadSDTestResultInput{ID: tid})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok png with custom size",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(finishedTest, nil)
+				sdtRepo.EXPECT().FindByID(ownerCtx, templateID, false).Return(template, nil)
+				signer.EXPECT().Sign(model.SDResultSignatureMessage(tid, 5)).Times(1).Return([]byte("signature"), nil)
+			},
+			Run: func() {
+				res, cerr := ucWithFont.DownloadResult(ownerCtx, &model.DownloadSDTestResultInput{ID: tid, Format: model.SDResultFormatPNG, Width: 640, DPI: 96})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.ContentType, "image/png")
+
+				cfg, err := png.DecodeConfig(bytes.NewReader(res.Buffer.Bytes()))
+				assert.NoError(t, err)
+				assert.Equal(t, cfg.Width, 640)
+			},
+		},
+		{
+			Name: "failed to find the package to write on the pdf",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(adminCtx, tid).Times(1).Return(finishedTest, nil)
+				sdtRepo.EXPECT().FindByID(adminCtx, templateID, false).Return(template, nil)
+				sdpRepo.EXPECT().FindByID(adminCtx, pid, true).Times(1).Return(nil, errors.New("err db"))
+			},
+			Run: func() {
+				_, cerr := ucWithFont.DownloadResult(adminCtx, &model.DownloadSDTestResultInput{ID: tid, Format: model.SDResultFormatPDF})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok jpeg",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(finishedTest, nil)
+				sdtRepo.EXPECT().FindByID(ownerCtx, templateID, false).Return(template, nil)
+				signer.EXPECT().Sign(model.SDResultSignatureMessage(tid, 5)).Times(1).Return([]byte("signature"), nil)
+			},
+			Run: func() {
+				res, cerr := ucWithFont.DownloadResult(ownerCtx, &model.DownloadSDTestResultInput{ID: tid})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.ContentType, "image/jpeg")
+				assert.NotZero(t, res.Buffer.Len())
+			},
+		},
+		{
+			Name: "ok pdf",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(finishedTest, nil)
+				sdtRepo.EXPECT().FindByID(ownerCtx, templateID, false).Return(template, nil)
+				sdpRepo.EXPECT().FindByID(ownerCtx, pid, true).Times(1).Return(&model.SpeechDelayPackage{ID: pid, Name: "package name"}, nil)
+				signer.EXPECT().Sign(model.SDResultSignatureMessage(tid, 5)).Times(1).Return([]byte("signature"), nil)
+			},
+			Run: func() {
+				res, cerr := ucWithFont.DownloadResult(ownerCtx, &model.DownloadSDTestResultInput{ID: tid, Format: model.SDResultFormatPDF})
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.ContentType, "application/pdf")
+
+				content := res.Buffer.String()
+				assert.True(t, strings.HasPrefix(content, "%PDF-1.4"))
+				assert.Contains(t, content, "(Paket: package name)")
+				assert.Contains(t, content, "2023-01-02 03:04 UTC")
+				assert.Contains(t, content, tid.String())
+				assert.Contains(t, content, "re\nf Q")
+			},
+		},
+		{
+			Name: "ok pdf written with the package name of the version the test was taken on",
+			MockFn: func() {
+				versionedTest := *finishedTest
+				versionedTest.PackageVersion = 1
+				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(&versionedTest, nil)
+				sdtRepo.EXPECT().FindByID(ownerCtx, templateID, false).Return(template, nil)
+				sdpRepo.EXPECT().FindByID(ownerCtx, pid, true).Times(1).Return(&model.SpeechDelayPackage{ID: pid, Name: "renamed package", CurrentVersion: 2}, nil)
+				sdpRepo.EXPECT().FindVersion(ownerCtx, pid, 1).Times(1).Return(&model.SDPackageVersion{PackageID: pid, Version: 1, Name: "package name"}, nil)
+				signer.EXPECT().Sign(model.SDResultSignatureMessage(tid, 5)).Times(1).Return([]byte("signature"), nil)
+			},
+			Run: func() {
+				res, cerr := ucWithFont.DownloadResult(ownerCtx, &model.DownloadSDTestResultInput{ID: tid, Format: model.SDResultFormatPDF})
+				assert.NoError(t, cerr.Type)
+
+				content := res.Buffer.String()
+				assert.Contains(t, content, "(Paket: package name)")
+				assert.NotContains(t, content, "renamed package")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestUsecase_ProgressChart(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
+	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	sdtRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	sdaRepo := mock.NewMockSDAssignmentRepository(kit.Ctrl)
+	cpRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
+	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+
+	ctx := context.Background()
+	uid := uuid.New()
+	templateID := uuid.New()
+	userCtx := model.SetUserToCtx(ctx, model.AuthUser{
+		UserID: uid,
+		Role:   model.RoleUser,
+	})
 
-	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sharedCryptor, db, nil)
+	fontBytes, err := os.ReadFile("../../assets/font.ttf")
+	assert.NoError(t, err)
+	f, err := truetype.Parse(fontBytes)
+	assert.NoError(t, err)
+	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, sdaRepo, cpRepo, nil, nil, sharedCryptor, nil, nil, nil, nil, f)
+
+	stat := model.SDTestStatistic{
+		TemplateID:          templateID,
+		TemplateName:        "template",
+		IndicationThreshold: 10,
+		Stats: []model.StatsComponent{
+			{TestResultID: uuid.New(), ResultPoint: 12, TestFinishedAt: time.Now().UTC(), SubGroups: []model.SDTestGroupResult{{GroupName: "group", Result: 12}}},
+		},
+	}
+	stat.ComputeTrends()
+	statInput := &model.SDTestStatisticInput{UserID: uid, TemplateID: uuid.NullUUID{UUID: templateID, Valid: true}, Unlimited: true}
 
 	tests := []common.TestStructure{
 		{
-			Name: "admin trying to search",
-			MockFn: func() {
-				sdtrRepo.EXPECT().Search(adminCtx, &model.ViewHistoriesInput{
-					UserID: uuid.NullUUID{UUID: randUserID, Valid: true},
-				}).Times(1).Return([]*model.SDTest{
-					{
-						ID: tid,
-					},
-				}, nil)
-			},
+			Name:   "unsupported format",
+			MockFn: func() {},
 			Run: func() {
-				res, cerr := uc.Histories(adminCtx, &model.ViewHistoriesInput{
-					UserID: uuid.NullUUID{UUID: randUserID, Valid: true},
-				})
-
-				assert.NoError(t, cerr.Type)
-				assert.Equal(t, res[0].ID, tid)
+				_, cerr := uc.ProgressChart(userCtx, &model.SDTestProgressChartInput{TemplateID: templateID, Format: model.SDResultFormatPDF})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInvalidSDTestProgressChartInput)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
 			},
 		},
 		{
-			Name: "admin trying to search",
-			MockFn: func() {
-				sdtrRepo.EXPECT().Search(adminCtx, &model.ViewHistoriesInput{
-					UserID:    uuid.NullUUID{UUID: randUserID, Valid: true},
-					PackageID: uuid.NullUUID{UUID: pid, Valid: true},
-				}).Times(1).Return([]*model.SDTest{
-					{
-						ID: tid,
-					},
-				}, nil)
+			Name:   "width too large",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.ProgressChart(userCtx, &model.SDTestProgressChartInput{TemplateID: templateID, Width: 5000})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInvalidSDTestProgressChartInput)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
 			},
+		},
+		{
+			Name:   "invalid date range",
+			MockFn: func() {},
 			Run: func() {
-				res, cerr := uc.Histories(adminCtx, &model.ViewHistoriesInput{
-					UserID:    uuid.NullUUID{UUID: randUserID, Valid: true},
-					PackageID: uuid.NullUUID{UUID: pid, Valid: true},
+				_, cerr := uc.ProgressChart(userCtx, &model.SDTestProgressChartInput{
+					TemplateID: templateID,
+					From:       time.Now().UTC(),
+					To:         time.Now().UTC().Add(-time.Hour),
 				})
-
-				assert.NoError(t, cerr.Type)
-				assert.Equal(t, res[0].ID, tid)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInvalidSDTestStatisticInput)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
 			},
 		},
 		{
-			Name: "admin trying to search",
+			Name: "failed to get statistic",
 			MockFn: func() {
-				sdtrRepo.EXPECT().Search(adminCtx, &model.ViewHistoriesInput{
-					UserID:       uuid.NullUUID{UUID: randUserID, Valid: true},
-					PackageID:    uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter: null.NewTime(now, true),
-				}).Times(1).Return([]*model.SDTest{
-					{
-						ID: tid,
-					},
-				}, nil)
+				sdtrRepo.EXPECT().Statistic(userCtx, statInput).Times(1).Return(nil, errors.New("err db"))
 			},
 			Run: func() {
-				res, cerr := uc.Histories(adminCtx, &model.ViewHistoriesInput{
-					UserID:       uuid.NullUUID{UUID: randUserID, Valid: true},
-					PackageID:    uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter: null.NewTime(now, true),
-				})
-
-				assert.NoError(t, cerr.Type)
-				assert.Equal(t, res[0].ID, tid)
+				_, cerr := uc.ProgressChart(userCtx, &model.SDTestProgressChartInput{UserID: uuid.New(), TemplateID: templateID})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
 			},
 		},
 		{
-			Name: "admin trying to search",
+			Name: "no finished test of the template",
 			MockFn: func() {
-				sdtrRepo.EXPECT().Search(adminCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: randUserID, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-				}).Times(1).Return([]*model.SDTest{
-					{
-						ID: tid,
-					},
-				}, nil)
+				sdtrRepo.EXPECT().Statistic(userCtx, statInput).Times(1).Return(nil, repository.ErrNotFound)
 			},
 			Run: func() {
-				res, cerr := uc.Histories(adminCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: randUserID, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-				})
-
-				assert.NoError(t, cerr.Type)
-				assert.Equal(t, res[0].ID, tid)
+				_, cerr := uc.ProgressChart(userCtx, &model.SDTestProgressChartInput{TemplateID: templateID})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
 			},
 		},
 		{
-			Name: "admin trying to search",
+			Name: "ok png with custom size",
 			MockFn: func() {
-				sdtrRepo.EXPECT().Search(adminCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: randUserID, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-				}).Times(1).Return([]*model.SDTest{
-					{
-						ID: tid,
-					},
-				}, nil)
+				sdtrRepo.EXPECT().Statistic(userCtx, statInput).Times(1).Return([]model.SDTestStatistic{stat}, nil)
 			},
 			Run: func() {
-				res, cerr := uc.Histories(adminCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: randUserID, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-				})
-
+				res, cerr := uc.ProgressChart(userCtx, &model.SDTestProgressChartInput{TemplateID: templateID, Width: 640, DPI: 96})
 				assert.NoError(t, cerr.Type)
-				assert.Equal(t, res[0].ID, tid)
+				assert.Equal(t, res.ContentType, "image/png")
+
+				cfg, err := png.DecodeConfig(bytes.NewReader(res.Buffer.Bytes()))
+				assert.NoError(t, err)
+				assert.Equal(t, cfg.Width, 640)
 			},
 		},
 		{
-			Name: "admin trying to search",
+			Name: "ok jpeg",
 			MockFn: func() {
-				sdtrRepo.EXPECT().Search(adminCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: randUserID, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-					Limit:             10,
-					Offset:            11,
-				}).Times(1).Return([]*model.SDTest{
-					{
-						ID: tid,
-					},
-				}, nil)
+				sdtrRepo.EXPECT().Statistic(userCtx, statInput).Times(1).Return([]model.SDTestStatistic{stat}, nil)
 			},
 			Run: func() {
-				res, cerr := uc.Histories(adminCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: randUserID, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-					Limit:             10,
-					Offset:            11,
-				})
-
+				res, cerr := uc.ProgressChart(userCtx, &model.SDTestProgressChartInput{TemplateID: templateID, Format: model.SDResultFormatJPEG})
 				assert.NoError(t, cerr.Type)
-				assert.Equal(t, res[0].ID, tid)
+				assert.Equal(t, res.ContentType, "image/jpeg")
+				assert.NotZero(t, res.Buffer.Len())
 			},
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestUsecase_VerifyResult(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
+	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	sdtRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	sdaRepo := mock.NewMockSDAssignmentRepository(kit.Ctrl)
+	cpRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
+	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+	signer := commonMock.NewMockSigner(kit.Ctrl)
+
+	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, sdaRepo, cpRepo, nil, nil, sharedCryptor, signer, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	tid := uuid.New()
+	signature := []byte("signature")
+	input := &model.VerifySDTestResultInput{
+		ID:        tid,
+		Total:     5,
+		Signature: model.EncodeSDResultSignature(signature),
+	}
+	finishedTest := &model.SDTest{
+		ID:         tid,
+		FinishedAt: null.NewTime(time.Now().UTC(), true),
+		Result: model.SDTestResult{
+			Result: []model.SDTestGroupResult{{GroupName: "group", Result: 5}},
+			Total:  5,
+		},
+	}
+
+	tests := []common.TestStructure{
 		{
-			Name: "non admin search param user id must be overriden",
-			MockFn: func() {
-				sdtrRepo.EXPECT().Search(userCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-					Limit:             10,
-					Offset:            11,
-				}).Times(1).Return([]*model.SDTest{
-					{
-						ID: tid,
-					},
-				}, nil)
+			Name:   "signature is missing",
+			MockFn: func() {},
+			Run: func() {
+				_, cerr := uc.VerifyResult(ctx, &model.VerifySDTestResultInput{ID: tid, Total: 5})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInvalidVerifySDTestResultInput)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
 			},
+		},
+		{
+			Name:   "signature is not base64 url encoded",
+			MockFn: func() {},
 			Run: func() {
-				res, cerr := uc.Histories(userCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: uuid.New(), Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-					Limit:             10,
-					Offset:            11,
-				})
-
-				assert.NoError(t, cerr.Type)
-				assert.Equal(t, res[0].ID, tid)
+				_, cerr := uc.VerifyResult(ctx, &model.VerifySDTestResultInput{ID: tid, Total: 5, Signature: "not/base64+url=="})
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInvalidVerifySDTestResultInput)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
 			},
 		},
 		{
-			Name: "non admin search param user id must be overriden",
+			Name: "signature is not valid",
 			MockFn: func() {
-				sdtrRepo.EXPECT().Search(userCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-					Limit:             10,
-					Offset:            11,
-				}).Times(1).Return([]*model.SDTest{
-					{
-						ID: tid,
-					},
-				}, nil)
+				signer.EXPECT().Verify(model.SDResultSignatureMessage(tid, 5), signature).Times(1).Return(errors.New("verification error"))
 			},
 			Run: func() {
-				res, cerr := uc.Histories(userCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-					Limit:             10,
-					Offset:            11,
-				})
-
+				res, cerr := uc.VerifyResult(ctx, input)
 				assert.NoError(t, cerr.Type)
-				assert.Equal(t, res[0].ID, tid)
+				assert.False(t, res.Valid)
+				assert.Equal(t, res.TestID, tid)
+				assert.Nil(t, res.Result)
 			},
 		},
 		{
-			Name: "err db",
+			Name: "failed to find sd test result",
 			MockFn: func() {
-				sdtrRepo.EXPECT().Search(userCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-					Limit:             10,
-					Offset:            11,
-				}).Times(1).Return(nil, errors.New("err db"))
+				signer.EXPECT().Verify(model.SDResultSignatureMessage(tid, 5), signature).Times(1).Return(nil)
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, errors.New("err db"))
 			},
 			Run: func() {
-				_, cerr := uc.Histories(userCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-					Limit:             10,
-					Offset:            11,
-				})
-
+				_, cerr := uc.VerifyResult(ctx, input)
 				assert.Error(t, cerr.Type)
 				assert.Equal(t, cerr.Type, ErrInternal)
 				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
 			},
 		},
 		{
-			Name: "empty array?",
+			Name: "sd test result not found",
+			MockFn: func() {
+				signer.EXPECT().Verify(model.SDResultSignatureMessage(tid, 5), signature).Times(1).Return(nil)
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, repository.ErrNotFound)
+			},
+			Run: func() {
+				_, cerr := uc.VerifyResult(ctx, input)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
+			},
+		},
+		{
+			Name: "stored total doesn't match the signed total",
 			MockFn: func() {
-				sdtrRepo.EXPECT().Search(userCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-					Limit:             10,
-					Offset:            11,
-				}).Times(1).Return(nil, nil)
+				changed := *finishedTest
+				changed.Result = model.SDTestResult{Total: 10}
+				signer.EXPECT().Verify(model.SDResultSignatureMessage(tid, 5), signature).Times(1).Return(nil)
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&changed, nil)
 			},
 			Run: func() {
-				res, cerr := uc.Histories(userCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-					Limit:             10,
-					Offset:            11,
-				})
-
+				res, cerr := uc.VerifyResult(ctx, input)
 				assert.NoError(t, cerr.Type)
-				assert.Equal(t, len(res), 0)
+				assert.False(t, res.Valid)
+				assert.Nil(t, res.Result)
 			},
 		},
 		{
-			Name: "empty array?",
+			Name: "ok",
 			MockFn: func() {
-				sdtrRepo.EXPECT().Search(userCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-					Limit:             10,
-					Offset:            11,
-				}).Times(1).Return([]*model.SDTest{}, nil)
+				signer.EXPECT().Verify(model.SDResultSignatureMessage(tid, 5), signature).Times(1).Return(nil)
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(finishedTest, nil)
 			},
 			Run: func() {
-				res, cerr := uc.Histories(userCtx, &model.ViewHistoriesInput{
-					UserID:            uuid.NullUUID{UUID: uid, Valid: true},
-					PackageID:         uuid.NullUUID{UUID: pid, Valid: true},
-					CreatedAfter:      null.NewTime(now, true),
-					IncludeUnfinished: true,
-					IncludeDeleted:    true,
-					Limit:             10,
-					Offset:            11,
-				})
+				res, cerr := uc.VerifyResult(ctx, input)
+				assert.NoError(t, cerr.Type)
+				assert.True(t, res.Valid)
+				assert.Equal(t, res.Result, &finishedTest.Result)
+				assert.Equal(t, res.FinishedAt, finishedTest.FinishedAt)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.MockFn()
+			tt.Run()
+		})
+	}
+}
+
+func TestSDTestUsecase_ResultPublicKey(t *testing.T) {
+	kit, closer := common.InitializeRepoTestKit(t)
+	defer closer()
+
+	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+	signer := commonMock.NewMockSigner(kit.Ctrl)
 
+	uc := NewSDTestResultUsecase(nil, nil, nil, nil, nil, nil, nil, sharedCryptor, signer, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	tests := []common.TestStructure{
+		{
+			Name: "failed to encode the public key",
+			MockFn: func() {
+				signer.EXPECT().PublicKeyPEM().Times(1).Return("", errors.New("err encode"))
+			},
+			Run: func() {
+				_, cerr := uc.ResultPublicKey(ctx)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				signer.EXPECT().PublicKeyPEM().Times(1).Return("public key", nil)
+			},
+			Run: func() {
+				res, cerr := uc.ResultPublicKey(ctx)
 				assert.NoError(t, cerr.Type)
-				assert.Equal(t, len(res), 0)
+				assert.Equal(t, res.PublicKey, "public key")
+				assert.Equal(t, res.Algorithm, common.SignatureAlgorithm)
+				assert.Equal(t, res.MessageFormat, model.SDResultSignatureMessageFormat)
 			},
 		},
 	}
@@ -1168,72 +3513,189 @@ func TestSDTestUsecase_Histories(t *testing.T) {
 	}
 }
 
-func TestSDTestUsecase_Statistic(t *testing.T) {
+func TestSDTestUsecase_DeliverResult(t *testing.T) {
 	kit, closer := common.InitializeRepoTestKit(t)
 	defer closer()
 
 	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
 	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	sdtRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	userRepo := mock.NewMockUserRepository(kit.Ctrl)
+	emailUsecase := mock.NewMockEmailUsecase(kit.Ctrl)
 	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
+	signer := commonMock.NewMockSigner(kit.Ctrl)
+
+	fontBytes, err := os.ReadFile("../../assets/font.ttf")
+	assert.NoError(t, err)
+	f, err := truetype.Parse(fontBytes)
+	assert.NoError(t, err)
+
+	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, nil, nil, nil, userRepo, sharedCryptor, signer, emailUsecase, nil, nil, f)
 
 	ctx := context.Background()
-	uid := uuid.New()
-	randUID := uuid.New()
-	userCtx := model.SetUserToCtx(ctx, model.AuthUser{
-		UserID: uid,
-		Role:   model.RoleUser,
-	})
-	adminCtx := model.SetUserToCtx(ctx, model.AuthUser{
-		Role: model.RoleAdmin,
-	})
+	tid := uuid.New()
+	pid := uuid.New()
+	owner := &model.User{
+		ID:       uuid.New(),
+		Username: "owner",
+		Email:    "encrypted email",
+	}
 
-	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sharedCryptor, nil, nil)
+	finishedTest := &model.SDTest{
+		ID:         tid,
+		UserID:     uuid.NullUUID{UUID: owner.ID, Valid: true},
+		FinishedAt: null.NewTime(time.Now().UTC(), true),
+		PackageID:  pid,
+		Result: model.SDTestResult{
+			Result:         []model.SDTestGroupResult{{GroupName: "group", Result: 5}},
+			Total:          5,
+			Interpretation: &model.SDTestInterpretation{IsPositive: true, IndicationText: "indicated"},
+		},
+	}
+	template := &model.SpeechDelayTemplate{
+		Type:     model.TestTypeSpeechDelay,
+		Template: &model.SDTemplate{IndicationThreshold: 5},
+	}
 
 	tests := []common.TestStructure{
 		{
-			Name: "non admin should only be able to view his own statistic",
+			Name: "failed to find sd test result",
 			MockFn: func() {
-				sdtrRepo.EXPECT().Statistic(userCtx, uid).Times(1).Return([]model.SDTestStatistic{}, nil)
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, errors.New("err db"))
 			},
 			Run: func() {
-				_, cerr := uc.Statistic(userCtx, uuid.New())
-				assert.NoError(t, cerr.Type)
+				cerr := uc.DeliverResult(ctx, tid)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
 			},
 		},
 		{
-			Name: "admin can use any user id",
+			Name: "not found",
 			MockFn: func() {
-				sdtrRepo.EXPECT().Statistic(adminCtx, randUID).Times(1).Return([]model.SDTestStatistic{}, nil)
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, repository.ErrNotFound)
 			},
 			Run: func() {
-				_, cerr := uc.Statistic(adminCtx, randUID)
-				assert.NoError(t, cerr.Type)
+				cerr := uc.DeliverResult(ctx, tid)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrResourceNotFound)
+				assert.Equal(t, cerr.Code, http.StatusNotFound)
 			},
 		},
 		{
-			Name: "err db",
+			Name: "test is still not answered",
 			MockFn: func() {
-				sdtrRepo.EXPECT().Statistic(adminCtx, randUID).Times(1).Return(nil, errors.New("err db"))
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					ID:     tid,
+					UserID: uuid.NullUUID{UUID: owner.ID, Valid: true},
+				}, nil)
 			},
 			Run: func() {
-				_, cerr := uc.Statistic(adminCtx, randUID)
+				cerr := uc.DeliverResult(ctx, tid)
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Type, ErrInternal)
-				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+				assert.Equal(t, cerr.Type, ErrForbiddenDownloadSDTestResult)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
 			},
 		},
 		{
-			Name: "not found on db",
+			Name: "test has no owner",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					ID:         tid,
+					FinishedAt: null.NewTime(time.Now().UTC(), true),
+				}, nil)
+			},
+			Run: func() {
+				cerr := uc.DeliverResult(ctx, tid)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrForbiddenDownloadSDTestResult)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "owner not found",
 			MockFn: func() {
-				sdtrRepo.EXPECT().Statistic(adminCtx, randUID).Times(1).Return(nil, repository.ErrNotFound)
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(finishedTest, nil)
+				userRepo.EXPECT().FindByID(ctx, owner.ID).Times(1).Return(nil, repository.ErrNotFound)
 			},
 			Run: func() {
-				_, cerr := uc.Statistic(adminCtx, randUID)
+				cerr := uc.DeliverResult(ctx, tid)
 				assert.Error(t, cerr.Type)
 				assert.Equal(t, cerr.Type, ErrResourceNotFound)
 				assert.Equal(t, cerr.Code, http.StatusNotFound)
 			},
 		},
+		{
+			Name: "failed to decrypt owner email",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(finishedTest, nil)
+				userRepo.EXPECT().FindByID(ctx, owner.ID).Times(1).Return(owner, nil)
+				sharedCryptor.EXPECT().Decrypt(owner.Email).Times(1).Return("", errors.New("err decrypt"))
+			},
+			Run: func() {
+				cerr := uc.DeliverResult(ctx, tid)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "failed to sign the result",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(finishedTest, nil)
+				userRepo.EXPECT().FindByID(ctx, owner.ID).Times(1).Return(owner, nil)
+				sharedCryptor.EXPECT().Decrypt(owner.Email).Times(1).Return("owner@test.com", nil)
+				sdtRepo.EXPECT().FindByID(ctx, gomock.Any(), false).Return(template, nil)
+				signer.EXPECT().Sign(model.SDResultSignatureMessage(tid, 5)).Times(1).Return(nil, errors.New("err sign"))
+			},
+			Run: func() {
+				cerr := uc.DeliverResult(ctx, tid)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "failed to register the email",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(finishedTest, nil)
+				userRepo.EXPECT().FindByID(ctx, owner.ID).Times(1).Return(owner, nil)
+				sharedCryptor.EXPECT().Decrypt(owner.Email).Times(1).Return("owner@test.com", nil)
+				sdtRepo.EXPECT().FindByID(ctx, gomock.Any(), false).Return(template, nil)
+				signer.EXPECT().Sign(model.SDResultSignatureMessage(tid, 5)).Times(1).Return([]byte("signature"), nil)
+				emailUsecase.EXPECT().Register(ctx, gomock.Any()).Times(1).Return(nil, errors.New("err email"))
+			},
+			Run: func() {
+				cerr := uc.DeliverResult(ctx, tid)
+				assert.Error(t, cerr.Type)
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(finishedTest, nil)
+				userRepo.EXPECT().FindByID(ctx, owner.ID).Times(1).Return(owner, nil)
+				sharedCryptor.EXPECT().Decrypt(owner.Email).Times(1).Return("owner@test.com", nil)
+				sdtRepo.EXPECT().FindByID(ctx, gomock.Any(), false).Return(template, nil)
+				signer.EXPECT().Sign(model.SDResultSignatureMessage(tid, 5)).Times(1).Return([]byte("signature"), nil)
+				emailUsecase.EXPECT().Register(ctx, gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, input *model.RegisterEmailInput) (*model.Email, error) {
+					assert.NoError(t, input.Validate())
+					assert.Equal(t, input.To, []string{"owner@test.com"})
+					assert.Len(t, input.Attachments, 1)
+					assert.Equal(t, input.Attachments[0].ContentType, model.SDResultFormatPNG.ContentType())
+
+					_, err := png.Decode(bytes.NewReader(input.Attachments[0].Content))
+					assert.NoError(t, err)
+					return &model.Email{}, nil
+				})
+			},
+			Run: func() {
+				cerr := uc.DeliverResult(ctx, tid)
+				assert.NoError(t, cerr.Type)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1244,151 +3706,146 @@ func TestSDTestUsecase_Statistic(t *testing.T) {
 	}
 }
 
-func TestSDTestUsecase_DownloadResult(t *testing.T) {
+func TestSDTestUsecase_Claim(t *testing.T) {
 	kit, closer := common.InitializeRepoTestKit(t)
 	defer closer()
 
 	sdtrRepo := mock.NewMockSDTestRepository(kit.Ctrl)
 	sdpRepo := mock.NewMockSDPackageRepository(kit.Ctrl)
+	sdtRepo := mock.NewMockSDTemplateRepository(kit.Ctrl)
+	sdaRepo := mock.NewMockSDAssignmentRepository(kit.Ctrl)
+	cpRepo := mock.NewMockChildProfileRepository(kit.Ctrl)
 	sharedCryptor := commonMock.NewMockSharedCryptor(kit.Ctrl)
 
-	ctx := context.Background()
-	tid := uuid.New()
-	pid := uuid.New()
-
-	randCtx := model.SetUserToCtx(ctx, model.AuthUser{
-		UserID:      uuid.New(),
-		AccessToken: "token",
-		Role:        model.RoleUser,
-	})
-
-	testOwnser := model.AuthUser{
+	user := model.AuthUser{
 		UserID:      uuid.New(),
 		AccessToken: "token",
 		Role:        model.RoleUser,
 	}
-	ownerCtx := model.SetUserToCtx(ctx, testOwnser)
-
-	admin := model.AuthUser{
-		UserID:      uuid.New(),
-		AccessToken: "token",
-		Role:        model.RoleAdmin,
+	ctx := model.SetUserToCtx(context.Background(), user)
+	tid := uuid.New()
+	input := &model.ClaimSDTestInput{
+		TestID:    tid,
+		SubmitKey: "plain",
 	}
-	adminCtx := model.SetUserToCtx(ctx, admin)
 
-	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sharedCryptor, nil, nil)
+	uc := NewSDTestResultUsecase(sdtrRepo, sdpRepo, sdtRepo, sdaRepo, cpRepo, nil, nil, sharedCryptor, nil, nil, nil, nil, nil)
 
 	tests := []common.TestStructure{
 		{
-			Name: "failed to find sd test result",
-			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, errors.New("err db"))
-			},
+			Name:   "invalid input",
+			MockFn: func() {},
 			Run: func() {
-				_, cerr := uc.DownloadResult(ctx, tid)
+				_, cerr := uc.Claim(ctx, &model.ClaimSDTestInput{TestID: tid})
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Type, ErrInternal)
-				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
-
+				assert.Equal(t, cerr.Type, ErrInvalidClaimSDTestInput)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
 			},
 		},
 		{
-			Name: "not found",
+			Name: "test not found",
 			MockFn: func() {
 				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, repository.ErrNotFound)
 			},
 			Run: func() {
-				_, cerr := uc.DownloadResult(ctx, tid)
+				_, cerr := uc.Claim(ctx, input)
 				assert.Error(t, cerr.Type)
 				assert.Equal(t, cerr.Type, ErrResourceNotFound)
 				assert.Equal(t, cerr.Code, http.StatusNotFound)
-
 			},
 		},
 		{
-			Name: "test has owner, but accessed by unregistered user",
+			Name: "err db when finding test",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
-					ID:     tid,
-					UserID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
-				}, nil)
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(nil, errors.New("err db"))
 			},
 			Run: func() {
-				_, cerr := uc.DownloadResult(ctx, tid)
+				_, cerr := uc.Claim(ctx, input)
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Type, ErrForbiddenDownloadSDTestResult)
-				assert.Equal(t, cerr.Code, http.StatusForbidden)
-
+				assert.Equal(t, cerr.Type, ErrInternal)
+				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
 			},
 		},
 		{
-			Name: "test has owner, but was accessed by different user",
+			Name: "test already owned",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(randCtx, tid).Times(1).Return(&model.SDTest{
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
 					ID:     tid,
 					UserID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
 				}, nil)
 			},
 			Run: func() {
-				_, cerr := uc.DownloadResult(randCtx, tid)
+				_, cerr := uc.Claim(ctx, input)
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Type, ErrForbiddenDownloadSDTestResult)
+				assert.Equal(t, cerr.Type, ErrSDTestAlreadyOwned)
 				assert.Equal(t, cerr.Code, http.StatusForbidden)
-
 			},
 		},
 		{
-			Name: "test is still not answered",
+			Name: "invalid submit key",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(ownerCtx, tid).Times(1).Return(&model.SDTest{
-					ID:     tid,
-					UserID: uuid.NullUUID{UUID: testOwnser.UserID, Valid: true},
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					ID:        tid,
+					SubmitKey: "enc",
 				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("different")
 			},
 			Run: func() {
-				_, cerr := uc.DownloadResult(ownerCtx, tid)
+				_, cerr := uc.Claim(ctx, input)
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Type, ErrForbiddenDownloadSDTestResult)
-				assert.Equal(t, cerr.Code, http.StatusForbidden)
-
+				assert.Equal(t, cerr.Type, ErrInvalidSubmitKey)
+				assert.Equal(t, cerr.Code, http.StatusBadRequest)
 			},
 		},
 		{
-			Name: "failed to find template",
+			Name: "err db when claiming test",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(adminCtx, tid).Times(1).Return(&model.SDTest{
-					ID:         tid,
-					UserID:     uuid.NullUUID{UUID: testOwnser.UserID, Valid: true},
-					FinishedAt: null.NewTime(time.Now().Add(time.Hour*-1).UTC(), true),
-					PackageID:  pid,
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					ID:        tid,
+					SubmitKey: "enc",
 				}, nil)
-				sdpRepo.EXPECT().GetTemplateByPackageID(adminCtx, pid).Times(1).Return(nil, errors.New("err db"))
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdtrRepo.EXPECT().Claim(ctx, tid, user.UserID, gomock.Any()).Times(1).Return(errors.New("err db"))
 			},
 			Run: func() {
-				_, cerr := uc.DownloadResult(adminCtx, tid)
+				_, cerr := uc.Claim(ctx, input)
 				assert.Error(t, cerr.Type)
 				assert.Equal(t, cerr.Type, ErrInternal)
 				assert.Equal(t, cerr.Code, http.StatusInternalServerError)
-
 			},
 		},
 		{
-			Name: "somehow template not found",
+			Name: "claimed concurrently by other account",
 			MockFn: func() {
-				sdtrRepo.EXPECT().FindByID(adminCtx, tid).Times(1).Return(&model.SDTest{
-					ID:         tid,
-					UserID:     uuid.NullUUID{UUID: testOwnser.UserID, Valid: true},
-					FinishedAt: null.NewTime(time.Now().Add(time.Hour*-1).UTC(), true),
-					PackageID:  pid,
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					ID:        tid,
+					SubmitKey: "enc",
 				}, nil)
-				sdpRepo.EXPECT().GetTemplateByPackageID(adminCtx, pid).Times(1).Return(nil, repository.ErrNotFound)
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdtrRepo.EXPECT().Claim(ctx, tid, user.UserID, gomock.Any()).Times(1).Return(repository.ErrNotFound)
 			},
 			Run: func() {
-				_, cerr := uc.DownloadResult(adminCtx, tid)
+				_, cerr := uc.Claim(ctx, input)
 				assert.Error(t, cerr.Type)
-				assert.Equal(t, cerr.Type, ErrResourceNotFound)
-				assert.Equal(t, cerr.Code, http.StatusNotFound)
-
+				assert.Equal(t, cerr.Type, ErrSDTestAlreadyOwned)
+				assert.Equal(t, cerr.Code, http.StatusForbidden)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				sdtrRepo.EXPECT().FindByID(ctx, tid).Times(1).Return(&model.SDTest{
+					ID:        tid,
+					SubmitKey: "enc",
+				}, nil)
+				sharedCryptor.EXPECT().ReverseSecureToken("plain").Times(1).Return("enc")
+				sdtrRepo.EXPECT().Claim(ctx, tid, user.UserID, gomock.Any()).Times(1).Return(nil)
+			},
+			Run: func() {
+				res, cerr := uc.Claim(ctx, input)
+				assert.NoError(t, cerr.Type)
+				assert.Equal(t, res.ID, tid)
+				assert.Equal(t, res.UserID, uuid.NullUUID{UUID: user.UserID, Valid: true})
 			},
 		},
 	}
@@ -70,3 +70,29 @@ func (c *client) EnqueueEnforceActiveTokenLimiterTask(ctx context.Context, userI
 
 	return info, nil
 }
+
+func (c *client) EnqueueDeliverSDTestResultTask(ctx context.Context, testID uuid.UUID) (*asynq.TaskInfo, error) {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":  "client.EnqueueDeliverSDTestResultTask",
+		"input": helper.Dump(testID),
+	})
+
+	payload, err := json.Marshal(testID)
+	if err != nil {
+		logger.WithError(err).Error("failed to marshal payload for enqueue deliver sd test result task")
+		return nil, err
+	}
+
+	info, err := c.workerClient.EnqueueTask(ctx,
+		asynq.NewTask(
+			string(model.TaskDeliverSDTestResult),
+			payload,
+			asynq.Queue(string(workerPkg.PriorityHigh))))
+
+	if err != nil {
+		logger.WithError(err).Error("failed to enqueue task")
+		return nil, err
+	}
+
+	return info, nil
+}
@@ -2,6 +2,8 @@ package worker
 
 import (
 	"github.com/hibiken/asynq"
+	"github.com/luckyAkbar/atec-api/internal/common"
+	"github.com/luckyAkbar/atec-api/internal/config"
 	"github.com/luckyAkbar/atec-api/internal/model"
 	"github.com/sweet-go/stdlib/mail"
 	workerPkg "github.com/sweet-go/stdlib/worker"
@@ -13,17 +15,22 @@ var mux = asynq.NewServeMux()
 func registerTaskHandler(taskHandler *th) {
 	mux.HandleFunc(string(model.TaskSendEmail), taskHandler.HandleSendEmail)
 	mux.HandleFunc(string(model.TaskEnforceActiveTokenLimiter), taskHandler.HandleEnforceActiveTokenLimiter)
+	mux.HandleFunc(string(model.TaskSweepExpiredSDTest), taskHandler.HandleSweepExpiredSDTest)
+	mux.HandleFunc(string(model.TaskDeliverSDTestResult), taskHandler.HandleDeliverSDTestResult)
 }
 
 // ServerConfig configuration options for worker server
 type ServerConfig struct {
-	AsynqConfig     asynq.Config
-	SchedulerOpts   *asynq.SchedulerOpts
-	MailUtil        mail.Utility
-	Limiter         *rate.Limiter
-	MailRepo        model.EmailRepository
-	UserRepo        model.UserRepository
-	AccessTokenRepo model.AccessTokenRepository
+	AsynqConfig      asynq.Config
+	SchedulerOpts    *asynq.SchedulerOpts
+	MailUtil         mail.Utility
+	AttachmentMailer common.AttachmentMailer
+	Limiter          *rate.Limiter
+	MailRepo         model.EmailRepository
+	UserRepo         model.UserRepository
+	AccessTokenRepo  model.AccessTokenRepository
+	SDTestRepo       model.SDTestRepository
+	SDTestUsecase    model.SDTestUsecase
 }
 
 // NewServer return worker server
@@ -34,13 +41,33 @@ func NewServer(redisHost string, cfg ServerConfig) (workerPkg.Server, error) {
 		cfg.SchedulerOpts,
 	)
 
-	th := newTaskHandler(cfg.MailUtil, cfg.Limiter, cfg.MailRepo, cfg.UserRepo, cfg.AccessTokenRepo)
+	th := newTaskHandler(cfg.MailUtil, cfg.AttachmentMailer, cfg.Limiter, cfg.MailRepo, cfg.UserRepo, cfg.AccessTokenRepo, cfg.SDTestRepo, cfg.SDTestUsecase)
 
 	registerTaskHandler(th)
 
 	return srv, err
 }
 
+// NewScheduler return worker scheduler with all the periodic tasks registered. Must be started by the caller
+func NewScheduler(redisHost string, opts *asynq.SchedulerOpts) (*asynq.Scheduler, error) {
+	redisOpt, err := asynq.ParseRedisURI(redisHost)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler := asynq.NewScheduler(redisOpt, opts)
+
+	_, err = scheduler.Register(
+		config.WorkerSweepExpiredSDTestCronspec(),
+		asynq.NewTask(string(model.TaskSweepExpiredSDTest), nil, asynq.Queue(string(workerPkg.PriorityHigh))),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduler, nil
+}
+
 // Mux return worker mux
 func Mux() *asynq.ServeMux {
 	return mux
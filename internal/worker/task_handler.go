@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
 	"sort"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
+	"github.com/luckyAkbar/atec-api/internal/common"
 	"github.com/luckyAkbar/atec-api/internal/config"
 	"github.com/luckyAkbar/atec-api/internal/model"
 	"github.com/luckyAkbar/atec-api/internal/repository"
@@ -21,20 +24,26 @@ import (
 )
 
 type th struct {
-	mailUtil        mail.Utility
-	limiter         *rate.Limiter
-	mailRepo        model.EmailRepository
-	userRepo        model.UserRepository
-	accessTokenRepo model.AccessTokenRepository
+	mailUtil         mail.Utility
+	attachmentMailer common.AttachmentMailer
+	limiter          *rate.Limiter
+	mailRepo         model.EmailRepository
+	userRepo         model.UserRepository
+	accessTokenRepo  model.AccessTokenRepository
+	sdtestRepo       model.SDTestRepository
+	sdtestUsecase    model.SDTestUsecase
 }
 
-func newTaskHandler(mailUtil mail.Utility, limiter *rate.Limiter, mailRepo model.EmailRepository, userRepo model.UserRepository, accessTokenRepo model.AccessTokenRepository) *th {
+func newTaskHandler(mailUtil mail.Utility, attachmentMailer common.AttachmentMailer, limiter *rate.Limiter, mailRepo model.EmailRepository, userRepo model.UserRepository, accessTokenRepo model.AccessTokenRepository, sdtestRepo model.SDTestRepository, sdtestUsecase model.SDTestUsecase) *th {
 	return &th{
-		mailUtil:        mailUtil,
-		limiter:         limiter,
-		mailRepo:        mailRepo,
-		accessTokenRepo: accessTokenRepo,
-		userRepo:        userRepo,
+		mailUtil:         mailUtil,
+		attachmentMailer: attachmentMailer,
+		limiter:          limiter,
+		mailRepo:         mailRepo,
+		accessTokenRepo:  accessTokenRepo,
+		userRepo:         userRepo,
+		sdtestRepo:       sdtestRepo,
+		sdtestUsecase:    sdtestUsecase,
 	}
 }
 
@@ -79,17 +88,37 @@ func (th *th) HandleSendEmail(ctx context.Context, task *asynq.Task) error {
 		return nil
 	}
 
-	md, sig, err := th.mailUtil.SendEmail(ctx, &mail.Mail{
+	attachments, err := th.mailRepo.FindAttachmentsByEmailID(ctx, email.ID)
+	if err != nil {
+		logger.WithError(err).Error("failed to find email attachments")
+		return err
+	}
+
+	email.Attachments = attachments
+
+	m := &mail.Mail{
 		ID:          email.ID.String(),
 		To:          email.GenericReceipientsTo(),
 		Cc:          email.GenericReceipientsCc(),
 		Bcc:         email.GenericReceipientsBcc(),
 		HTMLContent: email.Body,
 		Subject:     email.Subject,
-	})
+	}
 
-	if err != nil {
-		logger.WithError(err).Error("mailUtil.SendEmail returns error, failing to send email")
+	var md string
+	var sig mail.ClientSignature
+	if len(email.Attachments) > 0 {
+		md, sig, err = th.attachmentMailer.SendEmailWithAttachments(ctx, m, email.MailAttachments())
+	} else {
+		md, sig, err = th.mailUtil.SendEmail(ctx, m)
+	}
+
+	switch {
+	case errors.Is(err, common.ErrAttachmentMailerNotActivated):
+		logger.WithError(err).Error("unable to send email with attachments while sendinblue is not activated, will not be retried")
+		return fmt.Errorf("%w: %w", asynq.SkipRetry, err)
+	case err != nil:
+		logger.WithError(err).Error("failed to send email")
 		return err
 	}
 
@@ -176,6 +205,52 @@ func (th *th) HandleEnforceActiveTokenLimiter(ctx context.Context, task *asynq.T
 	return nil
 }
 
+func (th *th) HandleSweepExpiredSDTest(ctx context.Context, _ *asynq.Task) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func": "taskHandler.HandleSweepExpiredSDTest",
+	})
+
+	affected, err := th.sdtestRepo.MarkExpired(ctx, time.Now().UTC())
+	if err != nil {
+		logger.WithError(err).Error("failed to mark expired sd test")
+		return err
+	}
+
+	logger.WithField("affected", affected).Info("successfully marked expired sd test")
+
+	return nil
+}
+
+func (th *th) HandleDeliverSDTestResult(ctx context.Context, task *asynq.Task) error {
+	logger := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":    "taskHandler.HandleDeliverSDTestResult",
+		"payload": string(task.Payload()),
+	})
+
+	var testID uuid.UUID
+	if err := json.Unmarshal(task.Payload(), &testID); err != nil {
+		logger.WithError(err).Error("failed to unmarshal payload for deliver sd test result")
+		return err
+	}
+
+	if !th.limiter.Allow() {
+		logger.WithField("testID", testID).Warn("rate limit exceeded for task: ", task.Type())
+		return newWorkerRateLimitError()
+	}
+
+	cerr := th.sdtestUsecase.DeliverResult(ctx, testID)
+	switch {
+	case cerr.Type == nil:
+		return nil
+	case cerr.Code == http.StatusInternalServerError:
+		logger.WithError(cerr.Cause).Error("failed to deliver sd test result: ", cerr.Message)
+		return cerr.Cause
+	default:
+		logger.WithError(cerr.Cause).Warn("unable to deliver sd test result and will not be retried: ", cerr.Message)
+		return nil
+	}
+}
+
 func newWorkerRateLimitError() error {
 	return workerPkg.NewRateLimitError(config.WorkerLimiterRetryInterval())
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/hibiken/asynq"
 	"github.com/lib/pq"
 	"github.com/luckyAkbar/atec-api/internal/common"
+	commonMock "github.com/luckyAkbar/atec-api/internal/common/mock"
 	"github.com/luckyAkbar/atec-api/internal/model"
 	"github.com/luckyAkbar/atec-api/internal/model/mock"
 	"github.com/luckyAkbar/atec-api/internal/repository"
@@ -29,9 +31,12 @@ func TestWorker_HandleSendEmail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	mockMailUtility := mailMock.NewMockUtility(ctrl)
+	mockAttachmentMailer := commonMock.NewMockAttachmentMailer(ctrl)
 	mockMailRepo := mock.NewMockEmailRepository(ctrl)
 	mockUserRepo := mock.NewMockUserRepository(ctrl)
 	mockAccessTokenRepo := mock.NewMockAccessTokenRepository(ctrl)
+	mockSDTestRepo := mock.NewMockSDTestRepository(ctrl)
+	mockSDTestUsecase := mock.NewMockSDTestUsecase(ctrl)
 
 	normalLimiter := rate.NewLimiter(10, 20)
 	id := uuid.New()
@@ -80,7 +85,7 @@ func TestWorker_HandleSendEmail(t *testing.T) {
 		Subject:     email.Subject,
 	}
 
-	taskHandler := newTaskHandler(mockMailUtility, normalLimiter, mockMailRepo, mockUserRepo, mockAccessTokenRepo)
+	taskHandler := newTaskHandler(mockMailUtility, mockAttachmentMailer, normalLimiter, mockMailRepo, mockUserRepo, mockAccessTokenRepo, mockSDTestRepo, mockSDTestUsecase)
 
 	tests := []common.TestStructure{
 		{
@@ -108,7 +113,7 @@ func TestWorker_HandleSendEmail(t *testing.T) {
 			MockFn: func() {},
 			Run: func() {
 				rateLimited := rate.NewLimiter(0, 0)
-				rlTaskHandler := newTaskHandler(mockMailUtility, rateLimited, mockMailRepo, mockUserRepo, mockAccessTokenRepo)
+				rlTaskHandler := newTaskHandler(mockMailUtility, mockAttachmentMailer, rateLimited, mockMailRepo, mockUserRepo, mockAccessTokenRepo, mockSDTestRepo, mockSDTestUsecase)
 				err := rlTaskHandler.HandleSendEmail(ctx, task)
 				assert.Error(t, err)
 			},
@@ -139,6 +144,7 @@ func TestWorker_HandleSendEmail(t *testing.T) {
 			Name: "when email utility returns non nil error, should return error to be retried later",
 			MockFn: func() {
 				mockMailRepo.EXPECT().FindByID(ctx, id).Times(1).Return(email, nil)
+				mockMailRepo.EXPECT().FindAttachmentsByEmailID(ctx, id).Times(1).Return([]model.EmailAttachment{}, nil)
 				mockMailUtility.EXPECT().SendEmail(ctx, sendEmailInput).Times(1).Return("", mail.ClientSignature(""), errors.New("any random error here"))
 			},
 			Run: func() {
@@ -150,6 +156,7 @@ func TestWorker_HandleSendEmail(t *testing.T) {
 			Name: "avoid retry if failed to update data after success on send email",
 			MockFn: func() {
 				mockMailRepo.EXPECT().FindByID(ctx, id).Times(1).Return(email, nil)
+				mockMailRepo.EXPECT().FindAttachmentsByEmailID(ctx, id).Times(1).Return([]model.EmailAttachment{}, nil)
 				mockMailUtility.EXPECT().SendEmail(ctx, sendEmailInput).Times(1).Return("metadata", mail.MailgunSignature, nil)
 				mockMailRepo.EXPECT().Update(ctx, gomock.Any()).Times(1).Return(errors.New("failure on update to db"))
 			},
@@ -162,6 +169,7 @@ func TestWorker_HandleSendEmail(t *testing.T) {
 			Name: "successfully handle task to sent email",
 			MockFn: func() {
 				mockMailRepo.EXPECT().FindByID(ctx, id).Times(1).Return(email, nil)
+				mockMailRepo.EXPECT().FindAttachmentsByEmailID(ctx, id).Times(1).Return([]model.EmailAttachment{}, nil)
 				mockMailUtility.EXPECT().SendEmail(ctx, sendEmailInput).Times(1).Return("metadata", mail.MailgunSignature, nil)
 				mockMailRepo.EXPECT().Update(ctx, gomock.Any()).Times(1).Return(nil)
 			},
@@ -170,10 +178,70 @@ func TestWorker_HandleSendEmail(t *testing.T) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			Name: "failed to find the email attachments",
+			MockFn: func() {
+				mockMailRepo.EXPECT().FindByID(ctx, id).Times(1).Return(email, nil)
+				mockMailRepo.EXPECT().FindAttachmentsByEmailID(ctx, id).Times(1).Return(nil, errors.New("db error"))
+			},
+			Run: func() {
+				err := taskHandler.HandleSendEmail(ctx, task)
+				assert.Error(t, err)
+			},
+		},
+		{
+			Name: "successfully handle task to sent email with attachments",
+			MockFn: func() {
+				attachments := []model.EmailAttachment{{ID: uuid.New(), EmailID: id, Filename: "result.png", ContentType: "image/png", Content: []byte("content")}}
+
+				mockMailRepo.EXPECT().FindByID(ctx, id).Times(1).Return(email, nil)
+				mockMailRepo.EXPECT().FindAttachmentsByEmailID(ctx, id).Times(1).Return(attachments, nil)
+				mockAttachmentMailer.EXPECT().SendEmailWithAttachments(ctx, sendEmailInput, []common.MailAttachment{{Filename: "result.png", ContentType: "image/png", Content: []byte("content")}}).
+					Times(1).Return("metadata", common.SendinblueSignature, nil)
+				mockMailRepo.EXPECT().Update(ctx, gomock.Any()).Times(1).Return(nil)
+			},
+			Run: func() {
+				err := taskHandler.HandleSendEmail(ctx, task)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name: "failed to send email with attachments",
+			MockFn: func() {
+				attachments := []model.EmailAttachment{{ID: uuid.New(), EmailID: id, Filename: "result.png", ContentType: "image/png", Content: []byte("content")}}
+
+				mockMailRepo.EXPECT().FindByID(ctx, id).Times(1).Return(email, nil)
+				mockMailRepo.EXPECT().FindAttachmentsByEmailID(ctx, id).Times(1).Return(attachments, nil)
+				mockAttachmentMailer.EXPECT().SendEmailWithAttachments(ctx, sendEmailInput, gomock.Any()).
+					Times(1).Return("", mail.ClientSignature(""), errors.New("err sendinblue"))
+			},
+			Run: func() {
+				err := taskHandler.HandleSendEmail(ctx, task)
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, asynq.SkipRetry)
+			},
+		},
+		{
+			Name: "email with attachments is not retried when sendinblue is not activated",
+			MockFn: func() {
+				attachments := []model.EmailAttachment{{ID: uuid.New(), EmailID: id, Filename: "result.png", ContentType: "image/png", Content: []byte("content")}}
+
+				mockMailRepo.EXPECT().FindByID(ctx, id).Times(1).Return(email, nil)
+				mockMailRepo.EXPECT().FindAttachmentsByEmailID(ctx, id).Times(1).Return(attachments, nil)
+				mockAttachmentMailer.EXPECT().SendEmailWithAttachments(ctx, sendEmailInput, gomock.Any()).
+					Times(1).Return("", mail.ClientSignature(""), common.ErrAttachmentMailerNotActivated)
+			},
+			Run: func() {
+				err := taskHandler.HandleSendEmail(ctx, task)
+				assert.ErrorIs(t, err, asynq.SkipRetry)
+				assert.ErrorIs(t, err, common.ErrAttachmentMailerNotActivated)
+			},
+		},
 		{
 			Name: "successfully handle task to sent email before the deadline",
 			MockFn: func() {
 				mockMailRepo.EXPECT().FindByID(ctx, id).Times(1).Return(beforeDeadlineEmail, nil)
+				mockMailRepo.EXPECT().FindAttachmentsByEmailID(ctx, id).Times(1).Return([]model.EmailAttachment{}, nil)
 				mockMailUtility.EXPECT().SendEmail(ctx, sendEmailInput).Times(1).Return("metadata", mail.MailgunSignature, nil)
 				mockMailRepo.EXPECT().Update(ctx, gomock.Any()).Times(1).Return(nil)
 			},
@@ -195,9 +263,12 @@ func TestTaskHandler_HandleEnforceActiveTokenLimiter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	mockMailUtility := mailMock.NewMockUtility(ctrl)
+	mockAttachmentMailer := commonMock.NewMockAttachmentMailer(ctrl)
 	mockMailRepo := mock.NewMockEmailRepository(ctrl)
 	mockUserRepo := mock.NewMockUserRepository(ctrl)
 	mockAccessTokenRepo := mock.NewMockAccessTokenRepository(ctrl)
+	mockSDTestRepo := mock.NewMockSDTestRepository(ctrl)
+	mockSDTestUsecase := mock.NewMockSDTestUsecase(ctrl)
 
 	normalLimiter := rate.NewLimiter(10, 20)
 	id := uuid.New()
@@ -207,7 +278,7 @@ func TestTaskHandler_HandleEnforceActiveTokenLimiter(t *testing.T) {
 
 	task := asynq.NewTask(string(model.TaskEnforceActiveTokenLimiter), payload)
 
-	th := newTaskHandler(mockMailUtility, normalLimiter, mockMailRepo, mockUserRepo, mockAccessTokenRepo)
+	th := newTaskHandler(mockMailUtility, mockAttachmentMailer, normalLimiter, mockMailRepo, mockUserRepo, mockAccessTokenRepo, mockSDTestRepo, mockSDTestUsecase)
 
 	activeTokenLimit := 5
 	viper.Set("server.auth.active_token_limit", activeTokenLimit)
@@ -251,7 +322,7 @@ func TestTaskHandler_HandleEnforceActiveTokenLimiter(t *testing.T) {
 			MockFn: func() {},
 			Run: func() {
 				rateLimited := rate.NewLimiter(0, 0)
-				rlTaskHandler := newTaskHandler(mockMailUtility, rateLimited, mockMailRepo, mockUserRepo, mockAccessTokenRepo)
+				rlTaskHandler := newTaskHandler(mockMailUtility, mockAttachmentMailer, rateLimited, mockMailRepo, mockUserRepo, mockAccessTokenRepo, mockSDTestRepo, mockSDTestUsecase)
 				err := rlTaskHandler.HandleEnforceActiveTokenLimiter(ctx, task)
 				assert.Error(t, err)
 
@@ -356,3 +427,137 @@ func TestTaskHandler_HandleEnforceActiveTokenLimiter(t *testing.T) {
 		tt.Run()
 	}
 }
+
+func TestWorker_HandleSweepExpiredSDTest(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+
+	mockMailUtility := mailMock.NewMockUtility(ctrl)
+	mockAttachmentMailer := commonMock.NewMockAttachmentMailer(ctrl)
+	mockMailRepo := mock.NewMockEmailRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockAccessTokenRepo := mock.NewMockAccessTokenRepository(ctrl)
+	mockSDTestRepo := mock.NewMockSDTestRepository(ctrl)
+	mockSDTestUsecase := mock.NewMockSDTestUsecase(ctrl)
+
+	normalLimiter := rate.NewLimiter(10, 20)
+	task := asynq.NewTask(string(model.TaskSweepExpiredSDTest), nil)
+
+	th := newTaskHandler(mockMailUtility, mockAttachmentMailer, normalLimiter, mockMailRepo, mockUserRepo, mockAccessTokenRepo, mockSDTestRepo, mockSDTestUsecase)
+
+	tests := []common.TestStructure{
+		{
+			Name: "failed to mark expired sd test",
+			MockFn: func() {
+				mockSDTestRepo.EXPECT().MarkExpired(ctx, gomock.Any()).Times(1).Return(int64(0), errors.New("err db"))
+			},
+			Run: func() {
+				err := th.HandleSweepExpiredSDTest(ctx, task)
+				assert.Error(t, err)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockSDTestRepo.EXPECT().MarkExpired(ctx, gomock.Any()).Times(1).Return(int64(10), nil)
+			},
+			Run: func() {
+				err := th.HandleSweepExpiredSDTest(ctx, task)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.MockFn()
+		tt.Run()
+	}
+}
+
+func TestWorker_HandleDeliverSDTestResult(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+
+	mockMailUtility := mailMock.NewMockUtility(ctrl)
+	mockAttachmentMailer := commonMock.NewMockAttachmentMailer(ctrl)
+	mockMailRepo := mock.NewMockEmailRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockAccessTokenRepo := mock.NewMockAccessTokenRepository(ctrl)
+	mockSDTestRepo := mock.NewMockSDTestRepository(ctrl)
+	mockSDTestUsecase := mock.NewMockSDTestUsecase(ctrl)
+
+	normalLimiter := rate.NewLimiter(10, 20)
+	id := uuid.New()
+
+	payload, err := json.Marshal(id)
+	assert.NoError(t, err)
+
+	task := asynq.NewTask(string(model.TaskDeliverSDTestResult), payload, asynq.Queue(string(workerPkg.PriorityHigh)))
+	th := newTaskHandler(mockMailUtility, mockAttachmentMailer, normalLimiter, mockMailRepo, mockUserRepo, mockAccessTokenRepo, mockSDTestRepo, mockSDTestUsecase)
+
+	tests := []common.TestStructure{
+		{
+			Name:   "invalid payload",
+			MockFn: func() {},
+			Run: func() {
+				err := th.HandleDeliverSDTestResult(ctx, asynq.NewTask(string(model.TaskDeliverSDTestResult), []byte("invalid")))
+				assert.Error(t, err)
+			},
+		},
+		{
+			Name:   "got rate limited error",
+			MockFn: func() {},
+			Run: func() {
+				rateLimited := rate.NewLimiter(0, 0)
+				rlTaskHandler := newTaskHandler(mockMailUtility, mockAttachmentMailer, rateLimited, mockMailRepo, mockUserRepo, mockAccessTokenRepo, mockSDTestRepo, mockSDTestUsecase)
+				err := rlTaskHandler.HandleDeliverSDTestResult(ctx, task)
+				assert.Error(t, err)
+			},
+		},
+		{
+			Name: "internal error should be retried",
+			MockFn: func() {
+				mockSDTestUsecase.EXPECT().DeliverResult(ctx, id).Times(1).Return(&common.Error{
+					Message: "err internal",
+					Cause:   errors.New("err internal"),
+					Code:    http.StatusInternalServerError,
+					Type:    errors.New("internal"),
+				})
+			},
+			Run: func() {
+				err := th.HandleDeliverSDTestResult(ctx, task)
+				assert.Error(t, err)
+			},
+		},
+		{
+			Name: "non internal error will not be retried",
+			MockFn: func() {
+				mockSDTestUsecase.EXPECT().DeliverResult(ctx, id).Times(1).Return(&common.Error{
+					Message: "not found",
+					Cause:   repository.ErrNotFound,
+					Code:    http.StatusNotFound,
+					Type:    errors.New("not found"),
+				})
+			},
+			Run: func() {
+				err := th.HandleDeliverSDTestResult(ctx, task)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			Name: "ok",
+			MockFn: func() {
+				mockSDTestUsecase.EXPECT().DeliverResult(ctx, id).Times(1).Return(&common.Error{})
+			},
+			Run: func() {
+				err := th.HandleDeliverSDTestResult(ctx, task)
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.MockFn()
+		tt.Run()
+	}
+}